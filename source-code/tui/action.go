@@ -0,0 +1,61 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Actions
+//  An action is what selecting an item means, worked out once: the
+//  command line, its environment and how it runs. Confirmation, hooks,
+//  copying and escalation all read it rather than each rebuilding the
+//  argv from the item.
+// ─────────────────────────────────────────────────────────────────
+
+type action struct {
+	name            string   // hackeros-steam subcommand ("run", "update", …); "" for built-ins
+	argv            []string // full command line; nil for built-ins
+	env             []string // overrides, see actionEnv
+	category        string   // menu section, "" outside the main menu
+	requiresConfirm bool
+	streaming       bool // output is captured in the log panel
+}
+
+// builtin reports whether the action runs inside the TUI rather than
+// as a command.
+func (a action) builtin() bool { return a.argv == nil }
+
+// actionFor resolves item, which is in category.
+func actionFor(item menuItem, category string) action {
+	a := action{category: category, requiresConfirm: item.confirm}
+	if item.run != nil {
+		return a
+	}
+	a.name = actionName(item)
+	a.argv = commandArgv(item.cmd)
+	if item.script != "" {
+		a.argv = []string{item.script}
+	}
+	a.env = actionEnv(item)
+	a.streaming = !item.interactive
+	return a
+}
+
+// menuAction resolves menu item i.
+func menuAction(i int) action {
+	return actionFor(menuItems[i], sectionOf(i))
+}
+
+// copyAction puts a's command line, as it would run, on the clipboard.
+func (m *model) copyAction(item menuItem, a action) tea.Cmd {
+	if a.builtin() {
+		return m.showToast("Nothing to copy for " + item.label)
+	}
+	return copyToClipboardCmd(a.commandLine(), "command")
+}
+
+// commandLine is a as it runs on the managed host, quoted for a shell.
+func (a action) commandLine() string {
+	argv, env := hostArgv(a.argv, a.env, false)
+	return shellCommandLine(env, argv)
+}
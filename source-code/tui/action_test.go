@@ -0,0 +1,160 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMenuActions(t *testing.T) {
+	tests := []struct {
+		label    string
+		name     string
+		args     string // after the hackeros-steam path; "" for built-ins
+		category string
+		confirm  bool
+	}{
+		{"Launch Steam", "run", "run", "STEAM", false},
+		{"Big Picture Mode", "run", "run -gamepadui", "STEAM", false},
+		{"Test Launch", "test-launch", "test-launch", "STEAM", false},
+		{"Steam Channel", "", "", "STEAM", false},
+		{"Client Auto-Update", "", "", "STEAM", false},
+		{"Steam Overlay", "", "", "STEAM", false},
+		{"Gamescope", "", "", "STEAM", false},
+		{"MangoHud", "", "", "STEAM", false},
+		{"Launch GPU", "", "", "STEAM", false},
+		{"Toggle GE-Proton", "", "", "STEAM", false},
+		{"Installed Games", "", "", "STEAM", false},
+		{"Library Folders", "", "", "STEAM", false},
+		{"Verify Game Files", "", "", "STEAM", false},
+		{"Clear Download Cache", "clear-cache", "clear-cache", "STEAM", true},
+		{"Create Container", "create", "create", "CONTAINER", false},
+		{"Setup / Repair Steam", "setup", "setup", "CONTAINER", false},
+		{"Update Container", "update", "update", "CONTAINER", true},
+		{"Update Mirror", "", "", "CONTAINER", false},
+		{"Repair Container", "create", "--force create", "CONTAINER", true},
+		{"Factory Reset", "", "", "CONTAINER", false},
+		{"Container Environment", "", "", "CONTAINER", false},
+		{"Roll Back Config", "", "", "CONTAINER", false},
+		{"Export Settings", "", "", "CONTAINER", false},
+		{"Import Settings", "", "", "CONTAINER", false},
+		{"Rename Container", "", "", "CONTAINER", false},
+		{"Pause Container", "pause", "pause", "CONTAINER", false},
+		{"Resume Container", "resume", "resume", "CONTAINER", false},
+		{"Stop Container", "kill", "kill", "CONTAINER", false},
+		{"Remove Container", "remove", "--force remove", "CONTAINER", true},
+		{"Kill & Remove Container", "teardown", "--force teardown", "CONTAINER", true},
+		{"Container Status", "status", "status", "INFO", false},
+		{"List All Containers", "list", "list", "INFO", false},
+		{"Network Info", "", "", "INFO", false},
+		{"Export Logs", "", "", "INFO", false},
+		{"Side-by-Side Logs", "", "", "INFO", false},
+		{"Diagnostics Bundle", "", "", "INFO", false},
+		{"Check Steam Client", "", "", "INFO", false},
+	}
+	if len(tests) != len(menuItems) {
+		t.Fatalf("%d menu items, %d expectations", len(menuItems), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			if menuItems[i].label != tt.label {
+				t.Fatalf("menu item %d is %q", i, menuItems[i].label)
+			}
+			a := menuAction(i)
+			args := ""
+			if len(a.argv) > 0 && a.argv[0] == cli {
+				args = strings.Join(a.argv[1:], " ")
+			}
+			if a.name != tt.name || args != tt.args || a.category != tt.category || a.requiresConfirm != tt.confirm {
+				t.Errorf("menuAction(%d) = %+v", i, a)
+			}
+			if a.builtin() != (tt.args == "") || a.streaming == a.builtin() {
+				t.Errorf("builtin=%v streaming=%v", a.builtin(), a.streaming)
+			}
+		})
+	}
+}
+
+// The action resolved when a menu item is picked is the one that runs,
+// confirmation prompt or not.
+func TestSelectedActionKeepsCategory(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tests := []struct {
+		label string
+		keys  []string
+	}{
+		{"Container Status", nil},
+		{"Repair Container", []string{"y"}},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.width, m.height = 120, 40
+		m.containerStatus = "running"
+		i := itemIndex(t, tt.label)
+		m.setCursor(i)
+		m = settle(press(m, "enter"))
+		m = press(m, tt.keys...)
+		if m.lastItem == nil || m.lastItem.label != tt.label || m.lastAction.category != sectionOf(i) {
+			t.Errorf("%s: ran %v as %+v", tt.label, m.lastItem, m.lastAction)
+		}
+	}
+}
+
+func TestActionForInteractiveItem(t *testing.T) {
+	a := actionFor(menuItem{label: "Login", cmd: []string{"login"}, interactive: true}, "")
+	if a.builtin() || a.streaming || a.name != "login" {
+		t.Errorf("interactive action = %+v", a)
+	}
+}
+
+func TestActionEnvResolvedOnce(t *testing.T) {
+	defer func(saved []envVar) { persistentEnv = saved }(persistentEnv)
+	persistentEnv = []envVar{{name: "PROTON_LOG", value: "1"}}
+	a := actionFor(menuItem{label: "Setup", cmd: []string{"setup"}, env: []string{"DEBUG=1"}}, "")
+	if strings.Join(a.env, " ") != "PROTON_LOG=1 DEBUG=1" {
+		t.Errorf("env = %q", a.env)
+	}
+	// Later edits to the environment don't change an action already resolved.
+	persistentEnv = nil
+	if len(a.env) != 2 {
+		t.Errorf("env changed to %q", a.env)
+	}
+}
+
+func TestShowArgv(t *testing.T) {
+	defer func(saved config, env []envVar, l launchSettings, host string) {
+		cfg, persistentEnv, launch, remoteHost = saved, env, l, host
+	}(cfg, persistentEnv, launch, remoteHost)
+	persistentEnv = []envVar{{name: "PROTON_LOG", value: "1"}, {name: "WINEDLLOVERRIDES", value: "d3d11=n,b dxgi"}}
+	launch = launchSettings{Mirror: "https://mirror.example.de/$repo/os/$arch"}
+	tests := []struct {
+		name   string
+		show   bool
+		remote string
+		want   string
+	}{
+		{"off", false, "", ""},
+		{"local", true, "",
+			"PROTON_LOG=1 WINEDLLOVERRIDES='d3d11=n,b dxgi' HACKEROS_STEAM_MIRROR='https://mirror.example.de/$repo/os/$arch' " + cli + " update"},
+		{"remote", true, "deck",
+			"ssh -o BatchMode=yes deck -- 'env PROTON_LOG=1 WINEDLLOVERRIDES='\\''d3d11=n,b dxgi'\\'' HACKEROS_STEAM_MIRROR='\\''https://mirror.example.de/$repo/os/$arch'\\'' " + cli + " update'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.ShowArgv, remoteHost = tt.show, tt.remote
+			m := initialModel()
+			m.width, m.height = 400, 40
+			m.containerStatus = "running"
+			m = confirmUpdate(t, m)
+			if m.runningCmdline != tt.want {
+				t.Fatalf("runningCmdline =\n%s\nwant\n%s", m.runningCmdline, tt.want)
+			}
+			if tt.want != "" && !strings.Contains(m.renderStatusBar(), "$ "+tt.want) {
+				t.Errorf("status bar lacks the command line:\n%s", m.renderStatusBar())
+			}
+			next, _ := m.Update(cmdDoneMsg(true))
+			if next.(model).runningCmdline != "" {
+				t.Error("command line kept after the action ended")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import "strings"
+
+// ─────────────────────────────────────────────────────────────────
+//  ANSI escapes
+//  hackeros-steam colors its output. With ansi_colors on, color
+//  (SGR) sequences are kept and shown as the tool meant them; every
+//  other escape — cursor movement, OSC titles, anything malformed — is
+//  dropped so it can't disturb the layout. With it off, or when a line
+//  has no color of its own, colorLine's highlighting applies instead.
+// ─────────────────────────────────────────────────────────────────
+
+const ansiReset = "\x1b[0m"
+
+// escapeEnd returns the index just past the escape sequence starting at
+// s[i] (an ESC), and whether it is a well-formed SGR sequence. An
+// unterminated or malformed sequence ends where it stops being valid.
+func escapeEnd(s string, i int) (int, bool) {
+	j := i + 1
+	if j >= len(s) {
+		return j, false
+	}
+	switch c := s[j]; {
+	case c == '[': // CSI: params, intermediates, final byte
+		j++
+		start := j
+		for j < len(s) && s[j] >= 0x30 && s[j] <= 0x3f {
+			j++
+		}
+		params := s[start:j]
+		inter := j
+		for j < len(s) && s[j] >= 0x20 && s[j] <= 0x2f {
+			j++
+		}
+		if j >= len(s) || s[j] < 0x40 || s[j] > 0x7e {
+			return j, false
+		}
+		sgr := s[j] == 'm' && inter == j && strings.Trim(params, "0123456789;") == ""
+		return j + 1, sgr
+	case c == ']': // OSC: ends at BEL or ST (ESC \)
+		for j++; j < len(s); j++ {
+			if s[j] == '\a' {
+				return j + 1, false
+			}
+			if s[j] == '\x1b' && j+1 < len(s) && s[j+1] == '\\' {
+				return j + 2, false
+			}
+		}
+		return j, false
+	case c >= 0x40 && c <= 0x5f: // two-byte escape
+		return j + 1, false
+	default:
+		return j, false
+	}
+}
+
+// filterANSI copies s, keeping SGR sequences when keepSGR is set and
+// dropping every other escape and control character but tab and
+// newline.
+func filterANSI(s string, keepSGR bool) string {
+	if !strings.ContainsAny(s, "\x1b\r\a\b\x7f") {
+		return s
+	}
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '\x1b':
+			end, sgr := escapeEnd(s, i)
+			if sgr && keepSGR {
+				out.WriteString(s[i:end])
+			}
+			i = end
+		case c < 0x20 && c != '\t' && c != '\n', c == 0x7f:
+			i++
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
+
+func stripANSI(s string) string {
+	return filterANSI(s, false)
+}
+
+// sanitizeANSI keeps only color sequences.
+func sanitizeANSI(s string) string {
+	return filterANSI(s, true)
+}
+
+// renderOutputLine prepares a line of command output for the log.
+func renderOutputLine(line string) string {
+	plain := stripANSI(line)
+	if cfg.ANSIColors && !cfg.NoColor && plain != line {
+		return line + ansiReset
+	}
+	return colorLine(plain)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		stripped string
+		kept     string
+	}{
+		{"plain", "hello world", "hello world", "hello world"},
+		{"color", "\x1b[1;32mok\x1b[0m done", "ok done", "\x1b[1;32mok\x1b[0m done"},
+		{"bare reset", "a\x1b[mb", "ab", "a\x1b[mb"},
+		{"256 color", "\x1b[38;5;208mwarn\x1b[39m", "warn", "\x1b[38;5;208mwarn\x1b[39m"},
+		{"cursor movement", "\x1b[2K\x1b[1Gprogress", "progress", "progress"},
+		{"private mode", "\x1b[?25lhidden", "hidden", "hidden"},
+		{"OSC title BEL", "\x1b]0;title\atext", "text", "text"},
+		{"OSC title ST", "\x1b]2;title\x1b\\text", "text", "text"},
+		{"two-byte escape", "\x1bMup", "up", "up"},
+		{"carriage return and bell", "50%\r100%\a", "50%100%", "50%100%"},
+		{"tab kept", "a\tb", "a\tb", "a\tb"},
+		{"unterminated CSI", "text\x1b[31", "text", "text"},
+		{"unterminated OSC", "text\x1b]0;never ends", "text", "text"},
+		{"lone ESC", "text\x1b", "text", "text"},
+		{"bad final byte", "a\x1b[31\x01b", "ab", "ab"},
+		{"intermediate is not SGR", "a\x1b[1 mb", "ab", "ab"},
+		{"utf-8 untouched", "\x1b[33m✔ fertig\x1b[0m", "✔ fertig", "\x1b[33m✔ fertig\x1b[0m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripANSI(tt.in); got != tt.stripped {
+				t.Errorf("stripANSI(%q) = %q, want %q", tt.in, got, tt.stripped)
+			}
+			if got := sanitizeANSI(tt.in); got != tt.kept {
+				t.Errorf("sanitizeANSI(%q) = %q, want %q", tt.in, got, tt.kept)
+			}
+		})
+	}
+}
+
+func TestRenderOutputLine(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	colored := "\x1b[31merror: disk full\x1b[0m"
+	tests := []struct {
+		name    string
+		ansi    bool
+		noColor bool
+		line    string
+		raw     bool // the line's own colors are kept
+	}{
+		{"colors on", true, false, colored, true},
+		{"colors off", false, false, colored, false},
+		{"no_color wins", true, true, colored, false},
+		{"uncolored line", true, false, "error: disk full", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.ANSIColors, cfg.NoColor = tt.ansi, tt.noColor
+			got := renderOutputLine(tt.line)
+			if raw := strings.Contains(got, "\x1b[31merror"); raw != tt.raw {
+				t.Errorf("renderOutputLine(%q) = %q, raw colors %v, want %v", tt.line, got, raw, tt.raw)
+			}
+			if tt.raw && !strings.HasSuffix(got, ansiReset) {
+				t.Errorf("colored line not reset: %q", got)
+			}
+			if stripANSI(got) != "error: disk full" {
+				t.Errorf("text changed: %q", stripANSI(got))
+			}
+		})
+	}
+}
+
+func TestColoredOutputIsPlainForMatching(t *testing.T) {
+	m := initialModel()
+	m.busy, m.state = true, stateRunning
+	next, _ := m.Update(cmdOutputMsg("\x1b[1;31mPermission denied\x1b[0m"))
+	m = next.(model)
+	if len(m.runOutput) != 1 || m.runOutput[0] != "Permission denied" {
+		t.Errorf("runOutput = %q", m.runOutput)
+	}
+}
+
+func TestANSIToggle(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.ANSIColors = true
+	m := initialModel()
+	m = press(m, "A")
+	if cfg.ANSIColors {
+		t.Fatal("A did not turn output colors off")
+	}
+	if !strings.Contains(m.toast, "stripped") {
+		t.Errorf("toast = %q", m.toast)
+	}
+	m = press(m, "A")
+	if !cfg.ANSIColors {
+		t.Error("second A did not turn output colors back on")
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Completion bell
+//  attention = "bell" rings the terminal bell when an action that ran
+//  for attention_after seconds or longer finishes; "flash" briefly
+//  inverts the screen instead. Terminals that report focus are left
+//  alone while the TUI has it: the result is on screen already.
+//
+//    attention = "bell"
+//    attention_after = 30
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	attentionOff   = "off"
+	attentionBell  = "bell"
+	attentionFlash = "flash"
+)
+
+const defaultAttentionAfter = 30 // seconds
+
+// flashDuration is how long the screen stays inverted.
+const flashDuration = 150 * time.Millisecond
+
+// focusState is what the terminal last said about focus; most never
+// say, and stay focusUnknown.
+type focusState int
+
+const (
+	focusUnknown focusState = iota
+	focusIn
+	focusOut
+)
+
+func validateAttention(mode string) error {
+	switch mode {
+	case "", attentionOff, attentionBell, attentionFlash:
+		return nil
+	}
+	return fmt.Errorf("attention: %q is not bell, flash or off", mode)
+}
+
+// attentionDue reports whether an action that took elapsed should be
+// announced.
+func attentionDue(mode string, after, elapsed time.Duration, focus focusState) bool {
+	if mode != attentionBell && mode != attentionFlash {
+		return false
+	}
+	return elapsed >= after && focus != focusIn
+}
+
+// attentionCmd rings or flashes. The bytes go straight to the
+// terminal; neither moves the cursor, so the renderer isn't disturbed.
+func attentionCmd(mode string) tea.Cmd {
+	return func() tea.Msg {
+		switch mode {
+		case attentionBell:
+			os.Stdout.WriteString("\a")
+		case attentionFlash:
+			os.Stdout.WriteString("\x1b[?5h") // reverse video (DECSCNM)
+			time.Sleep(flashDuration)
+			os.Stdout.WriteString("\x1b[?5l")
+		}
+		return nil
+	}
+}
+
+// attention announces an action that ran for elapsed, if it is due.
+func (m model) attention(elapsed time.Duration) tea.Cmd {
+	after := time.Duration(cfg.AttentionAfter) * time.Second
+	if !attentionDue(cfg.Attention, after, elapsed, m.focus) {
+		return nil
+	}
+	return attentionCmd(cfg.Attention)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestValidateAttention(t *testing.T) {
+	tests := []struct {
+		mode string
+		ok   bool
+	}{
+		{"", true},
+		{"off", true},
+		{"bell", true},
+		{"flash", true},
+		{"Bell", false},
+		{"beep", false},
+	}
+	for _, tt := range tests {
+		if err := validateAttention(tt.mode); (err == nil) != tt.ok {
+			t.Errorf("validateAttention(%q) = %v", tt.mode, err)
+		}
+	}
+}
+
+func TestAttentionDue(t *testing.T) {
+	after := 30 * time.Second
+	tests := []struct {
+		mode    string
+		elapsed time.Duration
+		focus   focusState
+		want    bool
+	}{
+		{"bell", 29 * time.Second, focusUnknown, false},
+		{"bell", 30 * time.Second, focusUnknown, true},
+		{"bell", 5 * time.Minute, focusUnknown, true},
+		{"flash", 30 * time.Second, focusOut, true},
+		{"bell", time.Hour, focusIn, false},
+		{"off", time.Hour, focusOut, false},
+		{"", time.Hour, focusOut, false},
+	}
+	for _, tt := range tests {
+		if got := attentionDue(tt.mode, after, tt.elapsed, tt.focus); got != tt.want {
+			t.Errorf("attentionDue(%q, %v, focus %d) = %v, want %v", tt.mode, tt.elapsed, tt.focus, got, tt.want)
+		}
+	}
+}
+
+func TestAttentionFollowsFocus(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.Attention, cfg.AttentionAfter = attentionBell, 30
+	m := initialModel()
+	if m.attention(time.Minute) == nil {
+		t.Error("no bell without focus reports")
+	}
+	next, _ := m.Update(tea.FocusMsg{})
+	m = next.(model)
+	if m.attention(time.Minute) != nil {
+		t.Error("bell rang while focused")
+	}
+	next, _ = m.Update(tea.BlurMsg{})
+	m = next.(model)
+	if m.attention(time.Minute) == nil || m.attention(10*time.Second) != nil {
+		t.Error("threshold not applied after blur")
+	}
+}
+
+func TestAttentionConfigDefaults(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Attention != attentionOff || c.AttentionAfter != defaultAttentionAfter {
+		t.Errorf("defaults attention %q after %d", c.Attention, c.AttentionAfter)
+	}
+	if err := os.MkdirAll(configDir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath(), []byte("attention = \"flash\"\nattention_after = -5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if c, err = loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if c.Attention != attentionFlash || c.AttentionAfter != defaultAttentionAfter {
+		t.Errorf("attention %q after %d, want flash after the default", c.Attention, c.AttentionAfter)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Audit log
+//  With audit = true every command the TUI runs is appended to
+//  audit_log, apart from the session log: actions and hooks, what
+//  built-in actions ask the host or container themselves (Update
+//  Container's plan, the MangoHud check), and the TUI's own status
+//  polls, clipboard and notifications. Those are named by what they
+//  are for ("status", "clipboard") rather than a menu label.
+//  One JSON object per line; the fields below are kept stable, new
+//  ones are only ever added. Environment values are never written,
+//  only the variable names, and credential-looking arguments are
+//  redacted:
+//
+//    {"v":1,"time":"2026-01-02T15:04:05Z","user":"alice","uid":1000,
+//     "pid":4242,"host":"local","id":3,"event":"exec","action":"update",
+//     "argv":["/usr/bin/hackeros-steam","update"],"env":["STEAM_FORCE…"]}
+//    {"v":1,…,"id":3,"event":"exit","action":"update","result":"ok"}
+//
+//  Audit mode fails closed: if the log can't be opened or written,
+//  commands are refused rather than run unrecorded. It is also on when
+//  HACKEROS_STEAM_AUDIT=1 is set, whatever config.toml says, and when
+//  config.toml has audit = true but doesn't parse.
+// ─────────────────────────────────────────────────────────────────
+
+const auditVersion = 1
+
+const auditEnvVar = "HACKEROS_STEAM_AUDIT"
+
+type auditRecord struct {
+	V      int      `json:"v"`
+	Time   string   `json:"time"`
+	User   string   `json:"user"`
+	UID    int      `json:"uid"`
+	PID    int      `json:"pid"`
+	Host   string   `json:"host"`
+	ID     int      `json:"id"`
+	Event  string   `json:"event"` // "exec" or "exit"
+	Action string   `json:"action,omitempty"`
+	Argv   []string `json:"argv,omitempty"`
+	Env    []string `json:"env,omitempty"`
+	Result string   `json:"result,omitempty"` // exit: "ok", "failed", "not started", or a hook's error
+}
+
+type auditLog struct {
+	mu     sync.Mutex // hooks are audited from their goroutine
+	f      *os.File
+	nextID int
+	user   string
+}
+
+var (
+	audit    *auditLog // nil when audit mode is off
+	auditErr error     // why the log couldn't be opened; commands are refused
+)
+
+func defaultAuditPath() string {
+	return filepath.Join(stateDir(), "audit.log")
+}
+
+// reAuditOn finds audit = true in a config file that doesn't parse.
+var reAuditOn = regexp.MustCompile(`(?m)^\s*audit\s*=\s*true\b`)
+
+// auditRequested reports whether audit mode is on.
+func auditRequested(c config, loadErr error) bool {
+	if c.Audit || os.Getenv(auditEnvVar) == "1" {
+		return true
+	}
+	if loadErr != nil {
+		if data, err := os.ReadFile(configPath()); err == nil {
+			return reAuditOn.Match(data)
+		}
+	}
+	return false
+}
+
+// openAudit opens path for appending, creating it private to the user.
+func openAudit(path string) (*auditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	name := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	return &auditLog{f: f, user: name}, nil
+}
+
+// setupAudit runs at startup, after the config is loaded.
+func setupAudit(loadErr error) {
+	if !auditRequested(cfg, loadErr) {
+		return
+	}
+	cfg.Audit = true
+	path := cfg.AuditLog
+	if path == "" {
+		path = defaultAuditPath()
+	}
+	if audit, auditErr = openAudit(path); auditErr != nil {
+		auditErr = fmt.Errorf("audit log %s: %w", tildePath(path), auditErr)
+		startupNotes = append(startupNotes, "Audit mode is on but "+auditErr.Error()+"; commands are disabled")
+	}
+}
+
+// auditReason is the disabledReason for commands while audit mode is
+// on without a working log.
+func auditReason(item menuItem) string {
+	if !cfg.Audit || audit != nil || (item.run != nil && item.script == "") {
+		return ""
+	}
+	return "audit log unavailable"
+}
+
+// redactArgv hides credential-looking values in a command line.
+func redactArgv(argv []string) []string {
+	out := make([]string, len(argv))
+	for i, a := range argv {
+		out[i] = reSecret.ReplaceAllString(a, "$1$2<redacted>")
+	}
+	return out
+}
+
+// envNames keeps only the names of KEY=value pairs.
+func envNames(env []string) []string {
+	var out []string
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		if name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func (l *auditLog) write(r auditRecord) error {
+	r.V = auditVersion
+	r.Time = time.Now().UTC().Format(time.RFC3339)
+	r.User, r.UID, r.PID = l.user, os.Getuid(), os.Getpid()
+	r.Host = "local"
+	if remoteHost != "" {
+		r.Host = remoteHost
+	}
+	var line bytes.Buffer
+	enc := json.NewEncoder(&line)
+	enc.SetEscapeHTML(false) // keep "<redacted>" readable
+	if err := enc.Encode(r); err != nil {
+		return err
+	}
+	_, err := l.f.Write(line.Bytes())
+	return err
+}
+
+// auditExec records a command about to run and returns the id its exit
+// is recorded under. Off audit mode it does nothing; with audit mode
+// on, an error means the command must not run.
+func auditExec(action string, argv, env []string) (int, error) {
+	if !cfg.Audit {
+		return 0, nil
+	}
+	if audit == nil {
+		if auditErr == nil {
+			return 0, errors.New("audit log unavailable")
+		}
+		return 0, auditErr
+	}
+	audit.mu.Lock()
+	defer audit.mu.Unlock()
+	audit.nextID++
+	id := audit.nextID
+	err := audit.write(auditRecord{ID: id, Event: "exec", Action: action, Argv: redactArgv(argv), Env: envNames(env)})
+	if err != nil {
+		return 0, fmt.Errorf("audit log: %w", err)
+	}
+	return id, nil
+}
+
+// auditExit records how command id ended. A failed write is noted in
+// the log panel by the caller; the command has already run.
+func auditExit(id int, action, result string) error {
+	if audit == nil || id == 0 {
+		return nil
+	}
+	audit.mu.Lock()
+	defer audit.mu.Unlock()
+	return audit.write(auditRecord{ID: id, Event: "exit", Action: action, Result: result})
+}
+
+// auditedOutput runs c, recorded under action like the commands actions
+// start and listed in the process panel, and returns what capture
+// collected (stdoutOf, combinedTailOf). The TUI runs everything outside
+// an action's stream through it; with audit mode on and the log
+// unusable, c is not run.
+func auditedOutput[T any](action string, c *exec.Cmd, capture func(*exec.Cmd) func() T) (T, error) {
+	var env []string
+	if n := len(os.Environ()); len(c.Env) > n {
+		env = c.Env[n:]
+	}
+	id, err := auditExec(action, c.Args, env)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	out := capture(c)
+	err = runTracked(c)
+	result := "ok"
+	if err != nil {
+		result = "failed"
+	}
+	auditExit(id, action, result)
+	return out(), err
+}
+
+// auditedRun is auditedOutput for a command whose output isn't read.
+func auditedRun(action string, c *exec.Cmd) error {
+	_, err := auditedOutput(action, c, func(*exec.Cmd) func() struct{} {
+		return func() struct{} { return struct{}{} }
+	})
+	return err
+}
+
+// auditAction names an action in the log: its subcommand, or the menu
+// label for scripts.
+func auditAction(item menuItem, a action) string {
+	if a.name != "" {
+		return a.name
+	}
+	return item.label
+}
+
+// auditDone records the end of the running command.
+func (m *model) auditDone(ok bool) {
+	result := "failed"
+	switch {
+	case m.startFailed:
+		result = "not started"
+	case ok:
+		result = "ok"
+	}
+	if err := auditExit(m.auditID, m.auditName, result); err != nil {
+		m.logError("Audit log: " + err.Error())
+	}
+	m.auditID, m.auditName = 0, ""
+}
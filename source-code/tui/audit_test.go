@@ -0,0 +1,316 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// withAudit turns audit mode on with a log in a temp dir for the test.
+func withAudit(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	l, err := openAudit(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	savedCfg, savedLog, savedErr := cfg, audit, auditErr
+	t.Cleanup(func() {
+		l.f.Close()
+		cfg, audit, auditErr = savedCfg, savedLog, savedErr
+	})
+	cfg.Audit, audit, auditErr = true, l, nil
+	return path
+}
+
+func readAudit(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		var r map[string]any
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("line %q: %v", line, err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestRedactArgv(t *testing.T) {
+	tests := []struct {
+		argv []string
+		want []string
+	}{
+		{[]string{"hackeros-steam", "update"}, []string{"hackeros-steam", "update"}},
+		{[]string{"curl", "--header", "Authorization: Bearer abc123"}, []string{"curl", "--header", "Authorization: <redacted>"}},
+		{[]string{"env", "GITHUB_TOKEN=ghp_x", "make"}, []string{"env", "GITHUB_TOKEN=<redacted>", "make"}},
+		{[]string{"login", "--password=hunter2"}, []string{"login", "--password=<redacted>"}},
+		{[]string{"run", "api-key:42"}, []string{"run", "api-key:<redacted>"}},
+		{nil, []string{}},
+	}
+	for _, tt := range tests {
+		if got := redactArgv(tt.argv); !slices.Equal(got, tt.want) {
+			t.Errorf("redactArgv(%q) = %q, want %q", tt.argv, got, tt.want)
+		}
+	}
+	argv := []string{"PASSWORD=x"}
+	redactArgv(argv)
+	if argv[0] != "PASSWORD=x" {
+		t.Error("redactArgv modified its argument")
+	}
+}
+
+func TestEnvNames(t *testing.T) {
+	tests := []struct {
+		env  []string
+		want []string
+	}{
+		{[]string{"PROTON_LOG=1", "STEAM_TOKEN=secret"}, []string{"PROTON_LOG", "STEAM_TOKEN"}},
+		{[]string{"EMPTY=", "NOVALUE"}, []string{"EMPTY", "NOVALUE"}},
+		{[]string{"=nameless"}, nil},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := envNames(tt.env); !slices.Equal(got, tt.want) {
+			t.Errorf("envNames(%q) = %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestAuditRecordFormat(t *testing.T) {
+	path := withAudit(t)
+	id, err := auditExec("update", []string{cli, "update", "--token=abc"}, []string{"HTTP_PROXY=http://u:p@proxy"})
+	if err != nil || id != 1 {
+		t.Fatalf("auditExec = %d, %v", id, err)
+	}
+	if err := auditExit(id, "update", "ok"); err != nil {
+		t.Fatal(err)
+	}
+	if id2, _ := auditExec("kill", []string{cli, "kill"}, nil); id2 != 2 {
+		t.Errorf("second id = %d, want 2", id2)
+	}
+
+	records := readAudit(t, path)
+	if len(records) != 3 {
+		t.Fatalf("%d records, want 3", len(records))
+	}
+	fields := []string{"v", "time", "user", "uid", "pid", "host", "id", "event"}
+	want := []map[string]any{
+		{"v": 1.0, "id": 1.0, "event": "exec", "action": "update", "host": "local",
+			"argv": []any{cli, "update", "--token=<redacted>"}, "env": []any{"HTTP_PROXY"}},
+		{"v": 1.0, "id": 1.0, "event": "exit", "action": "update", "result": "ok"},
+		{"v": 1.0, "id": 2.0, "event": "exec", "action": "kill", "argv": []any{cli, "kill"}},
+	}
+	for i, r := range records {
+		for _, f := range fields {
+			if _, ok := r[f]; !ok {
+				t.Errorf("record %d lacks %q: %v", i, f, r)
+			}
+		}
+		for k, v := range want[i] {
+			got, _ := json.Marshal(r[k])
+			exp, _ := json.Marshal(v)
+			if string(got) != string(exp) {
+				t.Errorf("record %d %s = %s, want %s", i, k, got, exp)
+			}
+		}
+		if pid := r["pid"].(float64); int(pid) != os.Getpid() {
+			t.Errorf("record %d pid %v", i, pid)
+		}
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "proxy") || strings.Contains(string(data), "abc") {
+		t.Errorf("secret values in the audit log:\n%s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("audit log mode %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestAuditAppends(t *testing.T) {
+	path := withAudit(t)
+	auditExec("status", []string{cli, "status"}, nil)
+	l, err := openAudit(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.f.Close()
+	audit = l
+	auditExec("status", []string{cli, "status"}, nil)
+	if n := len(readAudit(t, path)); n != 2 {
+		t.Errorf("reopening kept %d records, want 2", n)
+	}
+}
+
+func TestAuditRequested(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	if err := os.MkdirAll(filepath.Dir(configPath()), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	broken := errors.New("toml: bad")
+	tests := []struct {
+		name    string
+		audit   bool
+		env     string
+		file    string
+		loadErr error
+		want    bool
+	}{
+		{"off", false, "", "", nil, false},
+		{"config", true, "", "", nil, true},
+		{"environment", false, "1", "", nil, true},
+		{"environment not 1", false, "yes", "", nil, false},
+		{"broken config with audit", false, "", "audit = true\nlayout = [\n", broken, true},
+		{"broken config without audit", false, "", "layout = [\n", broken, false},
+		{"parsed config, audit off", false, "", "audit = true\n", nil, false},
+	}
+	for _, tt := range tests {
+		t.Setenv(auditEnvVar, tt.env)
+		if err := os.WriteFile(configPath(), []byte(tt.file), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if got := auditRequested(config{Audit: tt.audit}, tt.loadErr); got != tt.want {
+			t.Errorf("%s: auditRequested = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAuditedRun(t *testing.T) {
+	path := withAudit(t)
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.containerStatus = "running"
+	m = confirmUpdate(t, m)
+	if m.auditID != 1 || m.auditName != "update" {
+		t.Fatalf("running command audited as %d %q", m.auditID, m.auditName)
+	}
+	next, _ := m.Update(cmdDoneMsg(false))
+	m = next.(model)
+	if m.auditID != 0 {
+		t.Error("audit id kept after the command ended")
+	}
+	records := readAudit(t, path)
+	if len(records) != 2 || records[0]["event"] != "exec" || records[1]["result"] != "failed" {
+		t.Errorf("records = %v", records)
+	}
+}
+
+func TestAuditFailsClosed(t *testing.T) {
+	defer func(c config, l *auditLog, e error) { cfg, audit, auditErr = c, l, e }(cfg, audit, auditErr)
+	defer func(saved []string) { startupNotes = saved }(startupNotes)
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Audit, cfg.AuditLog, audit, auditErr = true, filepath.Join(file, "audit.log"), nil, nil
+	startupNotes = nil
+	setupAudit(nil)
+	if audit != nil || auditErr == nil {
+		t.Fatalf("audit log opened under a file (err %v)", auditErr)
+	}
+	if len(startupNotes) != 1 || !strings.Contains(startupNotes[0], "commands are disabled") {
+		t.Errorf("startup notes %q", startupNotes)
+	}
+
+	m := initialModel()
+	m.containerStatus = "running"
+	tests := []struct {
+		label  string
+		reason string
+	}{
+		{"Update Container", "audit log unavailable"},
+		{"Container Status", "audit log unavailable"},
+		{"Installed Games", ""},
+		{"Export Logs", ""},
+	}
+	for _, tt := range tests {
+		if got := m.disabledReason(menuItems[itemIndex(t, tt.label)]); got != tt.reason {
+			t.Errorf("%s: disabledReason %q, want %q", tt.label, got, tt.reason)
+		}
+	}
+
+	// Started some other way, the command is still refused.
+	item := menuItems[itemIndex(t, "Update Container")]
+	m.lastItem, m.lastAction = &item, actionFor(item, "")
+	m.busy = true
+	m.startCommand()
+	if countLogged(m, "Not run: audit log") != 1 || m.busy {
+		t.Errorf("command not refused: busy=%v\n%s", m.busy, strings.Join(plainLogLines(m.logLines, false), "\n"))
+	}
+}
+
+func TestAuditedOutput(t *testing.T) {
+	path := withAudit(t)
+	tests := []struct {
+		argv   []string
+		out    string
+		result string
+	}{
+		{[]string{"sh", "-c", "echo plan"}, "plan\n", "ok"},
+		{[]string{"sh", "-c", "echo partial; exit 3"}, "partial\n", "failed"},
+	}
+	for _, tt := range tests {
+		c := exec.Command(tt.argv[0], tt.argv[1:]...)
+		c.Env = commandEnv([]string{"STEAM_FORCE_DESKTOPUI_SCALING=2"})
+		if out, _ := auditedOutput("Update Container", c, combinedTailOf); out != tt.out {
+			t.Errorf("%q: output %q, want %q", tt.argv, out, tt.out)
+		}
+	}
+	records := readAudit(t, path)
+	if len(records) != 2*len(tests) {
+		t.Fatalf("records = %v", records)
+	}
+	for i, tt := range tests {
+		start, exit := records[2*i], records[2*i+1]
+		if start["action"] != "Update Container" || start["argv"].([]any)[2] != tt.argv[2] {
+			t.Errorf("exec record %v", start)
+		}
+		if env := start["env"].([]any); len(env) != 1 || env[0] != "STEAM_FORCE_DESKTOPUI_SCALING" {
+			t.Errorf("env recorded as %v, want only the override", env)
+		}
+		if exit["id"] != start["id"] || exit["result"] != tt.result {
+			t.Errorf("exit record %v, want %q", exit, tt.result)
+		}
+	}
+
+	audit, auditErr = nil, errors.New("disk full")
+	ran := false
+	_, err := auditedOutput("MangoHud", exec.Command("true"), func(*exec.Cmd) func() []byte {
+		ran = true
+		return func() []byte { return nil }
+	})
+	if ran || err == nil || err.Error() != "disk full" {
+		t.Errorf("with the log unusable: ran %v, err %v", ran, err)
+	}
+}
+
+func TestBackgroundCommandsAudited(t *testing.T) {
+	tests := []struct {
+		action string
+		run    func()
+	}{
+		{"status", func() { probeStatus() }},
+		{"engine detection", func() { detectBackend() }},
+		{"stats", func() { statsCmd("podman")() }},
+		{"Rename Container", func() { checkNameFreeCmd("podman", "steam-box")() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			path := withAudit(t)
+			tt.run()
+			records := readAudit(t, path)
+			if len(records) < 2 || records[0]["action"] != tt.action || records[len(records)-1]["event"] != "exit" {
+				t.Errorf("records = %v", records)
+			}
+		})
+	}
+}
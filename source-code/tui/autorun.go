@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Autorun (--autorun name, autorun = "name")
+//  Kiosk-style setups start a launch action right away. A short
+//  countdown gives the user a chance to press a key and stay in the
+//  menu instead. With autorun_exit the TUI quits once that action ends
+//  successfully, i.e. when Steam is closed again.
+// ─────────────────────────────────────────────────────────────────
+
+const autorunDelay = 3 // seconds
+
+// autorunTargets maps autorun names to menu labels.
+var autorunTargets = map[string]string{
+	"steam":      "Launch Steam",
+	"bigpicture": "Big Picture Mode",
+}
+
+type autorunTickMsg struct{}
+
+var autorunFlag string
+
+func autorunNames() string {
+	names := make([]string, 0, len(autorunTargets))
+	for n := range autorunTargets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// autorunItem returns the menu item for name.
+func autorunItem(name string) (menuItem, error) {
+	label, ok := autorunTargets[name]
+	if ok {
+		for _, item := range menuItems {
+			if item.label == label {
+				return item, nil
+			}
+		}
+	}
+	return menuItem{}, fmt.Errorf("unknown autorun action %q (valid: %s)", name, autorunNames())
+}
+
+// setupAutorun arms the countdown; a bad name from the config is
+// reported in the log rather than refusing to start.
+func (m *model) setupAutorun(name string) {
+	if name == "" {
+		return
+	}
+	item, err := autorunItem(name)
+	if err != nil {
+		m.appendLog(styleLogWarning.Render("  ⚠  " + err.Error()))
+		return
+	}
+	m.autorun = &item
+	m.autorunLeft = autorunDelay
+	m.appendLog(styleLogInfo.Render(fmt.Sprintf("  → Autorun: %s in %ds — press any key to cancel.", item.label, autorunDelay)))
+}
+
+func autorunTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return autorunTickMsg{} })
+}
+
+func (m *model) handleAutorunTick() tea.Cmd {
+	if m.autorun == nil {
+		return nil
+	}
+	m.autorunLeft--
+	if m.autorunLeft > 0 {
+		return autorunTick()
+	}
+	item := *m.autorun
+	m.autorun = nil
+	m.autorunQuit = cfg.AutorunExit
+	return m.dispatch(item)
+}
+
+// cancelAutorun is called for any key while the countdown runs.
+func (m *model) cancelAutorun() {
+	m.autorun = nil
+	m.appendLog(styleLogDim.Render("  Autorun cancelled."))
+}
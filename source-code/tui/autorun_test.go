@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAutorunItem(t *testing.T) {
+	tests := []struct {
+		name  string
+		label string
+		ok    bool
+	}{
+		{"steam", "Launch Steam", true},
+		{"bigpicture", "Big Picture Mode", true},
+		{"Steam", "", false},
+		{"remove", "", false},
+	}
+	for _, tt := range tests {
+		item, err := autorunItem(tt.name)
+		if (err == nil) != tt.ok || item.label != tt.label {
+			t.Errorf("autorunItem(%q) = %q, %v", tt.name, item.label, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), autorunNames()) {
+			t.Errorf("error %q doesn't list the valid names", err)
+		}
+	}
+}
+
+func autorunModel(t *testing.T, name string, exit bool) model {
+	t.Helper()
+	saved := cfg
+	t.Cleanup(func() { cfg = saved })
+	cfg.Autorun, cfg.AutorunExit = name, exit
+	return initialModel()
+}
+
+func TestAutorunDispatchOnInit(t *testing.T) {
+	m := autorunModel(t, "bigpicture", false)
+	if m.autorun == nil || m.autorunLeft != autorunDelay {
+		t.Fatalf("autorun not armed: %+v", m.autorun)
+	}
+	if m.Init() == nil {
+		t.Fatal("Init returned no commands")
+	}
+	for i := 1; i < autorunDelay; i++ {
+		next, cmd := m.Update(autorunTickMsg{})
+		m = next.(model)
+		if m.busy || cmd == nil {
+			t.Fatalf("tick %d: busy=%v, countdown stopped=%v", i, m.busy, cmd == nil)
+		}
+	}
+	next, _ := m.Update(autorunTickMsg{})
+	m = next.(model)
+	if countLogged(m, "$ hackeros-steam run -gamepadui") != 1 || m.autorun != nil {
+		t.Error("Big Picture Mode not started when the countdown ran out")
+	}
+}
+
+func TestAutorunCancelledByKey(t *testing.T) {
+	m := autorunModel(t, "steam", false)
+	m = press(m, "down")
+	if m.autorun != nil || countLogged(m, "Autorun cancelled.") != 1 {
+		t.Fatal("key did not cancel the autorun")
+	}
+	if m.cursor != 0 {
+		t.Error("the cancelling key also moved the cursor")
+	}
+	next, _ := m.Update(autorunTickMsg{})
+	m = next.(model)
+	if m.busy {
+		t.Error("a late tick started the cancelled autorun")
+	}
+}
+
+func TestAutorunBadConfigName(t *testing.T) {
+	m := autorunModel(t, "kiosk", false)
+	if m.autorun != nil || countLogged(m, `unknown autorun action "kiosk"`) != 1 {
+		t.Error("bad autorun name not reported in the log")
+	}
+}
+
+func TestAutorunExit(t *testing.T) {
+	tests := []struct {
+		exit, ok, quits bool
+	}{
+		{true, true, true},
+		{true, false, false},
+		{false, true, false},
+	}
+	for _, tt := range tests {
+		m := autorunModel(t, "steam", tt.exit)
+		m.autorunLeft = 1
+		next, _ := m.Update(autorunTickMsg{})
+		m = next.(model)
+		next, cmd := m.Update(cmdDoneMsg(tt.ok))
+		m = next.(model)
+		if quits := isQuit(cmd); quits != tt.quits {
+			t.Errorf("exit=%v ok=%v: quit = %v, want %v", tt.exit, tt.ok, quits, tt.quits)
+		}
+	}
+}
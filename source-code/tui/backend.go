@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Container backend
+//  distrobox can sit on podman, docker or lilipod. The engine that
+//  actually holds the container is found by asking each candidate to
+//  inspect it; actions that rely on an engine feature are gated on it.
+// ─────────────────────────────────────────────────────────────────
+
+// backendCap is an engine feature an action relies on.
+type backendCap int
+
+const (
+	capNone  backendCap = iota
+	capLogs             // engine keeps container stdout (`<engine> logs`)
+	capPause            // engine can freeze a container (`<engine> pause`)
+)
+
+// backendCaps lists what each known engine supports. lilipod keeps no
+// container logs and can't pause.
+var backendCaps = map[string][]backendCap{
+	"podman":  {capLogs, capPause},
+	"docker":  {capLogs, capPause},
+	"lilipod": {},
+}
+
+type backendDetectedMsg struct{ name string }
+
+// backendSupports reports whether engine b has c. An engine that hasn't
+// been detected yet is given the benefit of the doubt.
+func backendSupports(b string, c backendCap) bool {
+	if c == capNone || b == "" {
+		return true
+	}
+	for _, have := range backendCaps[b] {
+		if have == c {
+			return true
+		}
+	}
+	return false
+}
+
+// backendCandidates returns the engines to try, the configured one first.
+// Only the configured one can be tried on a remote host.
+func backendCandidates() []string {
+	first := containerManager()
+	out := []string{first}
+	if remoteHost != "" {
+		return out
+	}
+	for _, mgr := range []string{"podman", "docker", "lilipod"} {
+		if mgr == first {
+			continue
+		}
+		if _, err := exec.LookPath(mgr); err == nil {
+			out = append(out, mgr)
+		}
+	}
+	return out
+}
+
+// detectBackend returns the engine that knows the container, falling
+// back to the configured one when none does (e.g. not created yet).
+func detectBackend() string {
+	candidates := backendCandidates()
+	for _, mgr := range candidates {
+		err := auditedRun("engine detection", hostCommand([]string{mgr, "inspect", "--type", "container", containerName}, nil, false))
+		if err == nil {
+			return mgr
+		}
+	}
+	return candidates[0]
+}
+
+func detectBackendCmd() tea.Cmd {
+	return func() tea.Msg {
+		return backendDetectedMsg{name: detectBackend()}
+	}
+}
+
+// engine is the backend to run engine commands against.
+func (m model) engine() string {
+	if m.backend != "" {
+		return m.backend
+	}
+	return containerManager()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackendSupports(t *testing.T) {
+	tests := []struct {
+		backend string
+		c       backendCap
+		want    bool
+	}{
+		{"podman", capLogs, true},
+		{"docker", capLogs, true},
+		{"lilipod", capLogs, false},
+		{"lilipod", capNone, true},
+		{"podman", capPause, true},
+		{"lilipod", capPause, false},
+		{"", capLogs, true},
+		{"mystery", capLogs, false},
+	}
+	for _, tt := range tests {
+		if got := backendSupports(tt.backend, tt.c); got != tt.want {
+			t.Errorf("backendSupports(%q, %v) = %v, want %v", tt.backend, tt.c, got, tt.want)
+		}
+	}
+}
+
+// fakeEngines puts stub engines on PATH; those listed in knows succeed
+// at inspecting the container, the rest fail.
+func fakeEngines(t *testing.T, engines, knows []string) {
+	t.Helper()
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("no /bin/sh")
+	}
+	dir := t.TempDir()
+	ok := map[string]bool{}
+	for _, k := range knows {
+		ok[k] = true
+	}
+	for _, e := range engines {
+		script := "#!/bin/sh\nexit 1\n"
+		if ok[e] {
+			script = "#!/bin/sh\nexit 0\n"
+		}
+		if err := os.WriteFile(filepath.Join(dir, e), []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+	t.Setenv("DBX_CONTAINER_MANAGER", "")
+}
+
+func TestDetectBackend(t *testing.T) {
+	tests := []struct {
+		name    string
+		engines []string
+		knows   []string
+		want    string
+	}{
+		{"podman holds it", []string{"podman", "docker"}, []string{"podman"}, "podman"},
+		{"docker holds it", []string{"podman", "docker"}, []string{"docker"}, "docker"},
+		{"lilipod holds it", []string{"podman", "lilipod"}, []string{"lilipod"}, "lilipod"},
+		{"not created yet", []string{"podman", "docker"}, nil, "podman"},
+		{"docker only", []string{"docker"}, nil, "docker"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeEngines(t, tt.engines, tt.knows)
+			if got := detectBackend(); got != tt.want {
+				t.Errorf("detectBackend() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportLogsNeedsLogs(t *testing.T) {
+	tests := []struct {
+		backend  string
+		disabled bool
+	}{
+		{"", false},
+		{"podman", false},
+		{"lilipod", true},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		next, _ := m.Update(backendDetectedMsg{name: tt.backend})
+		m = next.(model)
+		export := menuItems[itemIndex(t, "Export Logs")]
+		if got := m.disabledReason(export) != ""; got != tt.disabled {
+			t.Errorf("backend %q: Export Logs disabled = %v, want %v", tt.backend, got, tt.disabled)
+		}
+		if status := menuItems[itemIndex(t, "Container Status")]; m.disabledReason(status) != "" {
+			t.Errorf("backend %q: Container Status disabled", tt.backend)
+		}
+	}
+}
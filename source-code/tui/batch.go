@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Batch uninstall
+//  Games marked with space in the games view are uninstalled one at a
+//  time, in list order, each waited for until its manifest is gone or
+//  the wait times out (games.go). s stops the batch once the current
+//  game is done. Steam can still decline an uninstall, so a game whose
+//  manifest stays counts as skipped. The batch is announced with
+//  one notification at the end rather than one per game.
+// ─────────────────────────────────────────────────────────────────
+
+type uninstallBatch struct {
+	queue     []game
+	current   int // index into queue of the game being uninstalled
+	removed   []game
+	skipped   []game // still installed after their command finished
+	stopping  bool   // finish the current game, then stop
+	startedAt time.Time
+}
+
+// markedGames returns the marked games in list order.
+func (gl *gameList) markedGames() []game {
+	var out []game
+	for _, g := range gl.all {
+		if gl.marked[g.appID] {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+func (gl *gameList) toggleMark(g game) {
+	if gl.marked == nil {
+		gl.marked = map[string]bool{}
+	}
+	if gl.marked[g.appID] {
+		delete(gl.marked, g.appID)
+	} else {
+		gl.marked[g.appID] = true
+	}
+}
+
+// batchUninstallItem is the confirmation for uninstalling games.
+func batchUninstallItem(games []game) menuItem {
+	warning := []string{fmt.Sprintf("%d games, %s on disk:", len(games),
+		strings.TrimSuffix(formatSpeed(float64(totalSize(games))), "/s"))}
+	for i, g := range games {
+		if i == 6 {
+			warning = append(warning, fmt.Sprintf("… and %d more", len(games)-i))
+			break
+		}
+		warning = append(warning, "  "+g.name)
+	}
+	warning = append(warning, "Steam asks once more for each game. s stops after the current one.")
+	return menuItem{icon: "✕", label: fmt.Sprintf("Uninstall %d games", len(games)),
+		requires: reqExists, confirm: true, destructive: true, warning: warning,
+		run: func(m *model) tea.Cmd { return m.startBatch(games) }}
+}
+
+func (m *model) startBatch(games []game) tea.Cmd {
+	m.batch = &uninstallBatch{queue: games, startedAt: time.Now()}
+	keys.StopBatch.SetEnabled(true)
+	return m.runBatchGame()
+}
+
+func (m *model) runBatchGame() tea.Cmd {
+	b := m.batch
+	g := b.queue[b.current]
+	m.appendLog(styleLogHeader.Render(fmt.Sprintf("  ── Uninstalling %d/%d: %s", b.current+1, len(b.queue), g.name)))
+	return m.execUninstall(g)
+}
+
+// stopBatch is s during a batch.
+func (m *model) stopBatch() tea.Cmd {
+	if m.batch == nil || m.batch.stopping {
+		return nil
+	}
+	m.batch.stopping = true
+	return m.showToast("Stopping after " + m.batch.queue[m.batch.current].name)
+}
+
+// advanceBatch records the game that just finished and starts the next
+// one, or reports the batch once it is done or stopped.
+func (m *model) advanceBatch(removed bool) tea.Cmd {
+	b := m.batch
+	g := b.queue[b.current]
+	if removed {
+		b.removed = append(b.removed, g)
+	} else {
+		b.skipped = append(b.skipped, g)
+	}
+	b.current++
+	if b.current < len(b.queue) && !b.stopping {
+		return tea.Batch(m.runBatchGame(), m.spinnerTick())
+	}
+
+	m.batch = nil
+	keys.StopBatch.SetEnabled(false)
+	m.logBatchSummary(b)
+	done := tea.Batch(m.showToast(fmt.Sprintf("Uninstalled %d of %d games", len(b.removed), len(b.queue))),
+		m.attention(time.Since(b.startedAt)))
+	if !cfg.Notify || time.Since(b.startedAt) < notifyMinDuration {
+		return done
+	}
+	return tea.Batch(done, notifyCmd(batchNotification(b)))
+}
+
+// batchNotification sums up a finished batch, e.g. "3/4 done" with
+// "1 failed: Portal 2" as the body.
+func batchNotification(b *uninstallBatch) notification {
+	n := notification{summary: fmt.Sprintf("Uninstall: %d/%d done", len(b.removed), len(b.queue))}
+	var body []string
+	if len(b.skipped) > 0 {
+		names := make([]string, len(b.skipped))
+		for i, g := range b.skipped {
+			names[i] = g.name
+		}
+		body = append(body, fmt.Sprintf("%d failed: %s", len(b.skipped), strings.Join(names, ", ")))
+		n.failed = true
+	}
+	if left := len(b.queue) - b.current; left > 0 {
+		body = append(body, fmt.Sprintf("%d not started", left))
+	}
+	if len(body) == 0 {
+		body = append(body, "All games were uninstalled.")
+	}
+	n.body = strings.Join(body, "; ")
+	return n
+}
+
+func (m *model) logBatchSummary(b *uninstallBatch) {
+	m.appendLog(styleLogHeader.Render("  ── Batch uninstall"))
+	for _, g := range b.removed {
+		m.appendLog(styleLogSuccess.Render("  ✔  Removed  " + g.name))
+	}
+	for _, g := range b.skipped {
+		m.appendLog(styleLogWarning.Render("  ⚠  Skipped  " + g.name + " — still installed"))
+	}
+	for _, g := range b.queue[b.current:] {
+		m.appendLog(styleLogDim.Render("  ·  Not started  " + g.name))
+	}
+	m.appendLog("")
+}
+
+// batchStatus is the overall progress shown in the status bar.
+func (m model) batchStatus() string {
+	if m.batch == nil {
+		return ""
+	}
+	s := fmt.Sprintf("Batch %d/%d", m.batch.current+1, len(m.batch.queue))
+	if m.batch.stopping {
+		s += " (stopping)"
+	}
+	return s
+}
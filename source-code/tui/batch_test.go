@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// batchModel opens the games view on three installed games with real
+// manifests, and marks the rows at the given indexes.
+func batchModel(t *testing.T, marks ...int) (model, []game) {
+	t.Helper()
+	t.Cleanup(func() { keys.StopBatch.SetEnabled(false) })
+	dir := filepath.Join(t.TempDir(), "steamapps")
+	writeManifests(t, dir,
+		game{appID: "570", name: "Dota 2", size: 1 << 30},
+		game{appID: "620", name: "Portal 2", size: 2 << 30},
+		game{appID: "440", name: "Team Fortress 2", size: 3 << 30},
+	)
+	games, err := loadGames(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.containerStatus = "running"
+	next, _ := m.Update(gamesLoadedMsg{games: games})
+	m = next.(model)
+	for _, i := range marks {
+		m.games.cursor = i
+		m = press(m, " ")
+	}
+	return m, games
+}
+
+// finishGame ends the running uninstall, with the game's manifest gone
+// when removed is set and the wait for it timed out otherwise.
+func finishGame(t *testing.T, m model, g game, removed bool) model {
+	t.Helper()
+	if removed {
+		if err := os.Remove(g.manifest); err != nil {
+			t.Fatal(err)
+		}
+	}
+	next, _ := m.Update(cmdDoneMsg(true))
+	m = next.(model)
+	if !removed {
+		if m.uninstalling == nil || m.batch.queue[m.batch.current].appID != g.appID {
+			t.Fatalf("batch moved past %s before Steam removed it", g.name)
+		}
+		m.uninstalling.deadline = time.Now().Add(-time.Second)
+		next, _ = m.Update(uninstallTickMsg{})
+		m = next.(model)
+	}
+	return m
+}
+
+func TestMarkedGamesInListOrder(t *testing.T) {
+	m, games := batchModel(t, 2, 0)
+	got := m.games.markedGames()
+	if len(got) != 2 || got[0].appID != games[0].appID || got[1].appID != games[2].appID {
+		t.Fatalf("markedGames = %v", got)
+	}
+	m.games.cursor = 0
+	m = press(m, " ")
+	if got := m.games.markedGames(); len(got) != 1 || got[0].appID != games[2].appID {
+		t.Errorf("unmarking left %v", got)
+	}
+	if !strings.Contains(m.renderGames(), "1 marked") {
+		t.Error("marked count not shown")
+	}
+}
+
+func TestBatchUninstallOrder(t *testing.T) {
+	m, games := batchModel(t, 0, 1, 2)
+	m = press(m, "u")
+	if m.state != stateConfirm || m.pendingItem.label != "Uninstall 3 games" {
+		t.Fatalf("u opened state %v (%v)", m.state, m.pendingItem)
+	}
+	m = press(settle(m), "y")
+
+	// The second game stays installed: Steam's own prompt was declined.
+	removed := []bool{true, false, true}
+	for i, g := range games {
+		if m.batch == nil {
+			t.Fatalf("batch ended before %s", g.name)
+		}
+		if m.batch.current != i || countLogged(m, "steam://uninstall/"+g.appID) != 1 {
+			t.Fatalf("step %d: current %d, log %q", i, m.batch.current, plainLogLines(m.logLines, false))
+		}
+		if got := m.batchStatus(); got != fmt.Sprintf("Batch %d/3", i+1) {
+			t.Errorf("step %d: status %q", i, got)
+		}
+		m = finishGame(t, m, g, removed[i])
+	}
+
+	if m.batch != nil || keys.StopBatch.Enabled() {
+		t.Error("batch still active after the last game")
+	}
+	for _, want := range []string{"Removed  Dota 2", "Skipped  Portal 2", "Removed  Team Fortress 2"} {
+		if countLogged(m, want) != 1 {
+			t.Errorf("summary lacks %q", want)
+		}
+	}
+	if !strings.Contains(m.toast, "Uninstalled 2 of 3 games") {
+		t.Errorf("toast = %q", m.toast)
+	}
+}
+
+func TestBatchStopFinishesCurrentGame(t *testing.T) {
+	m, games := batchModel(t, 0, 1, 2)
+	m = press(settle(press(m, "u")), "y", "s")
+	if m.batch == nil || !m.batch.stopping || !strings.Contains(m.batchStatus(), "stopping") {
+		t.Fatalf("s did not mark the batch as stopping: %+v", m.batch)
+	}
+	if countLogged(m, "steam://uninstall/") != 1 {
+		t.Fatal("s interrupted the current game")
+	}
+
+	m = finishGame(t, m, games[0], true)
+	if m.batch != nil || countLogged(m, "steam://uninstall/") != 1 {
+		t.Fatalf("batch carried on after stopping (%d started)", countLogged(m, "steam://uninstall/"))
+	}
+	for _, want := range []string{"Removed  Dota 2", "Not started  Portal 2", "Not started  Team Fortress 2"} {
+		if countLogged(m, want) != 1 {
+			t.Errorf("summary lacks %q", want)
+		}
+	}
+	for _, g := range games[1:] {
+		if _, err := os.Stat(g.manifest); err != nil {
+			t.Errorf("%s touched after the stop", g.name)
+		}
+	}
+}
+
+// The next game starts once Steam has removed the current one.
+func TestBatchWaitsForEachGame(t *testing.T) {
+	m, games := batchModel(t, 0, 1)
+	m = press(settle(press(m, "u")), "y")
+	next, _ := m.Update(cmdDoneMsg(true))
+	m = next.(model)
+	if !m.busy || countLogged(m, "steam://uninstall/"+games[1].appID) != 0 {
+		t.Fatal("second game started while the first was still installed")
+	}
+	if err := os.Remove(games[0].manifest); err != nil {
+		t.Fatal(err)
+	}
+	next, _ = m.Update(uninstallTickMsg{})
+	m = next.(model)
+	if m.batch == nil || m.batch.current != 1 || countLogged(m, "steam://uninstall/"+games[1].appID) != 1 {
+		t.Fatalf("second game not started once the first was gone (batch %+v)", m.batch)
+	}
+	if len(m.batch.removed) != 1 || m.batch.removed[0].appID != games[0].appID {
+		t.Errorf("removed = %v", m.batch.removed)
+	}
+}
+
+// A failed game in a batch doesn't stop it for an escalation prompt.
+func TestBatchFailureKeepsGoing(t *testing.T) {
+	m, games := batchModel(t, 0, 1)
+	m = press(settle(press(m, "u")), "y")
+	m.runOutput = []string{"Permission denied"}
+	next, _ := m.Update(cmdDoneMsg(false))
+	m = next.(model)
+	if m.state == stateEscalate || m.batch == nil || m.batch.current != 1 {
+		t.Fatalf("state %v, batch %+v", m.state, m.batch)
+	}
+	if countLogged(m, "steam://uninstall/"+games[1].appID) != 1 {
+		t.Error("next game not started")
+	}
+}
+
+func TestBatchNotification(t *testing.T) {
+	dota := game{appID: "570", name: "Dota 2"}
+	portal := game{appID: "620", name: "Portal 2"}
+	tf2 := game{appID: "440", name: "Team Fortress 2"}
+	cs := game{appID: "730", name: "Counter-Strike 2"}
+	tests := []struct {
+		name    string
+		b       uninstallBatch
+		summary string
+		body    string
+		failed  bool
+	}{
+		{"all removed", uninstallBatch{queue: []game{dota, portal}, current: 2, removed: []game{dota, portal}},
+			"Uninstall: 2/2 done", "All games were uninstalled.", false},
+		{"one failed", uninstallBatch{queue: []game{dota, portal, tf2, cs}, current: 4, removed: []game{dota, tf2, cs}, skipped: []game{portal}},
+			"Uninstall: 3/4 done", "1 failed: Portal 2", true},
+		{"stopped early", uninstallBatch{queue: []game{dota, portal, tf2, cs}, current: 2, removed: []game{dota}, skipped: []game{portal}},
+			"Uninstall: 1/4 done", "1 failed: Portal 2; 2 not started", true},
+		{"stopped cleanly", uninstallBatch{queue: []game{dota, portal, tf2}, current: 1, removed: []game{dota}},
+			"Uninstall: 1/3 done", "2 not started", false},
+		{"several failed", uninstallBatch{queue: []game{dota, portal}, current: 2, skipped: []game{dota, portal}},
+			"Uninstall: 0/2 done", "2 failed: Dota 2, Portal 2", true},
+	}
+	for _, tt := range tests {
+		n := batchNotification(&tt.b)
+		if n.summary != tt.summary || n.body != tt.body || n.failed != tt.failed {
+			t.Errorf("%s: %+v", tt.name, n)
+		}
+	}
+}
+
+func TestSafeModeBlocksBatchUninstall(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.SafeMode = true
+	m := initialModel()
+	m.containerStatus = "running"
+	item := batchUninstallItem([]game{{appID: "570", name: "Dota 2"}, {appID: "620", name: "Portal 2"}})
+	if reason := m.disabledReason(item); reason != "disabled in safe mode" {
+		t.Errorf("%s: reason %q in safe mode", item.label, reason)
+	}
+}
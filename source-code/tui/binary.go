@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  CLI binary changes
+//  A system update can replace hackeros-steam while the TUI runs, and
+//  the next action then runs a different CLI than the one the session
+//  started with. The binary is stat'ed before every run; a new size
+//  or mtime is logged, and with warn_binary_change a toast says so
+//  too. A remote host's binary can't be stat'ed and isn't tracked.
+// ─────────────────────────────────────────────────────────────────
+
+// binaryStamp identifies a version of a file on disk.
+type binaryStamp struct {
+	size  int64
+	mtime time.Time
+}
+
+// statBinary stamps the file at path; ok is false when it can't be
+// stat'ed.
+func statBinary(path string) (binaryStamp, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return binaryStamp{}, false
+	}
+	return binaryStamp{size: info.Size(), mtime: info.ModTime()}, true
+}
+
+func (s binaryStamp) String() string {
+	return fmt.Sprintf("%d bytes, modified %s", s.size, s.mtime.Format("2006-01-02 15:04:05"))
+}
+
+// binaryWatch remembers the stamp of the binary as last run.
+type binaryWatch struct {
+	path  string
+	stamp binaryStamp
+	seen  bool
+}
+
+// check stamps the binary again and returns the previous stamp when
+// it changed since the last check.
+func (w *binaryWatch) check() (binaryStamp, bool) {
+	cur, ok := statBinary(w.path)
+	if !ok {
+		return binaryStamp{}, false
+	}
+	prev, seen := w.stamp, w.seen
+	w.stamp, w.seen = cur, true
+	if !seen || (cur.size == prev.size && cur.mtime.Equal(prev.mtime)) {
+		return binaryStamp{}, false
+	}
+	return prev, true
+}
+
+// cliWatch follows the hackeros-steam binary.
+var cliWatch = &binaryWatch{path: cli}
+
+// noteBinaryChange is called before an action runs argv.
+func (m *model) noteBinaryChange(argv []string) tea.Cmd {
+	if remoteHost != "" || len(argv) == 0 || argv[0] != cliWatch.path {
+		return nil
+	}
+	prev, changed := cliWatch.check()
+	if !changed {
+		return nil
+	}
+	m.appendLog(styleLogWarning.Render("  ⚠  " + cliWatch.path + " was replaced since it last ran"))
+	m.appendLog(styleLogDim.Render("     before: " + prev.String()))
+	m.appendLog(styleLogDim.Render("     now:    " + cliWatch.stamp.String()))
+	m.recordStatus("hackeros-steam replaced")
+	if !cfg.WarnBinaryChange {
+		return nil
+	}
+	return m.showToast("⚠ hackeros-steam was updated during this session")
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBinaryWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hackeros-steam")
+	os.WriteFile(path, []byte("v1"), 0o755)
+	w := &binaryWatch{path: path}
+	then := time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local)
+	os.Chtimes(path, then, then)
+
+	steps := []struct {
+		name    string
+		change  func()
+		changed bool
+	}{
+		{"first look", func() {}, false},
+		{"untouched", func() {}, false},
+		{"new size", func() { os.WriteFile(path, []byte("version 2"), 0o755); os.Chtimes(path, then, then) }, true},
+		{"same again", func() {}, false},
+		{"new mtime only", func() { os.Chtimes(path, then.Add(time.Hour), then.Add(time.Hour)) }, true},
+		{"removed", func() { os.Remove(path) }, false},
+		{"back as it was", func() {
+			os.WriteFile(path, []byte("version 2"), 0o755)
+			os.Chtimes(path, then.Add(time.Hour), then.Add(time.Hour))
+		}, false},
+	}
+	for _, s := range steps {
+		s.change()
+		before := w.stamp
+		prev, changed := w.check()
+		if changed != s.changed {
+			t.Fatalf("%s: changed = %v, want %v", s.name, changed, s.changed)
+		}
+		if changed && prev != before {
+			t.Errorf("%s: previous stamp %v, want %v", s.name, prev, before)
+		}
+	}
+}
+
+func TestNoteBinaryChange(t *testing.T) {
+	defer func(saved *binaryWatch, c config, host string) { cliWatch, cfg, remoteHost = saved, c, host }(cliWatch, cfg, remoteHost)
+	path := filepath.Join(t.TempDir(), "hackeros-steam")
+	os.WriteFile(path, []byte("v1"), 0o755)
+	cliWatch = &binaryWatch{path: path}
+	remoteHost = ""
+
+	m := initialModel()
+	if cmd := m.noteBinaryChange([]string{path, "run"}); cmd != nil || countLogged(m, "was replaced") != 0 {
+		t.Fatal("first run reported a change")
+	}
+	os.WriteFile(path, []byte("version 2"), 0o755)
+
+	// Other commands don't look at it.
+	if m.noteBinaryChange([]string{"xdg-open", "/tmp"}); countLogged(m, "was replaced") != 0 {
+		t.Fatal("checked for a command that isn't hackeros-steam")
+	}
+
+	cfg.WarnBinaryChange = true
+	if cmd := m.noteBinaryChange([]string{path, "setup"}); cmd == nil || !strings.Contains(m.toast, "updated during this session") {
+		t.Errorf("no toast for the replaced binary (toast %q)", m.toast)
+	}
+	if countLogged(m, path+" was replaced since it last ran") != 1 || countLogged(m, "before: 2 bytes") != 1 || countLogged(m, "now:    9 bytes") != 1 {
+		t.Errorf("change not logged: %q", plainLogLines(m.logLines, false))
+	}
+
+	os.WriteFile(path, []byte("v3"), 0o755)
+	cfg.WarnBinaryChange = false
+	m.toast = ""
+	if cmd := m.noteBinaryChange([]string{path, "setup"}); cmd != nil || m.toast != "" || countLogged(m, "was replaced") != 2 {
+		t.Errorf("warn_binary_change = false: cmd %v, toast %q", cmd, m.toast)
+	}
+
+	remoteHost = "deck"
+	os.WriteFile(path, []byte("remote v4"), 0o755)
+	if m.noteBinaryChange([]string{path, "setup"}); countLogged(m, "was replaced") != 2 {
+		t.Error("a remote host's binary was compared with the local one")
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Output capture limit
+//  An action keeps at most cfg.OutputLimit bytes of its output, in the
+//  log panel and in memory, so a command that prints without end can't
+//  exhaust memory. The tail is kept since that is where errors are;
+//  the dropped head is replaced by a single notice.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	defaultOutputLimit = 2 << 20 // 2MB
+	truncatedNotice    = "[output truncated]"
+)
+
+// outputLimit is cfg.OutputLimit in bytes.
+var outputLimit = defaultOutputLimit
+
+// parseSize reads sizes like "2MB", "512K" or "1048576".
+func parseSize(s string) (int, error) {
+	t := strings.ToUpper(strings.TrimSpace(s))
+	mult := 1
+	for _, u := range []struct {
+		suffix string
+		mult   int
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(t, u.suffix) {
+			t, mult = strings.TrimSpace(strings.TrimSuffix(t, u.suffix)), u.mult
+			break
+		}
+	}
+	n, err := strconv.Atoi(t)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * mult, nil
+}
+
+// compileOutputLimit validates cfg.OutputLimit; a bad value is reported
+// and the default kept.
+func compileOutputLimit(s string) (int, error) {
+	if s == "" {
+		return defaultOutputLimit, nil
+	}
+	n, err := parseSize(s)
+	switch {
+	case err != nil:
+		return defaultOutputLimit, fmt.Errorf("output_limit: %w", err)
+	case n < 4<<10:
+		return defaultOutputLimit, fmt.Errorf("output_limit: %s is below 4KB", s)
+	}
+	return n, nil
+}
+
+// tailBuffer is an io.Writer that keeps the last limit bytes written.
+type tailBuffer struct {
+	limit   int
+	buf     []byte
+	written int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.written += len(p)
+	t.buf = append(t.buf, p...)
+	// Trim in chunks so each byte is copied a bounded number of times.
+	if len(t.buf) > 2*t.limit {
+		t.buf = append(t.buf[:0], t.buf[len(t.buf)-t.limit:]...)
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) truncated() bool { return t.written > t.limit }
+
+// String returns the kept tail, starting at a line boundary and behind
+// the notice when something was dropped.
+func (t *tailBuffer) String() string {
+	b := t.buf
+	if len(b) > t.limit {
+		b = b[len(b)-t.limit:]
+	}
+	if !t.truncated() {
+		return string(b)
+	}
+	if i := strings.IndexByte(string(b), '\n'); i >= 0 {
+		b = b[i+1:]
+	}
+	return truncatedNotice + "\n" + string(b)
+}
+
+// combinedOutputTail is CombinedOutput bounded by outputLimit.
+func combinedOutputTail(c *exec.Cmd) (string, error) {
+	out := combinedTailOf(c)
+	err := runTracked(c)
+	return out(), err
+}
+
+// combinedTailOf has c write stdout and stderr, bounded by outputLimit,
+// to a buffer read once c has run.
+func combinedTailOf(c *exec.Cmd) func() string {
+	tb := &tailBuffer{limit: outputLimit}
+	c.Stdout, c.Stderr = tb, tb
+	return tb.String
+}
+
+// stdoutOf is combinedTailOf for stdout alone, unbounded, like Output.
+func stdoutOf(c *exec.Cmd) func() []byte {
+	var b bytes.Buffer
+	c.Stdout = &b
+	return b.Bytes
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Streamed capture
+//  Output lines of the running action are tagged with its run ID, so
+//  the oldest can be found in the log and dropped.
+// ─────────────────────────────────────────────────────────────────
+
+// runCapture tracks the running action's output size.
+type runCapture struct {
+	id        int
+	bytes     int   // in runOutput
+	sizes     []int // of each runOutput line
+	truncated bool
+}
+
+// beginCapture starts a new action's capture.
+func (m *model) beginCapture() {
+	m.runOutput = nil
+	m.startFailed = false
+	m.warnings.reset()
+	m.capture = runCapture{id: m.capture.id + 1}
+}
+
+// captureLine records an output line of the running action, dropping
+// the oldest ones once the limit is passed.
+func (m *model) captureLine(plain, rendered string) {
+	m.runOutput = append(m.runOutput, plain)
+	size := len(plain) + 1
+	m.capture.sizes = append(m.capture.sizes, size)
+	m.capture.bytes += size
+	m.logLines = append(m.logLines, logLine{text: rendered, at: time.Now(), run: m.capture.id})
+	if m.capture.bytes > outputLimit {
+		m.trimCapture()
+	}
+	m.flushLog()
+}
+
+// trimCapture drops the oldest lines down to 3/4 of the limit, so the
+// copying is spread over many lines.
+func (m *model) trimCapture() {
+	c := &m.capture
+	n := 0
+	for c.bytes > outputLimit*3/4 && n < len(c.sizes)-1 {
+		c.bytes -= c.sizes[n]
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	c.sizes = append(c.sizes[:0], c.sizes[n:]...)
+	m.runOutput = append(m.runOutput[:0], m.runOutput[n:]...)
+
+	kept := m.logLines[:0]
+	dropped := 0
+	for _, l := range m.logLines {
+		if l.run == c.id && dropped < n {
+			if dropped == 0 && !c.truncated {
+				kept = append(kept, logLine{text: styleLogWarning.Render("  " + truncatedNotice), at: l.at})
+			}
+			dropped++
+			continue
+		}
+		kept = append(kept, l)
+	}
+	m.logLines = kept
+	c.truncated = true
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"2MB", 2 << 20, true},
+		{"512K", 512 << 10, true},
+		{" 1 gb ", 1 << 30, true},
+		{"1048576", 1 << 20, true},
+		{"100B", 100, true},
+		{"0", 0, false},
+		{"-1MB", 0, false},
+		{"lots", 0, false},
+		{"MB", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseSize(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("parseSize(%q) = %d, %v; want %d, ok %v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCompileOutputLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"", defaultOutputLimit, false},
+		{"8MB", 8 << 20, false},
+		{"4KB", 4 << 10, false},
+		{"1KB", defaultOutputLimit, true},
+		{"huge", defaultOutputLimit, true},
+	}
+	for _, tt := range tests {
+		got, err := compileOutputLimit(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("compileOutputLimit(%q) = %d, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestTailBufferBoundary(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		write []string
+		want  string
+	}{
+		{"under the limit", 10, []string{"abc\n", "def\n"}, "abc\ndef\n"},
+		{"exactly the limit", 8, []string{"abc\n", "def\n"}, "abc\ndef\n"},
+		{"one byte over", 8, []string{"abc\n", "def\n", "g"}, truncatedNotice + "\ndef\ng"},
+		{"cut at a line boundary", 10, []string{"line1\nline2\nline3\n"}, truncatedNotice + "\nline3\n"},
+		{"one long line", 4, []string{"abcdefgh"}, truncatedNotice + "\nefgh"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &tailBuffer{limit: tt.limit}
+			for _, w := range tt.write {
+				tb.Write([]byte(w))
+			}
+			if got := tb.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Many small writes keep memory bounded and the newest bytes intact.
+func TestTailBufferKeepsTail(t *testing.T) {
+	tb := &tailBuffer{limit: 1 << 10}
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(tb, "line %05d\n", i)
+	}
+	if len(tb.buf) > 2*tb.limit {
+		t.Errorf("buffer grew to %d bytes", len(tb.buf))
+	}
+	out := tb.String()
+	if !strings.HasPrefix(out, truncatedNotice+"\nline ") || !strings.HasSuffix(out, "line 09999\n") {
+		t.Errorf("tail = %q…%q", out[:40], out[len(out)-20:])
+	}
+	if len(out) > tb.limit+len(truncatedNotice)+1 {
+		t.Errorf("kept %d bytes", len(out))
+	}
+}
+
+func TestCombinedOutputTail(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	defer func(saved int) { outputLimit = saved }(outputLimit)
+	outputLimit = 4 << 10
+	out, err := combinedOutputTail(exec.Command(sh, "-c", `i=0; while [ $i -lt 2000 ]; do echo "out $i"; i=$((i+1)); done; echo "fatal: last words" >&2; exit 3`))
+	if err == nil {
+		t.Error("exit status lost")
+	}
+	if !strings.HasPrefix(out, truncatedNotice) || !strings.HasSuffix(out, "fatal: last words\n") {
+		t.Errorf("output head %q, tail %q", out[:30], out[len(out)-30:])
+	}
+}
+
+func TestStreamedCaptureKeepsTail(t *testing.T) {
+	defer func(saved int) { outputLimit = saved }(outputLimit)
+	outputLimit = 100 // bytes
+
+	m := initialModel()
+	m.appendLog("before the action")
+	m.beginCapture()
+	for i := 0; i < 50; i++ {
+		line := fmt.Sprintf("line %02d", i) // 8 bytes + newline
+		m.captureLine(line, line)
+	}
+
+	if m.capture.bytes > outputLimit || !m.capture.truncated {
+		t.Fatalf("captured %d bytes, truncated %v", m.capture.bytes, m.capture.truncated)
+	}
+	if last := m.runOutput[len(m.runOutput)-1]; last != "line 49" {
+		t.Errorf("last runOutput line = %q", last)
+	}
+	if first := m.runOutput[0]; first == "line 00" {
+		t.Error("head was kept")
+	}
+	if countLogged(m, truncatedNotice) != 1 {
+		t.Errorf("%d truncation notices", countLogged(m, truncatedNotice))
+	}
+	if countLogged(m, "before the action") != 1 {
+		t.Error("the TUI's own lines were dropped")
+	}
+	kept := 0
+	for _, l := range m.logLines {
+		if l.run == m.capture.id {
+			kept++
+		}
+	}
+	if kept != len(m.runOutput) {
+		t.Errorf("log keeps %d of the action's lines, runOutput %d", kept, len(m.runOutput))
+	}
+
+	// The next action starts a fresh capture and leaves the old one be.
+	m.beginCapture()
+	m.captureLine("next", "next")
+	if len(m.runOutput) != 1 || m.capture.truncated || countLogged(m, "line 49") != 1 {
+		t.Errorf("new capture: %q, truncated %v", m.runOutput, m.capture.truncated)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Steam client version
+//  The client's build number is the Unix time it was built, so the
+//  header can show both. "Check Steam Client" asks Steam's update
+//  server which build the current channel offers, without installing
+//  anything; Steam itself updates on its next start.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	clientUpdateURL     = "https://client-update.akamai.steamstatic.com/"
+	clientCheckTimeout  = 10 * time.Second
+	stableClientPackage = "steam_client_ubuntu12"
+	betaClientPackage   = "steam_client_publicbeta_ubuntu12"
+)
+
+type clientCheckMsg struct {
+	channel   steamChannel
+	installed string
+	latest    string
+	err       error
+}
+
+// clientBuildDate turns a build number into its date; ok is false for
+// anything that doesn't look like one.
+func clientBuildDate(version string) (time.Time, bool) {
+	n, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	t := time.Unix(n, 0)
+	// Builds before Steam for Linux existed, or from the future, are
+	// something else.
+	if t.Year() < 2012 || t.After(time.Now().AddDate(1, 0, 0)) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// describeClient renders a version as "1716584667 (2024-05-24)".
+func describeClient(version string) string {
+	if version == "" {
+		return "unknown"
+	}
+	if t, ok := clientBuildDate(version); ok {
+		return version + " (" + t.Format("2006-01-02") + ")"
+	}
+	return version
+}
+
+// clientHeader is the header element; empty when Steam's version
+// can't be read.
+func (m model) clientHeader() string {
+	if m.clientVersion == "" {
+		return ""
+	}
+	return "Steam " + describeClient(m.clientVersion)
+}
+
+func clientPackage(ch steamChannel) string {
+	if ch == channelBeta {
+		return betaClientPackage
+	}
+	return stableClientPackage
+}
+
+// fetchLatestClient reads the build the update server offers for ch.
+func fetchLatestClient(base string, ch steamChannel) (string, error) {
+	client := &http.Client{Timeout: clientCheckTimeout}
+	resp, err := client.Get(base + clientPackage(ch))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("update server answered %s", resp.Status)
+	}
+	v := parseClientVersion(resp.Body)
+	if v == "" {
+		return "", fmt.Errorf("no version in the update server's manifest")
+	}
+	return v, nil
+}
+
+// checkClient is the "Check Steam Client" action.
+func (m *model) checkClient() tea.Cmd {
+	m.busy = true
+	ch := currentChannel()
+	return func() tea.Msg {
+		latest, err := fetchLatestClient(clientUpdateURL, ch)
+		return clientCheckMsg{channel: ch, installed: readClientVersion(), latest: latest, err: err}
+	}
+}
+
+func (m *model) handleClientCheck(msg clientCheckMsg) {
+	m.busy = false
+	m.clientVersion = msg.installed
+	m.appendLog(styleLogHeader.Render("  ── Steam client (" + channelLabel(msg.channel) + " channel)"))
+	m.appendLog(styleLogInfo.Render("  Installed: " + describeClient(msg.installed)))
+	if msg.err != nil {
+		m.logError("Could not check for a newer client: " + msg.err.Error())
+		m.appendLog("")
+		return
+	}
+	m.appendLog(styleLogInfo.Render("  Latest:    " + describeClient(msg.latest)))
+	switch {
+	case msg.installed == "":
+		m.appendLog(styleLogDim.Render("  → Steam isn't installed yet; it fetches this build on first start."))
+	case clientNewer(msg.latest, msg.installed):
+		m.appendLog(styleLogWarning.Render("  ⚠  A newer client is available; Steam installs it on its next start."))
+	default:
+		m.appendLog(styleLogSuccess.Render("  ✔  Up to date."))
+	}
+	m.appendLog("")
+}
+
+// clientNewer compares build numbers; unparsable ones never are.
+func clientNewer(latest, installed string) bool {
+	l, err1 := strconv.ParseInt(latest, 10, 64)
+	i, err2 := strconv.ParseInt(installed, 10, 64)
+	return err1 == nil && err2 == nil && l > i
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientBuildDate(t *testing.T) {
+	future := strconv.FormatInt(time.Now().AddDate(2, 0, 0).Unix(), 10)
+	tests := []struct {
+		version string
+		want    int64
+		ok      bool
+	}{
+		{"1716584667", 1716584667, true},
+		{"1341000000", 1341000000, true},
+		{"1000000000", 0, false}, // 2001, before Steam for Linux
+		{future, 0, false},
+		{"v2.10.91", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := clientBuildDate(tt.version)
+		if ok != tt.ok || (ok && got.Unix() != tt.want) {
+			t.Errorf("clientBuildDate(%q) = %v, %v; want %v", tt.version, got, ok, tt.ok)
+		}
+	}
+}
+
+func TestDescribeClient(t *testing.T) {
+	day := time.Unix(1716584667, 0).Format("2006-01-02")
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1716584667", "1716584667 (" + day + ")"},
+		{"beta-7", "beta-7"},
+		{"", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := describeClient(tt.version); got != tt.want {
+			t.Errorf("describeClient(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestClientNewer(t *testing.T) {
+	tests := []struct {
+		latest, installed string
+		want              bool
+	}{
+		{"1716584667", "1700000000", true},
+		{"1700000000", "1716584667", false},
+		{"1716584667", "1716584667", false},
+		{"1716584667", "", false},
+		{"garbage", "1700000000", false},
+	}
+	for _, tt := range tests {
+		if got := clientNewer(tt.latest, tt.installed); got != tt.want {
+			t.Errorf("clientNewer(%q, %q) = %v, want %v", tt.latest, tt.installed, got, tt.want)
+		}
+	}
+}
+
+func TestParseClientVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+	}{
+		{"installed manifest", "\"ubuntu12\"\n{\n\t\"version\"\t\t\"1716584667\"\n}\n", "1716584667"},
+		{"server manifest", "\"ubuntu12\"\n{\n\t\"version\"\t\t\"1716584668\"\n\t\"bins_ubuntu12\"\n\t{\n\t}\n}\n", "1716584668"},
+		{"nested version key ignored", "\"x\"\n{\n\t\"versionstring\"\t\"1\"\n}\n", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		if got := parseClientVersion(strings.NewReader(tt.manifest)); got != tt.want {
+			t.Errorf("%s: parseClientVersion = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFetchLatestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + stableClientPackage:
+			w.Write([]byte("\"ubuntu12\"\n{\n\t\"version\"\t\t\"1716584667\"\n}\n"))
+		case "/" + betaClientPackage:
+			w.Write([]byte("\"ubuntu12\"\n{\n}\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	if v, err := fetchLatestClient(srv.URL+"/", channelStable); err != nil || v != "1716584667" {
+		t.Errorf("stable: %q, %v", v, err)
+	}
+	if _, err := fetchLatestClient(srv.URL+"/", channelBeta); err == nil || !strings.Contains(err.Error(), "no version") {
+		t.Errorf("beta without a version: err = %v", err)
+	}
+	if _, err := fetchLatestClient(srv.URL+"/missing/", channelStable); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("not found: err = %v", err)
+	}
+}
+
+// The check only reports; nothing is run in the container.
+func TestHandleClientCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  clientCheckMsg
+		want string
+	}{
+		{"newer", clientCheckMsg{channel: channelStable, installed: "1700000000", latest: "1716584667"}, "A newer client is available"},
+		{"current", clientCheckMsg{channel: channelBeta, installed: "1716584667", latest: "1716584667"}, "Up to date."},
+		{"not installed", clientCheckMsg{channel: channelStable, latest: "1716584667"}, "isn't installed yet"},
+		{"offline", clientCheckMsg{channel: channelStable, installed: "1700000000", err: errors.New("no route to host")}, "Could not check for a newer client: no route to host"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.busy = true
+			m.handleClientCheck(tt.msg)
+			if m.busy || m.clientVersion != tt.msg.installed {
+				t.Errorf("busy=%v clientVersion=%q", m.busy, m.clientVersion)
+			}
+			if countLogged(m, tt.want) != 1 {
+				t.Errorf("log lacks %q: %q", tt.want, plainLogLines(m.logLines, false))
+			}
+			if countLogged(m, "$ hackeros-steam") != 0 {
+				t.Error("check ran a command")
+			}
+			if tt.msg.err != nil && (m.errorCount != 1 || countLogged(m, "✖  ✖") != 0) {
+				t.Errorf("error logged %d times", m.errorCount)
+			}
+		})
+	}
+}
+
+func TestClientHeader(t *testing.T) {
+	m := initialModel()
+	m.clientVersion = ""
+	if h := m.clientHeader(); h != "" {
+		t.Errorf("unknown version header = %q", h)
+	}
+	m.clientVersion = "1716584667"
+	if h := m.clientHeader(); !strings.HasPrefix(h, "Steam 1716584667 (") {
+		t.Errorf("header = %q", h)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Clipboard
+// ─────────────────────────────────────────────────────────────────
+
+// clipboardTools are tried in order; the first one found on PATH wins.
+var clipboardTools = [][]string{
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
+
+type clipboardMsg struct {
+	what     string // shown in the toast, e.g. "command"
+	terminal bool   // sent as OSC 52, which the terminal may ignore
+}
+
+// copyToClipboardCmd copies text using a system clipboard tool, falling
+// back to an OSC 52 escape sequence which most terminals understand.
+// Nothing tells whether the terminal took it, so the toast says where
+// the text went rather than claiming it was copied.
+func copyToClipboardCmd(text, what string) tea.Cmd {
+	return func() tea.Msg {
+		for _, tool := range clipboardTools {
+			if _, err := exec.LookPath(tool[0]); err != nil {
+				continue
+			}
+			c := exec.Command(tool[0], tool[1:]...)
+			c.Stdin = strings.NewReader(text)
+			if err := auditedRun("clipboard", c); err == nil {
+				return clipboardMsg{what: what}
+			}
+		}
+		termenv.Copy(text)
+		return clipboardMsg{what: what, terminal: true}
+	}
+}
+
+func (msg clipboardMsg) toast() string {
+	if msg.terminal {
+		return "Sent " + msg.what + " to the terminal clipboard"
+	}
+	return "✔ Copied " + msg.what + " to clipboard"
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClipboardToast(t *testing.T) {
+	tests := []struct {
+		msg  clipboardMsg
+		want string
+	}{
+		{clipboardMsg{what: "command"}, "✔ Copied command to clipboard"},
+		{clipboardMsg{what: "command", terminal: true}, "Sent command to the terminal clipboard"},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		next, _ := m.Update(tt.msg)
+		if got := next.(model).toast; got != tt.want {
+			t.Errorf("toast for %+v = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestCopyWithClipboardTool(t *testing.T) {
+	defer func(saved [][]string) { clipboardTools = saved }(clipboardTools)
+	clipboardTools = [][]string{{"hackeros-no-such-tool"}, {"cat"}}
+	if got := copyToClipboardCmd("text", "log")().(clipboardMsg); got.terminal || !strings.Contains(got.toast(), "Copied log") {
+		t.Errorf("copied with a tool: %+v", got)
+	}
+}
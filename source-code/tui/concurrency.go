@@ -0,0 +1,77 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Concurrency
+//  Most actions are exclusive: nothing else starts while they run.
+//  A few only look — the split log view, the network info — and are
+//  concurrent: their shortcut works while another action runs, say
+//  to watch the logs while Steam is up. Only built-ins can be, since
+//  the log panel follows one command, and not while the running
+//  action is changing the container under them.
+// ─────────────────────────────────────────────────────────────────
+
+type concurrency int
+
+const (
+	exclusive  concurrency = iota // runs alone
+	concurrent                    // may start while another action runs
+)
+
+// concurrencyReason says why next can't start while running does, or
+// "" if it can.
+func concurrencyReason(running *menuItem, next menuItem) string {
+	if running == nil {
+		return ""
+	}
+	switch {
+	case next.policy != concurrent || next.run == nil:
+		return running.label + " is running"
+	case running.destructive:
+		return running.label + " is changing the container"
+	}
+	return ""
+}
+
+// runningReason is concurrencyReason against what runs now. busy
+// without a command stream is a built-in or a prompt being prepared,
+// which nothing joins.
+func (m model) runningReason(item menuItem) string {
+	if !m.busy {
+		return ""
+	}
+	if m.stream == nil || m.lastItem == nil {
+		return "another action is running"
+	}
+	return concurrencyReason(m.lastItem, item)
+}
+
+// dispatchAlongside starts a concurrent item next to the running
+// action, which stays the last item and keeps the busy flag.
+func (m *model) dispatchAlongside(item menuItem) tea.Cmd {
+	if reason := m.disabledReason(item); reason != "" {
+		return m.showToast(item.label + " unavailable: " + reason)
+	}
+	return item.run(m)
+}
+
+// handleRunningShortcut is an action shortcut pressed while an action
+// runs.
+func (m *model) handleRunningShortcut(i int) tea.Cmd {
+	item := menuItems[i]
+	if reason := m.runningReason(item); reason != "" {
+		return m.showToast(item.label + " unavailable: " + reason)
+	}
+	return m.dispatchAction(item, menuAction(i))
+}
+
+// idleState is the state to go back to from a concurrent view.
+func (m model) idleState() viewState {
+	if m.busy && m.stream != nil {
+		return stateRunning
+	}
+	return stateMenu
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConcurrencyReason(t *testing.T) {
+	tests := []struct {
+		running, next string
+		want          string // "" = may start
+	}{
+		{"Launch Steam", "Side-by-Side Logs", ""},
+		{"Launch Steam", "Network Info", ""},
+		{"Update Container", "Side-by-Side Logs", ""},
+		{"Update Container", "Create Container", "Update Container is running"},
+		{"Launch Steam", "Update Container", "Launch Steam is running"},
+		{"Launch Steam", "Export Logs", "Launch Steam is running"},
+		{"Repair Container", "Network Info", "Repair Container is changing the container"},
+		{"Kill & Remove Container", "Side-by-Side Logs", "Kill & Remove Container is changing the container"},
+	}
+	for _, tt := range tests {
+		running := menuItems[itemIndex(t, tt.running)]
+		if got := concurrencyReason(&running, menuItems[itemIndex(t, tt.next)]); got != tt.want {
+			t.Errorf("%s during %s: %q, want %q", tt.next, tt.running, got, tt.want)
+		}
+	}
+	if got := concurrencyReason(nil, menuItems[itemIndex(t, "Create Container")]); got != "" {
+		t.Errorf("nothing running: %q", got)
+	}
+	// Only built-ins join: the log panel follows one command.
+	cmdItem := menuItem{label: "Status", cmd: []string{"status"}, policy: concurrent}
+	launch := menuItems[itemIndex(t, "Launch Steam")]
+	if got := concurrencyReason(&launch, cmdItem); got == "" {
+		t.Error("a concurrent command item may start alongside")
+	}
+}
+
+func TestRunningReason(t *testing.T) {
+	logs := menuItems[itemIndex(t, "Side-by-Side Logs")]
+	m := initialModel()
+	if got := m.runningReason(logs); got != "" {
+		t.Errorf("idle: %q", got)
+	}
+	// Busy with no command stream: a built-in, or a prompt being made.
+	m.busy = true
+	if got := m.runningReason(logs); got != "another action is running" {
+		t.Errorf("busy built-in: %q", got)
+	}
+	m.lastItem = &menuItems[itemIndex(t, "Launch Steam")]
+	m.stream = make(chan tea.Msg)
+	if got := m.runningReason(logs); got != "" {
+		t.Errorf("during a launch: %q", got)
+	}
+}
+
+func TestShortcutWhileRunning(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m, _ := runningModel(menuItems[itemIndex(t, "Launch Steam")], true)
+	m.containerStatus = "running"
+	m.stream = make(chan tea.Msg)
+
+	m = press(m, "U")
+	if !strings.Contains(m.toast, "Update Container unavailable: Launch Steam is running") {
+		t.Errorf("exclusive shortcut while running: toast %q", m.toast)
+	}
+
+	m = press(m, "O")
+	if m.state != stateSplitLogs || !m.busy || m.lastItem.label != "Launch Steam" {
+		t.Fatalf("side-by-side logs during a launch: state %v, busy %v, last %q", m.state, m.busy, m.lastItem.label)
+	}
+	m = press(m, "esc")
+	if m.state != stateRunning {
+		t.Errorf("leaving the logs went to state %v, want the running view", m.state)
+	}
+
+	// Finishing while the logs are open leaves them open.
+	m = press(m, "O")
+	next, _ := m.Update(cmdDoneMsg(true))
+	m = next.(model)
+	if m.state != stateSplitLogs {
+		t.Errorf("the launch ending closed the logs (state %v)", m.state)
+	}
+	if m = press(m, "esc"); m.state != stateMenu {
+		t.Errorf("leaving the logs when idle went to state %v", m.state)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"path/filepath"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Config
+// ─────────────────────────────────────────────────────────────────
+
+const configFileName = "config.toml"
+
+// config is read from config.toml in configDir() — by default
+// ~/.hackeros/HackerOS-Steam. Every field is optional; missing keys keep
+// their defaults.
+type config struct {
+	// PrivilegeCmd is prepended to a command when the user agrees to
+	// re-run it with elevated privileges, e.g. "pkexec" or "sudo".
+	PrivilegeCmd string `toml:"privilege_cmd"`
+
+	// LogDir is where "Export Logs" writes its files.
+	LogDir string `toml:"log_dir"`
+
+	// NoColor renders without colors; the NO_COLOR environment variable
+	// has the same effect.
+	NoColor bool `toml:"no_color"`
+
+	// ReducedMotion stops the spinner and replaces the speed sparkline
+	// with a plain figure.
+	ReducedMotion bool `toml:"reduced_motion"`
+
+	// ConfirmTimeout cancels an unanswered confirmation prompt after
+	// this many seconds; 0 keeps prompts open indefinitely.
+	ConfirmTimeout int `toml:"confirm_timeout"`
+
+	// HideDisabled hides actions that can't run right now instead of
+	// showing them dimmed.
+	HideDisabled bool `toml:"hide_disabled"`
+
+	// Hooks maps "pre_<action>"/"post_<action>" to shell commands.
+	Hooks map[string]string `toml:"hooks"`
+
+	// HookTimeout bounds each hook, in seconds.
+	HookTimeout int `toml:"hook_timeout"`
+
+	// HookAbortOnFailure skips the action when its pre hook fails.
+	HookAbortOnFailure bool `toml:"hook_abort_on_failure"`
+
+	// Patterns overrides the regexes used to parse command output; see
+	// patternSpecs for the names and their defaults.
+	Patterns map[string]string `toml:"patterns"`
+
+	// ExportPrefixes adds line numbers and timestamps to exported logs.
+	ExportPrefixes bool `toml:"export_prefixes"`
+
+	// SafeMode disables create, repair, stop and remove, for demos and
+	// locked-down setups. --safe turns it on for one session.
+	SafeMode bool `toml:"safe_mode"`
+
+	// Notify posts a desktop notification when an action that ran for a
+	// while finishes.
+	Notify bool `toml:"notify"`
+
+	// Autorun starts "steam" or "bigpicture" on startup after a short,
+	// cancellable countdown.
+	Autorun string `toml:"autorun"`
+
+	// AutorunExit quits the TUI once the autorun action has finished
+	// successfully.
+	AutorunExit bool `toml:"autorun_exit"`
+
+	// Layout is "list" (sidebar) or "grid" (menu in columns above the
+	// log); g switches between them.
+	Layout string `toml:"layout"`
+
+	// ANSIColors shows the colors in command output as printed; when
+	// off they are stripped and the TUI's own highlighting is used.
+	// Shift+A toggles it.
+	ANSIColors bool `toml:"ansi_colors"`
+
+	// Poll sets how often status, stats, update_check and network
+	// refresh, as durations like "5s" or "6h"; "0" turns one off.
+	Poll map[string]string `toml:"poll"`
+
+	// OutputLimit caps how much output one action keeps, e.g. "2MB";
+	// beyond it the oldest lines are dropped.
+	OutputLimit string `toml:"output_limit"`
+
+	// Theme sets the accent color and, optionally, separate colors for
+	// the progress bar, spinner and cursor.
+	Theme themeConfig `toml:"theme"`
+
+	// ShowArgv shows the exact command line of the running action,
+	// environment included, in the status bar.
+	ShowArgv bool `toml:"show_argv"`
+
+	// PhraseActions are confirmed by typing ConfirmPhrase instead of
+	// pressing y; see phrase.go.
+	PhraseActions []string `toml:"phrase_actions"`
+	ConfirmPhrase string   `toml:"confirm_phrase"`
+
+	// Shortcuts maps menu labels to their key in the menu; see
+	// shortcuts.go.
+	Shortcuts map[string]string `toml:"shortcuts"`
+
+	// Audit appends every command run to AuditLog (default
+	// audit.log in the state dir); see audit.go.
+	Audit    bool   `toml:"audit"`
+	AuditLog string `toml:"audit_log"`
+
+	// Attention rings the bell ("bell") or flashes the screen
+	// ("flash") when an action of AttentionAfter seconds or more
+	// finishes; see attention.go.
+	Attention      string `toml:"attention"`
+	AttentionAfter int    `toml:"attention_after"`
+
+	// CopyLines is how many of the last log lines y copies.
+	CopyLines int `toml:"copy_lines"`
+
+	// WarnBinaryChange adds a toast to the log note when hackeros-steam
+	// was replaced since it last ran; see binary.go.
+	WarnBinaryChange bool `toml:"warn_binary_change"`
+
+	// Locked, read from the system config only, lists the keys users
+	// can't override; see sysconfig.go.
+	Locked []string `toml:"locked"`
+}
+
+var (
+	cfg        = defaultConfig()
+	cfgLoadErr error // reported in the log panel on startup
+)
+
+func defaultConfig() config {
+	return config{
+		PrivilegeCmd:       "pkexec",
+		LogDir:             defaultLogDir(),
+		ConfirmTimeout:     30,
+		HookTimeout:        30,
+		HookAbortOnFailure: true,
+		Notify:             true,
+		Layout:             "list",
+		ANSIColors:         true,
+		ConfirmPhrase:      defaultConfirmPhrase,
+		Attention:          attentionOff,
+		AttentionAfter:     defaultAttentionAfter,
+		CopyLines:          defaultCopyLines,
+		WarnBinaryChange:   true,
+	}
+}
+
+func configPath() string {
+	return filepath.Join(configDir(), configFileName)
+}
+
+// loadConfig returns the defaults merged with the system config and the
+// user's config file. Missing files are not an error.
+func loadConfig() (config, error) {
+	c, locked, notes, err := layerConfig(systemConfigPath, configPath())
+	lockedKeys = map[string]bool{}
+	for _, key := range locked {
+		lockedKeys[key] = true
+	}
+	startupNotes = append(startupNotes, notes...)
+	if c.PrivilegeCmd == "" {
+		c.PrivilegeCmd = defaultConfig().PrivilegeCmd
+	}
+	if c.LogDir == "" {
+		c.LogDir = defaultConfig().LogDir
+	}
+	if c.Layout != "grid" {
+		c.Layout = "list"
+	}
+	if c.HookTimeout <= 0 {
+		c.HookTimeout = defaultConfig().HookTimeout
+	}
+	if c.AttentionAfter <= 0 {
+		c.AttentionAfter = defaultAttentionAfter
+	}
+	if c.CopyLines <= 0 {
+		c.CopyLines = defaultCopyLines
+	}
+	return c, err
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string // "" = no file
+		priv    string
+		wantErr bool
+	}{
+		{"missing file", "", "pkexec", false},
+		{"custom command", `privilege_cmd = "sudo -A"`, "sudo -A", false},
+		{"empty command", `privilege_cmd = ""`, "pkexec", false},
+		{"broken file", `privilege_cmd = `, "pkexec", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			if tt.content != "" {
+				if err := os.MkdirAll(configDir(), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(configDir(), configFileName), []byte(tt.content+"\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			c, err := loadConfig()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if c.PrivilegeCmd != tt.priv {
+				t.Errorf("PrivilegeCmd = %q, want %q", c.PrivilegeCmd, tt.priv)
+			}
+		})
+	}
+}
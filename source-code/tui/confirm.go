@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Confirmation timeout
+//  A prompt left open is cancelled after cfg.ConfirmTimeout seconds so
+//  a destructive action never stays armed. Any key resets the timer.
+// ─────────────────────────────────────────────────────────────────
+
+type confirmTickMsg struct{ id int }
+
+// promptGuard is how long a new prompt ignores the keys that accept it,
+// so key repeat or a double press on the key that opened it can't
+// answer it too.
+const promptGuard = 400 * time.Millisecond
+
+// openPrompt switches to a confirmation state for item and starts the
+// auto-cancel countdown.
+func (m *model) openPrompt(state viewState, item menuItem) tea.Cmd {
+	return m.openActionPrompt(state, item, actionFor(item, ""))
+}
+
+// openActionPrompt is openPrompt for item resolved as a, which the
+// answer dispatches.
+func (m *model) openActionPrompt(state viewState, item menuItem, a action) tea.Cmd {
+	if state == stateConfirm && needsPhrase(item) {
+		state = statePhrase
+		m.phraseInput = ""
+	}
+	if item.warningFor != nil {
+		item.warning = item.warningFor()
+	}
+	m.state = state
+	m.pendingItem, m.pendingAction = &item, a
+	m.promptOpenedAt = time.Now()
+	m.confirmID++
+	m.resetPromptTimer()
+	return m.confirmTick()
+}
+
+func (m *model) resetPromptTimer() {
+	m.confirmDeadline = time.Now().Add(time.Duration(cfg.ConfirmTimeout) * time.Second)
+}
+
+func (m model) promptTimeoutEnabled() bool {
+	return cfg.ConfirmTimeout > 0
+}
+
+func (m *model) confirmTick() tea.Cmd {
+	if !m.promptTimeoutEnabled() {
+		return nil
+	}
+	id := m.confirmID
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return confirmTickMsg{id: id}
+	})
+}
+
+// guardedKey reports whether msg would accept a prompt that has only
+// just opened.
+func (m model) guardedKey(msg tea.KeyMsg) bool {
+	if !isPromptState(m.state) && m.state != stateQuitUpdate || time.Since(m.promptOpenedAt) >= promptGuard {
+		return false
+	}
+	// The phrase itself is typed, y and all; only enter accepts it.
+	if m.state == statePhrase {
+		return key.Matches(msg, phraseSubmit)
+	}
+	return key.Matches(msg, keys.Confirm, keys.Rerun, keys.RunInTerminal, keys.CancelAndQuit, keys.QuitAnyway)
+}
+
+func isPromptState(s viewState) bool {
+	return s == stateConfirm || s == stateEscalate || s == stateInteractive || s == stateForceKill || s == statePhrase
+}
+
+// promptReturnState is where a closed prompt goes back to; some open
+// while an action is still running.
+func (m model) promptReturnState() viewState {
+	if m.busy {
+		return stateRunning
+	}
+	return stateMenu
+}
+
+// handleConfirmTick cancels the prompt once its deadline has passed.
+// Ticks from an earlier prompt are ignored.
+func (m *model) handleConfirmTick(msg confirmTickMsg) tea.Cmd {
+	if msg.id != m.confirmID || !isPromptState(m.state) {
+		return nil
+	}
+	if time.Now().Before(m.confirmDeadline) {
+		return m.confirmTick()
+	}
+	m.pendingItem = nil
+	m.state = m.promptReturnState()
+	m.appendLog(styleLogWarning.Render("  ⚠  Timed out — cancelled."))
+	return nil
+}
+
+// renderCountdown is the "auto-cancel in Ns" line shown in prompts.
+func (m model) renderCountdown() string {
+	if !m.promptTimeoutEnabled() {
+		return ""
+	}
+	left := int(time.Until(m.confirmDeadline).Round(time.Second) / time.Second)
+	if left < 0 {
+		left = 0
+	}
+	return lipgloss.NewStyle().Foreground(colDim).Render(fmt.Sprintf("Auto-cancel in %ds", left))
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConfirmAutoCancel(t *testing.T) {
+	tests := []struct {
+		name     string
+		deadline time.Duration // relative to now when the tick lands
+		staleID  bool
+		state    viewState
+		open     bool
+	}{
+		{"expired confirm", -time.Second, false, stateConfirm, false},
+		{"expired escalate", -time.Second, false, stateEscalate, false},
+		{"still counting", 10 * time.Second, false, stateConfirm, true},
+		{"tick from an old prompt", -time.Second, true, stateConfirm, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.openPrompt(tt.state, menuItem{label: "Remove Container", cmd: []string{"remove"}})
+			m.confirmDeadline = time.Now().Add(tt.deadline)
+			id := m.confirmID
+			if tt.staleID {
+				id--
+			}
+			next, cmd := m.Update(confirmTickMsg{id: id})
+			m = next.(model)
+			if open := m.state == tt.state && m.pendingItem != nil; open != tt.open {
+				t.Fatalf("prompt open = %v, want %v", open, tt.open)
+			}
+			if !tt.open && countLogged(m, "Timed out") != 1 {
+				t.Error("auto-cancel not logged")
+			}
+			if tt.open && !tt.staleID && cmd == nil {
+				t.Error("countdown stopped ticking")
+			}
+		})
+	}
+}
+
+func TestConfirmKeyResetsTimer(t *testing.T) {
+	m := initialModel()
+	m.openPrompt(stateConfirm, menuItem{label: "Remove Container", cmd: []string{"remove"}})
+	m.confirmDeadline = time.Now().Add(time.Second)
+	m = press(m, "x")
+	if m.state != stateConfirm {
+		t.Fatalf("unrelated key closed the prompt")
+	}
+	if left := time.Until(m.confirmDeadline); left < time.Duration(cfg.ConfirmTimeout-1)*time.Second {
+		t.Errorf("deadline not reset, %v left", left)
+	}
+}
+
+func TestConfirmTimeoutDisabled(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.ConfirmTimeout = 0
+	m := initialModel()
+	if cmd := m.openPrompt(stateConfirm, menuItem{label: "x"}); cmd != nil {
+		t.Error("openPrompt scheduled a tick with the timeout disabled")
+	}
+	if s := m.renderCountdown(); s != "" {
+		t.Errorf("renderCountdown = %q, want empty", s)
+	}
+}
+
+// settle lets the open prompt's key guard run out.
+func settle(m model) model {
+	m.promptOpenedAt = time.Time{}
+	return m
+}
+
+func TestPromptIgnoresEarlyAccept(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []string
+		settle bool
+		open   bool
+	}{
+		{"double enter", []string{"enter", "enter"}, false, true},
+		{"enter then y", []string{"enter", "y"}, false, true},
+		{"key repeat", []string{"enter", "enter", "enter", "enter"}, false, true},
+		{"esc still cancels", []string{"enter", "esc"}, false, false},
+		{"fresh y after the guard", []string{"enter"}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.containerStatus = "running"
+			m.cursor = itemIndex(t, "Repair Container")
+			m = press(m, tt.keys...)
+			if tt.settle {
+				m = press(settle(m), "y")
+			}
+			if open := m.state == stateConfirm; open != tt.open {
+				t.Fatalf("prompt open = %v, want %v (state %v)", open, tt.open, m.state)
+			}
+			started := countLogged(m, "$ hackeros-steam --force create") == 1
+			if want := tt.settle; started != want {
+				t.Errorf("started = %v, want %v", started, want)
+			}
+		})
+	}
+}
+
+func TestQuitUpdateGuard(t *testing.T) {
+	m, _ := runningModel(menuItems[itemIndex(t, "Update Container")], true)
+	m.requestQuit()
+	if m.state != stateQuitUpdate {
+		t.Fatalf("state %v, want the quit-during-update prompt", m.state)
+	}
+	q := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+	if !m.guardedKey(q) {
+		t.Error("quit-anyway accepted right after the prompt opened")
+	}
+	if settle(m).guardedKey(q) {
+		t.Error("quit-anyway still guarded after promptGuard")
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Control socket (--listen unix:/path)
+//  Lets other HackerOS components drive the open TUI. Only the owner
+//  can connect (the socket is 0600); requests go through the same
+//  dispatcher as the menu, so busy and availability rules apply. An
+//  action is also refused while the TUI shows anything but the menu:
+//  starting it would take over a prompt the user is answering.
+//
+//  Protocol: one JSON request per connection, one JSON reply.
+//    {"op":"status"}                  → {"ok":true,"status":"running",…}
+//    {"op":"run","action":"update"}   → {"ok":true,"action":"Update Container"}
+//    {"op":"stop"}                    → stops the container, as "kill"
+// ─────────────────────────────────────────────────────────────────
+
+const controlReplyTimeout = 5 * time.Second
+
+var listenFlag string
+
+type controlRequest struct {
+	Op     string `json:"op"`
+	Action string `json:"action,omitempty"`
+}
+
+type controlReply struct {
+	OK      bool   `json:"ok"`
+	Status  string `json:"status,omitempty"`
+	Busy    bool   `json:"busy,omitempty"`
+	Running string `json:"running,omitempty"` // label of the action in progress
+	Action  string `json:"action,omitempty"`  // label of the action started
+	Error   string `json:"error,omitempty"`
+}
+
+// controlMsg hands a request to Update; the reply goes back on reply.
+type controlMsg struct {
+	req   controlRequest
+	reply chan<- controlReply
+}
+
+// parseListen returns the socket path from a --listen value.
+func parseListen(spec string) (string, error) {
+	path, ok := strings.CutPrefix(spec, "unix:")
+	if !ok || path == "" {
+		return "", fmt.Errorf("--listen wants unix:/path, got %q", spec)
+	}
+	return path, nil
+}
+
+// controlActions maps names accepted by "run" to menu item indexes: the
+// hackeros-steam subcommand, first item wins, so "run" is Launch Steam.
+// Items that need a confirmation in the TUI aren't offered, unless the
+// confirmation is only a preview.
+func controlActions() map[string]int {
+	actions := map[string]int{}
+	for i, item := range menuItems {
+		name := actionName(item)
+		if name == "" || item.confirm && !item.preview || item.interactive {
+			continue
+		}
+		if _, dup := actions[name]; !dup {
+			actions[name] = i
+		}
+	}
+	return actions
+}
+
+// listenPrivateSocket binds a unix socket only the owner may connect
+// to, replacing a stale socket file at path.
+func listenPrivateSocket(path string) (net.Listener, error) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// listenControl binds the control socket, refusing to take over one
+// another process is still serving.
+func listenControl(path string) (net.Listener, error) {
+	if conn, err := net.DialTimeout("unix", path, daemonDialTimeout); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("something is already listening on %s", path)
+	}
+	return listenPrivateSocket(path)
+}
+
+// serveControl accepts connections until ln is closed or ctx is done,
+// passing each request to the program with send.
+func serveControl(ctx context.Context, ln net.Listener, send func(tea.Msg)) {
+	stop := context.AfterFunc(ctx, func() { ln.Close() })
+	defer stop()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		started := appLife.goTracked(func(ctx context.Context) {
+			handleControlConn(ctx, conn, send)
+		})
+		if !started {
+			conn.Close()
+		}
+	}
+}
+
+func handleControlConn(ctx context.Context, conn net.Conn, send func(tea.Msg)) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(controlReplyTimeout + time.Second))
+	// Quitting drops the connection, whatever it is waiting for.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	enc := json.NewEncoder(conn)
+	var req controlRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		enc.Encode(controlReply{Error: "bad request: " + err.Error()})
+		return
+	}
+	reply := make(chan controlReply, 1)
+	send(controlMsg{req: req, reply: reply})
+	select {
+	case r := <-reply:
+		enc.Encode(r)
+	case <-time.After(controlReplyTimeout):
+		enc.Encode(controlReply{Error: "timed out waiting for the TUI"})
+	case <-ctx.Done():
+	}
+}
+
+// handleControl answers a request from the control socket.
+func (m *model) handleControl(msg controlMsg) tea.Cmd {
+	r, cmd := m.controlRequest(msg.req)
+	msg.reply <- r
+	return cmd
+}
+
+func (m *model) controlRequest(req controlRequest) (controlReply, tea.Cmd) {
+	switch req.Op {
+	case "status":
+		r := controlReply{OK: true, Status: m.containerStatus, Busy: m.busy}
+		if m.busy && m.lastItem != nil {
+			r.Running = m.lastItem.label
+		}
+		return r, nil
+	case "run":
+		return m.controlRun(req.Action)
+	case "stop":
+		return m.controlRun("kill")
+	default:
+		return controlReply{Error: "unknown op " + req.Op}, nil
+	}
+}
+
+// controlRun starts the action named name through dispatch.
+func (m *model) controlRun(name string) (controlReply, tea.Cmd) {
+	i, ok := controlActions()[name]
+	if !ok {
+		return controlReply{Error: fmt.Sprintf("unknown action %q", name)}, nil
+	}
+	item, a := menuItems[i], menuAction(i)
+	if m.busy {
+		running := "another action"
+		if m.lastItem != nil {
+			running = m.lastItem.label
+		}
+		return controlReply{Busy: true, Running: running, Error: "busy: " + running + " is running"}, nil
+	}
+	if m.state != stateMenu || m.logFilter.editing != filterNone {
+		return controlReply{Error: "the TUI has a prompt open; try again once it is answered"}, nil
+	}
+	if reason := m.disabledReason(item); reason != "" {
+		return controlReply{Error: item.label + " unavailable: " + reason}, nil
+	}
+	m.appendLog(styleLogDim.Render("  → " + item.label + " requested over the control socket"))
+	m.recordStatus("Control: " + name)
+	// What's left to confirm is a preview, like Update Container's;
+	// the caller has already decided.
+	item.confirm, item.describe, a.requiresConfirm = false, nil, false
+	return controlReply{OK: true, Action: item.label}, m.dispatchAction(item, a)
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseListen(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+		ok   bool
+	}{
+		{"unix:/run/user/1000/hackeros-steam.sock", "/run/user/1000/hackeros-steam.sock", true},
+		{"unix:rel.sock", "rel.sock", true},
+		{"unix:", "", false},
+		{"/tmp/x.sock", "", false},
+		{"tcp:127.0.0.1:9000", "", false},
+	}
+	for _, tt := range tests {
+		got, err := parseListen(tt.spec)
+		if got != tt.want || (err == nil) != tt.ok {
+			t.Errorf("parseListen(%q) = %q, %v", tt.spec, got, err)
+		}
+	}
+}
+
+func TestControlActions(t *testing.T) {
+	actions := controlActions()
+	tests := []struct {
+		name  string
+		label string // "" = not offered
+	}{
+		{"run", "Launch Steam"},
+		{"update", "Update Container"},
+		{"setup", "Setup / Repair Steam"},
+		{"status", "Container Status"},
+		{"kill", "Stop Container"},
+		{"remove", ""},      // needs a confirmation
+		{"clear-cache", ""}, // deletes the download cache
+		{"reset", ""},       // a built-in submenu
+	}
+	for _, tt := range tests {
+		i, ok := actions[tt.name]
+		var item menuItem
+		if ok {
+			item = menuItems[i]
+		}
+		if ok != (tt.label != "") || item.label != tt.label {
+			t.Errorf("controlActions()[%q] = %q, %v; want %q", tt.name, item.label, ok, tt.label)
+		}
+	}
+	for name, i := range actions {
+		if item := menuItems[i]; item.confirm && !item.preview {
+			t.Errorf("%q offered, though %s asks for a confirmation", name, item.label)
+		}
+	}
+}
+
+// A request never takes over a prompt the user is answering.
+func TestControlRunRefusedDuringPrompt(t *testing.T) {
+	repair := menuItems[itemIndex(t, "Repair Container")]
+	tests := []struct {
+		name  string
+		setup func(m *model)
+		state viewState
+	}{
+		{"confirm", func(m *model) { m.openPrompt(stateConfirm, repair) }, stateConfirm},
+		{"submenu", func(m *model) { m.openChannelMenu() }, stateSubmenu},
+		{"rename", func(m *model) { m.state = stateRename }, stateRename},
+		{"filter", func(m *model) { m.startFilter(filterInclude) }, stateMenu},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			m := initialModel()
+			m.containerStatus = "running"
+			tt.setup(&m)
+			for _, req := range []controlRequest{{Op: "run", Action: "update"}, {Op: "stop"}} {
+				got, cmd := m.controlRequest(req)
+				if got.OK || !strings.Contains(got.Error, "prompt open") || cmd != nil {
+					t.Errorf("%+v: reply %+v", req, got)
+				}
+			}
+			if m.state != tt.state || m.busy || countLogged(m, "$ hackeros-steam") != 0 {
+				t.Errorf("state %v busy %v after the refused requests", m.state, m.busy)
+			}
+		})
+	}
+}
+
+func TestControlRequest(t *testing.T) {
+	update := menuItems[itemIndex(t, "Update Container")]
+	tests := []struct {
+		name    string
+		status  string
+		busy    bool
+		req     controlRequest
+		want    controlReply
+		started string // logged command, "" = none
+	}{
+		{"status idle", "running", false, controlRequest{Op: "status"},
+			controlReply{OK: true, Status: "running"}, ""},
+		{"status busy", "running", true, controlRequest{Op: "status"},
+			controlReply{OK: true, Status: "running", Busy: true, Running: "Update Container"}, ""},
+		{"run update", "running", false, controlRequest{Op: "run", Action: "update"},
+			controlReply{OK: true, Action: "Update Container"}, "$ hackeros-steam update"},
+		{"stop", "running", false, controlRequest{Op: "stop"},
+			controlReply{OK: true, Action: "Stop Container"}, "$ hackeros-steam kill"},
+		{"busy", "running", true, controlRequest{Op: "run", Action: "setup"},
+			controlReply{Busy: true, Running: "Update Container", Error: "busy: Update Container is running"}, ""},
+		{"unavailable", "missing", false, controlRequest{Op: "run", Action: "update"},
+			controlReply{Error: "Update Container unavailable: container not created yet"}, ""},
+		{"unknown action", "running", false, controlRequest{Op: "run", Action: "remove"},
+			controlReply{Error: `unknown action "remove"`}, ""},
+		{"unknown op", "running", false, controlRequest{Op: "reboot"},
+			controlReply{Error: "unknown op reboot"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.containerStatus = tt.status
+			if tt.busy {
+				m.busy, m.state, m.lastItem = true, stateRunning, &update
+			}
+			got, _ := m.controlRequest(tt.req)
+			if got != tt.want {
+				t.Errorf("reply = %+v, want %+v", got, tt.want)
+			}
+			if tt.started != "" && countLogged(m, tt.started) != 1 {
+				t.Errorf("%q not started: %q", tt.started, plainLogLines(m.logLines, false))
+			}
+			if tt.started == "" && !tt.busy && m.busy {
+				t.Error("a refused request started something")
+			}
+		})
+	}
+}
+
+// controlSocket serves a model over a socket in a temporary directory.
+func controlSocket(t *testing.T, m *model) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ctl.sock")
+	ln, err := listenControl(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	msgs := make(chan tea.Msg)
+	go serveControl(context.Background(), ln, func(msg tea.Msg) { msgs <- msg })
+	go func() {
+		for msg := range msgs {
+			m.handleControl(msg.(controlMsg))
+		}
+	}()
+	return path
+}
+
+func askControl(t *testing.T, path, req string) controlReply {
+	t.Helper()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.Write([]byte(req + "\n"))
+	conn.(*net.UnixConn).CloseWrite()
+	var r controlReply
+	if err := json.NewDecoder(conn).Decode(&r); err != nil {
+		t.Fatalf("reply to %s: %v", req, err)
+	}
+	return r
+}
+
+func TestControlSocket(t *testing.T) {
+	m := initialModel()
+	m.containerStatus = "stopped"
+	path := controlSocket(t, &m)
+
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("socket mode: %v, %v", fi.Mode(), err)
+	}
+	if r := askControl(t, path, `{"op":"status"}`); !r.OK || r.Status != "stopped" {
+		t.Errorf("status reply = %+v", r)
+	}
+	if r := askControl(t, path, `{"op":`); r.OK || r.Error == "" {
+		t.Errorf("malformed request reply = %+v", r)
+	}
+	if r := askControl(t, path, `{"op":"run","action":"kill"}`); r.OK {
+		t.Errorf("stop on a stopped container = %+v", r)
+	}
+
+	// A second TUI can't take the socket over while this one serves it.
+	if _, err := listenControl(path); err == nil {
+		t.Error("listenControl took over a live socket")
+	}
+}
+
+func TestListenControlReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctl.sock")
+	os.WriteFile(path, nil, 0o600)
+	ln, err := listenControl(path)
+	if err != nil {
+		t.Fatalf("stale socket file: %v", err)
+	}
+	ln.Close()
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Crash reports
+//  When Steam — plain, in Gamescope or launching a game — ends on a
+//  signal like SIGSEGV or SIGABRT, or on the 128+N code a shell or the
+//  engine reports for one, the tail of its output and of the container
+//  log are written to a crash report in log_dir and a prompt offers to
+//  show it. Quitting Steam, stopping it and ordinary errors are told
+//  apart in the log and don't produce a report.
+// ─────────────────────────────────────────────────────────────────
+
+// crashTailLines is how much of the output and the container log a
+// report keeps.
+const crashTailLines = 200
+
+// crashSignals are the signals that mean the process crashed rather
+// than being asked to stop.
+var crashSignals = []syscall.Signal{
+	syscall.SIGSEGV, syscall.SIGABRT, syscall.SIGBUS, syscall.SIGFPE,
+	syscall.SIGILL, syscall.SIGTRAP, syscall.SIGSYS,
+}
+
+// stopSignals are the signals that stop a process on request.
+var stopSignals = []syscall.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGKILL}
+
+var crashView = key.NewBinding(key.WithKeys("v", "enter"), key.WithHelp("v", "view report"))
+
+type exitKind int
+
+const (
+	exitNormal  exitKind = iota // exit 0
+	exitStopped                 // ended by SIGINT, SIGTERM, SIGHUP or SIGKILL
+	exitFailed                  // any other non-zero exit
+	exitCrashed                 // ended by one of crashSignals
+)
+
+// exitSignal is the signal that ended the process: the one it was
+// killed by, or the one a 128+N exit code stands for.
+func exitSignal(code int, sig syscall.Signal) syscall.Signal {
+	if sig == 0 && code > 128 && code < 128+65 {
+		sig = syscall.Signal(code - 128)
+	}
+	return sig
+}
+
+func classifyExit(code int, sig syscall.Signal) exitKind {
+	sig = exitSignal(code, sig)
+	for _, s := range crashSignals {
+		if sig == s {
+			return exitCrashed
+		}
+	}
+	for _, s := range stopSignals {
+		if sig == s {
+			return exitStopped
+		}
+	}
+	if code == 0 && sig == 0 {
+		return exitNormal
+	}
+	return exitFailed
+}
+
+// exitReason describes msg for the log: "signal segmentation fault
+// (SIGSEGV)", "exit 1".
+func exitReason(msg procExitedMsg) string {
+	sig := exitSignal(msg.code, msg.signal)
+	if sig == 0 {
+		return msg.status()
+	}
+	reason := "signal " + sig.String()
+	if name := signalName(sig); name != "" {
+		reason += " (" + name + ")"
+	}
+	if msg.signal == 0 {
+		reason += fmt.Sprintf(", exit %d", msg.code)
+	}
+	return reason
+}
+
+func signalName(s syscall.Signal) string {
+	return map[syscall.Signal]string{
+		syscall.SIGSEGV: "SIGSEGV", syscall.SIGABRT: "SIGABRT", syscall.SIGBUS: "SIGBUS",
+		syscall.SIGFPE: "SIGFPE", syscall.SIGILL: "SIGILL", syscall.SIGTRAP: "SIGTRAP",
+		syscall.SIGSYS: "SIGSYS", syscall.SIGINT: "SIGINT", syscall.SIGTERM: "SIGTERM",
+		syscall.SIGHUP: "SIGHUP", syscall.SIGKILL: "SIGKILL",
+	}[s]
+}
+
+// isLaunchItem is true for the actions that run the Steam client:
+// Launch Steam, Launch in Gamescope and the games' Launch items.
+func isLaunchItem(item *menuItem) bool {
+	if item == nil || actionName(*item) != "run" {
+		return false
+	}
+	for _, arg := range item.cmd {
+		if strings.HasPrefix(arg, "steam://") {
+			return false
+		}
+	}
+	return true
+}
+
+type crashInput struct {
+	now       time.Time
+	label     string
+	argv      []string
+	reason    string
+	runtime   time.Duration
+	output    []string
+	engine    string
+	container string // the container log, or why it couldn't be read
+}
+
+// buildCrashReport lays out a crash report.
+func buildCrashReport(in crashInput) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "hackeros-steam crash report\n\n")
+	fmt.Fprintf(&b, "time:     %s\n", in.now.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "action:   %s (hackeros-steam %s)\n", in.label, shellJoin(in.argv))
+	fmt.Fprintf(&b, "exit:     %s\n", in.reason)
+	fmt.Fprintf(&b, "ran for:  %s\n", in.runtime.Round(time.Second))
+	fmt.Fprintf(&b, "\n── Output (last %d lines) ──\n", crashTailLines)
+	output := in.output[max(len(in.output)-crashTailLines, 0):]
+	if len(output) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, line := range output {
+		b.WriteString(stripANSI(line) + "\n")
+	}
+	fmt.Fprintf(&b, "\n── %s logs --tail %d %s ──\n", in.engine, crashTailLines, containerName)
+	b.WriteString(strings.TrimRight(in.container, "\n") + "\n")
+	return b.String()
+}
+
+type crashSavedMsg struct {
+	path   string
+	report string
+	reason string
+	err    error
+}
+
+// crashReport is the last report saved this session.
+type crashReport struct {
+	path   string
+	reason string
+	pane   logPane
+}
+
+// captureCrash writes the crash report of the action that just ended.
+func (m *model) captureCrash(exit procExitedMsg) tea.Cmd {
+	in := crashInput{
+		now:     time.Now(),
+		label:   m.lastItem.label,
+		argv:    m.lastItem.cmd,
+		reason:  exitReason(exit),
+		runtime: exit.at.Sub(m.startedAt),
+		output:  append([]string(nil), m.runOutput...),
+		engine:  m.engine(),
+	}
+	dir := cfg.LogDir
+	return func() tea.Msg {
+		out, err := auditedOutput("crash report", hostCommand([]string{in.engine, "logs", "--tail", fmt.Sprint(crashTailLines), containerName}, nil, false), combinedTailOf)
+		in.container = stripANSI(out)
+		if err != nil {
+			in.container += fmt.Sprintf("(%s logs failed: %v)\n", in.engine, err)
+		}
+		report := buildCrashReport(in)
+		msg := crashSavedMsg{report: report, reason: in.reason}
+		f, err := createExportFile(dir, "hackeros-steam-crash-"+in.now.Format("20060102-150405")+".log", 0o644)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		_, werr := f.WriteString(report)
+		if cerr := f.Close(); werr == nil {
+			werr = cerr
+		}
+		if werr != nil {
+			os.Remove(f.Name())
+			msg.err = werr
+			return msg
+		}
+		msg.path = f.Name()
+		return msg
+	}
+}
+
+// logLaunchExit says how a launch ended and starts a crash report when
+// it crashed.
+func (m *model) logLaunchExit() tea.Cmd {
+	exit := m.lastExit
+	if exit == nil || m.startFailed {
+		return nil
+	}
+	switch classifyExit(exit.code, exit.signal) {
+	case exitNormal:
+		m.appendLog(styleLogInfo.Render("  → Steam quit normally."))
+	case exitStopped:
+		m.appendLog(styleLogInfo.Render("  → Steam was stopped (" + exitReason(*exit) + ")."))
+	case exitCrashed:
+		m.appendLog(styleLogWarning.Render("  ⚠  Steam crashed: " + exitReason(*exit)))
+		m.appendLog(styleLogDim.Render("     Saving a crash report…"))
+		m.recordStatus(m.lastItem.label + ": crashed")
+		return m.captureCrash(*exit)
+	}
+	return nil
+}
+
+func (m *model) handleCrashSaved(msg crashSavedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.logError("Crash report not saved: "+msg.err.Error(), strings.Split(msg.report, "\n")...)
+		return nil
+	}
+	m.appendLog(styleLogInfo.Render("  → Crash report: " + tildePath(msg.path)))
+	m.crash = &crashReport{
+		path:   msg.path,
+		reason: msg.reason,
+		pane:   logPane{title: "Crash report · " + tildePath(msg.path), lines: strings.Split(strings.TrimRight(msg.report, "\n"), "\n"), vp: viewport.New(1, 1)},
+	}
+	if m.state != stateMenu {
+		return m.showToast("Steam crashed; report saved to " + tildePath(msg.path))
+	}
+	m.state = stateCrash
+	return nil
+}
+
+func (m *model) resizeCrash() {
+	if m.crash == nil {
+		return
+	}
+	p := &m.crash.pane
+	iw, ih := paneInner(m.splitSize())
+	if p.vp.Width != iw || p.vp.Height != ih {
+		p.vp.Width, p.vp.Height = iw, ih
+		p.wrap()
+	}
+}
+
+func (m *model) handleCrashKey(msg tea.KeyMsg) tea.Cmd {
+	c := m.crash
+	switch {
+	case key.Matches(msg, keys.Back):
+		m.state = m.idleState()
+	case m.state == stateCrash && key.Matches(msg, crashView):
+		m.state = stateCrashReport
+		c.pane.vp.Width = 0 // lay out at the current size
+		m.resizeCrash()
+		c.pane.vp.GotoTop()
+	case m.state == stateCrashReport:
+		var cmd tea.Cmd
+		c.pane.vp, cmd = c.pane.vp.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+func (m model) renderCrash() string {
+	c := m.crash
+	if m.state == stateCrashReport {
+		w, h := m.splitSize()
+		iw, ih := paneInner(w, h)
+		title := c.pane.title
+		if c.pane.vp.TotalLineCount() > ih {
+			title += fmt.Sprintf("  %3.0f%%", c.pane.vp.ScrollPercent()*100)
+		}
+		return lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(colRed).
+			Width(iw).
+			Height(ih + 1).
+			Render(lipgloss.NewStyle().Foreground(colRed).Bold(true).Render(truncate(title, iw)) + "\n" + c.pane.vp.View())
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("✖  Steam crashed"),
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render(c.reason),
+		styleLogDim.Render("Report saved to " + tildePath(c.path)),
+		"",
+		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[V]") + " " +
+			lipgloss.NewStyle().Foreground(colText).Render("view report") + "   " +
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[Esc]") + " " +
+			lipgloss.NewStyle().Foreground(colText).Render("dismiss"),
+	}
+	return m.placeOverlay(styleConfirmBox, lipgloss.JoinVertical(lipgloss.Center, lines...))
+}
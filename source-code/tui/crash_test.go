@@ -0,0 +1,177 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestClassifyExit(t *testing.T) {
+	tests := []struct {
+		name string
+		code int
+		sig  syscall.Signal
+		want exitKind
+	}{
+		{"quit", 0, 0, exitNormal},
+		{"error", 1, 0, exitFailed},
+		{"segfault", -1, syscall.SIGSEGV, exitCrashed},
+		{"abort", -1, syscall.SIGABRT, exitCrashed},
+		{"segfault code from a shell", 139, 0, exitCrashed},
+		{"abort code from a shell", 134, 0, exitCrashed},
+		{"interrupted", -1, syscall.SIGINT, exitStopped},
+		{"killed", -1, syscall.SIGKILL, exitStopped},
+		{"terminated code", 143, 0, exitStopped},
+		{"code past the signals", 250, 0, exitFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyExit(tt.code, tt.sig); got != tt.want {
+				t.Errorf("classifyExit(%d, %v) = %v, want %v", tt.code, tt.sig, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitReason(t *testing.T) {
+	tests := []struct {
+		msg  procExitedMsg
+		want string
+	}{
+		{procExitedMsg{code: 1}, "exit 1"},
+		{procExitedMsg{code: -1, signal: syscall.SIGSEGV}, "signal segmentation fault (SIGSEGV)"},
+		{procExitedMsg{code: 134}, "signal aborted (SIGABRT), exit 134"},
+	}
+	for _, tt := range tests {
+		if got := exitReason(tt.msg); got != tt.want {
+			t.Errorf("exitReason(%+v) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestIsLaunchItem(t *testing.T) {
+	tests := []struct {
+		item *menuItem
+		want bool
+	}{
+		{&menuItem{cmd: []string{"run"}}, true},
+		{&menuItem{cmd: []string{"run", "-gamepadui"}}, true},
+		{&menuItem{cmd: []string{"run", "-applaunch", "570"}}, true},
+		{&menuItem{cmd: []string{"run", "steam://uninstall/570"}}, false},
+		{&menuItem{cmd: []string{"update"}}, false},
+		{&menuItem{run: (*model).openOverlayMenu}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := isLaunchItem(tt.item); got != tt.want {
+			t.Errorf("isLaunchItem(%+v) = %v, want %v", tt.item, got, tt.want)
+		}
+	}
+}
+
+func TestBuildCrashReport(t *testing.T) {
+	var output []string
+	for i := 1; i <= crashTailLines+10; i++ {
+		output = append(output, "line "+string(rune('a'+i%26)))
+	}
+	output[len(output)-1] = "\x1b[31mlast words\x1b[0m"
+	report := buildCrashReport(crashInput{
+		now:       time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC),
+		label:     "Launch Steam",
+		argv:      []string{"run"},
+		reason:    "signal segmentation fault (SIGSEGV)",
+		runtime:   90*time.Second + 400*time.Millisecond,
+		output:    output,
+		engine:    "podman",
+		container: "container line\n",
+	})
+	for _, s := range []string{
+		"time:     2026-10-14 15:30:00",
+		"action:   Launch Steam (hackeros-steam run)",
+		"exit:     signal segmentation fault (SIGSEGV)",
+		"ran for:  1m30s",
+		"\nlast words\n",
+		"── podman logs --tail 200 " + containerName + " ──\ncontainer line\n",
+	} {
+		if !strings.Contains(report, s) {
+			t.Errorf("report lacks %q:\n%s", s, report)
+		}
+	}
+	if n := strings.Count(report, "\nline "); n != crashTailLines-1 {
+		t.Errorf("report keeps %d output lines, want %d", n+1, crashTailLines)
+	}
+	if empty := buildCrashReport(crashInput{}); !strings.Contains(empty, "(none)") {
+		t.Errorf("report without output:\n%s", empty)
+	}
+}
+
+func TestLogLaunchExit(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.LogDir = t.TempDir()
+	tests := []struct {
+		exit   *procExitedMsg
+		failed bool // the command never started
+		logged string
+		report bool
+	}{
+		{&procExitedMsg{code: 0}, false, "Steam quit normally", false},
+		{&procExitedMsg{code: -1, signal: syscall.SIGINT}, false, "Steam was stopped (signal interrupt (SIGINT))", false},
+		{&procExitedMsg{code: 1}, false, "", false},
+		{&procExitedMsg{code: -1, signal: syscall.SIGSEGV}, false, "Steam crashed: signal segmentation fault", true},
+		{&procExitedMsg{code: -1, signal: syscall.SIGSEGV}, true, "", false},
+		{nil, false, "", false},
+	}
+	for _, tt := range tests {
+		m, _ := runningModel(menuItems[itemIndex(t, "Launch Steam")], true)
+		m.lastExit, m.startFailed = tt.exit, tt.failed
+		before := len(m.logLines)
+		cmd := m.logLaunchExit()
+		if tt.logged != "" && countLogged(m, tt.logged) != 1 {
+			t.Errorf("%+v: %q not logged", tt.exit, tt.logged)
+		}
+		if tt.logged == "" && len(m.logLines) != before {
+			t.Errorf("%+v: logged %q", tt.exit, plainLogLines(m.logLines[before:], false))
+		}
+		if report := cmd != nil; report != tt.report {
+			t.Errorf("%+v: report started = %v, want %v", tt.exit, report, tt.report)
+		}
+	}
+}
+
+func TestCrashReportFlow(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.LogDir = t.TempDir()
+
+	m, _ := runningModel(menuItems[itemIndex(t, "Launch Steam")], true)
+	m.startedAt = time.Now().Add(-time.Minute)
+	m.runOutput = []string{"Steam is starting", "Fatal error: crashed"}
+	msg, ok := m.captureCrash(procExitedMsg{code: -1, signal: syscall.SIGABRT, at: time.Now()})().(crashSavedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("captureCrash = %+v", msg)
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil || !strings.Contains(string(data), "Fatal error: crashed") || !strings.Contains(string(data), "(SIGABRT)") {
+		t.Fatalf("report %s (%v):\n%s", msg.path, err, data)
+	}
+
+	// While another action runs, the report is only announced.
+	m.handleCrashSaved(msg)
+	if m.state != stateRunning || !strings.Contains(m.toast, "report saved") {
+		t.Errorf("state %v toast %q while running", m.state, m.toast)
+	}
+
+	m.busy, m.state = false, stateMenu
+	m.handleCrashSaved(msg)
+	if m.state != stateCrash || !strings.Contains(stripANSI(m.renderCrash()), "Steam crashed") {
+		t.Fatalf("state %v after the report, want the crash prompt", m.state)
+	}
+	m = press(m, "v")
+	if m.state != stateCrashReport || !strings.Contains(stripANSI(m.renderCrash()), "hackeros-steam crash report") {
+		t.Fatalf("state %v after v, want the report", m.state)
+	}
+	if m = press(m, "esc"); m.state != stateMenu {
+		t.Errorf("state %v after esc", m.state)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Background watcher (--daemon)
+//  Detaching leaves a headless process that keeps polling the container
+//  and records state changes. The next TUI start reattaches over a unix
+//  socket, replays the recorded events and takes over, stopping it.
+//
+//  Protocol: one JSON request per connection, one JSON reply.
+//    {"op":"hello"} → {"pid":…, "since":…, "events":[…]}
+//    {"op":"stop"}  → {"pid":…} and the watcher exits
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	daemonSocketName   = "hackeros-steam-tui.sock"
+	daemonPollInterval = 10 * time.Second // when the status poll is off
+	daemonMaxEvents    = 200
+	daemonDialTimeout  = time.Second
+)
+
+type daemonEvent struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+type daemonRequest struct {
+	Op string `json:"op"`
+}
+
+type daemonReply struct {
+	PID    int           `json:"pid"`
+	Since  time.Time     `json:"since"`
+	Events []daemonEvent `json:"events,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type reattachedMsg struct{ reply daemonReply }
+
+type detachFailedMsg struct{ err error }
+
+func daemonSocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = stateDir()
+	}
+	return filepath.Join(dir, daemonSocketName)
+}
+
+// daemon holds the watcher's event log.
+type daemon struct {
+	mu     sync.Mutex
+	since  time.Time
+	events []daemonEvent
+	stop   chan struct{}
+}
+
+func (d *daemon) record(text string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, daemonEvent{Time: time.Now(), Text: text})
+	if len(d.events) > daemonMaxEvents {
+		d.events = d.events[len(d.events)-daemonMaxEvents:]
+	}
+}
+
+func (d *daemon) reply() daemonReply {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return daemonReply{PID: os.Getpid(), Since: d.since, Events: append([]daemonEvent(nil), d.events...)}
+}
+
+func (d *daemon) serve(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	var req daemonRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+	enc := json.NewEncoder(conn)
+	switch req.Op {
+	case "hello":
+		enc.Encode(d.reply())
+	case "stop":
+		enc.Encode(daemonReply{PID: os.Getpid(), Since: d.since})
+		select {
+		case <-d.stop:
+		default:
+			close(d.stop)
+		}
+	default:
+		enc.Encode(daemonReply{PID: os.Getpid(), Error: "unknown op " + req.Op})
+	}
+}
+
+// listenDaemonSocket binds the socket, clearing a stale one left by a
+// watcher that died. Only the owner may connect.
+func listenDaemonSocket(path string) (net.Listener, error) {
+	if _, err := daemonCall(path, "hello"); err == nil {
+		return nil, fmt.Errorf("a watcher is already listening on %s", path)
+	}
+	return listenPrivateSocket(path)
+}
+
+// runDaemon is the body of `tui --daemon`.
+func runDaemon() error {
+	path := daemonSocketPath()
+	ln, err := listenDaemonSocket(path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+	defer ln.Close()
+
+	d := &daemon{since: time.Now(), stop: make(chan struct{})}
+	d.record("Watcher started (pid " + fmt.Sprint(os.Getpid()) + ")")
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go d.serve(conn)
+		}
+	}()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
+
+	last := ""
+	interval := daemonPollInterval
+	if d := pollIntervals[pollStatus]; d > 0 {
+		interval = d
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if status := probeStatus(); status != last {
+			d.record("Container " + containerName + ": " + status)
+			last = status
+		}
+		select {
+		case <-ticker.C:
+		case <-d.stop:
+			return nil
+		case <-sigs:
+			return nil
+		}
+	}
+}
+
+// daemonCall sends a single request to the watcher at path.
+func daemonCall(path, op string) (daemonReply, error) {
+	var reply daemonReply
+	conn, err := net.DialTimeout("unix", path, daemonDialTimeout)
+	if err != nil {
+		return reply, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if err := json.NewEncoder(conn).Encode(daemonRequest{Op: op}); err != nil {
+		return reply, err
+	}
+	if err := json.NewDecoder(conn).Decode(&reply); err != nil {
+		return reply, err
+	}
+	if reply.Error != "" {
+		return reply, errors.New(reply.Error)
+	}
+	return reply, nil
+}
+
+// reattachCmd picks up a running watcher's events and stops it, since
+// the TUI watches the container itself while it is open.
+func reattachCmd() tea.Cmd {
+	return func() tea.Msg {
+		path := daemonSocketPath()
+		reply, err := daemonCall(path, "hello")
+		if err != nil {
+			return nil
+		}
+		daemonCall(path, "stop")
+		return reattachedMsg{reply: reply}
+	}
+}
+
+// detach starts the background watcher and quits the TUI.
+func (m *model) detach() tea.Cmd {
+	self, err := os.Executable()
+	if err != nil {
+		return func() tea.Msg { return detachFailedMsg{err: err} }
+	}
+	args := []string{"--daemon"}
+	if remoteHost != "" {
+		args = append(args, "--remote", remoteHost)
+	}
+	c := exec.Command(self, args...)
+	c.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	// The watcher outlives the TUI, so its exit record only says it
+	// started.
+	id, err := auditExec("detach", c.Args, nil)
+	if err != nil {
+		return func() tea.Msg { return detachFailedMsg{err: err} }
+	}
+	if err := c.Start(); err != nil {
+		auditExit(id, "detach", "failed")
+		return func() tea.Msg { return detachFailedMsg{err: err} }
+	}
+	auditExit(id, "detach", "ok")
+	c.Process.Release()
+	return quit
+}
+
+func (m *model) logReattach(reply daemonReply) {
+	m.appendLog(styleLogHeader.Render(fmt.Sprintf("  Reattached to background watcher (pid %d, since %s)",
+		reply.PID, reply.Since.Format("15:04:05"))))
+	for _, ev := range reply.Events {
+		m.appendLog(styleLogDim.Render("  " + ev.Time.Format("15:04:05") + "  " + ev.Text))
+	}
+	m.appendLog("")
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// startTestDaemon serves a watcher on a fresh socket. unix socket paths
+// are length-limited, so a short temp dir is used instead of t.TempDir.
+func startTestDaemon(t *testing.T) (string, *daemon) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "hs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, daemonSocketName)
+
+	ln, err := listenDaemonSocket(path)
+	if err != nil {
+		t.Fatalf("listenDaemonSocket: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	d := &daemon{since: time.Now(), stop: make(chan struct{})}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go d.serve(conn)
+		}
+	}()
+	return path, d
+}
+
+func TestDaemonHandshake(t *testing.T) {
+	path, d := startTestDaemon(t)
+	d.record("Container HackerOS-Steam: running")
+	d.record("Container HackerOS-Steam: stopped")
+
+	tests := []struct {
+		op      string
+		events  int
+		wantErr bool
+	}{
+		{"hello", 2, false},
+		{"hello", 2, false},
+		{"bogus", 0, true},
+		{"stop", 0, false},
+	}
+	for _, tt := range tests {
+		reply, err := daemonCall(path, tt.op)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: err = %v, wantErr %v", tt.op, err, tt.wantErr)
+		}
+		if reply.PID != os.Getpid() {
+			t.Errorf("%s: pid = %d, want %d", tt.op, reply.PID, os.Getpid())
+		}
+		if len(reply.Events) != tt.events {
+			t.Errorf("%s: %d events, want %d", tt.op, len(reply.Events), tt.events)
+		}
+	}
+
+	select {
+	case <-d.stop:
+	case <-time.After(time.Second):
+		t.Fatal("stop request did not stop the watcher")
+	}
+}
+
+func TestDaemonSocketIsExclusive(t *testing.T) {
+	path, _ := startTestDaemon(t)
+	if ln, err := listenDaemonSocket(path); err == nil {
+		ln.Close()
+		t.Fatal("second watcher bound the socket of a live one")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket mode = %o, want 600", perm)
+	}
+}
+
+func TestDaemonStaleSocketIsReplaced(t *testing.T) {
+	dir, err := os.MkdirTemp("", "hs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, daemonSocketName)
+
+	// A socket file nobody listens on, as left by a killed watcher.
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+
+	ln, err = listenDaemonSocket(path)
+	if err != nil {
+		t.Fatalf("listenDaemonSocket over a stale socket: %v", err)
+	}
+	ln.Close()
+}
+
+func TestDaemonEventsAreBounded(t *testing.T) {
+	d := &daemon{}
+	for i := 0; i < daemonMaxEvents+5; i++ {
+		d.record("event")
+	}
+	if n := len(d.reply().Events); n != daemonMaxEvents {
+		t.Errorf("%d events kept, want %d", n, daemonMaxEvents)
+	}
+}
+
+func TestDaemonCallWithoutWatcher(t *testing.T) {
+	if _, err := daemonCall(filepath.Join(t.TempDir(), "none.sock"), "hello"); err == nil {
+		t.Error("daemonCall without a watcher succeeded")
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Diagnostics bundle
+//  One Markdown file with everything a bug report usually needs. It is
+//  written to cfg.LogDir and copied to the clipboard for pasting into an
+//  issue. The home directory, the user name and anything that looks
+//  like a credential are redacted first.
+// ─────────────────────────────────────────────────────────────────
+
+// diagLogLines caps how much of the session log goes in the bundle.
+const diagLogLines = 200
+
+type diagnosticsMsg struct {
+	path   string
+	bundle string
+	err    error
+}
+
+// reSecret matches "GITHUB_TOKEN=…", "password: …", "Authorization:
+// Bearer …" and the like; the value is replaced.
+var reSecret = regexp.MustCompile(`(?i)\b(\w*(?:token|secret|password|passwd|api[_-]?key|auth(?:orization)?))(\s*[:=]\s*)(bearer\s+)?\S+`)
+
+// redact hides personal paths and credential-looking values in s.
+func redact(s string) string {
+	if home, err := os.UserHomeDir(); err == nil && len(home) > 1 {
+		s = strings.ReplaceAll(s, home, "~")
+	}
+	if user := os.Getenv("USER"); len(user) > 2 {
+		s = regexp.MustCompile(`\b`+regexp.QuoteMeta(user)+`\b`).ReplaceAllString(s, "<user>")
+	}
+	return reSecret.ReplaceAllString(s, "$1$2$3<redacted>")
+}
+
+// redactedConfig renders c as TOML with hook commands removed; they are
+// free-form shell and may embed anything.
+func redactedConfig(c config) string {
+	hooks := make(map[string]string, len(c.Hooks))
+	for k := range c.Hooks {
+		hooks[k] = "<redacted>"
+	}
+	c.Hooks = hooks
+	var b strings.Builder
+	if err := toml.NewEncoder(&b).Encode(c); err != nil {
+		return "(could not encode config: " + err.Error() + ")\n"
+	}
+	return b.String()
+}
+
+// osRelease returns PRETTY_NAME from /etc/os-release.
+func osRelease() string {
+	f, err := os.Open("/etc/os-release")
+	if err != nil {
+		return "unknown"
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if v, ok := strings.CutPrefix(sc.Text(), "PRETTY_NAME="); ok {
+			return strings.Trim(v, `"`)
+		}
+	}
+	return "unknown"
+}
+
+func tuiVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "unknown"
+}
+
+// diagnosticsInput is what the bundle is built from, gathered up front
+// so assembling it stays free of side effects.
+type diagnosticsInput struct {
+	now           time.Time
+	status        string
+	engine        string
+	clientVersion string
+	logLines      []string
+	inspect       string
+	kernel        string
+}
+
+// buildDiagnostics assembles the bundle text.
+func buildDiagnostics(in diagnosticsInput) string {
+	var b strings.Builder
+	section := func(title string) { b.WriteString("\n### " + title + "\n\n") }
+	fence := func(body string) {
+		b.WriteString("```\n" + strings.TrimRight(body, "\n") + "\n```\n")
+	}
+
+	fmt.Fprintf(&b, "## HackerOS-Steam diagnostics — %s\n", in.now.Format(time.RFC3339))
+
+	section("Versions")
+	rows := [][2]string{
+		{"TUI", tuiVersion()},
+		{"Go", runtime.Version()},
+		{"CLI", cli},
+		{"Steam client", in.clientVersion},
+	}
+	for _, r := range rows {
+		if r[1] == "" {
+			r[1] = "unknown"
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", r[0], r[1])
+	}
+
+	section("System")
+	kernel := in.kernel
+	if kernel == "" {
+		kernel = "unknown"
+	}
+	fmt.Fprintf(&b, "- OS: %s\n- Kernel: %s\n- Arch: %s/%s\n- TERM: %s\n",
+		osRelease(), kernel, runtime.GOOS, runtime.GOARCH, os.Getenv("TERM"))
+	if remoteHost != "" {
+		b.WriteString("- Remote: yes\n")
+	}
+	if env := desktopEnv(); env != "" {
+		fmt.Fprintf(&b, "- Desktop: %s\n", env)
+	}
+
+	section("Container")
+	fmt.Fprintf(&b, "- Name: %s\n- Status: %s\n- Engine: %s\n\n", containerName, in.status, in.engine)
+	fence(in.inspect)
+
+	section("Config")
+	fence(redactedConfig(cfg))
+
+	section(fmt.Sprintf("Session log (last %d lines)", diagLogLines))
+	lines := in.logLines
+	if len(lines) > diagLogLines {
+		lines = lines[len(lines)-diagLogLines:]
+	}
+	fence(strings.Join(lines, "\n"))
+
+	return redact(b.String())
+}
+
+// desktopEnv names the session type and desktop, e.g. "wayland KDE".
+func desktopEnv() string {
+	var parts []string
+	for _, k := range []string{"XDG_SESSION_TYPE", "XDG_CURRENT_DESKTOP"} {
+		if v := os.Getenv(k); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// diagnosticsBundle is the "Diagnostics Bundle" action.
+func (m *model) diagnosticsBundle() tea.Cmd {
+	m.busy = true
+	in := diagnosticsInput{
+		now:           time.Now(),
+		status:        m.containerStatus,
+		engine:        m.engine(),
+		clientVersion: readClientVersion(),
+		logLines:      plainLogLines(m.logLines, true),
+	}
+	dir := cfg.LogDir
+	return func() tea.Msg {
+		inspect, err := auditedOutput("Diagnostics Bundle", hostCommand([]string{in.engine, "inspect", "--type", "container", containerName}, nil, false), combinedTailOf)
+		in.inspect = inspect
+		if err != nil {
+			in.inspect += fmt.Sprintf("(inspect failed: %v)\n", err)
+		}
+		if out, err := auditedOutput("Diagnostics Bundle", exec.Command("uname", "-r"), stdoutOf); err == nil {
+			in.kernel = strings.TrimSpace(string(out))
+		}
+		bundle := buildDiagnostics(in)
+
+		f, err := createExportFile(dir, "hackeros-steam-diag-"+in.now.Format("20060102-150405")+".md", 0o644)
+		if err != nil {
+			return diagnosticsMsg{bundle: bundle, err: err}
+		}
+		_, werr := f.WriteString(bundle)
+		if cerr := f.Close(); werr == nil {
+			werr = cerr
+		}
+		if werr != nil {
+			os.Remove(f.Name())
+			return diagnosticsMsg{bundle: bundle, err: werr}
+		}
+		return diagnosticsMsg{path: f.Name(), bundle: bundle}
+	}
+}
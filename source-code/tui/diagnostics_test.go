@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedact(t *testing.T) {
+	t.Setenv("HOME", "/home/gamer")
+	t.Setenv("USER", "gamer")
+	tests := []struct {
+		in, want string
+	}{
+		{"/home/gamer/.local/share/Steam", "~/.local/share/Steam"},
+		{"owned by gamer:gamer", "owned by <user>:<user>"},
+		{"gamers united", "gamers united"},
+		{"GITHUB_TOKEN=ghp_abc123", "GITHUB_TOKEN=<redacted>"},
+		{"password: hunter2", "password: <redacted>"},
+		{"Authorization: Bearer eyJhbGci", "Authorization: Bearer <redacted>"},
+		{"steam_api_key = 0123abcd", "steam_api_key = <redacted>"},
+		{"nothing secret here", "nothing secret here"},
+	}
+	for _, tt := range tests {
+		if got := redact(tt.in); got != tt.want {
+			t.Errorf("redact(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactedConfigHidesHooks(t *testing.T) {
+	c := defaultConfig()
+	c.Hooks = map[string]string{"pre_run": "curl -H 'X-Key: s3cr3t' https://example.invalid"}
+	out := redactedConfig(c)
+	if strings.Contains(out, "s3cr3t") || !strings.Contains(out, "pre_run") {
+		t.Errorf("redactedConfig =\n%s", out)
+	}
+	if !strings.Contains(out, `privilege_cmd = "pkexec"`) {
+		t.Errorf("redactedConfig dropped settings:\n%s", out)
+	}
+}
+
+func TestBuildDiagnostics(t *testing.T) {
+	t.Setenv("HOME", "/home/gamer")
+	t.Setenv("USER", "gamer")
+	var log []string
+	for i := 1; i <= diagLogLines+20; i++ {
+		log = append(log, fmt.Sprintf("line %d", i))
+	}
+	log = append(log, "wrote /home/gamer/.cache/x with token=abc")
+
+	bundle := buildDiagnostics(diagnosticsInput{
+		now:           time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC),
+		status:        "running",
+		engine:        "podman",
+		clientVersion: "",
+		logLines:      log,
+		inspect:       `[{"Name": "HackerOS-Steam", "Mounts": ["/home/gamer"]}]`,
+		kernel:        "6.18.44",
+	})
+
+	for _, want := range []string{
+		"## HackerOS-Steam diagnostics — 2026-10-14T15:00:00Z",
+		"### Versions", "- Steam client: unknown",
+		"### System", "- Kernel: 6.18.44",
+		"### Container", "- Status: running", "- Engine: podman", `"Mounts": ["~"]`,
+		"### Config",
+		fmt.Sprintf("### Session log (last %d lines)", diagLogLines),
+		"wrote ~/.cache/x with token=<redacted>",
+	} {
+		if !strings.Contains(bundle, want) {
+			t.Errorf("bundle lacks %q", want)
+		}
+	}
+	if strings.Contains(bundle, "line 21\n") || !strings.Contains(bundle, "line 22\n") {
+		t.Errorf("session log not trimmed to the last %d lines", diagLogLines)
+	}
+	if strings.Contains(bundle, "/home/gamer") {
+		t.Error("bundle still contains the home directory")
+	}
+	if n := strings.Count(bundle, "```"); n%2 != 0 {
+		t.Errorf("unbalanced code fences (%d)", n)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Download cache
+//  "Clear Download Cache" runs `clear-cache`, which deletes what
+//  Steam's own option does — appcache/httpcache and depotcache — and
+//  ends with "reclaimed: BYTES". It asks first: a download in
+//  progress is cut off and starts over.
+// ─────────────────────────────────────────────────────────────────
+
+var clearCacheWarning = []string{
+	"Deletes Steam's download cache (appcache/httpcache, depotcache).",
+	"Downloads in progress are interrupted; Steam restarts them.",
+	"Often gets a stuck download going again.",
+}
+
+func isClearCacheItem(item *menuItem) bool {
+	return item != nil && actionName(*item) == "clear-cache"
+}
+
+// parseReclaimed reads the byte count clear-cache printed last.
+func parseReclaimed(lines []string) (int64, bool) {
+	for i := len(lines) - 1; i >= 0; i-- {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(stripANSI(lines[i])), "reclaimed: ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(rest, 10, 64)
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// logReclaimed reports the space a finished clear-cache freed.
+func (m *model) logReclaimed() {
+	n, ok := parseReclaimed(m.runOutput)
+	switch {
+	case !ok:
+		m.appendLog(styleLogDim.Render("  → Space reclaimed: unknown"))
+	case n == 0:
+		m.appendLog(styleLogInfo.Render("  → Nothing to reclaim; the cache was empty."))
+	default:
+		m.appendLog(styleLogInfo.Render("  → Space reclaimed: " + strings.TrimSuffix(formatSpeed(float64(n)), "/s")))
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestParseReclaimed(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  int64
+		ok    bool
+	}{
+		{"reclaimed", []string{"Deleting depotcache (1.2 GiB)...", "reclaimed: 1288490188"}, 1288490188, true},
+		{"empty cache", []string{"The download cache is already empty.", "reclaimed: 0"}, 0, true},
+		{"colored and indented", []string{"\x1b[32m  reclaimed: 4096\x1b[0m"}, 4096, true},
+		{"last one counts", []string{"reclaimed: 1", "reclaimed: 2"}, 2, true},
+		{"trailing output", []string{"reclaimed: 512", "Done."}, 512, true},
+		{"not a number", []string{"reclaimed: lots"}, 0, false},
+		{"negative", []string{"reclaimed: -5"}, 0, false},
+		{"old CLI", []string{"Download cache cleared."}, 0, false},
+		{"no output", nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseReclaimed(tt.lines)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("parseReclaimed(%q) = %d, %v; want %d, %v", tt.lines, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestClearCacheConfirmation(t *testing.T) {
+	item := menuItems[itemIndex(t, "Clear Download Cache")]
+	tests := []struct {
+		answer  string
+		started bool
+	}{
+		{"y", true},
+		{"n", false},
+		{"esc", false},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.width, m.height = 120, 40
+		m.containerStatus = "running"
+		m.cursor = itemIndex(t, item.label)
+		m = press(m, "enter")
+		if m.state != stateConfirm || m.pendingItem == nil || m.pendingItem.label != item.label {
+			t.Fatalf("enter on Clear Download Cache did not ask first (state %v)", m.state)
+		}
+		m = press(settle(m), tt.answer)
+		if started := countLogged(m, "$ hackeros-steam clear-cache") == 1; started != tt.started {
+			t.Errorf("answer %q: started = %v, want %v", tt.answer, started, tt.started)
+		}
+	}
+}
+
+func TestLogReclaimed(t *testing.T) {
+	tests := []struct {
+		output []string
+		want   string
+	}{
+		{[]string{"reclaimed: 3145728"}, "Space reclaimed: 3.0 MiB"},
+		{[]string{"reclaimed: 0"}, "Nothing to reclaim"},
+		{[]string{"done"}, "Space reclaimed: unknown"},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.width, m.height = 120, 40
+		m.containerStatus = "running"
+		m.openPrompt(stateConfirm, menuItems[itemIndex(t, "Clear Download Cache")])
+		m = press(settle(m), "y")
+		for _, line := range tt.output {
+			next, _ := m.Update(cmdOutputMsg(line))
+			m = next.(model)
+		}
+		next, _ := m.Update(cmdDoneMsg(true))
+		m = next.(model)
+		if countLogged(m, tt.want) != 1 {
+			t.Errorf("output %q: %q not logged in %q", tt.output, tt.want, plainLogLines(m.logLines, false))
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Container environment
+//  Variables kept in container.env (KEY=VALUE per line, next to the
+//  config) are passed to every hackeros-steam command. distrobox enter
+//  carries the caller's environment into the container, so they reach
+//  Steam and its games. An item's own env still wins over them.
+// ─────────────────────────────────────────────────────────────────
+
+const containerEnvFileName = "container.env"
+
+type envVar struct {
+	name  string
+	value string
+}
+
+// persistentEnv is loaded at startup and replaced on every save.
+var persistentEnv []envVar
+
+var reEnvName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedEnvNames would break the container session if overridden.
+var reservedEnvNames = map[string]bool{
+	"HOME": true, "PATH": true, "USER": true, "SHELL": true, "PWD": true,
+	"CONTAINER_ID": true, "DBX_CONTAINER_MANAGER": true,
+}
+
+func containerEnvPath() string {
+	return filepath.Join(configDir(), containerEnvFileName)
+}
+
+func validateEnvName(name string) error {
+	switch {
+	case !reEnvName.MatchString(name):
+		return fmt.Errorf("%q is not a valid variable name", name)
+	case reservedEnvNames[name]:
+		return fmt.Errorf("%s can't be overridden", name)
+	}
+	return nil
+}
+
+// readEnvFile parses path; a missing file is an empty environment.
+// Blank lines and # comments are skipped.
+func readEnvFile(path string) ([]envVar, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseEnv(f, path)
+}
+
+// parseEnv reads container.env content from r; name is used in
+// errors.
+func parseEnv(r io.Reader, name string) ([]envVar, error) {
+	var vars []envVar
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || validateEnvName(key) != nil {
+			return nil, fmt.Errorf("%s:%d: expected NAME=value", name, n)
+		}
+		vars = setEnvVar(vars, key, value)
+	}
+	return vars, sc.Err()
+}
+
+// writeEnvFile replaces path atomically.
+func writeEnvFile(path string, vars []envVar) error {
+	var b strings.Builder
+	b.WriteString("# Managed by the HackerOS Steam TUI (Container Environment).\n")
+	for _, v := range vars {
+		b.WriteString(v.name + "=" + v.value + "\n")
+	}
+	return writeFileAtomic(path, []byte(b.String()), 0o600)
+}
+
+// setEnvVar sets name, keeping its position if it already exists.
+func setEnvVar(vars []envVar, name, value string) []envVar {
+	out := append([]envVar(nil), vars...)
+	for i := range out {
+		if out[i].name == name {
+			out[i].value = value
+			return out
+		}
+	}
+	return append(out, envVar{name: name, value: value})
+}
+
+func removeEnvVar(vars []envVar, name string) []envVar {
+	var out []envVar
+	for _, v := range vars {
+		if v.name != name {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// looksSecret reports whether a variable's value should be masked.
+func looksSecret(name string) bool {
+	up := strings.ToUpper(name)
+	for _, w := range []string{"TOKEN", "SECRET", "PASSWORD", "PASSWD", "KEY", "AUTH", "CREDENTIAL"} {
+		if strings.Contains(up, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// actionEnv is the environment overrides for item: the persistent
+// container environment, the launch settings when it starts Steam, then
+// the item's own.
+func actionEnv(item menuItem) []string {
+	env := make([]string, 0, len(persistentEnv)+len(item.env))
+	for _, v := range persistentEnv {
+		env = append(env, v.name+"="+v.value)
+	}
+	switch actionName(item) {
+	case "run":
+		env = append(env, launchEnv()...)
+	case "update":
+		env = append(env, mirrorEnv()...)
+	}
+	return append(env, item.env...)
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Editor
+// ─────────────────────────────────────────────────────────────────
+
+type envEditor struct {
+	cursor int
+	adding bool   // typing a NAME=value line
+	input  string // the line being typed
+	err    string // last validation or save error
+}
+
+// openEnvEditor is the "Container Environment" action.
+func (m *model) openEnvEditor() tea.Cmd {
+	m.envEditor = &envEditor{}
+	m.state = stateEnv
+	return nil
+}
+
+func (m *model) closeEnvEditor() {
+	m.envEditor = nil
+	m.state = stateMenu
+}
+
+// saveEnv writes vars and makes them current. The file is the source
+// of truth, so nothing changes when the write fails.
+func (m *model) saveEnv(vars []envVar) bool {
+	if err := writeEnvFile(containerEnvPath(), vars); err != nil {
+		m.envEditor.err = err.Error()
+		return false
+	}
+	persistentEnv = vars
+	m.envEditor.err = ""
+	m.refreshLaunchSummary()
+	return true
+}
+
+func (m *model) updateEnvEditor(msg tea.KeyMsg) tea.Cmd {
+	ed := m.envEditor
+	if ed.adding {
+		switch msg.Type {
+		case tea.KeyEsc:
+			ed.adding, ed.input, ed.err = false, "", ""
+		case tea.KeyEnter:
+			name, value, ok := strings.Cut(strings.TrimSpace(ed.input), "=")
+			if !ok {
+				ed.err = "expected NAME=value"
+				return nil
+			}
+			if err := validateEnvName(name); err != nil {
+				ed.err = err.Error()
+				return nil
+			}
+			if m.saveEnv(setEnvVar(persistentEnv, name, value)) {
+				ed.adding, ed.input = false, ""
+				return m.showToast("✔ Set " + name)
+			}
+		case tea.KeyBackspace:
+			if r := []rune(ed.input); len(r) > 0 {
+				ed.input = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			ed.input += string(msg.Runes)
+		}
+		return nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.Up):
+		if ed.cursor > 0 {
+			ed.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if ed.cursor < len(persistentEnv)-1 {
+			ed.cursor++
+		}
+	case key.Matches(msg, keys.AddEnv):
+		ed.adding, ed.err = true, ""
+	case key.Matches(msg, keys.Select):
+		// Edit: start from the current line.
+		if len(persistentEnv) > 0 {
+			v := persistentEnv[ed.cursor]
+			ed.adding, ed.input, ed.err = true, v.name+"="+v.value, ""
+		}
+	case key.Matches(msg, keys.RemoveEnv):
+		if len(persistentEnv) == 0 {
+			return nil
+		}
+		name := persistentEnv[ed.cursor].name
+		if m.saveEnv(removeEnvVar(persistentEnv, name)) {
+			ed.cursor = max(0, min(ed.cursor, len(persistentEnv)-1))
+			return m.showToast("Removed " + name)
+		}
+	case key.Matches(msg, keys.Back):
+		m.closeEnvEditor()
+	}
+	return nil
+}
+
+func (m model) renderEnvEditor() string {
+	ed := m.envEditor
+	rows := []string{
+		styleTitle.Render("Container Environment"),
+		styleLogDim.Render("Passed to every container command. " + tildePath(containerEnvPath())),
+		"",
+	}
+
+	if len(persistentEnv) == 0 {
+		rows = append(rows, styleLogDim.Render("No variables set."))
+	}
+	for i, v := range persistentEnv {
+		value := v.value
+		if looksSecret(v.name) && value != "" {
+			value = "••••••"
+		}
+		text := v.name + "=" + value
+		if i == ed.cursor && !ed.adding {
+			rows = append(rows, lipgloss.NewStyle().Foreground(colCursor).Bold(true).Render("▶ "+text))
+		} else {
+			rows = append(rows, "  "+lipgloss.NewStyle().Foreground(colText).Render(text))
+		}
+	}
+
+	if ed.adding {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(colText).Render("> "+ed.input+"█"))
+	}
+	if ed.err != "" {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(colRed).Render(ed.err))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colAccent).
+		Padding(1, 4)
+
+	return m.placeOverlay(box, strings.Join(rows, "\n"))
+}
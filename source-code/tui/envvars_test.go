@@ -0,0 +1,244 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateEnvName(t *testing.T) {
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{"MANGOHUD", true},
+		{"_private1", true},
+		{"DXVK_HUD", true},
+		{"1BAD", false},
+		{"WITH-DASH", false},
+		{"", false},
+		{"PATH", false},
+		{"HOME", false},
+	}
+	for _, tt := range tests {
+		if err := validateEnvName(tt.name); (err == nil) != tt.ok {
+			t.Errorf("validateEnvName(%q) = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestSetAndRemoveEnvVar(t *testing.T) {
+	base := []envVar{{"A", "1"}, {"B", "2"}}
+	tests := []struct {
+		name string
+		got  []envVar
+		want []envVar
+	}{
+		{"add", setEnvVar(base, "C", "3"), []envVar{{"A", "1"}, {"B", "2"}, {"C", "3"}}},
+		{"replace keeps position", setEnvVar(base, "A", "9"), []envVar{{"A", "9"}, {"B", "2"}}},
+		{"remove", removeEnvVar(base, "A"), []envVar{{"B", "2"}}},
+		{"remove missing", removeEnvVar(base, "Z"), []envVar{{"A", "1"}, {"B", "2"}}},
+		{"remove last", removeEnvVar([]envVar{{"A", "1"}}, "A"), nil},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if base[0].value != "1" || len(base) != 2 {
+		t.Errorf("input modified: %v", base)
+	}
+}
+
+func TestEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string // "" = no file
+		want    []envVar
+		err     string // the error's end, after the path; "" = none
+	}{
+		{"missing", "", nil, ""},
+		{"values", "# comment\n\nA=1\nB=x=y\nC=\n", []envVar{{"A", "1"}, {"B", "x=y"}, {"C", ""}}, ""},
+		{"repeated name", "A=1\nB=2\nA=3\n", []envVar{{"A", "3"}, {"B", "2"}}, ""},
+		{"no equals", "A=1\nsk-live-1234\n", nil, ":2: expected NAME=value"},
+		{"bad name", "9A=1\n", nil, ":1: expected NAME=value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".env")
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := readEnvFile(path)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("err = %v, want none", err)
+			case tt.err != "" && (err == nil || err.Error() != path+tt.err):
+				t.Fatalf("err = %v, want %q", err, path+tt.err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	path := filepath.Join(dir, "sub", "round.env")
+	vars := []envVar{{"MANGOHUD", "1"}, {"STEAM_TOKEN", "s3cret"}}
+	if err := writeEnvFile(path, vars); err != nil {
+		t.Fatalf("writeEnvFile: %v", err)
+	}
+	if got, err := readEnvFile(path); err != nil || !reflect.DeepEqual(got, vars) {
+		t.Errorf("round trip = %v, %v", got, err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Errorf("file mode %v, %v", fi.Mode(), err)
+	}
+}
+
+func TestLooksSecret(t *testing.T) {
+	for name, want := range map[string]bool{
+		"STEAM_TOKEN": true, "api_key": true, "DB_PASSWORD": true, "AUTH_HEADER": true,
+		"MANGOHUD": false, "DXVK_HUD": false, "PROTON_LOG": false,
+	} {
+		if got := looksSecret(name); got != want {
+			t.Errorf("looksSecret(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func envEditorModel(t *testing.T) model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	saved := persistentEnv
+	persistentEnv = nil
+	t.Cleanup(func() { persistentEnv = saved })
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.cursor = itemIndex(t, "Container Environment")
+	m = press(m, "enter")
+	if m.state != stateEnv || m.envEditor == nil {
+		t.Fatalf("Container Environment opened state %v", m.state)
+	}
+	return m
+}
+
+func TestEnvEditorCRUD(t *testing.T) {
+	m := envEditorModel(t)
+	onDisk := func() []envVar {
+		t.Helper()
+		vars, err := readEnvFile(containerEnvPath())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return vars
+	}
+
+	steps := []struct {
+		name    string
+		keys    []string
+		want    []envVar
+		wantErr string
+	}{
+		{"add", []string{"a", "MANGOHUD=1", "enter"}, []envVar{{"MANGOHUD", "1"}}, ""},
+		{"add secret", []string{"a", "STEAM_TOKEN=abc", "enter"}, []envVar{{"MANGOHUD", "1"}, {"STEAM_TOKEN", "abc"}}, ""},
+		{"invalid name", []string{"a", "1X=y", "enter"}, []envVar{{"MANGOHUD", "1"}, {"STEAM_TOKEN", "abc"}}, "not a valid variable name"},
+		{"reserved", []string{"esc", "a", "PATH=/tmp", "enter"}, []envVar{{"MANGOHUD", "1"}, {"STEAM_TOKEN", "abc"}}, "can't be overridden"},
+		{"no equals", []string{"esc", "a", "JUSTNAME", "enter"}, []envVar{{"MANGOHUD", "1"}, {"STEAM_TOKEN", "abc"}}, "expected NAME=value"},
+		{"edit", []string{"esc", "enter", "backspace", "0", "enter"}, []envVar{{"MANGOHUD", "0"}, {"STEAM_TOKEN", "abc"}}, ""},
+		{"remove", []string{"down", "d"}, []envVar{{"MANGOHUD", "0"}}, ""},
+	}
+	for _, s := range steps {
+		m = press(m, s.keys...)
+		if got := onDisk(); !reflect.DeepEqual(got, s.want) {
+			t.Errorf("%s: file has %v, want %v", s.name, got, s.want)
+		}
+		if !reflect.DeepEqual(persistentEnv, s.want) {
+			t.Errorf("%s: persistentEnv = %v", s.name, persistentEnv)
+		}
+		if !strings.Contains(m.envEditor.err, s.wantErr) || (s.wantErr == "" && m.envEditor.err != "") {
+			t.Errorf("%s: err = %q, want %q", s.name, m.envEditor.err, s.wantErr)
+		}
+	}
+
+	m = press(m, "esc")
+	if m.state != stateMenu || m.envEditor != nil {
+		t.Errorf("esc left state %v", m.state)
+	}
+}
+
+func TestEnvEditorMasksSecrets(t *testing.T) {
+	m := envEditorModel(t)
+	persistentEnv = []envVar{{"MANGOHUD", "1"}, {"STEAM_TOKEN", "abc123"}, {"EMPTY_KEY", ""}}
+	view := m.renderEnvEditor()
+	for _, want := range []string{"MANGOHUD=1", "STEAM_TOKEN=••••••", "EMPTY_KEY="} {
+		if !strings.Contains(view, want) {
+			t.Errorf("editor lacks %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "abc123") {
+		t.Errorf("secret value shown:\n%s", view)
+	}
+}
+
+// The persistent environment reaches the command; an item's own env
+// wins over it.
+func TestPersistentEnvReachesCommand(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	saved := persistentEnv
+	defer func() { persistentEnv = saved }()
+	persistentEnv = []envVar{{"HS_A", "persistent"}, {"HS_B", "persistent"}}
+
+	item := menuItem{env: []string{"HS_B=item"}}
+	out, err := hostCommand([]string{sh, "-c", `echo "$HS_A $HS_B"`}, actionEnv(item), false).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "persistent item" {
+		t.Errorf("command saw %q", got)
+	}
+}
+
+// The same, the way an action runs: dispatched from the model and
+// streamed with the environment it was resolved with.
+func TestPersistentEnvReachesDispatchedCommand(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	defer func(saved []envVar) { persistentEnv = saved }(persistentEnv)
+	t.Setenv("HOME", t.TempDir())
+	persistentEnv = []envVar{{"HS_A", "persistent"}, {"HS_B", "persistent"}}
+	script := writePlugin(t, t.TempDir(), "env.sh", "#!/bin/sh\necho \"$HS_A $HS_B\"\n", 0o755)
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.dispatch(menuItem{label: "Env", script: script, env: []string{"HS_B=item"}})
+	if m.lastItem == nil || m.lastItem.label != "Env" || !m.busy {
+		t.Fatalf("not started: %+v", m.lastItem)
+	}
+	// Edits made while it starts don't reach it.
+	persistentEnv = nil
+	started, ok := runStreamCmd(m.lastAction.argv, m.lastAction.env)().(streamStartedMsg)
+	if !ok {
+		t.Fatal("stream not started")
+	}
+	var output []string
+	for msg := range started.ch {
+		if line, ok := msg.(cmdOutputMsg); ok {
+			output = append(output, string(line))
+		}
+	}
+	if strings.Join(output, "\n") != "persistent item" {
+		t.Errorf("command saw %q", output)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Session errors
+//  Every error logged this session is counted, and the most recent one
+//  is kept with its detail so it can be reopened (e) after it scrolled
+//  out of view. The count shows in the footer until cleared.
+// ─────────────────────────────────────────────────────────────────
+
+// errorDetailLines is how much command output is kept with an error.
+const errorDetailLines = 15
+
+type errorRecord struct {
+	at      time.Time
+	text    string
+	command string // what was running, "" if nothing
+	detail  []string
+}
+
+// logError logs text as an error and records it as the last error.
+func (m *model) logError(text string, detail ...string) {
+	m.appendLog(styleLogError.Render("  ✖  " + text))
+	m.errorCount++
+	if len(detail) > errorDetailLines {
+		detail = detail[len(detail)-errorDetailLines:]
+	}
+	m.lastError = &errorRecord{at: time.Now(), text: text, command: m.errorCommand(), detail: append([]string(nil), detail...)}
+}
+
+func (m *model) clearErrors() {
+	m.errorCount = 0
+	m.lastError = nil
+}
+
+func (m *model) handleLastErrorKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.ForceQuit):
+		return m.requestQuit()
+	case key.Matches(msg, keys.ClearErrors):
+		m.clearErrors()
+		m.popup = popupNone
+		return m.showToast("Errors cleared")
+	case key.Matches(msg, keys.CopyIssue):
+		return m.copyIssue()
+	case key.Matches(msg, keys.LastError, keys.Back):
+		m.popup = popupNone
+	}
+	return nil
+}
+
+// errorBadge is the footer's error count, or "" when there are none.
+func (m model) errorBadge() string {
+	if m.errorCount == 0 {
+		return ""
+	}
+	noun := "errors"
+	if m.errorCount == 1 {
+		noun = "error"
+	}
+	return lipgloss.NewStyle().Foreground(colRed).Bold(true).Render(fmt.Sprintf("✖ %d %s", m.errorCount, noun))
+}
+
+func (m model) renderLastError() string {
+	rows := []string{lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("Last error"), ""}
+
+	if e := m.lastError; e == nil {
+		rows = append(rows, styleLogDim.Render("No errors this session."))
+	} else {
+		rows = append(rows,
+			styleLogDim.Render(e.at.Format("15:04:05"))+"  "+lipgloss.NewStyle().Foreground(colText).Render(e.text))
+		if len(e.detail) > 0 {
+			rows = append(rows, "")
+			for _, l := range e.detail {
+				rows = append(rows, styleLogDim.Render(stripANSI(l)))
+			}
+		}
+		if m.errorCount > 1 {
+			rows = append(rows, "", styleLogDim.Render(fmt.Sprintf("%d errors this session.", m.errorCount)))
+		}
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colRed).
+		Padding(1, 4)
+
+	return m.placeOverlay(box, strings.Join(rows, "\n"))
+}
+
+// errorCommand is the command line of the last action, for the record.
+func (m model) errorCommand() string {
+	switch {
+	case m.lastItem == nil:
+		return ""
+	case m.lastItem.script != "":
+		return tildePath(m.lastItem.script)
+	case m.lastItem.run != nil:
+		return m.lastItem.label
+	}
+	return "hackeros-steam " + strings.Join(m.lastItem.cmd, " ")
+}
+
+// lastItemLabel names the last action for error messages.
+func (m model) lastItemLabel() string {
+	if m.lastItem == nil {
+		return "Command"
+	}
+	return m.lastItem.label
+}
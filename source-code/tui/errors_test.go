@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestErrorCounter(t *testing.T) {
+	tests := []struct {
+		errors int
+		badge  string
+	}{
+		{0, ""},
+		{1, "✖ 1 error"},
+		{3, "✖ 3 errors"},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		for i := 1; i <= tt.errors; i++ {
+			m.logError(fmt.Sprintf("failure %d", i))
+		}
+		if m.errorCount != tt.errors {
+			t.Errorf("%d errors: count = %d", tt.errors, m.errorCount)
+		}
+		if got := stripANSI(m.errorBadge()); got != tt.badge {
+			t.Errorf("%d errors: badge = %q, want %q", tt.errors, got, tt.badge)
+		}
+		if tt.errors > 0 && m.lastError.text != fmt.Sprintf("failure %d", tt.errors) {
+			t.Errorf("%d errors: last error = %q", tt.errors, m.lastError.text)
+		}
+	}
+}
+
+func TestLastErrorKeepsTail(t *testing.T) {
+	m := initialModel()
+	var out []string
+	for i := 1; i <= errorDetailLines+5; i++ {
+		out = append(out, fmt.Sprintf("line %d", i))
+	}
+	m.logError("Setup: command exited with error.", out...)
+	out[len(out)-1] = "changed"
+
+	e := m.lastError
+	if len(e.detail) != errorDetailLines || e.detail[0] != "line 6" {
+		t.Fatalf("detail = %q", e.detail)
+	}
+	if e.detail[len(e.detail)-1] != fmt.Sprintf("line %d", errorDetailLines+5) {
+		t.Error("detail aliases the caller's slice")
+	}
+}
+
+func TestLastErrorSurvivesLaterRuns(t *testing.T) {
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.busy, m.state = true, stateRunning
+	m.lastItem = &menuItem{label: "Update Container"}
+	m.runOutput = []string{"error: mirror unreachable"}
+	next, _ := m.Update(cmdDoneMsg(false))
+	m = next.(model)
+
+	m.busy, m.state = true, stateRunning
+	m.lastItem = &menuItem{label: "Container Status"}
+	m.runOutput = []string{"running"}
+	next, _ = m.Update(cmdDoneMsg(true))
+	m = next.(model)
+
+	if m.errorCount != 1 || !strings.HasPrefix(m.lastError.text, "Update Container") {
+		t.Fatalf("count %d, last error %+v", m.errorCount, m.lastError)
+	}
+	if !strings.Contains(stripANSI(m.renderFooter()), "✖ 1 error") {
+		t.Errorf("footer lacks the badge: %q", stripANSI(m.renderFooter()))
+	}
+
+	m = press(m, "e")
+	if m.popup != popupLastError {
+		t.Fatal("e did not open the last error")
+	}
+	if popup := m.renderLastError(); !strings.Contains(popup, "mirror unreachable") {
+		t.Errorf("popup lacks the output:\n%s", popup)
+	}
+	m = press(m, "esc")
+	if m.popup != popupNone || m.errorCount != 1 {
+		t.Errorf("esc: popup %v, count %d", m.popup, m.errorCount)
+	}
+
+	m = press(m, "e", "x")
+	if m.popup != popupNone || m.errorCount != 0 || m.lastError != nil {
+		t.Errorf("x: popup %v, count %d, last %v", m.popup, m.errorCount, m.lastError)
+	}
+	if popup := m.renderLastError(); !strings.Contains(popup, "No errors this session.") {
+		t.Errorf("cleared popup:\n%s", popup)
+	}
+}
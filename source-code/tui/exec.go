@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Streaming exec
+// ─────────────────────────────────────────────────────────────────
+
+// progressInterval caps progress redraws at ~20fps no matter how fast
+// the command prints.
+const progressInterval = 50 * time.Millisecond
+
+type (
+	// streamStartedMsg carries the channel a running command reports on
+	// and a function that interrupts it.
+	streamStartedMsg struct {
+		ch   <-chan tea.Msg
+		stop func()
+	}
+
+	// progressMsg reports overall completion in the range 0..1 and,
+	// while files are being extracted, the file counts (0 = unknown).
+	progressMsg struct {
+		percent    float64
+		files      int
+		totalFiles int
+	}
+
+	// speedMsg reports the latest transfer rate in bytes per second.
+	speedMsg struct{ bps float64 }
+)
+
+// runStreamCmd starts a command line from an action; its output arrives
+// as cmdOutputMsg / progressMsg and ends with a single cmdDoneMsg.
+func runStreamCmd(argv, env []string) tea.Cmd {
+	return func() tea.Msg {
+		ch := make(chan tea.Msg, 64)
+		stopCh := make(chan struct{})
+		var once sync.Once
+		stop := func() { once.Do(func() { close(stopCh) }) }
+		started := appLife.goTracked(func(ctx context.Context) {
+			streamCommand(ctx, argv, env, ch, stopCh)
+		})
+		if !started {
+			return cmdDoneMsg(false)
+		}
+		return streamStartedMsg{ch: ch, stop: stop}
+	}
+}
+
+// waitForStream delivers the next message from a running command.
+func waitForStream(ch <-chan tea.Msg) tea.Cmd {
+	if ch == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// streamCommand runs argv, reporting on ch until it exits or ctx is
+// done. On ctx it stops reading and returns without waiting: the
+// command loses its output pipes, as it would if the TUI had died, and
+// is left to finish on its own.
+func streamCommand(ctx context.Context, argv, env []string, ch chan<- tea.Msg, stop <-chan struct{}) {
+	defer close(ch)
+	send := func(msg tea.Msg) {
+		select {
+		case ch <- msg:
+		case <-ctx.Done():
+		}
+	}
+
+	cmd := hostCommand(argv, env, false)
+	// Its own process group, so an interrupt reaches pacman and the
+	// other children too, not just hackeros-steam.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	// stdout and stderr get a pipe and a reader each, so a command that
+	// fills one while the other is quiet never blocks on a full buffer.
+	stdout, err := cmd.StdoutPipe()
+	var stderr io.ReadCloser
+	if err == nil {
+		stderr, err = cmd.StderrPipe()
+	}
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		send(cmdStartErrMsg{lines: startErrorLines(cmd.Args, err)})
+		return
+	}
+	send(procStartedMsg{pid: cmd.Process.Pid, argv: cmd.Args, at: time.Now()})
+
+	lines := make(chan outputLine)
+	var readers sync.WaitGroup
+	for i, r := range []io.Reader{stdout, stderr} {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			drainLines(ctx, r, i == 1, lines)
+		}()
+	}
+
+	// Wait closes the pipes, so it only runs once both readers are done.
+	waitErr := make(chan error, 1)
+	exited := make(chan struct{})
+	go func() {
+		readers.Wait()
+		close(lines)
+		err := cmd.Wait()
+		close(exited)
+		waitErr <- err
+	}()
+	// An interrupt lets the command clean up the way ctrl+c in a
+	// terminal would. Quitting only closes the pipes, which ends the
+	// readers.
+	go func() {
+		select {
+		case <-stop:
+			syscall.Kill(-cmd.Process.Pid, syscall.SIGINT)
+		case <-ctx.Done():
+			stdout.Close()
+			stderr.Close()
+		case <-exited:
+		}
+	}()
+
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	// Only the latest progress value is kept; it is sent on the next
+	// tick, so a flood of lines produces at most one redraw per tick.
+	var latest progressMsg
+	var speed float64
+	dirty, speedDirty := false, false
+
+	track := func(line string) {
+		if cur, total, ok := parseFileCount(line); ok {
+			latest.files, latest.totalFiles = cur, total
+			latest.percent = float64(cur) / float64(total)
+			dirty = true
+		} else if p, ok := parseProgress(line); ok {
+			latest.percent, dirty = p, true
+		}
+		if bps, ok := parseSpeed(line); ok {
+			speed, speedDirty = bps, true
+		}
+	}
+
+	var tail *fileTailer
+	if progressFile != "" {
+		tail = newFileTailer(progressFile)
+		defer tail.close()
+	}
+
+	for open := true; open; {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				open = false
+				break
+			}
+			// Progress comes from stdout; stderr carries warnings.
+			if line.stderr {
+				send(cmdStderrMsg(line.text))
+				break
+			}
+			send(cmdOutputMsg(line.text))
+			if !line.partial {
+				track(stripANSI(line.text))
+			}
+		case <-ticker.C:
+			if tail != nil {
+				for _, line := range tail.poll() {
+					track(line)
+				}
+			}
+			if dirty {
+				send(latest)
+				dirty = false
+			}
+			if speedDirty {
+				send(speedMsg{bps: speed})
+				speedDirty = false
+			}
+		case <-ctx.Done():
+			readers.Wait()
+			return
+		}
+	}
+
+	err = <-waitErr
+	send(exitedMsg(cmd))
+	if tail != nil {
+		for _, line := range tail.poll() {
+			track(line)
+		}
+	}
+	if isUnreachable(err) {
+		send(cmdOutputMsg("  ✖  Could not reach " + remoteHost + " over SSH."))
+	}
+	if dirty {
+		send(latest)
+	}
+	if err == nil {
+		send(progressMsg{percent: 1, files: latest.totalFiles, totalFiles: latest.totalFiles})
+	}
+	send(cmdDoneMsg(err == nil))
+}
+
+// outputLine is a line read from one of a command's streams.
+type outputLine struct {
+	text   string
+	stderr bool
+	// partial is set on text the stream ended in without a newline,
+	// which may be a line cut off mid-write: "Progress: 4" of 42%.
+	partial bool
+}
+
+// lineSplitter is bufio.ScanLines remembering whether the last line
+// had its newline. Reads that split a line are joined by the scanner;
+// only the remainder at EOF can come without one.
+type lineSplitter struct{ complete bool }
+
+func (s *lineSplitter) split(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if token != nil {
+		s.complete = bytes.IndexByte(data[:advance], '\n') >= 0
+	}
+	return advance, token, err
+}
+
+// drainLines sends each line read from r to lines until EOF, or until
+// ctx is done.
+func drainLines(ctx context.Context, r io.Reader, stderr bool, lines chan<- outputLine) {
+	var ls lineSplitter
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	sc.Split(ls.split)
+	for sc.Scan() {
+		select {
+		case lines <- outputLine{text: sanitizeANSI(sc.Text()), stderr: stderr, partial: !ls.complete}:
+		case <-ctx.Done():
+			return
+		}
+	}
+	// Keep draining so the writer never blocks on a line that was too
+	// long for the scanner.
+	io.Copy(io.Discard, r)
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Progress parsing
+// ─────────────────────────────────────────────────────────────────
+
+// parseFileCount extracts the current and total file counts from an
+// extraction line.
+func parseFileCount(line string) (int, int, bool) {
+	m := pat.files.FindStringSubmatch(line)
+	if m == nil {
+		return 0, 0, false
+	}
+	cur, _ := strconv.Atoi(m[1])
+	total, _ := strconv.Atoi(m[2])
+	if total <= 0 || cur > total {
+		return 0, 0, false
+	}
+	return cur, total, true
+}
+
+// parseProgress extracts a completion fraction from a line of output.
+func parseProgress(line string) (float64, bool) {
+	if m := pat.step.FindStringSubmatch(line); m != nil {
+		cur, _ := strconv.Atoi(m[1])
+		total, _ := strconv.Atoi(m[2])
+		if total > 0 && cur <= total {
+			return float64(cur) / float64(total), true
+		}
+	}
+	if m := pat.progress.FindStringSubmatch(line); m != nil {
+		p, err := strconv.ParseFloat(m[1], 64)
+		if err == nil && p <= 100 {
+			return p / 100, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseProgress(t *testing.T) {
+	tests := []struct {
+		line string
+		want float64
+		ok   bool
+	}{
+		{"Progress: 42%", 0.42, true},
+		{"downloading 12.5 % done", 0.125, true},
+		{"(3/4) upgrading steam", 0.75, true},
+		{"(5/4) bogus step", 0, false},
+		{"Progress: 250%", 0, false},
+		{"no numbers here", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseProgress(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseProgress(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// runStream runs argv through streamCommand and collects what it sends.
+func runStream(argv []string) []any {
+	return runStreamEnv(argv, nil)
+}
+
+func runStreamEnv(argv, env []string) []any {
+	ch := make(chan tea.Msg, 64)
+	go streamCommand(context.Background(), argv, env, ch, nil)
+	var msgs []any
+	for msg := range ch {
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// A flood of progress lines must not turn into a redraw per line.
+func TestProgressIsCoalesced(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	const lines = 5000
+	script := `i=0; while [ $i -lt 5000 ]; do echo "Progress: $((i % 100))%"; i=$((i+1)); done`
+	start := time.Now()
+	msgs := runStream([]string{sh, "-c", script})
+	elapsed := time.Since(start)
+
+	progress, output := 0, 0
+	var last progressMsg
+	var done, sawDone bool
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case progressMsg:
+			progress++
+			last = msg
+		case cmdOutputMsg:
+			output++
+		case cmdDoneMsg:
+			done, sawDone = bool(msg), true
+		}
+	}
+	if output != lines {
+		t.Errorf("%d output lines, want %d", output, lines)
+	}
+	// One per tick, plus the pending value and the final 100%.
+	if limit := int(elapsed/progressInterval) + 3; progress > limit {
+		t.Errorf("%d progress messages in %v, want at most %d", progress, elapsed, limit)
+	}
+	if last.percent != 1 {
+		t.Errorf("last progress = %v, want 1", last.percent)
+	}
+	if !sawDone || !done {
+		t.Errorf("done = %v (seen %v), want a successful finish", done, sawDone)
+	}
+}
+
+func TestParseFileCount(t *testing.T) {
+	tests := []struct {
+		line       string
+		cur, total int
+		ok         bool
+	}{
+		{"Extracting 1234/5678 files", 1234, 5678, true},
+		{"  extracted 12 / 40 files", 12, 40, true},
+		{"Extracting: 1/1 file", 1, 1, true},
+		{"EXTRACTING archive (3/9 files)", 3, 9, true},
+		{"Extracting 9/3 files", 0, 0, false},
+		{"Extracting 0/0 files", 0, 0, false},
+		{"Downloading 3/9 files", 0, 0, false},
+		{"Progress: 42%", 0, 0, false},
+	}
+	for _, tt := range tests {
+		cur, total, ok := parseFileCount(tt.line)
+		if cur != tt.cur || total != tt.total || ok != tt.ok {
+			t.Errorf("parseFileCount(%q) = %d, %d, %v; want %d, %d, %v", tt.line, cur, total, ok, tt.cur, tt.total, tt.ok)
+		}
+	}
+}
+
+func TestFileCountRendering(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  progressMsg
+		want string // "" = no counter shown
+	}{
+		{"extracting", progressMsg{percent: 0.3, files: 12, totalFiles: 40}, "Extracting 12/40 files"},
+		{"percentage only", progressMsg{percent: 0.3}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			next, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 40})
+			m = next.(model)
+			m.busy = true
+			next, _ = m.Update(tt.msg)
+			m = next.(model)
+			panel := m.renderLogPanel()
+			if tt.want == "" && strings.Contains(panel, "Extracting") {
+				t.Errorf("counter shown without file counts:\n%s", panel)
+			}
+			if tt.want != "" && !strings.Contains(panel, tt.want) {
+				t.Errorf("panel lacks %q:\n%s", tt.want, panel)
+			}
+		})
+	}
+}
+
+// A command filling both pipes, far past the OS buffer sizes, must
+// finish with every line delivered.
+func TestStreamDrainsBothPipes(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	tests := []struct {
+		name   string
+		script string
+		out    int
+		errs   int
+	}{
+		{"interleaved", `i=0; while [ $i -lt 20000 ]; do echo "out $i"; echo "err $i" >&2; i=$((i+1)); done`, 20000, 20000},
+		{"stderr first", `i=0; while [ $i -lt 20000 ]; do echo "err $i" >&2; i=$((i+1)); done; echo "out done"`, 1, 20000},
+		{"stdout first", `i=0; while [ $i -lt 20000 ]; do echo "out $i"; i=$((i+1)); done; echo "err done" >&2`, 20000, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan []any, 1)
+			go func() { done <- runStream([]string{sh, "-c", tt.script}) }()
+			var msgs []any
+			select {
+			case msgs = <-done:
+			case <-time.After(20 * time.Second):
+				t.Fatal("command deadlocked")
+			}
+			out, errs, ok := 0, 0, false
+			for _, msg := range msgs {
+				switch msg := msg.(type) {
+				case cmdOutputMsg:
+					if strings.HasPrefix(string(msg), "out ") {
+						out++
+					}
+				case cmdStderrMsg:
+					if strings.HasPrefix(string(msg), "err ") {
+						errs++
+					}
+				case cmdDoneMsg:
+					ok = bool(msg)
+				}
+			}
+			if out != tt.out || errs != tt.errs || !ok {
+				t.Errorf("got %d stdout and %d stderr lines (ok %v), want %d and %d", out, errs, ok, tt.out, tt.errs)
+			}
+		})
+	}
+}
+
+func TestDrainLines(t *testing.T) {
+	long := strings.Repeat("x", 2<<20)
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"lines", "a\nb\n", []string{"a", "b"}},
+		{"no trailing newline", "a\nb", []string{"a", "b"}},
+		{"colors kept, cursor moves dropped", "\x1b[32mok\x1b[0m\n\x1b[2Kbar\n", []string{"\x1b[32mok\x1b[0m", "bar"}},
+		{"oversized line drained", "first\n" + long + "\nafter\n", []string{"first"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make(chan outputLine)
+			go func() {
+				drainLines(context.Background(), strings.NewReader(tt.in), true, lines)
+				close(lines)
+			}()
+			var got []string
+			for l := range lines {
+				if !l.stderr {
+					t.Errorf("%q not marked as stderr", l.text)
+				}
+				got = append(got, l.text)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// chunkReader returns one chunk per Read, the way a pipe hands over
+// what the writer has flushed so far.
+type chunkReader struct{ chunks []string }
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	if r.chunks[0] = r.chunks[0][n:]; r.chunks[0] == "" {
+		r.chunks = r.chunks[1:]
+	}
+	return n, nil
+}
+
+func TestLineSplitter(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []string
+		want   []string // text, with a trailing "…" when partial
+	}{
+		{"one read", []string{"Progress: 42%\n"}, []string{"Progress: 42%"}},
+		{"split across reads", []string{"Progress: 4", "2%\n"}, []string{"Progress: 42%"}},
+		{"byte by byte", strings.Split("ab\ncd\n", ""), []string{"ab", "cd"}},
+		{"cut off at EOF", []string{"done\nProgress: 4"}, []string{"done", "Progress: 4…"}},
+		{"crlf", []string{"a\r\n", "b\r", "\n"}, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := make(chan outputLine)
+			go func() {
+				drainLines(context.Background(), &chunkReader{chunks: tt.chunks}, false, lines)
+				close(lines)
+			}()
+			var got []string
+			for l := range lines {
+				if l.partial {
+					l.text += "…"
+				}
+				got = append(got, l.text)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitProgressLineParsedOnce(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	// Failing leaves out the final 100%, so only parsed values remain.
+	tests := []struct {
+		script string
+		want   []float64
+	}{
+		{`printf 'Progress: 4'; sleep 0.2; printf '2%%\n'; exit 1`, []float64{0.42}},
+		{`printf 'Progress: 4'; exit 1`, nil},
+	}
+	for _, tt := range tests {
+		var got []float64
+		output := 0
+		for _, msg := range runStream([]string{sh, "-c", tt.script}) {
+			switch msg := msg.(type) {
+			case progressMsg:
+				got = append(got, msg.percent)
+			case cmdOutputMsg:
+				output++
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) || output != 1 {
+			t.Errorf("%q: progress %v from %d lines, want %v from 1", tt.script, got, output, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Log export
+// ─────────────────────────────────────────────────────────────────
+
+type logExportedMsg struct {
+	path  string
+	bytes int
+	err   error
+}
+
+// containerManager returns the engine distrobox drives, honouring the
+// same DBX_CONTAINER_MANAGER override distrobox itself reads. PATH can't
+// be probed on a remote host, so podman is assumed there.
+func containerManager() string {
+	if mgr := os.Getenv("DBX_CONTAINER_MANAGER"); mgr != "" {
+		return mgr
+	}
+	if remoteHost != "" {
+		return "podman"
+	}
+	for _, mgr := range []string{"podman", "docker"} {
+		if _, err := exec.LookPath(mgr); err == nil {
+			return mgr
+		}
+	}
+	return "podman"
+}
+
+// exportFileName returns the timestamped name used for an export taken
+// at t, e.g. "hackeros-steam-20261014-153000.log".
+func exportFileName(t time.Time) string {
+	return "hackeros-steam-" + t.Format("20060102-150405") + ".log"
+}
+
+// createExportFile creates name inside dir with perm, without ever
+// overwriting an existing file; on collision a numeric suffix is added.
+func createExportFile(dir, name string, perm fs.FileMode) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 0; i < 100; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s-%d%s", base, i, ext)
+		}
+		f, err := os.OpenFile(filepath.Join(dir, candidate), os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		return f, err
+	}
+	return nil, fmt.Errorf("too many exports named %s in %s", name, dir)
+}
+
+// writeLogExport writes the session log followed by the container's own
+// log into a new file in dir and returns its path and size.
+func writeLogExport(dir string, now time.Time, session []string, containerLog string) (string, int, error) {
+	f, err := createExportFile(dir, exportFileName(now), 0o644)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HackerOS-Steam log export — %s\n\n", now.Format(time.RFC3339))
+	b.WriteString("## TUI session\n\n")
+	for _, line := range session {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteString("\n## Container " + containerName + "\n\n")
+	b.WriteString(containerLog)
+
+	n, werr := f.WriteString(b.String())
+	cerr := f.Close()
+	if werr == nil {
+		werr = cerr
+	}
+	if werr != nil {
+		os.Remove(f.Name())
+		return "", 0, werr
+	}
+	return f.Name(), n, nil
+}
+
+// exportLogs is the "Export Logs" action.
+func (m *model) exportLogs() tea.Cmd {
+	m.busy = true
+	session := plainLogLines(m.logLines, cfg.ExportPrefixes)
+	dir := cfg.LogDir
+	engine := m.engine()
+	return func() tea.Msg {
+		containerLog, err := auditedOutput("Export Logs", hostCommand([]string{engine, "logs", containerName}, nil, false), combinedTailOf)
+		if err != nil {
+			containerLog += fmt.Sprintf("(container logs unavailable: %v)\n", err)
+		}
+		path, n, err := writeLogExport(dir, time.Now(), session, containerLog)
+		return logExportedMsg{path: path, bytes: n, err: err}
+	}
+}
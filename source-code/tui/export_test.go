@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportFileName(t *testing.T) {
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC), "hackeros-steam-20261014-153000.log"},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "hackeros-steam-20240102-030405.log"},
+	}
+	for _, tt := range tests {
+		if got := exportFileName(tt.at); got != tt.want {
+			t.Errorf("exportFileName(%v) = %q, want %q", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestCreateExportFileCollisions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	want := []string{"a.log", "a-1.log", "a-2.log"}
+	for _, name := range want {
+		f, err := createExportFile(dir, "a.log", 0o644)
+		if err != nil {
+			t.Fatalf("createExportFile: %v", err)
+		}
+		f.Close()
+		if got := filepath.Base(f.Name()); got != name {
+			t.Errorf("created %q, want %q", got, name)
+		}
+	}
+}
+
+func TestWriteLogExport(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	session := []string{"$ hackeros-steam setup", "done"}
+
+	path, n, err := writeLogExport(dir, now, session, "container says hi\n")
+	if err != nil {
+		t.Fatalf("writeLogExport: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(data) {
+		t.Errorf("reported %d bytes, file has %d", n, len(data))
+	}
+	for _, s := range []string{"$ hackeros-steam setup\ndone\n", "## Container " + containerName, "container says hi"} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("export missing %q:\n%s", s, data)
+		}
+	}
+
+	second, _, err := writeLogExport(dir, now, nil, "")
+	if err != nil {
+		t.Fatalf("second export: %v", err)
+	}
+	if second == path {
+		t.Errorf("second export overwrote %s", path)
+	}
+}
+
+func TestWriteLogExportUnwritableDir(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := writeLogExport(filepath.Join(file, "logs"), time.Now(), nil, ""); err == nil {
+		t.Error("writeLogExport into a file path succeeded, want error")
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Favorites
+//  f in the games view stars a game. Starred games head the menu as a
+//  quick-launch bar, launched with 1–9. They are kept in favorites.toml
+//  by AppID, with the name so a game that has since been uninstalled
+//  can still be shown, dimmed.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	favoritesFileName = "favorites.toml"
+	maxQuickLaunch    = 9
+)
+
+type favorite struct {
+	AppID string `toml:"app_id"`
+	Name  string `toml:"name"`
+
+	installed bool // its manifest exists; refreshed by refreshFavorites
+}
+
+type favoritesFile struct {
+	Favorite []favorite `toml:"favorite"`
+}
+
+// favorites is in the order they were starred.
+var favorites []favorite
+
+func favoritesPath() string {
+	return filepath.Join(configDir(), favoritesFileName)
+}
+
+// loadFavorites reads path; a missing file is not an error.
+func loadFavorites(path string) ([]favorite, error) {
+	var f favoritesFile
+	if _, err := toml.DecodeFile(path, &f); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("favorites %s: %w", path, err)
+	}
+	// Drop blank and repeated IDs a hand edit may have left.
+	seen := map[string]bool{}
+	var out []favorite
+	for _, fav := range f.Favorite {
+		if fav.AppID == "" || seen[fav.AppID] {
+			continue
+		}
+		seen[fav.AppID] = true
+		if fav.Name == "" {
+			fav.Name = "App " + fav.AppID
+		}
+		out = append(out, fav)
+	}
+	return out, nil
+}
+
+func saveFavorites(path string, favs []favorite) error {
+	var b bytes.Buffer
+	if err := toml.NewEncoder(&b).Encode(favoritesFile{Favorite: favs}); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b.Bytes(), 0o644)
+}
+
+func isFavorite(appID string) bool {
+	for _, f := range favorites {
+		if f.AppID == appID {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleFavorite stars or unstars g and saves the list.
+func (m *model) toggleFavorite(g game) tea.Cmd {
+	next := make([]favorite, 0, len(favorites)+1)
+	starred := true
+	for _, f := range favorites {
+		if f.AppID == g.appID {
+			starred = false
+			continue
+		}
+		next = append(next, f)
+	}
+	if starred {
+		next = append(next, favorite{AppID: g.appID, Name: g.name, installed: true})
+	}
+	if err := saveFavorites(favoritesPath(), next); err != nil {
+		m.logError("Could not save favorites: " + err.Error())
+		return nil
+	}
+	favorites = next
+	if starred {
+		return m.showToast("★ " + g.name + " added to favorites")
+	}
+	return m.showToast(g.name + " removed from favorites")
+}
+
+// refreshFavorites marks which favorites are still installed.
+func refreshFavorites(steamapps string) {
+	for i := range favorites {
+		_, err := os.Stat(filepath.Join(steamapps, "appmanifest_"+favorites[i].AppID+".acf"))
+		favorites[i].installed = err == nil
+	}
+}
+
+// quickLaunch returns the favorites in the quick-launch bar.
+func quickLaunch() []favorite {
+	return favorites[:min(len(favorites), maxQuickLaunch)]
+}
+
+// launchFavorite is the digit key n in the menu.
+func (m *model) launchFavorite(n int) tea.Cmd {
+	bar := quickLaunch()
+	if n < 1 || n > len(bar) {
+		return nil
+	}
+	f := bar[n-1]
+	if !f.installed {
+		return m.showToast(f.Name + " is no longer installed")
+	}
+	return m.dispatch(launchGameItem(game{appID: f.AppID, name: f.Name}))
+}
+
+// favoriteKey returns the quick-launch number of a digit key.
+func favoriteKey(msg tea.KeyMsg) (int, bool) {
+	if msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(string(msg.Runes))
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// renderQuickLaunch draws one row per favorite, each at most width wide.
+func renderQuickLaunch(width int) []string {
+	var rows []string
+	for i, f := range quickLaunch() {
+		num := styleMenuIcon.Render(strconv.Itoa(i + 1))
+		name := truncate(f.Name, width-6)
+		if f.installed {
+			rows = append(rows, "  "+num+" "+lipgloss.NewStyle().Foreground(colYellow).Render("★")+" "+styleMenuItem.Render(name))
+		} else {
+			rows = append(rows, "  "+num+" "+styleMenuItem.Foreground(colDim).Render("☆ "+name))
+		}
+	}
+	return rows
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// favoritesHome isolates HOME and the favorites list for one test.
+func favoritesHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	saved := favorites
+	favorites = nil
+	t.Cleanup(func() { favorites = saved })
+}
+
+func TestLoadFavorites(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string // "" = no file
+		want    []favorite
+		wantErr bool
+	}{
+		{"missing", "", nil, false},
+		{"two", "[[favorite]]\napp_id = \"620\"\nname = \"Portal 2\"\n\n[[favorite]]\napp_id = \"570\"\nname = \"Dota 2\"\n",
+			[]favorite{{AppID: "620", Name: "Portal 2"}, {AppID: "570", Name: "Dota 2"}}, false},
+		{"hand edits", "[[favorite]]\napp_id = \"\"\n\n[[favorite]]\napp_id = \"440\"\n\n[[favorite]]\napp_id = \"440\"\nname = \"again\"\n",
+			[]favorite{{AppID: "440", Name: "App 440"}}, false},
+		{"broken", "[[favorite]\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".toml")
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := loadFavorites(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToggleFavoritePersists(t *testing.T) {
+	favoritesHome(t)
+	m := initialModel()
+	portal := game{appID: "620", name: "Portal 2"}
+	dota := game{appID: "570", name: "Dota 2"}
+
+	steps := []struct {
+		g    game
+		want []string
+	}{
+		{portal, []string{"620"}},
+		{dota, []string{"620", "570"}},
+		{portal, []string{"570"}},
+		{portal, []string{"570", "620"}},
+	}
+	for i, s := range steps {
+		m.toggleFavorite(s.g)
+		saved, err := loadFavorites(favoritesPath())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, f := range saved {
+			ids = append(ids, f.AppID)
+		}
+		if !reflect.DeepEqual(ids, s.want) || len(favorites) != len(s.want) {
+			t.Errorf("step %d: saved %v, in memory %d, want %v", i, ids, len(favorites), s.want)
+		}
+	}
+	if !isFavorite("620") || isFavorite("440") {
+		t.Error("isFavorite disagrees with the list")
+	}
+}
+
+func TestUninstalledFavorite(t *testing.T) {
+	favoritesHome(t)
+	writeManifests(t, steamappsDir(), game{appID: "620", name: "Portal 2"})
+	favorites = []favorite{{AppID: "620", Name: "Portal 2"}, {AppID: "570", Name: "Dota 2"}}
+	refreshFavorites(steamappsDir())
+	if !favorites[0].installed || favorites[1].installed {
+		t.Fatalf("installed = %v, %v", favorites[0].installed, favorites[1].installed)
+	}
+
+	bar := renderQuickLaunch(30)
+	if len(bar) != 2 || !strings.Contains(bar[0], "★") || !strings.Contains(bar[0], "Portal 2") ||
+		!strings.Contains(bar[1], "☆ Dota 2") {
+		t.Errorf("quick-launch bar:\n%s", strings.Join(bar, "\n"))
+	}
+
+	tests := []struct {
+		key     string
+		started string
+		toast   string
+	}{
+		{"1", "run -applaunch 620", ""},
+		{"2", "", "Dota 2 is no longer installed"},
+		{"3", "", ""},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.containerStatus = "running"
+		m = press(m, tt.key)
+		if tt.started != "" && countLogged(m, "$ hackeros-steam "+tt.started) != 1 {
+			t.Errorf("key %s: %q not started", tt.key, tt.started)
+		}
+		if tt.started == "" && m.busy {
+			t.Errorf("key %s started a command", tt.key)
+		}
+		if m.toast != tt.toast {
+			t.Errorf("key %s: toast %q, want %q", tt.key, m.toast, tt.toast)
+		}
+	}
+}
+
+func TestFavoriteKey(t *testing.T) {
+	tests := []struct {
+		msg tea.KeyMsg
+		n   int
+		ok  bool
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")}, 1, true},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("9")}, 9, true},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("0")}, 0, false},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("12")}, 0, false},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}, 0, false},
+		{tea.KeyMsg{Type: tea.KeyEnter}, 0, false},
+	}
+	for _, tt := range tests {
+		if n, ok := favoriteKey(tt.msg); n != tt.n || ok != tt.ok {
+			t.Errorf("favoriteKey(%q) = %d, %v", tt.msg, n, ok)
+		}
+	}
+}
+
+func TestQuickLaunchIsCapped(t *testing.T) {
+	favoritesHome(t)
+	for i := 0; i < maxQuickLaunch+3; i++ {
+		favorites = append(favorites, favorite{AppID: string(rune('a' + i)), Name: "Game", installed: true})
+	}
+	if n := len(quickLaunch()); n != maxQuickLaunch {
+		t.Errorf("quick-launch bar has %d entries", n)
+	}
+}
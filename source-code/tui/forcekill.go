@@ -0,0 +1,121 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Force kill
+//  "Stop Container" asks the container to shut down. When that is
+//  still going after forceKillAfter, or the container is up once it
+//  returns, one confirmation escalates to `hackeros-steam --force kill`,
+//  which SIGKILLs the container and, with it, every process in it.
+// ─────────────────────────────────────────────────────────────────
+
+const forceKillAfter = 20 * time.Second
+
+var forceKillItem = menuItem{icon: "☠", label: "Force Kill Container", cmd: []string{"--force", "kill"}, destructive: true}
+
+// killTimeoutMsg fires forceKillAfter into stop attempt id.
+type killTimeoutMsg struct{ id int }
+
+// isStopItem reports whether item is a plain (not forced) stop.
+func isStopItem(item *menuItem) bool {
+	return item != nil && actionName(*item) == "kill" && !slices.Contains(item.cmd, "--force")
+}
+
+// watchStop starts the timeout for the stop that was just dispatched.
+func (m *model) watchStop() tea.Cmd {
+	m.stopID++
+	id := m.stopID
+	return tea.Tick(forceKillAfter, func(time.Time) tea.Msg { return killTimeoutMsg{id: id} })
+}
+
+// handleKillTimeout offers the escalation when stop attempt msg.id is
+// still running.
+func (m *model) handleKillTimeout(msg killTimeoutMsg) tea.Cmd {
+	if msg.id != m.stopID || !m.busy || !isStopItem(m.lastItem) || isPromptState(m.state) {
+		return nil
+	}
+	m.forceKillReason = "Stopping hasn't finished after " + forceKillAfter.String() + "."
+	return m.openPrompt(stateForceKill, forceKillItem)
+}
+
+// stopFinished is called when a plain stop returns; the next status
+// tells whether it worked.
+func (m *model) stopFinished() {
+	m.stopID++ // its timeout no longer applies
+	m.checkStopped = true
+}
+
+// checkStopResult offers the escalation when the container survived
+// the stop that just finished.
+func (m *model) checkStopResult(status string) tea.Cmd {
+	if !m.checkStopped {
+		return nil
+	}
+	m.checkStopped = false
+	if status != "running" && status != "paused" || m.busy || isPromptState(m.state) {
+		return nil
+	}
+	m.forceKillReason = "The container is still running after Stop."
+	return m.openPrompt(stateForceKill, forceKillItem)
+}
+
+func (m *model) handleForceKillKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Confirm):
+		m.pendingItem = nil
+		m.state = m.promptReturnState()
+		if m.busy {
+			// The stuck stop is interrupted; cmdDone starts the kill.
+			m.forceKillQueued = true
+			if m.stopStream != nil {
+				m.stopStream()
+			}
+			m.appendLog(styleLogWarning.Render("  ⚠  Interrupting the stop to force-kill the container…"))
+			return nil
+		}
+		return m.dispatch(forceKillItem)
+	case key.Matches(msg, keys.Cancel):
+		m.pendingItem = nil
+		m.state = m.promptReturnState()
+		m.appendLog(styleLogDim.Render("  Not force-killing the container."))
+	default:
+		m.resetPromptTimer()
+	}
+	return nil
+}
+
+// takeQueuedForceKill reports whether a force kill waits for the
+// interrupted stop to wind down, clearing the request.
+func (m *model) takeQueuedForceKill() bool {
+	queued := m.forceKillQueued
+	m.forceKillQueued = false
+	if queued {
+		m.checkStopped = false
+	}
+	return queued
+}
+
+func (m model) renderForceKillDialog() string {
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("☠  Force Kill?"),
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render(m.forceKillReason),
+		lipgloss.NewStyle().Foreground(colSub).Render("SIGKILL ends every process in "+containerName+" at once;"),
+		lipgloss.NewStyle().Foreground(colSub).Render("unsaved game progress is lost."),
+		"",
+		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[Y]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("force kill")+"   "+
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[N]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("cancel"),
+		m.renderCountdown(),
+	)
+	return m.placeOverlay(styleConfirmBox.BorderForeground(colRed), content)
+}
@@ -0,0 +1,123 @@
+package main
+
+import "testing"
+
+func TestIsStopItem(t *testing.T) {
+	tests := []struct {
+		item *menuItem
+		want bool
+	}{
+		{&menuItems[itemIndex(t, "Stop Container")], true},
+		{&forceKillItem, false},
+		{&menuItems[itemIndex(t, "Update Container")], false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := isStopItem(tt.item); got != tt.want {
+			t.Errorf("isStopItem(%v) = %v, want %v", tt.item, got, tt.want)
+		}
+	}
+}
+
+// stoppingModel has dispatched Stop Container, which hasn't returned.
+func stoppingModel(t *testing.T) (model, *int) {
+	t.Helper()
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.containerStatus = "running"
+	m.cursor = itemIndex(t, "Stop Container")
+	m = press(m, "enter")
+	if !m.busy || !isStopItem(m.lastItem) || m.stopID == 0 {
+		t.Fatalf("stop not running: busy=%v item=%v", m.busy, m.lastItem)
+	}
+	stops := new(int)
+	m.stopStream = func() { *stops++ }
+	return m, stops
+}
+
+func TestKillTimeout(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(m *model) killTimeoutMsg
+		offer bool
+	}{
+		{"still stopping", func(m *model) killTimeoutMsg { return killTimeoutMsg{id: m.stopID} }, true},
+		{"an earlier attempt", func(m *model) killTimeoutMsg { return killTimeoutMsg{id: m.stopID - 1} }, false},
+		{"stop returned", func(m *model) killTimeoutMsg {
+			next, _ := m.Update(cmdDoneMsg(true))
+			*m = next.(model)
+			return killTimeoutMsg{id: m.stopID}
+		}, false},
+		{"another prompt open", func(m *model) killTimeoutMsg {
+			m.openPrompt(stateConfirm, menuItems[itemIndex(t, "Remove Container")])
+			return killTimeoutMsg{id: m.stopID}
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := stoppingModel(t)
+			next, _ := m.Update(tt.setup(&m))
+			m = next.(model)
+			if offered := m.state == stateForceKill; offered != tt.offer {
+				t.Errorf("force kill offered = %v, want %v (state %v)", offered, tt.offer, m.state)
+			}
+		})
+	}
+}
+
+func TestForceKillInterruptsStuckStop(t *testing.T) {
+	m, stops := stoppingModel(t)
+	next, _ := m.Update(killTimeoutMsg{id: m.stopID})
+	m = settle(next.(model))
+	m = press(m, "y")
+	if *stops != 1 || !m.forceKillQueued || m.state != stateRunning {
+		t.Fatalf("stops=%d queued=%v state=%v", *stops, m.forceKillQueued, m.state)
+	}
+
+	// The interrupted stop fails; that starts the kill instead of an
+	// escalation prompt.
+	next, _ = m.Update(cmdDoneMsg(false))
+	m = next.(model)
+	if m.state == stateEscalate || countLogged(m, "$ hackeros-steam --force kill") != 1 {
+		t.Errorf("state %v, log %q", m.state, plainLogLines(m.logLines, false))
+	}
+	if m.checkStopped {
+		t.Error("the interrupted stop still waits for a status check")
+	}
+}
+
+func TestForceKillDeclined(t *testing.T) {
+	m, stops := stoppingModel(t)
+	next, _ := m.Update(killTimeoutMsg{id: m.stopID})
+	m = press(settle(next.(model)), "n")
+	if *stops != 0 || m.forceKillQueued || m.state != stateRunning || !m.busy {
+		t.Errorf("stops=%d queued=%v state=%v busy=%v", *stops, m.forceKillQueued, m.state, m.busy)
+	}
+}
+
+func TestForceKillAfterStopReturns(t *testing.T) {
+	tests := []struct {
+		status string
+		offer  bool
+	}{
+		{"running", true},
+		{"paused", true},
+		{"stopped", false},
+	}
+	for _, tt := range tests {
+		m, _ := stoppingModel(t)
+		next, _ := m.Update(cmdDoneMsg(true))
+		m = next.(model)
+		next, _ = m.Update(statusDoneMsg(tt.status))
+		m = next.(model)
+		if offered := m.state == stateForceKill; offered != tt.offer {
+			t.Errorf("%s after stop: offered = %v, want %v", tt.status, offered, tt.offer)
+		}
+		// Only the status right after the stop counts.
+		m.state, m.pendingItem = stateMenu, nil
+		next, _ = m.Update(statusDoneMsg(tt.status))
+		if next.(model).state == stateForceKill {
+			t.Errorf("%s: offered again on a later status", tt.status)
+		}
+	}
+}
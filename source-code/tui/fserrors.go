@@ -0,0 +1,99 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Filesystem failures
+//  A create or update that dies because a filesystem is read-only or
+//  full says so somewhere in its output (EROFS, ENOSPC, pacman's
+//  "too full"). Those failures get guidance for that cause instead of
+//  the generic "exited with error".
+// ─────────────────────────────────────────────────────────────────
+
+type fsProblemKind int
+
+const (
+	fsReadOnly fsProblemKind = iota + 1
+	fsFull
+)
+
+// fsMarkers are lowercase fragments of the errno-style messages each
+// problem shows up as.
+var fsMarkers = map[fsProblemKind][]string{
+	fsReadOnly: {"read-only file system", "read-only filesystem", "erofs"},
+	fsFull: {"no space left on device", "enospc", "disk quota exceeded", "edquot",
+		"not enough free disk space", "too full"},
+}
+
+// rePathBeforeError finds the path an error line is about, as in
+// "write /var/lib/containers/…: no space left on device" or pacman's
+// "Partition /var too full".
+var rePathBeforeError = regexp.MustCompile(`(/[^\s:'"]*)['"]?:?\s*(?:[^/]*?)(?i:read-only|no space|disk quota|too full)`)
+
+// fsProblem is a filesystem failure found in a command's output.
+type fsProblem struct {
+	kind fsProblemKind
+	path string // what the failing write was about, "" if not named
+	line string // the output line it was found on
+}
+
+// detectFSProblem returns the first filesystem failure in lines.
+func detectFSProblem(lines []string) *fsProblem {
+	for _, line := range lines {
+		lo := strings.ToLower(line)
+		for _, kind := range []fsProblemKind{fsReadOnly, fsFull} {
+			for _, marker := range fsMarkers[kind] {
+				if !strings.Contains(lo, marker) {
+					continue
+				}
+				p := &fsProblem{kind: kind, line: strings.TrimSpace(line)}
+				if m := rePathBeforeError.FindStringSubmatch(line); m != nil {
+					p.path = m[1]
+				}
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// guidance is the headline and the steps that fix p; the first line
+// is the problem.
+func (p *fsProblem) guidance(engine string) []string {
+	where := "the filesystem"
+	target := "~"
+	if p.path != "" {
+		where = "the filesystem holding " + p.path
+		target = p.path
+	}
+	switch p.kind {
+	case fsReadOnly:
+		return []string{
+			where + " is read-only.",
+			"Find its mount with: findmnt -T " + target,
+			"Remount it read-write: sudo mount -o remount,rw <mount point>",
+			"A disk that turned read-only on its own may have errors; check: sudo dmesg | tail",
+			"On an immutable system, keep container storage somewhere writable (graphroot in ~/.config/containers/storage.conf).",
+		}
+	default:
+		return []string{
+			"no space left on " + where + ".",
+			"See what is using it: df -h " + target,
+			"Free space: " + engine + " system prune (unused images), and the package cache with: distrobox enter " + containerName + " -- sudo pacman -Scc",
+			"Uninstall games you don't play from Installed Games.",
+			"Or move data to a larger disk: the Steam library via Steam → Settings → Storage, container storage via graphroot in ~/.config/containers/storage.conf.",
+		}
+	}
+}
+
+// logFSProblem reports p in place of the generic failure.
+func (m *model) logFSProblem(p *fsProblem) {
+	lines := p.guidance(m.engine())
+	m.logError(m.lastItemLabel()+": "+lines[0], p.line)
+	for _, line := range lines[1:] {
+		m.appendLog(styleLogInfo.Render("  → " + line))
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectFSProblem(t *testing.T) {
+	tests := []struct {
+		name   string
+		output []string
+		kind   fsProblemKind // 0 = none
+		path   string
+	}{
+		{"podman EROFS", []string{"Trying to pull…", "Error: writing blob: mkdir /var/lib/containers/storage/overlay/l: read-only file system"},
+			fsReadOnly, "/var/lib/containers/storage/overlay/l"},
+		{"touch", []string{"touch: cannot touch '/usr/share/x': Read-only file system"}, fsReadOnly, "/usr/share/x"},
+		{"errno name", []string{"open failed: EROFS"}, fsReadOnly, ""},
+		{"ENOSPC write", []string{"Error: write /home/u/.local/share/containers/storage/vfs/x/layer.tar: no space left on device"},
+			fsFull, "/home/u/.local/share/containers/storage/vfs/x/layer.tar"},
+		{"pacman partition", []string{"error: Partition /var too full: 63400 blocks needed, 1200 blocks free"}, fsFull, "/var"},
+		{"pacman free space", []string{"error: not enough free disk space", "error: failed to commit transaction"}, fsFull, ""},
+		{"quota", []string{"cp: error writing '/home/u/file': Disk quota exceeded"}, fsFull, "/home/u/file"},
+		{"first problem wins", []string{"mkdir /a: no space left on device", "mkdir /b: read-only file system"}, fsFull, "/a"},
+		{"permission only", []string{"mkdir /root/x: Permission denied"}, 0, ""},
+		{"no output", nil, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := detectFSProblem(tt.output)
+			if tt.kind == 0 {
+				if p != nil {
+					t.Fatalf("found %+v", p)
+				}
+				return
+			}
+			if p == nil || p.kind != tt.kind || p.path != tt.path {
+				t.Fatalf("detectFSProblem = %+v, want kind %d path %q", p, tt.kind, tt.path)
+			}
+		})
+	}
+}
+
+func TestFSGuidance(t *testing.T) {
+	tests := []struct {
+		p    fsProblem
+		want []string
+	}{
+		{fsProblem{kind: fsReadOnly, path: "/var/lib/containers"},
+			[]string{"the filesystem holding /var/lib/containers is read-only.", "findmnt -T /var/lib/containers", "remount,rw"}},
+		{fsProblem{kind: fsReadOnly},
+			[]string{"the filesystem is read-only.", "findmnt -T ~"}},
+		{fsProblem{kind: fsFull, path: "/var"},
+			[]string{"no space left on the filesystem holding /var.", "df -h /var", "docker system prune", "pacman -Scc", "Installed Games"}},
+	}
+	for _, tt := range tests {
+		got := strings.Join(tt.p.guidance("docker"), "\n")
+		for _, w := range tt.want {
+			if !strings.Contains(got, w) {
+				t.Errorf("%+v: guidance lacks %q:\n%s", tt.p, w, got)
+			}
+		}
+	}
+}
+
+// A full disk gets its guidance instead of the generic failure, and no
+// offer to retry with privileges that wouldn't help.
+func TestFSProblemReplacesGenericFailure(t *testing.T) {
+	m := initialModel()
+	item := menuItems[itemIndex(t, "Update Container")]
+	m.busy, m.state, m.lastItem = true, stateRunning, &item
+	m.beginCapture()
+	m.runOutput = []string{
+		"error: could not open file /var/lib/pacman/sync/core.db: Permission denied",
+		"error: Partition /var too full: 63400 blocks needed, 1200 blocks free",
+	}
+	next, _ := m.Update(cmdDoneMsg(false))
+	m = next.(model)
+	if m.state == stateEscalate {
+		t.Error("offered to rerun with privileges")
+	}
+	if countLogged(m, "command exited with error") != 0 || m.errorCount != 1 {
+		t.Errorf("errorCount %d, log %q", m.errorCount, plainLogLines(m.logLines, false))
+	}
+	if countLogged(m, "Update Container: no space left on the filesystem holding /var.") != 1 ||
+		countLogged(m, "→ See what is using it: df -h /var") != 1 {
+		t.Errorf("guidance not logged: %q", plainLogLines(m.logLines, false))
+	}
+	if m.lastError == nil || len(m.lastError.detail) != 1 || !strings.Contains(m.lastError.detail[0], "too full") {
+		t.Errorf("last error = %+v", m.lastError)
+	}
+}
@@ -0,0 +1,446 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Installed games
+//  Steam records each installed app in steamapps/appmanifest_<id>.acf.
+//  The home directory is shared with the container, so the manifests
+//  are read directly. Launching and uninstalling go through
+//  `hackeros-steam run` like any other Steam action.
+// ─────────────────────────────────────────────────────────────────
+
+type game struct {
+	appID    string
+	name     string
+	size     int64  // bytes on disk
+	manifest string // path of its appmanifest file
+}
+
+type gamesLoadedMsg struct {
+	games    []game
+	playtime map[string]int64 // seconds by AppID
+	err      error
+}
+
+// gameList is the state of the games overlay.
+type gameList struct {
+	all       []game
+	playtime  map[string]int64
+	filter    string
+	filtering bool            // typing into the filter
+	cursor    int             // index into shown()
+	offset    int             // first visible row
+	marked    map[string]bool // app IDs picked for a batch uninstall
+}
+
+// parseAppManifest reads the top-level fields of an appmanifest file.
+// Nested blocks (UserConfig, InstalledDepots, …) are skipped.
+func parseAppManifest(r io.Reader) (game, error) {
+	var g game
+	depth := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch line {
+		case "{":
+			depth++
+			continue
+		case "}":
+			depth--
+			continue
+		}
+		if depth != 1 {
+			continue
+		}
+		k, v, ok := vdfPair(line)
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(k) {
+		case "appid":
+			g.appID = v
+		case "name":
+			g.name = v
+		case "sizeondisk":
+			g.size, _ = strconv.ParseInt(v, 10, 64)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return g, err
+	}
+	if g.appID == "" {
+		return g, errors.New("no appid")
+	}
+	if g.name == "" {
+		g.name = "App " + g.appID
+	}
+	return g, nil
+}
+
+// vdfPair splits a `"key"  "value"` line.
+func vdfPair(line string) (string, string, bool) {
+	parts := strings.Split(line, `"`)
+	// "",key,ws,value,"" for a well-formed pair
+	if len(parts) < 5 {
+		return "", "", false
+	}
+	return parts[1], parts[3], true
+}
+
+// loadGames reads every manifest in dir, sorted by name. Unreadable
+// manifests are skipped.
+func loadGames(dir string) ([]game, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "appmanifest_*.acf"))
+	if err != nil {
+		return nil, err
+	}
+	var games []game
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			continue
+		}
+		g, err := parseAppManifest(f)
+		f.Close()
+		if err == nil {
+			g.manifest = p
+			games = append(games, g)
+		}
+	}
+	sort.Slice(games, func(i, j int) bool {
+		return strings.ToLower(games[i].name) < strings.ToLower(games[j].name)
+	})
+	return games, nil
+}
+
+func totalSize(games []game) int64 {
+	var n int64
+	for _, g := range games {
+		n += g.size
+	}
+	return n
+}
+
+// launchGameItem and uninstallGameItem are dispatched like menu items.
+func launchGameItem(g game) menuItem {
+	return menuItem{icon: "▶", label: "Launch " + g.name, cmd: []string{"run", "-applaunch", g.appID}, requires: reqExists}
+}
+
+func uninstallGameItem(g game) menuItem {
+	return menuItem{icon: "✕", label: "Uninstall " + g.name, requires: reqExists, confirm: true, destructive: true,
+		warning: []string{"Steam will ask once more before deleting the game files."},
+		run:     func(m *model) tea.Cmd { return m.execUninstall(g) }}
+}
+
+// uninstallCommand hands the uninstall of g to Steam.
+func uninstallCommand(g game) menuItem {
+	return menuItem{icon: "✕", label: "Uninstall " + g.name, cmd: []string{"run", "steam://uninstall/" + g.appID}, requires: reqExists, destructive: true}
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Waiting for an uninstall
+//  steam://uninstall only passes the request to Steam, which asks the
+//  user and deletes the files afterwards; the command is back long
+//  before that. The uninstall is over once the game's manifest is gone,
+//  or when uninstallTimeout passes with it still there: the user told
+//  Steam no, or never answered. The action stays busy until then, and
+//  a batch moves on to its next game only afterwards.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	uninstallPoll    = time.Second
+	uninstallTimeout = 2 * time.Minute
+)
+
+type uninstallWait struct {
+	game     game
+	deadline time.Time // zero while the command runs
+}
+
+type uninstallTickMsg struct{}
+
+func uninstallTickCmd() tea.Cmd {
+	return tea.Tick(uninstallPoll, func(time.Time) tea.Msg { return uninstallTickMsg{} })
+}
+
+func (m *model) execUninstall(g game) tea.Cmd {
+	m.uninstalling = &uninstallWait{game: g}
+	return m.execCommand(uninstallCommand(g))
+}
+
+// awaitUninstall starts watching the manifest once the command is done.
+func (m *model) awaitUninstall(ok bool) tea.Cmd {
+	w := m.uninstalling
+	if !ok {
+		return m.uninstallFinished(false)
+	}
+	w.deadline = time.Now().Add(uninstallTimeout)
+	m.busy = true
+	m.appendLog(styleLogDim.Render("  Waiting for Steam to remove " + w.game.name + "; confirm in Steam's dialog…"))
+	return m.handleUninstallTick()
+}
+
+func (m *model) handleUninstallTick() tea.Cmd {
+	w := m.uninstalling
+	if w == nil || w.deadline.IsZero() {
+		return nil
+	}
+	if _, err := os.Stat(w.game.manifest); err != nil {
+		return m.uninstallFinished(true)
+	}
+	if time.Now().After(w.deadline) {
+		return m.uninstallFinished(false)
+	}
+	return uninstallTickCmd()
+}
+
+func (m *model) uninstallFinished(removed bool) tea.Cmd {
+	g := m.uninstalling.game
+	m.uninstalling = nil
+	m.busy = false
+	toast := "✔ Uninstalled " + g.name
+	if removed {
+		m.appendLog(styleLogSuccess.Render("  ✔  " + g.name + " uninstalled."))
+	} else {
+		toast = g.name + " is still installed"
+		m.appendLog(styleLogWarning.Render("  ⚠  " + toast + "."))
+	}
+	m.appendLog("")
+	if m.batch != nil {
+		cmd := m.advanceBatch(removed)
+		if m.batch == nil {
+			cmd = tea.Batch(cmd, checkStatusCmd())
+		}
+		return cmd
+	}
+	return tea.Batch(m.showToast(toast), checkStatusCmd(), m.notifyDone(removed), m.attention(time.Since(m.startedAt)))
+}
+
+// openGames is the "Installed Games" action.
+func (m *model) openGames() tea.Cmd {
+	if remoteHost != "" {
+		return m.showToast("Installed games can't be listed over --remote")
+	}
+	m.busy = true
+	dir := steamappsDir()
+	return func() tea.Msg {
+		games, err := loadGames(dir)
+		// Playtime is extra; a broken file just leaves the column empty.
+		playtime, _ := loadPlaytime(playtimePath())
+		return gamesLoadedMsg{games: games, playtime: playtime, err: err}
+	}
+}
+
+func (m *model) handleGamesLoaded(msg gamesLoadedMsg) {
+	m.busy = false
+	if msg.err != nil {
+		m.logError("Could not read the Steam library: " + msg.err.Error())
+		return
+	}
+	m.games = &gameList{all: msg.games, playtime: msg.playtime}
+	m.state = stateGames
+}
+
+func (m *model) closeGames() {
+	m.games = nil
+	m.state = stateMenu
+}
+
+// shown returns the games matching the filter.
+func (gl *gameList) shown() []game {
+	if gl.filter == "" {
+		return gl.all
+	}
+	f := strings.ToLower(gl.filter)
+	var out []game
+	for _, g := range gl.all {
+		if strings.Contains(strings.ToLower(g.name), f) || strings.HasPrefix(g.appID, f) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// gameRows is how many list rows fit in the overlay.
+func (m model) gameRows() int {
+	return max(3, m.height-16)
+}
+
+func (m *model) updateGames(msg tea.KeyMsg) tea.Cmd {
+	gl := m.games
+	if gl.filtering {
+		switch msg.Type {
+		case tea.KeyEnter:
+			gl.filtering = false
+		case tea.KeyEsc:
+			gl.filtering, gl.filter = false, ""
+		case tea.KeyBackspace:
+			if r := []rune(gl.filter); len(r) > 0 {
+				gl.filter = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			gl.filter += string(msg.Runes)
+		}
+		gl.cursor, gl.offset = 0, 0
+		return nil
+	}
+
+	shown := gl.shown()
+	switch {
+	case key.Matches(msg, keys.Up):
+		if gl.cursor > 0 {
+			gl.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if gl.cursor < len(shown)-1 {
+			gl.cursor++
+		}
+	case key.Matches(msg, keys.Filter):
+		gl.filtering = true
+	case key.Matches(msg, keys.Mark):
+		if len(shown) == 0 {
+			return nil
+		}
+		gl.toggleMark(shown[gl.cursor])
+		if gl.cursor < len(shown)-1 {
+			gl.cursor++
+		}
+	case key.Matches(msg, keys.Favorite):
+		if len(shown) == 0 {
+			return nil
+		}
+		return m.toggleFavorite(shown[gl.cursor])
+	case key.Matches(msg, keys.Select):
+		if len(shown) == 0 {
+			return nil
+		}
+		item := launchGameItem(shown[gl.cursor])
+		m.closeGames()
+		return m.dispatch(item)
+	case key.Matches(msg, keys.Uninstall):
+		if len(shown) == 0 {
+			return nil
+		}
+		item := uninstallGameItem(shown[gl.cursor])
+		if marked := gl.markedGames(); len(marked) > 0 {
+			item = batchUninstallItem(marked)
+		}
+		m.closeGames()
+		return m.openPrompt(stateConfirm, item)
+	case key.Matches(msg, keys.Verify):
+		if len(shown) == 0 {
+			return nil
+		}
+		games := gl.markedGames()
+		if len(games) == 0 {
+			games = []game{shown[gl.cursor]}
+		}
+		m.closeGames()
+		return m.dispatch(verifyItem(games))
+	case key.Matches(msg, keys.Back):
+		m.closeGames()
+		return nil
+	}
+
+	// Keep the cursor row on screen.
+	rows := m.gameRows()
+	if gl.cursor < gl.offset {
+		gl.offset = gl.cursor
+	} else if gl.cursor >= gl.offset+rows {
+		gl.offset = gl.cursor - rows + 1
+	}
+	return nil
+}
+
+func (m model) renderGames() string {
+	gl := m.games
+	shown := gl.shown()
+
+	title := fmt.Sprintf("Installed Games — %d, %s on disk", len(gl.all),
+		strings.TrimSuffix(formatSpeed(float64(totalSize(gl.all))), "/s"))
+	rows := []string{styleTitle.Render(title)}
+
+	filter := "/ filter"
+	switch {
+	case gl.filtering:
+		filter = "/ " + gl.filter + "█"
+	case gl.filter != "":
+		filter = "/ " + gl.filter
+	}
+	rows = append(rows, styleLogDim.Render(filter), "")
+
+	switch {
+	case len(gl.all) == 0:
+		rows = append(rows, styleLogDim.Render("No games installed."))
+	case len(shown) == 0:
+		rows = append(rows, styleLogDim.Render("No games match the filter."))
+	}
+
+	end := min(len(shown), gl.offset+m.gameRows())
+	for i := gl.offset; i < end; i++ {
+		g := shown[i]
+		size := strings.TrimSuffix(formatSpeed(float64(g.size)), "/s")
+		played := ""
+		if s := gl.playtime[g.appID]; s > 0 {
+			played = formatPlaytime(time.Duration(s) * time.Second)
+		}
+		text := fmt.Sprintf("%s %9s %8s  %s", padRight(truncate(g.name, 32), 32), size, played, g.appID)
+		mark := "  "
+		switch {
+		case gl.marked[g.appID]:
+			mark = lipgloss.NewStyle().Foreground(colRed).Render("✕ ")
+		case isFavorite(g.appID):
+			mark = lipgloss.NewStyle().Foreground(colYellow).Render("★ ")
+		}
+		if i == gl.cursor {
+			rows = append(rows, mark+lipgloss.NewStyle().Foreground(colCursor).Bold(true).Render("▶ "+text))
+		} else {
+			rows = append(rows, mark+"  "+lipgloss.NewStyle().Foreground(colText).Render(text))
+		}
+	}
+	if len(shown) > end || gl.offset > 0 {
+		rows = append(rows, styleLogDim.Render(fmt.Sprintf("  %d–%d of %d", gl.offset+1, end, len(shown))))
+	}
+	if marked := gl.markedGames(); len(marked) > 0 {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(colRed).Render(fmt.Sprintf(
+			"%d marked, %s — u uninstalls them", len(marked),
+			strings.TrimSuffix(formatSpeed(float64(totalSize(marked))), "/s"))))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colAccent).
+		Padding(1, 2)
+
+	return m.placeOverlay(box, strings.Join(rows, "\n"))
+}
+
+// truncate shortens s to n terminal cells, marking the cut with "…".
+// Cells, not runes: a CJK character takes two. Widths are measured
+// the way lipgloss measures them, so cut text lines up with the
+// styles around it.
+func truncate(s string, n int) string {
+	return ansi.Truncate(s, n, "…")
+}
+
+// padRight fills s with spaces to n cells; fmt's %-*s counts runes.
+func padRight(s string, n int) string {
+	return s + strings.Repeat(" ", max(n-ansi.StringWidth(s), 0))
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const portalManifest = `"AppState"
+{
+	"appid"		"620"
+	"Universe"		"1"
+	"name"		"Portal 2"
+	"StateFlags"		"4"
+	"SizeOnDisk"		"13076715066"
+	"UserConfig"
+	{
+		"name"		"not the game name"
+		"language"		"english"
+	}
+	"InstalledDepots"
+	{
+		"621"
+		{
+			"manifest"		"123"
+			"size"		"999"
+		}
+	}
+}
+`
+
+func TestParseAppManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    game
+		wantErr bool
+	}{
+		{"full", portalManifest, game{appID: "620", name: "Portal 2", size: 13076715066}, false},
+		{"no name", "\"AppState\"\n{\n\t\"appid\"\t\t\"440\"\n}\n", game{appID: "440", name: "App 440"}, false},
+		{"bad size", "\"AppState\"\n{\n\t\"appid\"\t\t\"1\"\n\t\"name\"\t\t\"x\"\n\t\"SizeOnDisk\"\t\t\"lots\"\n}\n", game{appID: "1", name: "x"}, false},
+		{"no appid", "\"AppState\"\n{\n\t\"name\"\t\t\"Orphan\"\n}\n", game{}, true},
+		{"empty", "", game{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAppManifest(strings.NewReader(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseAppManifest = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func writeManifests(t *testing.T, dir string, games ...game) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range games {
+		body := "\"AppState\"\n{\n\t\"appid\"\t\t\"" + g.appID + "\"\n\t\"name\"\t\t\"" + g.name +
+			"\"\n\t\"SizeOnDisk\"\t\t\"" + strconv.FormatInt(g.size, 10) + "\"\n}\n"
+		if err := os.WriteFile(filepath.Join(dir, "appmanifest_"+g.appID+".acf"), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadGames(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "steamapps")
+	writeManifests(t, dir,
+		game{appID: "620", name: "Portal 2", size: 3 << 30},
+		game{appID: "570", name: "dota 2", size: 1 << 30},
+		game{appID: "440", name: "Team Fortress 2", size: 2 << 30},
+	)
+	if err := os.WriteFile(filepath.Join(dir, "appmanifest_1.acf"), []byte("garbage"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	games, err := loadGames(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, g := range games {
+		names = append(names, g.name)
+	}
+	if got := strings.Join(names, ","); got != "dota 2,Portal 2,Team Fortress 2" {
+		t.Errorf("games = %s", got)
+	}
+	if total := totalSize(games); total != 6<<30 {
+		t.Errorf("totalSize = %d", total)
+	}
+
+	empty, err := loadGames(filepath.Join(t.TempDir(), "none"))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("empty library: %v, %v", empty, err)
+	}
+}
+
+func gamesModel(t *testing.T) model {
+	t.Helper()
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.containerStatus = "running"
+	next, _ := m.Update(gamesLoadedMsg{games: []game{
+		{appID: "570", name: "Dota 2"},
+		{appID: "620", name: "Portal 2"},
+		{appID: "440", name: "Team Fortress 2"},
+	}})
+	return next.(model)
+}
+
+func TestGamesDispatchPerRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		started string // command logged, "" = none
+		prompt  string // pending uninstall label, "" = none
+	}{
+		{"launch first", []string{"enter"}, "run -applaunch 570", ""},
+		{"launch third", []string{"down", "down", "enter"}, "run -applaunch 440", ""},
+		{"launch filtered", []string{"/", "p", "o", "r", "enter", "enter"}, "run -applaunch 620", ""},
+		{"filter by appid", []string{"/", "4", "4", "enter", "enter"}, "run -applaunch 440", ""},
+		{"uninstall second", []string{"down", "u"}, "", "Uninstall Portal 2"},
+		{"no match", []string{"/", "z", "z", "enter", "enter", "u"}, "", ""},
+		{"back", []string{"esc"}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := gamesModel(t)
+			if m.state != stateGames {
+				t.Fatalf("state = %v after loading games", m.state)
+			}
+			m = press(m, tt.keys...)
+			if tt.started != "" && countLogged(m, "$ hackeros-steam "+tt.started) != 1 {
+				t.Errorf("%q not started", tt.started)
+			}
+			if tt.started == "" && countLogged(m, "$ hackeros-steam") != 0 {
+				t.Error("a command started")
+			}
+			pending := ""
+			if m.state == stateConfirm && m.pendingItem != nil {
+				pending = m.pendingItem.label
+			}
+			if pending != tt.prompt {
+				t.Errorf("pending confirm = %q, want %q", pending, tt.prompt)
+			}
+		})
+	}
+}
+
+// The uninstall is reported once Steam has removed the manifest, not
+// when the command handing it over returns.
+func TestUninstallWaitsForSteam(t *testing.T) {
+	tests := []struct {
+		name    string
+		ok      bool // the command succeeded
+		removed bool // Steam deleted the manifest
+		expire  bool // the wait times out first
+		toast   string
+	}{
+		{"removed", true, true, false, "✔ Uninstalled Dota 2"},
+		{"declined", true, false, true, "Dota 2 is still installed"},
+		{"command failed", false, false, false, "Dota 2 is still installed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "steamapps")
+			writeManifests(t, dir, game{appID: "570", name: "Dota 2"})
+			games, err := loadGames(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := initialModel()
+			m.width, m.height = 120, 40
+			m.containerStatus = "running"
+			next, _ := m.Update(gamesLoadedMsg{games: games})
+			m = press(settle(press(next.(model), "u")), "y")
+			if countLogged(m, "$ hackeros-steam run steam://uninstall/570") != 1 {
+				t.Fatalf("uninstall not started: %q", plainLogLines(m.logLines, false))
+			}
+
+			next, _ = m.Update(cmdDoneMsg(tt.ok))
+			m = next.(model)
+			if tt.ok {
+				if !m.busy || m.uninstalling == nil || strings.Contains(m.toast, "Dota 2") {
+					t.Fatalf("finished with the command: busy %v toast %q", m.busy, m.toast)
+				}
+				next, _ = m.Update(uninstallTickMsg{})
+				if m = next.(model); m.uninstalling == nil {
+					t.Fatal("wait ended with the manifest still there")
+				}
+				if tt.removed {
+					os.Remove(games[0].manifest)
+				}
+				if tt.expire {
+					m.uninstalling.deadline = time.Now().Add(-time.Second)
+				}
+				next, _ = m.Update(uninstallTickMsg{})
+				m = next.(model)
+			}
+			if m.busy || m.uninstalling != nil || m.toast != tt.toast {
+				t.Errorf("busy %v waiting %v toast %q, want toast %q", m.busy, m.uninstalling != nil, m.toast, tt.toast)
+			}
+		})
+	}
+}
+
+func TestGamesEmptyLibrary(t *testing.T) {
+	m := initialModel()
+	m.width, m.height = 120, 40
+	next, _ := m.Update(gamesLoadedMsg{})
+	m = next.(model)
+	if !strings.Contains(m.renderGames(), "No games installed.") {
+		t.Error("empty library not explained")
+	}
+	m = press(m, "enter", "u")
+	if m.state != stateGames {
+		t.Errorf("state = %v after enter/u on an empty list", m.state)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"Portal", 10, "Portal"},
+		{"Portal 2", 8, "Portal 2"},
+		{"Counter-Strike 2", 8, "Counter…"},
+		{"Ärger über Öl", 6, "Ärger…"},
+		{"原神", 4, "原神"},
+		{"原神インパクト", 7, "原神イ…"},
+		{"原神インパクト", 6, "原神…"},
+		{"\x1b[1mPortal 2\x1b[0m", 6, "\x1b[1mPorta…\x1b[0m"},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.s, tt.n)
+		if got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+		if w := lipgloss.Width(got); w > tt.n {
+			t.Errorf("truncate(%q, %d) is %d cells wide", tt.s, tt.n, w)
+		}
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"Portal", 8, "Portal  "},
+		{"原神", 6, "原神  "},
+		{"Ärger", 6, "Ärger "},
+		{"too long", 4, "too long"},
+	}
+	for _, tt := range tests {
+		if got := padRight(tt.s, tt.n); got != tt.want {
+			t.Errorf("padRight(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestGamesColumnsAlignWithWideNames(t *testing.T) {
+	m := initialModel()
+	m.width, m.height = 140, 40
+	next, _ := m.Update(gamesLoadedMsg{games: []game{
+		{appID: "620", name: "Portal 2", size: 3 << 30},
+		{appID: "1971870", name: "原神インパクト・スペシャルエディション・デラックス版", size: 2 << 30},
+		{appID: "440", name: "Ärger über Öl", size: 1 << 30},
+	}})
+	m = next.(model)
+	col := -1
+	for _, line := range strings.Split(m.renderGames(), "\n") {
+		for _, id := range []string{"  620", "  1971870", "  440"} {
+			i := strings.Index(line, id)
+			if i < 0 {
+				continue
+			}
+			at := lipgloss.Width(line[:i])
+			if col < 0 {
+				col = at
+			} else if at != col {
+				t.Errorf("app id column at cell %d, want %d:\n%s", at, col, line)
+			}
+		}
+	}
+	if col < 0 {
+		t.Fatal("no game rows rendered")
+	}
+}
+
+func TestSafeModeBlocksUninstall(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.SafeMode = true
+	m := initialModel()
+	m.containerStatus = "running"
+	g := game{appID: "570", name: "Dota 2"}
+	for _, item := range []menuItem{uninstallGameItem(g), uninstallCommand(g)} {
+		if reason := m.disabledReason(item); reason != "disabled in safe mode" {
+			t.Errorf("%s (%q): reason %q in safe mode", item.label, item.cmd, reason)
+		}
+	}
+}
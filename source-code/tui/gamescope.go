@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Gamescope
+//  "Launch in Gamescope" runs Steam inside the gamescope compositor;
+//  the CLI wraps /usr/bin/steam when HACKEROS_STEAM_GAMESCOPE is set.
+//  Its options are kept in the [gamescope] table of launch.toml.
+// ─────────────────────────────────────────────────────────────────
+
+const gamescopeEnvVar = "HACKEROS_STEAM_GAMESCOPE"
+
+type gamescopeSettings struct {
+	// HDR passes --hdr-enabled; only switched on once detectHDR agrees.
+	HDR bool `toml:"hdr"`
+}
+
+// gamescopeArgs are the gamescope options for s: fullscreen with Steam
+// integration, plus HDR when enabled.
+func gamescopeArgs(s gamescopeSettings) []string {
+	args := []string{"-e", "-f"}
+	if s.HDR {
+		args = append(args, "--hdr-enabled")
+	}
+	return args
+}
+
+func onOff(b bool) string {
+	if b {
+		return "On"
+	}
+	return "Off"
+}
+
+// openGamescopeMenu is the "Gamescope" action.
+func (m *model) openGamescopeMenu() tea.Cmd {
+	m.openSubmenu(&submenu{
+		title:  "Gamescope — HDR: " + onOff(launch.Gamescope.HDR),
+		marked: -1,
+		items: []menuItem{
+			{icon: "▶", label: "Launch in Gamescope", run: (*model).launchGamescope, requires: reqExists},
+			{icon: "☀", label: "HDR: " + onOff(launch.Gamescope.HDR), run: (*model).toggleHDR},
+		},
+	})
+	return nil
+}
+
+func (m *model) launchGamescope() tea.Cmd {
+	return m.execCommand(menuItem{
+		icon: "▶", label: "Launch in Gamescope", cmd: []string{"run", "-gamepadui"},
+		env:      []string{gamescopeEnvVar + "=" + strings.Join(gamescopeArgs(launch.Gamescope), " ")},
+		requires: reqExists,
+	})
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  HDR
+//  Turning HDR on is gated on detectHDR: a connected display whose EDID
+//  carries an HDR static metadata block, and a gamescope in the
+//  container that knows --hdr-enabled. Turning it off needs no check.
+// ─────────────────────────────────────────────────────────────────
+
+type hdrDetectedMsg struct {
+	display string // connector that advertises HDR
+	err     error  // why HDR can't be enabled
+}
+
+func (m *model) toggleHDR() tea.Cmd {
+	if launch.Gamescope.HDR {
+		return m.setHDR(false, "")
+	}
+	m.busy = true
+	return detectHDRCmd()
+}
+
+func (m *model) setHDR(on bool, display string) tea.Cmd {
+	next := launch
+	next.Gamescope.HDR = on
+	if err := m.saveLaunch(next); err != nil {
+		return nil
+	}
+	if !on {
+		return m.showToast("Gamescope HDR off")
+	}
+	return m.showToast("Gamescope HDR on (" + display + ")")
+}
+
+func (m *model) handleHDRDetected(msg hdrDetectedMsg) tea.Cmd {
+	m.busy = false
+	if msg.err != nil {
+		m.appendLog(styleLogWarning.Render("  ⚠  HDR left off: " + msg.err.Error()))
+		return m.showToast("HDR not supported here")
+	}
+	return m.setHDR(true, msg.display)
+}
+
+func detectHDRCmd() tea.Cmd {
+	return func() tea.Msg {
+		display, err := detectHDR(drmSysfs, gamescopeKnowsHDR)
+		return hdrDetectedMsg{display: display, err: err}
+	}
+}
+
+const drmSysfs = "/sys/class/drm"
+
+// detectHDR returns the first connected connector under drm whose EDID
+// advertises HDR, provided hasFlag reports gamescope support.
+func detectHDR(drm string, hasFlag func() (bool, error)) (string, error) {
+	if remoteHost != "" {
+		return "", errors.New("the display of a remote host can't be checked")
+	}
+	display, err := hdrDisplay(drm)
+	if err != nil {
+		return "", err
+	}
+	ok, err := hasFlag()
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("gamescope isn't installed in the container: %w", err)
+	case !ok:
+		return "", errors.New("the container's gamescope has no --hdr-enabled; update it")
+	}
+	return display, nil
+}
+
+func hdrDisplay(drm string) (string, error) {
+	connectors, _ := filepath.Glob(filepath.Join(drm, "card*-*"))
+	connected := 0
+	for _, c := range connectors {
+		status, err := os.ReadFile(filepath.Join(c, "status"))
+		if err != nil || strings.TrimSpace(string(status)) != "connected" {
+			continue
+		}
+		connected++
+		edid, err := os.ReadFile(filepath.Join(c, "edid"))
+		if err == nil && edidHasHDR(edid) {
+			// card0-DP-1 → DP-1
+			_, name, _ := strings.Cut(filepath.Base(c), "-")
+			return name, nil
+		}
+	}
+	if connected == 0 {
+		return "", errors.New("no connected display found")
+	}
+	return "", errors.New("no connected display advertises HDR")
+}
+
+// edidHasHDR looks for an HDR Static Metadata Data Block (CTA-861
+// extended tag 6) in the EDID's CTA extension blocks.
+func edidHasHDR(edid []byte) bool {
+	if len(edid) < 128 {
+		return false
+	}
+	for off := 128; off+128 <= len(edid); off += 128 {
+		block := edid[off : off+128]
+		if block[0] != 0x02 {
+			continue
+		}
+		end := int(block[2])
+		if end < 4 || end > 127 {
+			end = 127
+		}
+		for i := 4; i < end; {
+			tag, n := block[i]>>5, int(block[i]&0x1f)
+			if tag == 7 && n >= 1 && i+1 < end && block[i+1] == 0x06 {
+				return true
+			}
+			i += 1 + n
+		}
+	}
+	return false
+}
+
+// gamescopeKnowsHDR asks the container's gamescope whether it accepts
+// --hdr-enabled.
+func gamescopeKnowsHDR() (bool, error) {
+	out, err := auditedOutput("HDR", hostCommand([]string{"distrobox", "enter", containerName, "--",
+		"sh", "-c", "command -v gamescope >/dev/null && gamescope --help 2>&1"}, nil, false), stdoutOf)
+	if err != nil && len(out) == 0 {
+		return false, err
+	}
+	return bytes.Contains(out, []byte("--hdr-enabled")), nil
+}
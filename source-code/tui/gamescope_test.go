@@ -0,0 +1,170 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testEDID is a base block plus one CTA extension holding blocks.
+func testEDID(blocks ...byte) []byte {
+	edid := make([]byte, 256)
+	cta := edid[128:]
+	cta[0], cta[1] = 0x02, 0x03
+	copy(cta[4:], blocks)
+	cta[2] = byte(4 + len(blocks))
+	return edid
+}
+
+func TestEdidHasHDR(t *testing.T) {
+	hdrBlock := []byte{0xE3, 0x06, 0x05, 0x01}    // extended tag 6: HDR static metadata
+	colorimetry := []byte{0xE3, 0x05, 0xC0, 0x00} // extended tag 5
+	audio := []byte{0x23, 0x09, 0x07, 0x07}       // tag 1, not extended
+	tests := []struct {
+		name string
+		edid []byte
+		want bool
+	}{
+		{"HDR block", testEDID(hdrBlock...), true},
+		{"HDR after other blocks", testEDID(append(append(audio, colorimetry...), hdrBlock...)...), true},
+		{"no HDR", testEDID(append(audio, colorimetry...)...), false},
+		{"base block only", make([]byte, 128), false},
+		{"truncated", []byte{0x00, 0xff}, false},
+		{"not a CTA extension", func() []byte { e := testEDID(hdrBlock...); e[128] = 0x70; return e }(), false},
+	}
+	for _, tt := range tests {
+		if got := edidHasHDR(tt.edid); got != tt.want {
+			t.Errorf("%s: edidHasHDR = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// fakeDRM lays out connectors as /sys/class/drm does.
+func fakeDRM(t *testing.T, connectors map[string]struct {
+	status string
+	edid   []byte
+}) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, c := range connectors {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(p, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		os.WriteFile(filepath.Join(p, "status"), []byte(c.status+"\n"), 0o644)
+		os.WriteFile(filepath.Join(p, "edid"), c.edid, 0o644)
+	}
+	return dir
+}
+
+func TestDetectHDR(t *testing.T) {
+	hdr := testEDID(0xE3, 0x06, 0x05, 0x01)
+	sdr := testEDID(0x23, 0x09, 0x07, 0x07)
+	type conn = struct {
+		status string
+		edid   []byte
+	}
+	knows := func() (bool, error) { return true, nil }
+	tests := []struct {
+		name    string
+		conns   map[string]conn
+		hasFlag func() (bool, error)
+		remote  string
+		want    string
+		wantErr string
+	}{
+		{"HDR display", map[string]conn{"card0-DP-1": {"connected", hdr}}, knows, "", "DP-1", ""},
+		{"HDR on the second output", map[string]conn{
+			"card0-HDMI-A-1": {"connected", sdr}, "card0-DP-2": {"connected", hdr}}, knows, "", "DP-2", ""},
+		{"HDR display unplugged", map[string]conn{
+			"card0-DP-1": {"disconnected", hdr}, "card0-eDP-1": {"connected", sdr}}, knows, "", "", "no connected display advertises HDR"},
+		{"nothing connected", map[string]conn{"card0-DP-1": {"disconnected", nil}}, knows, "", "", "no connected display found"},
+		{"old gamescope", map[string]conn{"card0-DP-1": {"connected", hdr}},
+			func() (bool, error) { return false, nil }, "", "", "no --hdr-enabled"},
+		{"no gamescope", map[string]conn{"card0-DP-1": {"connected", hdr}},
+			func() (bool, error) { return false, errors.New("exit status 1") }, "", "", "isn't installed"},
+		{"remote host", map[string]conn{"card0-DP-1": {"connected", hdr}}, knows, "deck", "", "remote host"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(saved string) { remoteHost = saved }(remoteHost)
+			remoteHost = tt.remote
+			got, err := detectHDR(fakeDRM(t, tt.conns), tt.hasFlag)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("display = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGamescopeArgs(t *testing.T) {
+	if got := strings.Join(gamescopeArgs(gamescopeSettings{}), " "); got != "-e -f" {
+		t.Errorf("SDR args = %q", got)
+	}
+	if got := strings.Join(gamescopeArgs(gamescopeSettings{HDR: true}), " "); got != "-e -f --hdr-enabled" {
+		t.Errorf("HDR args = %q", got)
+	}
+}
+
+// HDR is only switched on, and persisted, once detection agrees.
+func TestHDRToggleGating(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	tests := []struct {
+		name   string
+		result hdrDetectedMsg
+		want   bool
+	}{
+		{"supported", hdrDetectedMsg{display: "DP-1"}, true},
+		{"unsupported", hdrDetectedMsg{err: errors.New("no connected display advertises HDR")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("XDG_CONFIG_HOME", "")
+			launch = launchSettings{}
+			m := initialModel()
+			if cmd := m.toggleHDR(); cmd == nil || !m.busy || launch.Gamescope.HDR {
+				t.Fatalf("toggle on did not start detection (busy %v, hdr %v)", m.busy, launch.Gamescope.HDR)
+			}
+			next, _ := m.Update(tt.result)
+			m = next.(model)
+			saved, err := loadLaunch(launchPath())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.busy || launch.Gamescope.HDR != tt.want || saved.Gamescope.HDR != tt.want {
+				t.Errorf("busy %v, hdr %v, saved %v; want hdr %v", m.busy, launch.Gamescope.HDR, saved.Gamescope.HDR, tt.want)
+			}
+			if !tt.want && countLogged(m, "HDR left off") != 1 {
+				t.Error("unsupported HDR not explained in the log")
+			}
+			if !tt.want {
+				return
+			}
+			if cmd := m.toggleHDR(); m.busy || launch.Gamescope.HDR || cmd == nil {
+				t.Errorf("toggle off: busy %v, hdr %v", m.busy, launch.Gamescope.HDR)
+			}
+			if saved, _ := loadLaunch(launchPath()); saved.Gamescope.HDR {
+				t.Error("HDR off not saved")
+			}
+		})
+	}
+}
+
+func TestGamescopeMenuShowsHDR(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	for _, hdr := range []bool{false, true} {
+		launch.Gamescope.HDR = hdr
+		m := initialModel()
+		m.openGamescopeMenu()
+		want := "HDR: " + onOff(hdr)
+		if !strings.Contains(m.submenu.title, want) || m.submenu.items[1].label != want {
+			t.Errorf("hdr=%v: title %q, item %q", hdr, m.submenu.title, m.submenu.items[1].label)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  GPU selection
+//  On hybrid laptops and desktops with an iGPU and a dGPU, the "GPU"
+//  submenu picks the one Steam renders on. The choice becomes the
+//  driver's offload variables: DRI_PRIME for Mesa, the PRIME render
+//  offload set for NVIDIA's driver, and VK_ICD_FILENAMES limiting
+//  Vulkan to that vendor's driver.
+// ─────────────────────────────────────────────────────────────────
+
+type gpu struct {
+	card    int    // N of /sys/class/drm/cardN
+	slot    string // PCI address, e.g. 0000:01:00.0
+	vendor  string // PCI vendor ID, e.g. 0x10de
+	driver  string // kernel driver: amdgpu, i915, nvidia, …
+	bootVGA bool   // the GPU the firmware booted on
+}
+
+var gpuVendors = map[string]string{
+	"0x1002": "AMD",
+	"0x8086": "Intel",
+	"0x10de": "NVIDIA",
+}
+
+// vulkanICDs are the Vulkan driver manifests per kernel driver, 64- and
+// 32-bit, as installed by the Arch packages in the container.
+var vulkanICDs = map[string][]string{
+	"amdgpu":  {"radeon_icd.x86_64.json", "radeon_icd.i686.json"},
+	"radeon":  {"radeon_icd.x86_64.json", "radeon_icd.i686.json"},
+	"i915":    {"intel_icd.x86_64.json", "intel_icd.i686.json"},
+	"xe":      {"intel_icd.x86_64.json", "intel_icd.i686.json"},
+	"nouveau": {"nouveau_icd.x86_64.json", "nouveau_icd.i686.json"},
+	"nvidia":  {"nvidia_icd.json"},
+}
+
+const vulkanICDDir = "/usr/share/vulkan/icd.d"
+
+var reCard = regexp.MustCompile(`^card(\d+)$`)
+
+func (g gpu) label() string {
+	name := gpuVendors[g.vendor]
+	if name == "" {
+		name = "GPU " + g.vendor
+	}
+	s := name + " (" + g.driver + ") " + g.slot
+	if g.bootVGA {
+		s += " · primary"
+	}
+	return s
+}
+
+// detectGPUs lists the GPUs under drm, one per PCI device, by card
+// number.
+func detectGPUs(drm string) []gpu {
+	entries, err := os.ReadDir(drm)
+	if err != nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var gpus []gpu
+	for _, e := range entries {
+		mm := reCard.FindStringSubmatch(e.Name())
+		if mm == nil {
+			continue
+		}
+		dev, err := filepath.EvalSymlinks(filepath.Join(drm, e.Name(), "device"))
+		if err != nil {
+			continue
+		}
+		g := gpu{slot: filepath.Base(dev), vendor: readTrimmed(filepath.Join(dev, "vendor"))}
+		if seen[g.slot] || g.vendor == "" {
+			continue
+		}
+		seen[g.slot] = true
+		g.card, _ = strconv.Atoi(mm[1])
+		if drv, err := filepath.EvalSymlinks(filepath.Join(dev, "driver")); err == nil {
+			g.driver = filepath.Base(drv)
+		}
+		g.bootVGA = readTrimmed(filepath.Join(dev, "boot_vga")) == "1"
+		gpus = append(gpus, g)
+	}
+	sort.Slice(gpus, func(i, j int) bool { return gpus[i].card < gpus[j].card })
+	return gpus
+}
+
+func readTrimmed(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// gpuEnv returns the variables that make Steam render on g.
+func gpuEnv(g gpu) []string {
+	var env []string
+	switch g.driver {
+	case "nvidia":
+		// Offloading only applies when NVIDIA isn't driving the display.
+		if !g.bootVGA {
+			env = append(env,
+				"__NV_PRIME_RENDER_OFFLOAD=1",
+				"__GLX_VENDOR_LIBRARY_NAME=nvidia",
+				"__VK_LAYER_NV_optimus=NVIDIA_only")
+		}
+	default:
+		// Mesa takes the PCI address with _ for : and .
+		env = append(env, "DRI_PRIME=pci-"+strings.NewReplacer(":", "_", ".", "_").Replace(g.slot))
+	}
+	if icds := vulkanICDs[g.driver]; len(icds) > 0 {
+		paths := make([]string, len(icds))
+		for i, icd := range icds {
+			paths[i] = vulkanICDDir + "/" + icd
+		}
+		env = append(env, "VK_ICD_FILENAMES="+strings.Join(paths, ":"))
+	}
+	return env
+}
+
+// openGPUMenu is the "GPU" action.
+func (m *model) openGPUMenu() tea.Cmd {
+	if remoteHost != "" {
+		return m.showToast("The GPUs of a remote host can't be listed")
+	}
+	gpus := detectGPUs(drmSysfs)
+	current := "Default"
+	sm := &submenu{
+		note:   "Applies to Steam and every game launched from it.",
+		marked: 0,
+		items:  []menuItem{{icon: "◌", label: "Default (let the drivers choose)", run: selectGPUAction(gpu{})}},
+	}
+	for i, g := range gpus {
+		sm.items = append(sm.items, menuItem{icon: "▣", label: g.label(), run: selectGPUAction(g)})
+		if g.slot == launch.GPU {
+			sm.marked = i + 1
+			current = gpuVendors[g.vendor]
+		}
+	}
+	if launch.GPU != "" && sm.marked == 0 {
+		current = launch.GPU + " (not present)"
+		sm.marked = -1
+	}
+	if len(gpus) < 2 {
+		sm.note = "Only one GPU found; there is nothing to choose between."
+	}
+	sm.title = "Launch GPU — current: " + current
+	m.openSubmenu(sm)
+	return nil
+}
+
+func selectGPUAction(g gpu) func(m *model) tea.Cmd {
+	return func(m *model) tea.Cmd {
+		next := launch
+		next.GPU = g.slot
+		if err := m.saveLaunch(next); err != nil {
+			return nil
+		}
+		if g.slot == "" {
+			return m.showToast("Steam launches on the default GPU")
+		}
+		return m.showToast("Steam launches on " + g.label())
+	}
+}
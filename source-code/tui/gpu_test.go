@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeGPU is one PCI device as sysfs shows it.
+type fakeGPU struct {
+	card    string
+	slot    string
+	vendor  string
+	driver  string
+	bootVGA bool
+}
+
+// fakeGPUSysfs lays out gpus as /sys/class/drm and /sys/bus/pci do,
+// with the device and driver links, and returns the drm directory.
+func fakeGPUSysfs(t *testing.T, gpus ...fakeGPU) string {
+	t.Helper()
+	root := t.TempDir()
+	drm := filepath.Join(root, "class", "drm")
+	for _, g := range gpus {
+		dev := filepath.Join(root, "devices", g.slot)
+		drv := filepath.Join(root, "drivers", g.driver)
+		for _, d := range []string{filepath.Join(drm, g.card), dev, drv} {
+			if err := os.MkdirAll(d, 0o755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		os.WriteFile(filepath.Join(dev, "vendor"), []byte(g.vendor+"\n"), 0o644)
+		boot := "0\n"
+		if g.bootVGA {
+			boot = "1\n"
+		}
+		os.WriteFile(filepath.Join(dev, "boot_vga"), []byte(boot), 0o644)
+		os.Symlink(drv, filepath.Join(dev, "driver"))
+		os.Symlink(dev, filepath.Join(drm, g.card, "device"))
+	}
+	return drm
+}
+
+func TestDetectGPUs(t *testing.T) {
+	intel := fakeGPU{"card0", "0000:00:02.0", "0x8086", "i915", true}
+	nvidia := fakeGPU{"card1", "0000:01:00.0", "0x10de", "nvidia", false}
+	drm := fakeGPUSysfs(t, nvidia, intel)
+	// A connector and a render node of card0 are not extra GPUs.
+	os.MkdirAll(filepath.Join(drm, "card0-eDP-1"), 0o755)
+	os.Symlink(filepath.Join(drm, "card0", "device"), filepath.Join(drm, "renderD128"))
+
+	got := detectGPUs(drm)
+	want := []gpu{
+		{card: 0, slot: "0000:00:02.0", vendor: "0x8086", driver: "i915", bootVGA: true},
+		{card: 1, slot: "0000:01:00.0", vendor: "0x10de", driver: "nvidia"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("detectGPUs = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("gpu %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if gpus := detectGPUs(filepath.Join(drm, "missing")); gpus != nil {
+		t.Errorf("missing drm dir: %+v", gpus)
+	}
+}
+
+func TestGPULabel(t *testing.T) {
+	tests := []struct {
+		g    gpu
+		want string
+	}{
+		{gpu{slot: "0000:00:02.0", vendor: "0x8086", driver: "i915", bootVGA: true}, "Intel (i915) 0000:00:02.0 · primary"},
+		{gpu{slot: "0000:03:00.0", vendor: "0x1002", driver: "amdgpu"}, "AMD (amdgpu) 0000:03:00.0"},
+		{gpu{slot: "0000:04:00.0", vendor: "0x1af4", driver: "virtio-pci"}, "GPU 0x1af4 (virtio-pci) 0000:04:00.0"},
+	}
+	for _, tt := range tests {
+		if got := tt.g.label(); got != tt.want {
+			t.Errorf("label() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestGPUEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		g    gpu
+		want []string
+	}{
+		{"NVIDIA offload", gpu{slot: "0000:01:00.0", driver: "nvidia"}, []string{
+			"__NV_PRIME_RENDER_OFFLOAD=1",
+			"__GLX_VENDOR_LIBRARY_NAME=nvidia",
+			"__VK_LAYER_NV_optimus=NVIDIA_only",
+			"VK_ICD_FILENAMES=/usr/share/vulkan/icd.d/nvidia_icd.json",
+		}},
+		{"NVIDIA driving the display", gpu{slot: "0000:01:00.0", driver: "nvidia", bootVGA: true}, []string{
+			"VK_ICD_FILENAMES=/usr/share/vulkan/icd.d/nvidia_icd.json",
+		}},
+		{"AMD dGPU", gpu{slot: "0000:03:00.0", driver: "amdgpu"}, []string{
+			"DRI_PRIME=pci-0000_03_00_0",
+			"VK_ICD_FILENAMES=/usr/share/vulkan/icd.d/radeon_icd.x86_64.json:/usr/share/vulkan/icd.d/radeon_icd.i686.json",
+		}},
+		{"Intel iGPU", gpu{slot: "0000:00:02.0", driver: "i915", bootVGA: true}, []string{
+			"DRI_PRIME=pci-0000_00_02_0",
+			"VK_ICD_FILENAMES=/usr/share/vulkan/icd.d/intel_icd.x86_64.json:/usr/share/vulkan/icd.d/intel_icd.i686.json",
+		}},
+		{"unknown driver", gpu{slot: "0000:04:00.0", driver: "virtio-pci"}, []string{
+			"DRI_PRIME=pci-0000_04_00_0",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gpuEnv(tt.g); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("gpuEnv = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
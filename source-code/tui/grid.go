@@ -0,0 +1,148 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Grid layout (layout = "grid", toggled with g)
+//  The menu wraps into as many columns as the width allows, above a
+//  full-width log panel. Items are laid out row by row; ←/→ move along
+//  a row and ↑/↓ jump a whole row. Section headers are left out; the
+//  launch and Gamescope summaries share a row under the cells.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	gridCellWidth = 26
+	gridMinWidth  = 2 * gridCellWidth // below this the list is used
+)
+
+func gridActive(width int) bool {
+	return cfg.Layout == "grid" && width >= gridMinWidth
+}
+
+// gridColumns is how many cells of n fit across width.
+func gridColumns(width, n int) int {
+	return max(1, min(n, width/gridCellWidth))
+}
+
+// gridHeight is the height the grid takes, bottom border, favorites
+// and summary rows included. It is sized for every item so hiding some
+// doesn't resize the log.
+func (m model) gridHeight() int {
+	cols := gridColumns(m.width, len(menuItems))
+	h := (len(menuItems)+cols-1)/cols + 1
+	if len(quickLaunch()) > 0 {
+		h++
+	}
+	if m.gridSummary() != "" {
+		h++
+	}
+	return h
+}
+
+// gridSummary joins the summaries the list draws under the launch
+// section and the Gamescope item, "" when neither is set.
+func (m model) gridSummary() string {
+	var parts []string
+	for _, s := range []string{m.launchSummary, m.gamescopeSummary} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " • ")
+}
+
+// gridStep returns the cell reached from pos by moving dx along a row or
+// dy rows, or -1 when that leaves the grid of n cells.
+func gridStep(pos, dx, dy, cols, n int) int {
+	if dx != 0 {
+		t := pos + dx
+		if t < 0 || t >= n || t/cols != pos/cols {
+			return -1
+		}
+		return t
+	}
+	t := pos + dy*cols
+	if t < 0 || t >= n {
+		return -1
+	}
+	return t
+}
+
+// gridCells lists the indexes of the items drawn in the grid.
+func (m model) gridCells() []int {
+	var cells []int
+	for i := range menuItems {
+		if m.visible(i) {
+			cells = append(cells, i)
+		}
+	}
+	return cells
+}
+
+// moveGrid moves the cursor in 2D, passing over unselectable cells.
+func (m *model) moveGrid(dx, dy int) {
+	cells := m.gridCells()
+	cols := gridColumns(m.width, len(cells))
+	pos := -1
+	for p, i := range cells {
+		if i == m.cursor {
+			pos = p
+		}
+	}
+	if pos < 0 {
+		return
+	}
+	for t := gridStep(pos, dx, dy, cols, len(cells)); t >= 0; t = gridStep(t, dx, dy, cols, len(cells)) {
+		if m.selectable(cells[t]) {
+			m.setCursor(cells[t])
+			return
+		}
+	}
+}
+
+func (m model) renderGrid() string {
+	cells := m.gridCells()
+	cols := gridColumns(m.width, len(cells))
+
+	var rows []string
+	if bar := renderQuickLaunch(gridCellWidth); len(bar) > 0 {
+		for i := range bar {
+			bar[i] = lipgloss.NewStyle().Width(gridCellWidth).Render(bar[i])
+		}
+		rows = append(rows, lipgloss.NewStyle().MaxWidth(m.width).Render(lipgloss.JoinHorizontal(lipgloss.Top, bar...)))
+	}
+	for start := 0; start < len(cells); start += cols {
+		var row []string
+		for _, i := range cells[start:min(start+cols, len(cells))] {
+			item := menuItems[i]
+			icon := styleMenuIcon.Render(item.icon)
+			cell := lipgloss.NewStyle().Width(gridCellWidth)
+			switch {
+			case !m.selectable(i):
+				row = append(row, cell.Render(m.hintedRow("  "+icon+" ", i, styleMenuItem.Foreground(colDim), gridCellWidth)))
+			case i == m.cursor:
+				row = append(row, cell.Background(lipgloss.Color("#0e2040")).Render(m.hintedRow(
+					styleMenuSelected.Render("")+icon+" ", i, lipgloss.NewStyle().Foreground(colCursor).Bold(true), gridCellWidth)))
+			default:
+				row = append(row, cell.Render(m.hintedRow("  "+icon+" ", i, styleMenuItem, gridCellWidth)))
+			}
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	if summary := m.gridSummary(); summary != "" {
+		rows = append(rows, styleLogDim.Render(truncate("  "+summary, m.width)))
+	}
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.gridHeight() - 1).
+		Background(colBg).
+		BorderBottom(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(colBorder).
+		Render(strings.Join(rows, "\n"))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestGridColumns(t *testing.T) {
+	tests := []struct {
+		width, n, want int
+	}{
+		{52, 14, 2},
+		{80, 14, 3},
+		{120, 14, 4},
+		{200, 14, 7},
+		{200, 3, 3},
+		{10, 14, 1},
+		{120, 0, 1},
+	}
+	for _, tt := range tests {
+		if got := gridColumns(tt.width, tt.n); got != tt.want {
+			t.Errorf("gridColumns(%d, %d) = %d, want %d", tt.width, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestGridStep(t *testing.T) {
+	// 3 columns, 8 cells:  0 1 2 / 3 4 5 / 6 7
+	tests := []struct {
+		pos, dx, dy, want int
+	}{
+		{0, 1, 0, 1},
+		{2, 1, 0, -1},
+		{3, -1, 0, -1},
+		{4, -1, 0, 3},
+		{7, 1, 0, -1},
+		{1, 0, 1, 4},
+		{4, 0, 1, 7},
+		{5, 0, 1, -1},
+		{2, 0, -1, -1},
+		{6, 0, -1, 3},
+	}
+	for _, tt := range tests {
+		if got := gridStep(tt.pos, tt.dx, tt.dy, 3, 8); got != tt.want {
+			t.Errorf("gridStep(%d, %d, %d) = %d, want %d", tt.pos, tt.dx, tt.dy, got, tt.want)
+		}
+	}
+}
+
+func TestGridNavigation(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.Layout = "grid"
+	cfg.HideDisabled = false
+
+	// "Create Container" is disabled while the container runs, so moves
+	// landing on it carry on in the same direction.
+	tests := []struct {
+		width int
+		from  string
+		keys  []string
+		want  string
+	}{
+		{80, "Launch Steam", []string{"right"}, "Big Picture Mode"},
+		{80, "Launch Steam", []string{"right", "right", "right"}, "Test Launch"},
+		{80, "Launch Steam", []string{"left"}, "Launch Steam"},
+		{80, "Launch Steam", []string{"down"}, "Steam Channel"},
+		{80, "Launch GPU", []string{"down"}, "Library Folders"},
+		{80, "Gamescope", []string{"right"}, "MangoHud"},
+		{80, "Launch GPU", []string{"right"}, "Launch GPU"},
+		{80, "Update Container", []string{"up", "up"}, "Installed Games"},
+		{120, "MangoHud", []string{"down"}, "Library Folders"},
+		{120, "Installed Games", []string{"right"}, "Library Folders"},
+		{120, "Library Folders", []string{"right"}, "Library Folders"},
+		{120, "Steam Channel", []string{"right"}, "Steam Channel"},
+		{40, "Launch Steam", []string{"down"}, "Big Picture Mode"},
+		{40, "Launch Steam", []string{"right"}, "Launch Steam"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d %s %v", tt.width, tt.from, tt.keys), func(t *testing.T) {
+			m := initialModel()
+			m.width, m.height = tt.width, 40
+			m.containerStatus = "running"
+			m.cursor = itemIndex(t, tt.from)
+			m = press(m, tt.keys...)
+			if got := menuItems[m.cursor].label; got != tt.want {
+				t.Errorf("cursor on %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGridLayout(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.Layout = "list"
+	t.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.layoutLog()
+	m = press(m, "g")
+	if cfg.Layout != "grid" || !gridActive(m.width) {
+		t.Fatalf("g left layout %q", cfg.Layout)
+	}
+	if m.logViewport.Width != m.width || m.logViewport.Height != logPanelHeight(m.height)-m.gridHeight() {
+		t.Errorf("grid log viewport %dx%d", m.logViewport.Width, m.logViewport.Height)
+	}
+	grid := m.renderGrid()
+	if w := lipgloss.Width(grid); w > m.width {
+		t.Errorf("grid is %d columns wide", w)
+	}
+	if strings.Contains(grid, "STEAM") {
+		t.Error("grid draws section headers")
+	}
+	m = press(m, "g")
+	if cfg.Layout != "list" || m.logViewport.Width != logPanelWidth(m.width) {
+		t.Errorf("second g: layout %q, viewport width %d", cfg.Layout, m.logViewport.Width)
+	}
+}
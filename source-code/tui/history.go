@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Status history
+//  Toasts and container status changes vanish from the status bar once
+//  replaced; the last historyLimit of them are kept here and shown in a
+//  popup, so a multi-step operation can be followed after the fact.
+// ─────────────────────────────────────────────────────────────────
+
+const historyLimit = 50
+
+type statusEntry struct {
+	at   time.Time
+	text string
+}
+
+// statusHistory is a fixed-size ring; the oldest entry is overwritten.
+type statusHistory struct {
+	buf   [historyLimit]statusEntry
+	start int
+	n     int
+}
+
+func (h *statusHistory) add(text string) {
+	e := statusEntry{at: time.Now(), text: text}
+	if h.n < historyLimit {
+		h.buf[(h.start+h.n)%historyLimit] = e
+		h.n++
+		return
+	}
+	h.buf[h.start] = e
+	h.start = (h.start + 1) % historyLimit
+}
+
+// entries returns the history oldest-first.
+func (h *statusHistory) entries() []statusEntry {
+	out := make([]statusEntry, h.n)
+	for i := range out {
+		out[i] = h.buf[(h.start+i)%historyLimit]
+	}
+	return out
+}
+
+func (m *model) recordStatus(text string) {
+	m.history.add(text)
+	mirrorStatus(text)
+}
+
+// handleHistoryKey closes the popup; quitting still works beneath it.
+func (m *model) handleHistoryKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.ForceQuit):
+		return m.requestQuit()
+	case key.Matches(msg, keys.History, keys.Back):
+		m.popup = popupNone
+	}
+	return nil
+}
+
+func (m model) renderHistory() string {
+	rows := []string{styleTitle.Render("Status history"), ""}
+
+	entries := m.history.entries()
+	// Newest at the bottom, trimmed to what fits between header and footer.
+	if max := m.height - 12; max > 0 && len(entries) > max {
+		entries = entries[len(entries)-max:]
+	}
+	if len(entries) == 0 {
+		rows = append(rows, styleLogDim.Render("Nothing yet."))
+	}
+	for _, e := range entries {
+		rows = append(rows, styleLogDim.Render(e.at.Format("15:04:05"))+"  "+
+			lipgloss.NewStyle().Foreground(colText).Render(e.text))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colAccent).
+		Padding(1, 4)
+
+	return m.placeOverlay(box, strings.Join(rows, "\n"))
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStatusHistoryRing(t *testing.T) {
+	tests := []struct {
+		adds        int
+		n           int
+		first, last string
+	}{
+		{0, 0, "", ""},
+		{1, 1, "status 1", "status 1"},
+		{historyLimit, historyLimit, "status 1", fmt.Sprintf("status %d", historyLimit)},
+		{historyLimit + 1, historyLimit, "status 2", fmt.Sprintf("status %d", historyLimit+1)},
+		{3*historyLimit + 7, historyLimit, fmt.Sprintf("status %d", 2*historyLimit+8), fmt.Sprintf("status %d", 3*historyLimit+7)},
+	}
+	for _, tt := range tests {
+		var h statusHistory
+		for i := 1; i <= tt.adds; i++ {
+			h.add(fmt.Sprintf("status %d", i))
+		}
+		e := h.entries()
+		if len(e) != tt.n {
+			t.Fatalf("%d adds: %d entries, want %d", tt.adds, len(e), tt.n)
+		}
+		if tt.n == 0 {
+			continue
+		}
+		if e[0].text != tt.first || e[len(e)-1].text != tt.last {
+			t.Errorf("%d adds: entries %q … %q, want %q … %q", tt.adds, e[0].text, e[len(e)-1].text, tt.first, tt.last)
+		}
+		for i := 1; i < len(e); i++ {
+			if e[i].at.Before(e[i-1].at) {
+				t.Errorf("%d adds: entries out of order at %d", tt.adds, i)
+			}
+		}
+	}
+}
+
+func TestHistoryPopup(t *testing.T) {
+	m := initialModel()
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	m = next.(model)
+	if !strings.Contains(m.renderHistory(), "Nothing yet.") {
+		t.Error("empty history popup lacks the placeholder")
+	}
+
+	for i := 1; i <= 30; i++ {
+		m.recordStatus(fmt.Sprintf("step %02d", i))
+	}
+	m = press(m, "h")
+	if m.popup != popupHistory {
+		t.Fatal("h did not open the history")
+	}
+	popup := m.renderHistory()
+	// Height 20 leaves room for the newest 8 entries.
+	for _, want := range []string{"Status history", "step 30", "step 23"} {
+		if !strings.Contains(popup, want) {
+			t.Errorf("popup lacks %q:\n%s", want, popup)
+		}
+	}
+	if strings.Contains(popup, "step 22") {
+		t.Errorf("popup not trimmed to the terminal height:\n%s", popup)
+	}
+	if !strings.Contains(m.View(), "Status history") {
+		t.Error("View() doesn't show the popup")
+	}
+
+	m = press(m, "esc")
+	if m.popup != popupNone {
+		t.Error("esc did not close the history")
+	}
+}
+
+func TestHistoryRecordsRuns(t *testing.T) {
+	m := initialModel()
+	m.dispatch(menuItem{label: "Container Status", cmd: []string{"status"}})
+	next, _ := m.Update(cmdDoneMsg(false))
+	m = next.(model)
+	next, _ = m.Update(statusDoneMsg("running"))
+	m = next.(model)
+	var got []string
+	for _, e := range m.history.entries() {
+		got = append(got, e.text)
+	}
+	want := "Container Status: started|Container Status: failed|Container running"
+	if strings.Join(got, "|") != want {
+		t.Errorf("history = %q, want %q", strings.Join(got, "|"), want)
+	}
+}
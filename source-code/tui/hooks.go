@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Action hooks
+//  [hooks] in config.toml maps "pre_<action>" / "post_<action>" to a
+//  shell command, e.g.  pre_run = "notify-send 'Starting Steam'".
+//  Hooks get HACKEROS_STEAM_ACTION (and HACKEROS_STEAM_RESULT for post
+//  hooks) in their environment and run under cfg.HookTimeout.
+// ─────────────────────────────────────────────────────────────────
+
+type hookPhase string
+
+const (
+	hookPre  hookPhase = "pre"
+	hookPost hookPhase = "post"
+)
+
+type hookDoneMsg struct {
+	phase  hookPhase
+	action string
+	output string
+	err    error
+}
+
+// actionName is the hackeros-steam subcommand an item runs ("run",
+// "update", ...), skipping global flags. Built-in actions have none.
+func actionName(item menuItem) string {
+	if item.run != nil {
+		return ""
+	}
+	for _, arg := range item.cmd {
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+	return ""
+}
+
+// hookFor returns the configured hook command for phase and action.
+func hookFor(phase hookPhase, action string) string {
+	if action == "" {
+		return ""
+	}
+	return strings.TrimSpace(cfg.Hooks[string(phase)+"_"+action])
+}
+
+func hookEnv(action, result string) []string {
+	env := append(os.Environ(), "HACKEROS_STEAM_ACTION="+action)
+	if result != "" {
+		env = append(env, "HACKEROS_STEAM_RESULT="+result)
+	}
+	return env
+}
+
+// runHookCmd runs a hook through sh; result is "" for pre hooks and
+// "success"/"failure" for post hooks.
+func runHookCmd(phase hookPhase, action, hook, result string) tea.Cmd {
+	timeout := time.Duration(cfg.HookTimeout) * time.Second
+	return func() tea.Msg {
+		name := string(phase) + "_" + action
+		extra := hookEnv(action, result)[len(os.Environ()):]
+		id, err := auditExec(name, []string{"sh", "-c", hook}, extra)
+		if err != nil {
+			return hookDoneMsg{phase: phase, action: action, err: err}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		c := exec.CommandContext(ctx, "sh", "-c", hook)
+		c.Env = hookEnv(action, result)
+		out, err := combinedOutputTail(c)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		outcome := "ok"
+		if err != nil {
+			outcome = err.Error()
+		}
+		auditExit(id, name, outcome)
+		return hookDoneMsg{phase: phase, action: action, output: stripANSI(out), err: err}
+	}
+}
+
+// logHook writes a hook's output and outcome to the log panel.
+func (m *model) logHook(msg hookDoneMsg) {
+	name := string(msg.phase) + "_" + msg.action
+	for _, line := range strings.Split(strings.TrimRight(msg.output, "\n"), "\n") {
+		if line != "" {
+			m.appendLog(styleLogDim.Render("  │ " + line))
+		}
+	}
+	if msg.err != nil {
+		m.appendLog(styleLogWarning.Render("  ⚠  Hook " + name + " failed: " + msg.err.Error()))
+	} else {
+		m.appendLog(styleLogDim.Render("  Hook " + name + " finished."))
+	}
+}
+
+// handleHookDone continues the action after a hook: a pre hook starts
+// the command (unless it failed and aborting is enabled), a post hook
+// ends the run.
+func (m *model) handleHookDone(msg hookDoneMsg) tea.Cmd {
+	m.logHook(msg)
+	if msg.phase == hookPost {
+		m.busy = false
+		return nil
+	}
+	if msg.err != nil && cfg.HookAbortOnFailure {
+		m.busy = false
+		m.state = stateMenu
+		m.logError("Skipped " + msg.action + ": pre-hook failed.")
+		m.appendLog("")
+		return nil
+	}
+	if m.lastItem == nil {
+		m.busy = false
+		m.state = stateMenu
+		return nil
+	}
+	return m.startCommand()
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestActionName(t *testing.T) {
+	tests := []struct {
+		item menuItem
+		want string
+	}{
+		{menuItem{cmd: []string{"run", "-gamepadui"}}, "run"},
+		{menuItem{cmd: []string{"--force", "remove"}}, "remove"},
+		{menuItem{cmd: []string{"update"}}, "update"},
+		{menuItem{cmd: nil}, ""},
+		{menuItem{cmd: []string{"status"}, run: (*model).exportLogs}, ""},
+	}
+	for _, tt := range tests {
+		if got := actionName(tt.item); got != tt.want {
+			t.Errorf("actionName(%q) = %q, want %q", tt.item.cmd, got, tt.want)
+		}
+	}
+}
+
+func TestHookOrder(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	defer func(saved config) { cfg = saved }(cfg)
+	trace := filepath.Join(t.TempDir(), "trace")
+	cfg.Hooks = map[string]string{
+		"pre_status":  `echo "pre $HACKEROS_STEAM_ACTION" >> ` + shellQuote(trace),
+		"post_status": `echo "post $HACKEROS_STEAM_ACTION $HACKEROS_STEAM_RESULT" >> ` + shellQuote(trace),
+	}
+
+	m := initialModel()
+	item := menuItem{label: "Container Status", cmd: []string{"status"}}
+	cmd := m.dispatch(item)
+	if n := countLogged(m, "$ hackeros-steam"); n != 0 {
+		t.Fatal("command started before its pre hook")
+	}
+	next, _ := m.Update(runHookCmd(hookPre, "status", cfg.Hooks["pre_status"], "")())
+	m = next.(model)
+	if cmd == nil || countLogged(m, "$ hackeros-steam status") != 1 {
+		t.Fatal("command did not start after its pre hook")
+	}
+
+	next, _ = m.Update(cmdDoneMsg(true))
+	m = next.(model)
+	if !m.busy {
+		t.Fatal("run ended before its post hook")
+	}
+	next, _ = m.Update(runHookCmd(hookPost, "status", cfg.Hooks["post_status"], "success")())
+	m = next.(model)
+	if m.busy {
+		t.Error("still busy after the post hook")
+	}
+
+	data, err := os.ReadFile(trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "pre status\npost status success\n"; got != want {
+		t.Errorf("hook trace = %q, want %q", got, want)
+	}
+}
+
+func TestPreHookFailure(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	tests := []struct {
+		abort   bool
+		started bool
+	}{
+		{true, false},
+		{false, true},
+	}
+	for _, tt := range tests {
+		cfg.HookAbortOnFailure = tt.abort
+		m := initialModel()
+		item := menuItem{label: "Launch Steam", cmd: []string{"run"}}
+		m.busy, m.state, m.lastItem, m.lastAction = true, stateRunning, &item, actionFor(item, "")
+		next, _ := m.Update(hookDoneMsg{phase: hookPre, action: "run", output: "nope\n", err: errors.New("exit status 1")})
+		m = next.(model)
+
+		if started := countLogged(m, "$ hackeros-steam run") == 1; started != tt.started {
+			t.Errorf("abort=%v: command started = %v, want %v", tt.abort, started, tt.started)
+		}
+		if !tt.started && (m.busy || m.state != stateMenu) {
+			t.Errorf("abort=%v: busy=%v state=%v after skipping", tt.abort, m.busy, m.state)
+		}
+		if countLogged(m, "Hook pre_run failed") != 1 || countLogged(m, "│ nope") != 1 {
+			t.Errorf("abort=%v: hook output/failure not logged:\n%s", tt.abort, strings.Join(plainLogLines(m.logLines, false), "\n"))
+		}
+	}
+}
+
+func TestHookTimeout(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("no sleep")
+	}
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.HookTimeout = 1
+	msg := runHookCmd(hookPre, "run", "exec sleep 5", "")().(hookDoneMsg)
+	if msg.err == nil || !strings.Contains(msg.err.Error(), "timed out") {
+		t.Errorf("err = %v, want a timeout", msg.err)
+	}
+}
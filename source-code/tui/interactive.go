@@ -0,0 +1,111 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Interactive commands
+//  Streamed commands get no stdin, so anything that prompts reads EOF
+//  and fails instead of hanging. Items marked interactive are run with
+//  the terminal handed over (tea.ExecProcess) and the TUI is restored
+//  when they exit. A streamed command that failed on a prompt is
+//  offered a re-run that way.
+// ─────────────────────────────────────────────────────────────────
+
+// inputPromptMarkers are lowercase fragments of prompts waiting for an
+// answer on stdin.
+var inputPromptMarkers = []string{
+	"[y/n]",
+	"(yes/no)",
+	"password for",
+	"password:",
+	"passphrase",
+	"press enter",
+}
+
+// endsWithInputPrompt reports whether the last non-empty output line is
+// a prompt; a prompt further up was answered and is not the cause.
+func endsWithInputPrompt(lines []string) bool {
+	for i := len(lines) - 1; i >= 0; i-- {
+		lo := strings.ToLower(strings.TrimSpace(lines[i]))
+		if lo == "" {
+			continue
+		}
+		for _, marker := range inputPromptMarkers {
+			if strings.Contains(lo, marker) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// execInteractive runs argv for item, resolved as a, with the terminal
+// handed over.
+func (m *model) execInteractive(item menuItem, a action, argv []string) tea.Cmd {
+	m.busy = true
+	m.state = stateRunning
+	m.lastItem = &item
+	m.lastAction = a
+	m.beginCapture()
+	m.startedAt = time.Now()
+	m.appendLog("")
+	m.appendLog(styleLogInfo.Render("  $ " + strings.Join(argv, " ")))
+	m.appendLog(styleLogDim.Render("  (running in the terminal; output is not captured)"))
+	m.appendLog("")
+	m.recordStatus(item.label + ": started")
+	c := hostCommand(argv, m.lastAction.env, true)
+	at := time.Now()
+	run := tea.ExecProcess(c, func(err error) tea.Msg {
+		// bubbletea starts and waits for c itself, so the panel only
+		// hears of it afterwards.
+		if c.Process != nil {
+			exited := exitedMsg(c)
+			reportProc(sideProcMsg{started: &procStartedMsg{pid: c.Process.Pid, argv: c.Args, at: at}, exited: &exited})
+		}
+		return interactiveDone(c.Args, err)
+	})
+	return tea.Batch(run, m.spinnerTick())
+}
+
+func (m *model) handleInteractiveKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.RunInTerminal):
+		item, a := *m.pendingItem, m.pendingAction
+		m.pendingItem = nil
+		m.state = stateMenu
+		item.interactive, a.streaming = true, false
+		return m.dispatchAction(item, a)
+	case key.Matches(msg, keys.Cancel):
+		m.pendingItem = nil
+		m.state = stateMenu
+		m.appendLog(styleLogDim.Render("  Not re-running in the terminal."))
+	default:
+		m.resetPromptTimer()
+	}
+	return nil
+}
+
+func (m model) renderInteractiveDialog() string {
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Foreground(colYellow).Bold(true).Render("⌨  Input Needed"),
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render(m.pendingItem.label+" stopped at a prompt."),
+		lipgloss.NewStyle().Foreground(colText).Render("Run it again in the terminal to answer it?"),
+		"",
+		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[Y]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("run in terminal")+"   "+
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[N]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("cancel"),
+		m.renderCountdown(),
+	)
+
+	return m.placeOverlay(styleConfirmBox.BorderForeground(colYellow), content)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestEndsWithInputPrompt(t *testing.T) {
+	tests := []struct {
+		lines []string
+		want  bool
+	}{
+		{[]string{"Proceed with installation? [Y/n]"}, true},
+		{[]string{"[sudo] password for user:", ""}, true},
+		{[]string{"Enter passphrase for key:"}, true},
+		{[]string{"Press ENTER to continue", "  "}, true},
+		{[]string{"Continue? [Y/n] y", "done"}, false},
+		{[]string{"error: target not found"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := endsWithInputPrompt(tt.lines); got != tt.want {
+			t.Errorf("endsWithInputPrompt(%q) = %v, want %v", tt.lines, got, tt.want)
+		}
+	}
+}
+
+func TestInteractiveRouting(t *testing.T) {
+	item := menuItem{label: "Login", cmd: []string{"login"}}
+	tests := []struct {
+		name        string
+		interactive bool
+		output      []string
+		want        viewState
+	}{
+		{"prompt at the end", false, []string{"Password:"}, stateInteractive},
+		{"permission error", false, []string{"Permission denied"}, stateEscalate},
+		{"plain failure", false, []string{"error: no network"}, stateMenu},
+		{"already interactive", true, []string{"Password:"}, stateMenu},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.busy, m.state = true, stateRunning
+			it := item
+			it.interactive = tt.interactive
+			m.lastItem = &it
+			m.runOutput = tt.output
+			next, _ := m.Update(cmdDoneMsg(false))
+			m = next.(model)
+			if m.state != tt.want {
+				t.Errorf("state = %v, want %v", m.state, tt.want)
+			}
+		})
+	}
+}
+
+func TestInteractiveRerun(t *testing.T) {
+	tests := []struct {
+		answer string
+		rerun  bool
+	}{
+		{"y", true},
+		{"n", false},
+		{"esc", false},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.openPrompt(stateInteractive, menuItem{label: "Login", cmd: []string{"login"}})
+		m = press(settle(m), tt.answer)
+		ran := countLogged(m, "(running in the terminal") == 1
+		if ran != tt.rerun {
+			t.Errorf("answer %q: ran in terminal = %v, want %v", tt.answer, ran, tt.rerun)
+		}
+		if tt.rerun && (!m.busy || m.state != stateRunning || !m.lastItem.interactive) {
+			t.Errorf("answer %q: busy=%v state=%v", tt.answer, m.busy, m.state)
+		}
+		if !tt.rerun && (m.state != stateMenu || m.pendingItem != nil) {
+			t.Errorf("answer %q: state %v, pending %v", tt.answer, m.state, m.pendingItem)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Issue text
+//  From the last-error popup, i copies the error as a filled-in bug
+//  report for the GitHub issue form: what failed, the command, the
+//  versions and the system it ran on. Only "what did you expect" is
+//  left to write. Redacted like the diagnostics bundle.
+// ─────────────────────────────────────────────────────────────────
+
+// issueInput is what the report is built from.
+type issueInput struct {
+	err           errorRecord
+	status        string
+	engine        string
+	clientVersion string
+}
+
+// buildIssue composes the report, ready to paste into a new issue.
+func buildIssue(in issueInput) string {
+	var b strings.Builder
+	section := func(title string) { b.WriteString("\n### " + title + "\n\n") }
+	fence := func(body string) {
+		b.WriteString("```\n" + strings.TrimRight(body, "\n") + "\n```\n")
+	}
+	orUnknown := func(s string) string {
+		if s == "" {
+			return "unknown"
+		}
+		return s
+	}
+
+	fmt.Fprintf(&b, "**Title:** %s\n", truncate(in.err.text, 80))
+
+	section("What happened")
+	b.WriteString(in.err.text + "\n")
+	if in.err.command != "" {
+		b.WriteString("\nCommand:\n\n")
+		fence(in.err.command)
+	}
+	if len(in.err.detail) > 0 {
+		b.WriteString("\nOutput:\n\n")
+		detail := make([]string, len(in.err.detail))
+		for i, l := range in.err.detail {
+			detail[i] = stripANSI(l)
+		}
+		fence(strings.Join(detail, "\n"))
+	}
+
+	section("What I expected")
+	b.WriteString("<!-- Describe what should have happened. -->\n")
+
+	section("Versions")
+	fmt.Fprintf(&b, "- TUI: %s\n- CLI: %s\n- Steam client: %s\n",
+		tuiVersion(), cli, orUnknown(in.clientVersion))
+
+	section("Environment")
+	fmt.Fprintf(&b, "- OS: %s\n- Arch: %s/%s\n- Engine: %s\n- Container: %s (%s)\n- TERM: %s\n",
+		osRelease(), runtime.GOOS, runtime.GOARCH, orUnknown(in.engine),
+		containerName, orUnknown(in.status), orUnknown(os.Getenv("TERM")))
+	if env := desktopEnv(); env != "" {
+		fmt.Fprintf(&b, "- Desktop: %s\n", env)
+	}
+	if remoteHost != "" {
+		b.WriteString("- Remote: yes\n")
+	}
+	fmt.Fprintf(&b, "- Error at: %s\n", in.err.at.Format(time.RFC3339))
+
+	return redact(b.String())
+}
+
+// copyIssue puts the last error on the clipboard as an issue.
+func (m *model) copyIssue() tea.Cmd {
+	if m.lastError == nil {
+		return m.showToast("No error to report")
+	}
+	return copyToClipboardCmd(buildIssue(issueInput{
+		err:           *m.lastError,
+		status:        m.containerStatus,
+		engine:        m.engine(),
+		clientVersion: readClientVersion(),
+	}), "issue text")
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestBuildIssue(t *testing.T) {
+	t.Setenv("HOME", "/home/alice")
+	t.Setenv("USER", "alice")
+	at := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	tests := []struct {
+		name    string
+		in      issueInput
+		want    []string
+		notWant []string
+	}{
+		{
+			"command and output",
+			issueInput{
+				err: errorRecord{at: at, text: "Update Container failed (exit status 1)",
+					command: "hackeros-steam update",
+					detail:  []string{"error: failed to commit transaction", red.Render("GITHUB_TOKEN=ghp_secret")}},
+				status: "running", engine: "podman", clientVersion: "1728000000",
+			},
+			[]string{
+				"**Title:** Update Container failed (exit status 1)\n",
+				"### What happened\n\nUpdate Container failed (exit status 1)\n",
+				"Command:\n\n```\nhackeros-steam update\n```",
+				"Output:\n\n```\nerror: failed to commit transaction\nGITHUB_TOKEN=<redacted>\n```",
+				"### What I expected",
+				"- Steam client: 1728000000",
+				"- Engine: podman",
+				"- Container: " + containerName + " (running)",
+				"- Error at: 2026-10-14T15:30:00Z",
+			},
+			[]string{"ghp_secret", "\x1b["},
+		},
+		{
+			"bare error",
+			issueInput{err: errorRecord{at: at, text: "Could not write /home/alice/.config/hackeros-steam/config.toml: read-only file system"}},
+			[]string{
+				"Could not write ~/.config/hackeros-steam/config.toml",
+				"- Steam client: unknown",
+				"- Engine: unknown",
+				"(unknown)",
+			},
+			[]string{"/home/alice", "Command:", "Output:"},
+		},
+		{
+			"long title",
+			issueInput{err: errorRecord{at: at, text: strings.Repeat("x", 120)}},
+			[]string{"**Title:** " + strings.Repeat("x", 79) + "…\n", strings.Repeat("x", 120) + "\n"},
+			nil,
+		},
+		{
+			"user name",
+			issueInput{err: errorRecord{at: at, text: "no such user alice"}},
+			[]string{"no such user <user>"},
+			[]string{"alice"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildIssue(tt.in)
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("issue lacks %q:\n%s", s, got)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(got, s) {
+					t.Errorf("issue contains %q:\n%s", s, got)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorCommand(t *testing.T) {
+	t.Setenv("HOME", "/home/alice")
+	update := menuItems[itemIndex(t, "Update Container")]
+	games := menuItems[itemIndex(t, "Installed Games")]
+	script := menuItem{label: "Backup", script: "/home/alice/.config/hackeros-steam/actions.d/backup"}
+	tests := []struct {
+		item *menuItem
+		want string
+	}{
+		{nil, ""},
+		{&update, "hackeros-steam update"},
+		{&games, "Installed Games"},
+		{&script, "~/.config/hackeros-steam/actions.d/backup"},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.lastItem = tt.item
+		if got := m.errorCommand(); got != tt.want {
+			t.Errorf("errorCommand() = %q, want %q", got, tt.want)
+		}
+		m.logError("failed")
+		if m.lastError.command != tt.want {
+			t.Errorf("recorded command %q, want %q", m.lastError.command, tt.want)
+		}
+	}
+}
+
+func TestCopyIssueKey(t *testing.T) {
+	m := initialModel()
+	m.popup = popupLastError
+	m = press(m, "i")
+	if m.toast != "No error to report" {
+		t.Errorf("toast %q without an error", m.toast)
+	}
+	m.logError("Update Container failed")
+	m.popup = popupLastError
+	if cmd := m.handleLastErrorKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")}); cmd == nil {
+		t.Error("i on an error copied nothing")
+	}
+}
@@ -0,0 +1,438 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Key bindings
+//  Every key the TUI reacts to is declared here; both the handlers and
+//  the footer read from this registry so the hints can't drift.
+// ─────────────────────────────────────────────────────────────────
+
+type keyMap struct {
+	Up           key.Binding
+	Down         key.Binding
+	Left         key.Binding
+	Right        key.Binding
+	Layout       key.Binding
+	ANSI         key.Binding
+	Select       key.Binding
+	Refresh      key.Binding
+	Copy         key.Binding
+	Detach       key.Binding
+	ToggleHidden key.Binding
+	LineNumbers  key.Binding
+	History      key.Binding
+	Include      key.Binding
+	Exclude      key.Binding
+	CopyTail     key.Binding
+	LastError    key.Binding
+	ClearErrors  key.Binding
+	CopyIssue    key.Binding
+	Timestamps   key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	Quit         key.Binding
+	ForceQuit    key.Binding
+
+	Confirm       key.Binding
+	Cancel        key.Binding
+	Rerun         key.Binding
+	RunInTerminal key.Binding
+	Back          key.Binding
+	Scroll        key.Binding
+
+	Filter    key.Binding
+	Mark      key.Binding
+	Favorite  key.Binding
+	Uninstall key.Binding
+	Verify    key.Binding
+	StopBatch key.Binding
+	AddEnv    key.Binding
+	RemoveEnv key.Binding
+
+	CancelAndQuit key.Binding
+	QuitAnyway    key.Binding
+}
+
+var keys = keyMap{
+	Up:           key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/↓", "navigate")),
+	Down:         key.NewBinding(key.WithKeys("down", "j")),
+	Left:         key.NewBinding(key.WithKeys("left")),
+	Right:        key.NewBinding(key.WithKeys("right")),
+	Layout:       key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "grid/list")),
+	ANSI:         key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "output colors")),
+	Select:       key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "select")),
+	Refresh:      key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+	Copy:         key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy cmd")),
+	Detach:       key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "detach")),
+	ToggleHidden: key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "show/hide unavailable")),
+	LineNumbers:  key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "line numbers")),
+	Timestamps:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "timestamps")),
+	History:      key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "history")),
+	Include:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/ \\", "filter log")),
+	Exclude:      key.NewBinding(key.WithKeys("\\")),
+	CopyTail:     key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy last lines")),
+	LastError:    key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "last error")),
+	ClearErrors:  key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "clear")),
+	CopyIssue:    key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "copy as issue")),
+	PageUp:       key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup/pgdn", "scroll log")),
+	PageDown:     key.NewBinding(key.WithKeys("pgdown")),
+	Quit:         key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	ForceQuit:    key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit")),
+
+	Confirm:       key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y", "confirm")),
+	Cancel:        key.NewBinding(key.WithKeys("n", "N", "q", "esc"), key.WithHelp("n/esc", "cancel")),
+	Rerun:         key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y", "re-run elevated")),
+	RunInTerminal: key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y", "run in terminal")),
+	Back:          key.NewBinding(key.WithKeys("esc", "q", "backspace"), key.WithHelp("esc", "back")),
+	Scroll:        key.NewBinding(key.WithKeys("up", "down", "k", "j", "pgup", "pgdown"), key.WithHelp("↑/↓", "scroll log")),
+
+	Filter:    key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+	Mark:      key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "mark")),
+	Favorite:  key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "favorite")),
+	Uninstall: key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "uninstall")),
+	Verify:    key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "verify files")),
+	StopBatch: key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "stop after this game"), key.WithDisabled()),
+	AddEnv:    key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add")),
+	RemoveEnv: key.NewBinding(key.WithKeys("d", "delete"), key.WithHelp("d", "remove")),
+
+	CancelAndQuit: key.NewBinding(key.WithKeys("c", "C"), key.WithHelp("c", "cancel update and quit")),
+	QuitAnyway:    key.NewBinding(key.WithKeys("q", "Q"), key.WithHelp("q", "quit anyway")),
+}
+
+// gameLaunch and envEdit are keys.Select with a hint that fits the view.
+var (
+	gameLaunch = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "launch"))
+	envEdit    = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "edit"))
+)
+
+// filterKeep and filterRevert end typing a log filter pattern.
+var (
+	filterKeep   = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "keep filter"))
+	filterRevert = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "undo"))
+)
+
+// bindingsFor lists the bindings active in a view state, in footer order.
+// A popup sits above any state and has its own set.
+func bindingsFor(state viewState, p popup) []key.Binding {
+	switch p {
+	case popupHistory:
+		return []key.Binding{keys.History, keys.Back, keys.ForceQuit}
+	case popupLastError:
+		return []key.Binding{keys.LastError, keys.CopyIssue, keys.ClearErrors, keys.Back, keys.ForceQuit}
+	}
+	switch state {
+	case stateConfirm:
+		return []key.Binding{keys.Confirm, keys.Cancel}
+	case stateEscalate:
+		return []key.Binding{keys.Rerun, keys.Cancel}
+	case stateForceKill:
+		return []key.Binding{keys.Confirm, keys.Cancel}
+	case statePhrase:
+		return []key.Binding{phraseSubmit, phraseCancel}
+	case stateInteractive:
+		return []key.Binding{keys.RunInTerminal, keys.Cancel}
+	case stateSubmenu:
+		return []key.Binding{keys.Up, keys.Down, keys.Select, keys.Back, keys.ForceQuit}
+	case stateQuitUpdate:
+		return []key.Binding{keys.CancelAndQuit, keys.QuitAnyway, keys.Back}
+	case stateEnv:
+		return []key.Binding{keys.Up, keys.Down, envEdit, keys.AddEnv, keys.RemoveEnv, keys.Back, keys.ForceQuit}
+	case stateLibrary:
+		return []key.Binding{keys.Up, keys.Down, libraryAdd, libraryRemove, keys.Back, keys.ForceQuit}
+	case stateSplitLogs:
+		return []key.Binding{keys.Scroll, splitFocus, splitReload, keys.Back, keys.ForceQuit}
+	case stateRename:
+		return []key.Binding{renameSubmit, renameCancel, keys.ForceQuit}
+	case stateCrash:
+		return []key.Binding{crashView, keys.Back, keys.ForceQuit}
+	case stateCrashReport:
+		return []key.Binding{keys.Scroll, keys.Back, keys.ForceQuit}
+	case stateImport:
+		return []key.Binding{importSubmit, importCancel, keys.ForceQuit}
+	case stateGames:
+		return []key.Binding{keys.Up, keys.Down, gameLaunch, keys.Mark, keys.Favorite, keys.Uninstall, keys.Verify, keys.Filter, keys.Back, keys.ForceQuit}
+	case stateRunning:
+		return []key.Binding{keys.StopBatch, keys.Scroll, keys.LineNumbers, keys.Timestamps, keys.Include, keys.CopyTail, keys.History, keys.LastError, keys.ForceQuit}
+	default:
+		return []key.Binding{keys.Up, keys.Down, keys.Select, keys.Copy, keys.Refresh, keys.ToggleHidden, keys.Layout, keys.ANSI, keys.PageUp, keys.PageDown, keys.LineNumbers, keys.Timestamps, keys.Include, keys.CopyTail, keys.History, keys.LastError, keys.Detach, keys.Quit}
+	}
+}
+
+// footerText renders the hints of the bindings that carry help text.
+func footerText(bindings []key.Binding) string {
+	var parts []string
+	for _, b := range bindings {
+		h := b.Help()
+		if h.Key == "" || !b.Enabled() {
+			continue
+		}
+		parts = append(parts, h.Key+" "+h.Desc)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// footerWithBadge puts badge in front of the key hints.
+func footerWithBadge(badge, hints string) string {
+	if badge == "" {
+		return hints
+	}
+	return badge + "  " + hints
+}
+
+// footerBindings is bindingsFor, or the filter's keys while a pattern
+// is being typed.
+func (m model) footerBindings() []key.Binding {
+	if m.logFilter.editing != filterNone && m.popup == popupNone && (m.state == stateMenu || m.state == stateRunning) {
+		return []key.Binding{filterKeep, filterRevert, keys.ForceQuit}
+	}
+	return bindingsFor(m.state, m.popup)
+}
+
+func (m model) renderFooter() string {
+	return lipgloss.NewStyle().
+		Foreground(colDim).
+		Background(colBg).
+		Width(m.width).
+		Padding(0, 1).
+		MaxHeight(1).
+		Render(footerWithBadge(m.errorBadge(), footerText(m.footerBindings())))
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Key handling
+// ─────────────────────────────────────────────────────────────────
+
+func (m *model) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch m.popup {
+	case popupHistory:
+		return m.handleHistoryKey(msg)
+	case popupLastError:
+		return m.handleLastErrorKey(msg)
+	}
+	if m.guardedKey(msg) {
+		return nil
+	}
+	if m.logFilter.editing != filterNone && (m.state == stateMenu || m.state == stateRunning) {
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.handleFilterKey(msg)
+	}
+	switch m.state {
+	case stateConfirm:
+		return m.handleConfirmKey(msg)
+	case stateEscalate:
+		return m.handleEscalateKey(msg)
+	case stateForceKill:
+		return m.handleForceKillKey(msg)
+	case statePhrase:
+		return m.handlePhraseKey(msg)
+	case stateInteractive:
+		return m.handleInteractiveKey(msg)
+	case stateSubmenu:
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.updateSubmenu(msg)
+	case stateGames:
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.updateGames(msg)
+	case stateEnv:
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.updateEnvEditor(msg)
+	case stateLibrary:
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.updateLibrary(msg)
+	case stateSplitLogs:
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.updateSplitLogs(msg)
+	case stateRename:
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.handleRenameKey(msg)
+	case stateCrash, stateCrashReport:
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.handleCrashKey(msg)
+	case stateImport:
+		if key.Matches(msg, keys.ForceQuit) {
+			return m.requestQuit()
+		}
+		return m.handleImportKey(msg)
+	case stateQuitUpdate:
+		return m.handleQuitUpdateKey(msg)
+	case stateRunning:
+		switch {
+		case key.Matches(msg, keys.ForceQuit):
+			return m.requestQuit()
+		case key.Matches(msg, keys.StopBatch):
+			return m.stopBatch()
+		case key.Matches(msg, keys.LineNumbers, keys.Timestamps):
+			m.toggleLogPrefix(msg)
+			return nil
+		case key.Matches(msg, keys.Include):
+			m.startFilter(filterInclude)
+			return nil
+		case key.Matches(msg, keys.Exclude):
+			m.startFilter(filterExclude)
+			return nil
+		case key.Matches(msg, keys.CopyTail):
+			return m.copyLogTail()
+		case key.Matches(msg, keys.History):
+			m.popup = popupHistory
+			return nil
+		case key.Matches(msg, keys.LastError):
+			m.popup = popupLastError
+			return nil
+		}
+		if i, ok := shortcutItem(msg); ok {
+			return m.handleRunningShortcut(i)
+		}
+		return m.updateViewport(msg)
+	default:
+		return m.handleMenuKey(msg)
+	}
+}
+
+func (m *model) handleMenuKey(msg tea.KeyMsg) tea.Cmd {
+	if n, ok := favoriteKey(msg); ok {
+		return m.launchFavorite(n)
+	}
+	if i, ok := shortcutItem(msg); ok {
+		if !m.selectable(i) {
+			return m.showToast(menuItems[i].label + " unavailable: " + m.disabledReason(menuItems[i]))
+		}
+		m.setCursor(i)
+		return m.selectItem(menuItems[i], menuAction(i))
+	}
+	switch {
+	case key.Matches(msg, keys.Quit):
+		return m.requestQuit()
+	case key.Matches(msg, keys.Up):
+		if gridActive(m.width) {
+			m.moveGrid(0, -1)
+		} else {
+			m.moveCursor(-1)
+		}
+	case key.Matches(msg, keys.Down):
+		if gridActive(m.width) {
+			m.moveGrid(0, 1)
+		} else {
+			m.moveCursor(1)
+		}
+	case key.Matches(msg, keys.Left):
+		if gridActive(m.width) {
+			m.moveGrid(-1, 0)
+		}
+	case key.Matches(msg, keys.Right):
+		if gridActive(m.width) {
+			m.moveGrid(1, 0)
+		}
+	case key.Matches(msg, keys.ANSI):
+		if configLocked("ansi_colors") {
+			return m.showToast("Output colors are set by the system config")
+		}
+		cfg.ANSIColors = !cfg.ANSIColors
+		if cfg.ANSIColors {
+			return m.showToast("Output colors shown as printed")
+		}
+		return m.showToast("Output colors stripped")
+	case key.Matches(msg, keys.Layout):
+		if configLocked("layout") {
+			return m.showToast("The layout is set by the system config")
+		}
+		if cfg.Layout == "grid" {
+			cfg.Layout = "list"
+		} else {
+			cfg.Layout = "grid"
+		}
+		m.layoutLog()
+		m.redrawLog()
+	case key.Matches(msg, keys.Select):
+		return m.selectItem(menuItems[m.cursor], menuAction(m.cursor))
+	case key.Matches(msg, keys.Refresh):
+		return checkStatusCmd()
+	case key.Matches(msg, keys.Copy):
+		return m.copyAction(menuItems[m.cursor], menuAction(m.cursor))
+	case key.Matches(msg, keys.PageUp, keys.PageDown):
+		return m.updateViewport(msg)
+	case key.Matches(msg, keys.LineNumbers, keys.Timestamps):
+		m.toggleLogPrefix(msg)
+	case key.Matches(msg, keys.Include):
+		m.startFilter(filterInclude)
+	case key.Matches(msg, keys.Exclude):
+		m.startFilter(filterExclude)
+	case key.Matches(msg, keys.CopyTail):
+		return m.copyLogTail()
+	case key.Matches(msg, keys.History):
+		m.popup = popupHistory
+	case key.Matches(msg, keys.LastError):
+		m.popup = popupLastError
+	case key.Matches(msg, keys.ToggleHidden):
+		if configLocked("hide_disabled") {
+			return m.showToast("Showing unavailable actions is set by the system config")
+		}
+		cfg.HideDisabled = !cfg.HideDisabled
+		if cfg.HideDisabled {
+			return m.showToast("Hiding unavailable actions")
+		}
+		return m.showToast("Showing unavailable actions dimmed")
+	case key.Matches(msg, keys.Detach):
+		if m.busy {
+			return m.showToast("Wait for the running action before detaching")
+		}
+		return m.detach()
+	}
+	return nil
+}
+
+func (m *model) handleConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Confirm):
+		item := m.pendingItem
+		m.pendingItem = nil
+		m.state = stateMenu
+		return m.dispatchAction(*item, m.pendingAction)
+	case key.Matches(msg, keys.Cancel):
+		m.pendingItem = nil
+		m.state = stateMenu
+		m.appendLog(styleLogDim.Render("  Aborted."))
+	default:
+		m.resetPromptTimer()
+	}
+	return nil
+}
+
+func (m *model) handleEscalateKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Rerun):
+		item := m.pendingItem
+		m.pendingItem = nil
+		m.state = stateMenu
+		return m.dispatchEscalated(*item, m.pendingAction)
+	case key.Matches(msg, keys.Cancel):
+		m.pendingItem = nil
+		m.state = stateMenu
+		m.appendLog(styleLogDim.Render("  Not re-running with elevated privileges."))
+	default:
+		m.resetPromptTimer()
+	}
+	return nil
+}
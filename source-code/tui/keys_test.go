@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+func TestFooterFollowsState(t *testing.T) {
+	tests := []struct {
+		state   viewState
+		want    []string
+		notWant []string
+	}{
+		{stateMenu, []string{"↑/↓ navigate", "enter select", "c copy cmd", "q quit"}, []string{"y confirm", "esc back"}},
+		{stateConfirm, []string{"y confirm", "n/esc cancel"}, []string{"enter select", "q quit"}},
+		{stateEscalate, []string{"y re-run elevated", "n/esc cancel"}, []string{"y confirm"}},
+		{stateSubmenu, []string{"↑/↓ navigate", "enter select", "esc back", "ctrl+c quit"}, []string{"c copy cmd"}},
+		{stateRunning, []string{"↑/↓ scroll log", "ctrl+c quit"}, []string{"enter select"}},
+	}
+	for _, tt := range tests {
+		got := footerText(bindingsFor(tt.state, popupNone))
+		for _, s := range tt.want {
+			if !strings.Contains(got, s) {
+				t.Errorf("state %v: footer %q lacks %q", tt.state, got, s)
+			}
+		}
+		for _, s := range tt.notWant {
+			if strings.Contains(got, s) {
+				t.Errorf("state %v: footer %q shows %q", tt.state, got, s)
+			}
+		}
+	}
+}
+
+func TestFooterSkipsHiddenBindings(t *testing.T) {
+	disabled := key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "hidden"), key.WithDisabled())
+	noHelp := key.NewBinding(key.WithKeys("z"))
+	shown := key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "act"))
+	if got := footerText([]key.Binding{disabled, noHelp, shown, shown}); got != "a act • a act" {
+		t.Errorf("footerText = %q", got)
+	}
+}
+
+func TestFooterInHistoryPopup(t *testing.T) {
+	for _, state := range []viewState{stateMenu, stateRunning} {
+		got := footerText(bindingsFor(state, popupHistory))
+		if got != "h history • esc back • ctrl+c quit" {
+			t.Errorf("state %v: history footer = %q", state, got)
+		}
+	}
+}
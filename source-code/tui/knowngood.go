@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Known-good configuration
+//  When Steam launches or the container updates successfully, the
+//  container's settings — launch.toml and container.env — are copied
+//  to known-good/ in the state directory. "Roll Back Config" puts that
+//  copy back after a change broke something. Only these two files are
+//  kept, never the container or the Steam data.
+// ─────────────────────────────────────────────────────────────────
+
+const knownGoodMetaName = "snapshot.toml"
+
+// knownGoodFile is a settings file covered by the snapshot.
+type knownGoodFile struct {
+	name string
+	path string // the live file
+}
+
+// knownGoodMeta describes a snapshot. Present lists the files that
+// existed; the others are removed on restore.
+type knownGoodMeta struct {
+	Taken   time.Time `toml:"taken"`
+	Action  string    `toml:"action"`
+	Present []string  `toml:"present"`
+}
+
+func knownGoodDir() string {
+	return filepath.Join(stateDir(), "known-good")
+}
+
+func knownGoodFiles() []knownGoodFile {
+	return []knownGoodFile{
+		{launchFileName, launchPath()},
+		{containerEnvFileName, containerEnvPath()},
+	}
+}
+
+// isKnownGoodItem reports whether a successful run of item vouches for
+// the settings it ran with.
+func isKnownGoodItem(item *menuItem) bool {
+	switch {
+	case item == nil:
+		return false
+	case isUpdateItem(item), isTestLaunchItem(item):
+		return true
+	}
+	return actionName(*item) == "run"
+}
+
+// saveKnownGood copies files into dir and records when and after what.
+func saveKnownGood(dir string, files []knownGoodFile, at time.Time, action string) error {
+	meta := knownGoodMeta{Taken: at, Action: action}
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			continue
+		case err != nil:
+			return err
+		}
+		if err := writeFileAtomic(filepath.Join(dir, f.name), data, 0o600); err != nil {
+			return err
+		}
+		meta.Present = append(meta.Present, f.name)
+	}
+	var b bytes.Buffer
+	if err := toml.NewEncoder(&b).Encode(meta); err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, knownGoodMetaName), b.Bytes(), 0o600)
+}
+
+// readKnownGood returns the snapshot in dir; fs.ErrNotExist when none
+// has been taken.
+func readKnownGood(dir string) (knownGoodMeta, error) {
+	var meta knownGoodMeta
+	_, err := toml.DecodeFile(filepath.Join(dir, knownGoodMetaName), &meta)
+	return meta, err
+}
+
+// restoreKnownGood puts the snapshot in dir back over files. Every
+// copy is read before anything is written, so a damaged snapshot
+// leaves the live files alone.
+func restoreKnownGood(dir string, files []knownGoodFile) (knownGoodMeta, error) {
+	meta, err := readKnownGood(dir)
+	if err != nil {
+		return meta, err
+	}
+	present := map[string]bool{}
+	for _, name := range meta.Present {
+		present[name] = true
+	}
+	saved := map[string][]byte{}
+	for _, f := range files {
+		if !present[f.name] {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.name))
+		if err != nil {
+			return meta, fmt.Errorf("snapshot is incomplete: %w", err)
+		}
+		saved[f.name] = data
+	}
+	for _, f := range files {
+		if data, ok := saved[f.name]; ok {
+			err = writeFileAtomic(f.path, data, 0o644)
+		} else if err = os.Remove(f.path); errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+		if err != nil {
+			return meta, err
+		}
+	}
+	return meta, nil
+}
+
+// recordKnownGood takes the snapshot after a successful run. Failing
+// to is only worth a dim line: the run itself went fine.
+func (m *model) recordKnownGood() {
+	if err := saveKnownGood(knownGoodDir(), knownGoodFiles(), time.Now(), m.lastItem.label); err != nil {
+		m.appendLog(styleLogDim.Render("  Known-good configuration not saved: " + err.Error()))
+	}
+}
+
+// rollbackConfig is the "Roll Back Config" action: it asks first,
+// naming the snapshot's age.
+func (m *model) rollbackConfig() tea.Cmd {
+	meta, err := readKnownGood(knownGoodDir())
+	if errors.Is(err, fs.ErrNotExist) {
+		return m.showToast("No known-good configuration yet: launch Steam once")
+	}
+	if err != nil {
+		m.logError("Could not read the known-good configuration: " + err.Error())
+		return nil
+	}
+	files := "no settings files"
+	if len(meta.Present) > 0 {
+		files = strings.Join(meta.Present, " and ")
+	}
+	return m.openPrompt(stateConfirm, menuItem{
+		label: "Roll Back Config",
+		run:   (*model).applyRollback,
+		warning: []string{
+			fmt.Sprintf("Restores %s as of %s,", files, meta.Taken.Local().Format("2006-01-02 15:04")),
+			"saved after " + meta.Action + " succeeded.",
+			"Changes made since then are lost.",
+		},
+	})
+}
+
+func (m *model) applyRollback() tea.Cmd {
+	meta, err := restoreKnownGood(knownGoodDir(), knownGoodFiles())
+	if err != nil {
+		m.logError("Roll back failed: " + err.Error())
+		return nil
+	}
+	if s, err := loadLaunch(launchPath()); err == nil {
+		launch = s
+	}
+	if vars, err := readEnvFile(containerEnvPath()); err == nil {
+		persistentEnv = vars
+	}
+	m.appendLog(styleLogSuccess.Render("  ✔  Configuration rolled back to " + meta.Taken.Local().Format("2006-01-02 15:04") + "."))
+	return m.showToast("Configuration rolled back")
+}
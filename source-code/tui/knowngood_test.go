@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestIsKnownGoodItem(t *testing.T) {
+	tests := []struct {
+		label string
+		want  bool
+	}{
+		{"Launch Steam", true},
+		{"Big Picture Mode", true},
+		{"Test Launch", true},
+		{"Update Container", true},
+		{"Setup / Repair Steam", false},
+		{"Container Status", false},
+		{"Roll Back Config", false},
+	}
+	for _, tt := range tests {
+		if got := isKnownGoodItem(&menuItems[itemIndex(t, tt.label)]); got != tt.want {
+			t.Errorf("isKnownGoodItem(%q) = %v, want %v", tt.label, got, tt.want)
+		}
+	}
+	if isKnownGoodItem(nil) {
+		t.Error("isKnownGoodItem(nil) = true")
+	}
+}
+
+// knownGoodFixture is two live settings files, the second absent.
+func knownGoodFixture(t *testing.T) (dir string, files []knownGoodFile) {
+	t.Helper()
+	live := t.TempDir()
+	files = []knownGoodFile{
+		{"launch.toml", filepath.Join(live, "launch.toml")},
+		{"container.env", filepath.Join(live, "container.env")},
+	}
+	if err := os.WriteFile(files[0].path, []byte("gpu = \"nvidia\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(t.TempDir(), "known-good"), files
+}
+
+func TestSaveAndRestoreKnownGood(t *testing.T) {
+	dir, files := knownGoodFixture(t)
+	if _, err := readKnownGood(dir); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("readKnownGood before any snapshot: %v, want ErrNotExist", err)
+	}
+	at := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	if err := saveKnownGood(dir, files, at, "Launch Steam"); err != nil {
+		t.Fatalf("saveKnownGood: %v", err)
+	}
+	meta, err := readKnownGood(dir)
+	if err != nil || !meta.Taken.Equal(at) || meta.Action != "Launch Steam" || strings.Join(meta.Present, ",") != "launch.toml" {
+		t.Fatalf("readKnownGood = %+v, %v", meta, err)
+	}
+
+	// Break both files: one changed, one created since.
+	os.WriteFile(files[0].path, []byte("gpu = \"broken\"\n"), 0o644)
+	os.WriteFile(files[1].path, []byte("DXVK_HUD=1\n"), 0o644)
+	if _, err := restoreKnownGood(dir, files); err != nil {
+		t.Fatalf("restoreKnownGood: %v", err)
+	}
+	if data, _ := os.ReadFile(files[0].path); string(data) != "gpu = \"nvidia\"\n" {
+		t.Errorf("launch.toml restored as %q", data)
+	}
+	if _, err := os.Stat(files[1].path); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("container.env absent from the snapshot was kept: %v", err)
+	}
+}
+
+func TestRestoreKnownGoodIncomplete(t *testing.T) {
+	dir, files := knownGoodFixture(t)
+	if err := saveKnownGood(dir, files, time.Now(), "Launch Steam"); err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(filepath.Join(dir, "launch.toml"))
+	os.WriteFile(files[1].path, []byte("DXVK_HUD=1\n"), 0o644)
+	if _, err := restoreKnownGood(dir, files); err == nil || !strings.Contains(err.Error(), "incomplete") {
+		t.Fatalf("restore from a damaged snapshot: %v, want incomplete", err)
+	}
+	if _, err := os.Stat(files[1].path); err != nil {
+		t.Errorf("a failed restore touched container.env: %v", err)
+	}
+}
+
+func TestKnownGoodRecordedAndRolledBack(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.rollbackConfig()
+	if m.state == stateConfirm || !strings.Contains(m.toast, "No known-good") {
+		t.Fatalf("roll back without a snapshot: state %v, toast %q", m.state, m.toast)
+	}
+
+	if err := writeFileAtomic(launchPath(), []byte("gpu = \"nvidia\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.lastItem = &menuItems[itemIndex(t, "Launch Steam")]
+	next, _ := m.Update(cmdDoneMsg(true))
+	m = next.(model)
+	if _, err := readKnownGood(knownGoodDir()); err != nil {
+		t.Fatalf("no snapshot after a successful launch: %v", err)
+	}
+
+	os.WriteFile(launchPath(), []byte("gpu = \"broken\"\n"), 0o644)
+	m.rollbackConfig()
+	if m.state != stateConfirm || !strings.Contains(strings.Join(m.pendingItem.warning, " "), "launch.toml") {
+		t.Fatalf("roll back did not ask first (state %v)", m.state)
+	}
+	next, _ = settle(m).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = next.(model)
+	if data, _ := os.ReadFile(launchPath()); string(data) != "gpu = \"nvidia\"\n" {
+		t.Errorf("launch.toml after roll back = %q", data)
+	}
+	if countLogged(m, "Configuration rolled back") != 1 {
+		t.Error("roll back not logged")
+	}
+}
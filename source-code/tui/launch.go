@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Launch settings
+//  How Steam is started — on which GPU, inside gamescope with which
+//  options, with or without MangoHud — and the mirror updates download
+//  from are chosen from the TUI and kept in launch.toml next to
+//  config.toml. The TUI rewrites the file on every change, so it holds
+//  nothing but these settings.
+// ─────────────────────────────────────────────────────────────────
+
+const launchFileName = "launch.toml"
+
+type launchSettings struct {
+	// GPU is the PCI slot ("0000:01:00.0") Steam runs on; empty leaves
+	// the choice to the drivers.
+	GPU string `toml:"gpu"`
+
+	// Mirror is the pacman server Update Container tries first; empty
+	// keeps the mirrorlist order.
+	Mirror string `toml:"mirror"`
+
+	Gamescope gamescopeSettings `toml:"gamescope"`
+	MangoHud  mangohudSettings  `toml:"mangohud"`
+}
+
+var launch launchSettings
+
+func launchPath() string {
+	return filepath.Join(configDir(), launchFileName)
+}
+
+// loadLaunch reads path; a missing file is not an error.
+func loadLaunch(path string) (launchSettings, error) {
+	var s launchSettings
+	if _, err := toml.DecodeFile(path, &s); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return launchSettings{}, nil
+		}
+		return launchSettings{}, fmt.Errorf("launch settings %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func writeLaunch(path string, s launchSettings) error {
+	var b bytes.Buffer
+	if err := toml.NewEncoder(&b).Encode(s); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, b.Bytes(), 0o644)
+}
+
+// saveLaunch writes s and makes it current; a failure is logged and
+// the current settings are kept.
+func (m *model) saveLaunch(s launchSettings) error {
+	if err := writeLaunch(launchPath(), s); err != nil {
+		m.logError("Could not save launch settings: " + err.Error())
+		return err
+	}
+	launch = s
+	m.refreshLaunchSummary()
+	return nil
+}
+
+// launchEnv is the environment added to every Steam launch.
+func launchEnv() []string {
+	return append(gpuLaunchEnv(), mangohudEnv(launch.MangoHud)...)
+}
+
+func gpuLaunchEnv() []string {
+	if launch.GPU == "" {
+		return nil
+	}
+	for _, g := range detectGPUs(drmSysfs) {
+		if g.slot == launch.GPU {
+			return gpuEnv(g)
+		}
+	}
+	// The GPU is gone (an unplugged eGPU, say): use the default.
+	return nil
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Launch summary
+//  In the list menu a dim row under the header of the launch section
+//  sums up what a launch gets beyond the defaults: "GE-Proton 9 •
+//  NVIDIA • MangoHud • 2 env vars". Gamescope options only apply to
+//  Launch in Gamescope, so they get their own row under the Gamescope
+//  item: "Gamescope: native res, HDR". Both are rebuilt whenever one
+//  of those settings is changed from the TUI.
+// ─────────────────────────────────────────────────────────────────
+
+// launchSummary lists the effective launch options; proton is the
+// default compatibility tool, gpuName the vendor of the chosen GPU.
+func launchSummary(s launchSettings, proton, gpuName string, envVars int) string {
+	var parts []string
+	if proton != "" {
+		parts = append(parts, protonLabel(proton))
+	}
+	if gpuName != "" {
+		parts = append(parts, gpuName)
+	}
+	if s.MangoHud.Enabled {
+		parts = append(parts, "MangoHud")
+	}
+	switch {
+	case envVars == 1:
+		parts = append(parts, "1 env var")
+	case envVars > 1:
+		parts = append(parts, fmt.Sprintf("%d env vars", envVars))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// gamescopeSummary is what Launch in Gamescope runs with, "" while no
+// gamescope option is set. gamescopeArgs asks for no size, so gamescope
+// takes the display's own.
+func gamescopeSummary(s gamescopeSettings) string {
+	if !s.HDR {
+		return ""
+	}
+	return "Gamescope: native res, HDR"
+}
+
+// protonLabel shortens GE-Proton9-20 to "GE-Proton 9"; other names are
+// kept.
+func protonLabel(name string) string {
+	m := reGEProton.FindStringSubmatch(name)
+	switch {
+	case m == nil:
+		return name
+	case m[1] != "":
+		return "GE-Proton " + m[1]
+	}
+	return "GE-Proton " + m[3]
+}
+
+// selectedGPUName is the vendor of the GPU launches are pinned to, ""
+// when the drivers choose or it is gone.
+func selectedGPUName() string {
+	if launch.GPU == "" {
+		return ""
+	}
+	for _, g := range detectGPUs(drmSysfs) {
+		if g.slot == launch.GPU {
+			if name := gpuVendors[g.vendor]; name != "" {
+				return name
+			}
+			return "GPU " + g.vendor
+		}
+	}
+	return ""
+}
+
+// refreshLaunchSummary rebuilds the summary from the current settings.
+// Steam's config.vdf is on this machine only, so --remote leaves the
+// Proton part out.
+func (m *model) refreshLaunchSummary() {
+	proton := ""
+	if remoteHost == "" {
+		if data, err := os.ReadFile(steamConfigPath()); err == nil {
+			proton = globalCompatTool(string(data))
+		}
+	}
+	m.launchSummary = launchSummary(launch, proton, selectedGPUName(), len(persistentEnv))
+	m.gamescopeSummary = gamescopeSummary(launch.Gamescope)
+}
+
+// isLaunchSection is true for the section of Launch Steam's item.
+func isLaunchSection(section string) bool {
+	for i, item := range menuItems {
+		if actionName(item) == "run" {
+			return sectionOf(i) == section
+		}
+	}
+	return false
+}
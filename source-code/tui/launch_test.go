@@ -0,0 +1,186 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLaunchSettingsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg", launchFileName)
+	if s, err := loadLaunch(path); err != nil || s != (launchSettings{}) {
+		t.Fatalf("missing file: %+v, %v", s, err)
+	}
+
+	want := launchSettings{GPU: "0000:01:00.0"}
+	want.Gamescope.HDR = true
+	if err := writeLaunch(path, want); err != nil {
+		t.Fatalf("writeLaunch: %v", err)
+	}
+	got, err := loadLaunch(path)
+	if err != nil {
+		t.Fatalf("loadLaunch: %v", err)
+	}
+	if got != want {
+		t.Errorf("loaded %+v, want %+v", got, want)
+	}
+
+	os.WriteFile(path, []byte("gpu = [\n"), 0o644)
+	if _, err := loadLaunch(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("malformed file: err = %v", err)
+	}
+}
+
+func TestSaveLaunchFailureKeepsCurrent(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "not-a-dir"))
+	os.WriteFile(filepath.Join(home, "not-a-dir"), nil, 0o644)
+
+	launch = launchSettings{GPU: "0000:00:02.0"}
+	m := initialModel()
+	if err := m.saveLaunch(launchSettings{GPU: "0000:01:00.0"}); err == nil {
+		t.Fatal("saveLaunch into a file path succeeded")
+	}
+	if launch.GPU != "0000:00:02.0" {
+		t.Errorf("failed save changed the current GPU to %q", launch.GPU)
+	}
+	if countLogged(m, "Could not save launch settings") != 1 {
+		t.Error("failure not logged")
+	}
+}
+
+func TestLaunchEnv(t *testing.T) {
+	defer func(saved launchSettings, env []envVar) { launch, persistentEnv = saved, env }(launch, persistentEnv)
+	persistentEnv = []envVar{{name: "PROTON_LOG", value: "1"}}
+
+	// A GPU that is not present falls back to the drivers' default.
+	launch = launchSettings{GPU: "ffff:ff:1f.7"}
+	if env := launchEnv(); env != nil {
+		t.Errorf("launchEnv for a missing GPU = %q", env)
+	}
+	launch = launchSettings{}
+	if env := launchEnv(); env != nil {
+		t.Errorf("launchEnv with no GPU chosen = %q", env)
+	}
+
+	run := menuItems[itemIndex(t, "Launch Steam")]
+	if got := actionEnv(run); len(got) == 0 || got[0] != "PROTON_LOG=1" {
+		t.Errorf("actionEnv(run) = %q", got)
+	}
+}
+
+func TestLaunchSummary(t *testing.T) {
+	var hdr, mango, both launchSettings
+	hdr.Gamescope.HDR = true
+	mango.MangoHud.Enabled = true
+	both.Gamescope.HDR, both.MangoHud.Enabled = true, true
+	tests := []struct {
+		name    string
+		s       launchSettings
+		proton  string
+		gpu     string
+		envVars int
+		want    string
+	}{
+		{"defaults", launchSettings{}, "", "", 0, ""},
+		{"proton", launchSettings{}, "GE-Proton9-20", "", 0, "GE-Proton 9"},
+		{"gpu", launchSettings{}, "", "NVIDIA", 0, "NVIDIA"},
+		{"hdr is gamescope's", hdr, "", "", 0, ""},
+		{"mangohud", mango, "", "", 0, "MangoHud"},
+		{"one env var", launchSettings{}, "", "", 1, "1 env var"},
+		{"everything", both, "proton_9", "AMD", 3, "proton_9 • AMD • MangoHud • 3 env vars"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := launchSummary(tt.s, tt.proton, tt.gpu, tt.envVars); got != tt.want {
+				t.Errorf("launchSummary = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGamescopeSummary(t *testing.T) {
+	tests := []struct {
+		s    gamescopeSettings
+		want string
+	}{
+		{gamescopeSettings{}, ""},
+		{gamescopeSettings{HDR: true}, "Gamescope: native res, HDR"},
+	}
+	for _, tt := range tests {
+		if got := gamescopeSummary(tt.s); got != tt.want {
+			t.Errorf("gamescopeSummary(%+v) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestProtonLabel(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"GE-Proton9-20", "GE-Proton 9"},
+		{"GE-Proton10-1", "GE-Proton 10"},
+		{"Proton-6.21-GE-2", "GE-Proton 6"},
+		{"proton_experimental", "proton_experimental"},
+	}
+	for _, tt := range tests {
+		if got := protonLabel(tt.name); got != tt.want {
+			t.Errorf("protonLabel(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLaunchSummaryRow(t *testing.T) {
+	defer func(saved launchSettings, env []envVar) { launch, persistentEnv = saved, env }(launch, persistentEnv)
+	defer func(saved config) { cfg = saved }(cfg)
+	t.Setenv("HOME", t.TempDir())
+	cfg.Layout = "list"
+	launch, persistentEnv = launchSettings{}, nil
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	if m.launchSummary != "" {
+		t.Fatalf("summary %q with nothing set", m.launchSummary)
+	}
+	if !isLaunchSection(sectionOf(itemIndex(t, "Launch Steam"))) || isLaunchSection("INFO") {
+		t.Error("isLaunchSection picks the wrong section")
+	}
+
+	persistentEnv = []envVar{{name: "PROTON_LOG", value: "1"}, {name: "DXVK_HUD", value: "fps"}}
+	launch.Gamescope.HDR = true
+	m.refreshLaunchSummary()
+	rows, _ := m.menuRows(sidebarWidth)
+	under := map[string]string{"2 env vars": "STEAM", "Gamescope: native res, HDR": "Gamescope"}
+	for i, row := range rows {
+		for summary, above := range under {
+			if !strings.Contains(stripANSI(row), summary) {
+				continue
+			}
+			if i == 0 || !strings.Contains(stripANSI(rows[i-1]), above) {
+				t.Errorf("%q on row %d, not under %s", summary, i, above)
+			}
+			delete(under, summary)
+		}
+	}
+	if len(under) != 0 {
+		t.Errorf("summary rows %v missing:\n%s", under, stripANSI(strings.Join(rows, "\n")))
+	}
+
+	cfg.Layout = "grid"
+	_, withSummary := m.logSize()
+	grid := stripANSI(m.renderGrid())
+	if !strings.Contains(grid, "2 env vars • Gamescope: native res, HDR") {
+		t.Errorf("grid has no summary row:\n%s", grid)
+	}
+	if h := lipgloss.Height(m.renderGrid()); h != m.gridHeight() {
+		t.Errorf("grid is %d rows, gridHeight %d", h, m.gridHeight())
+	}
+	launch, persistentEnv = launchSettings{}, nil
+	m.refreshLaunchSummary()
+	if _, h := m.logSize(); h != withSummary+1 {
+		t.Errorf("log height %d after the summary went, want %d", h, withSummary+1)
+	}
+}
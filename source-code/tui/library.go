@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Library folders
+//  Steam's library folders, from steamapps/libraryfolders.vdf, with
+//  the free space on each. Folders are added and removed through
+//  `hackeros-steam library add|remove PATH`, which edits the file
+//  while Steam isn't running; a removed folder's games stay on disk.
+// ─────────────────────────────────────────────────────────────────
+
+// libraryRoots are the places outside $HOME that distrobox shares
+// with the container.
+var libraryRoots = []string{"/mnt", "/media", "/run/media"}
+
+type libraryFolder struct {
+	path      string
+	games     int
+	free      uint64
+	freeKnown bool
+}
+
+func libraryFoldersPath() string {
+	return filepath.Join(steamappsDir(), "libraryfolders.vdf")
+}
+
+// parseLibraryFolders reads the folders of a libraryfolders.vdf in
+// file order; the default library comes first.
+func parseLibraryFolders(r io.Reader) ([]libraryFolder, error) {
+	var folders []libraryFolder
+	depth := 0
+	inApps := false
+	pending := ""
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch line {
+		case "{":
+			depth++
+			if depth == 2 {
+				folders = append(folders, libraryFolder{})
+			}
+			inApps = depth == 3 && strings.EqualFold(pending, "apps")
+			continue
+		case "}":
+			depth--
+			inApps = false
+			continue
+		}
+		if strings.Count(line, `"`) == 2 {
+			pending = strings.Trim(line, `"`)
+			continue
+		}
+		k, v, ok := vdfPair(line)
+		if !ok || len(folders) == 0 {
+			continue
+		}
+		f := &folders[len(folders)-1]
+		switch {
+		case depth == 2 && strings.EqualFold(k, "path"):
+			f.path = strings.ReplaceAll(v, `\\`, `\`)
+		case inApps:
+			f.games++
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	out := folders[:0]
+	for _, f := range folders {
+		if f.path != "" {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// loadLibraryFolders returns the folders with their free space. Before
+// Steam's first start there is only the default library.
+func loadLibraryFolders() ([]libraryFolder, error) {
+	folders := []libraryFolder{{path: steamDir()}}
+	f, err := os.Open(libraryFoldersPath())
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		parsed, err := parseLibraryFolders(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tildePath(libraryFoldersPath()), err)
+		}
+		if len(parsed) > 0 {
+			folders = parsed
+		}
+	}
+	for i := range folders {
+		var st syscall.Statfs_t
+		if syscall.Statfs(folders[i].path, &st) == nil {
+			folders[i].free = st.Bavail * uint64(st.Bsize)
+			folders[i].freeKnown = true
+		}
+	}
+	return folders, nil
+}
+
+// validateLibraryPath checks a folder typed in to be added; the CLI
+// checks again.
+func validateLibraryPath(path string, folders []libraryFolder) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("type the folder's path")
+	}
+	if rest, ok := strings.CutPrefix(path, "~"); ok && (rest == "" || rest[0] == '/') {
+		home, _ := os.UserHomeDir()
+		path = home + rest
+	}
+	if !filepath.IsAbs(path) {
+		return "", errors.New("use an absolute path, e.g. /mnt/games/SteamLibrary")
+	}
+	path = filepath.Clean(path)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("%s doesn't exist", path)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", path)
+	}
+	if syscall.Access(path, 2) != nil { // W_OK
+		return "", fmt.Errorf("%s is not writable", path)
+	}
+	home, _ := os.UserHomeDir()
+	visible := home != "" && strings.HasPrefix(path, home+"/")
+	for _, root := range libraryRoots {
+		visible = visible || strings.HasPrefix(path, root+"/")
+	}
+	if !visible {
+		return "", fmt.Errorf("the container can't see %s; use a folder under ~, %s", path, strings.Join(libraryRoots, ", "))
+	}
+	for _, f := range folders {
+		if filepath.Clean(f.path) == path {
+			return "", fmt.Errorf("%s is already a library folder", path)
+		}
+	}
+	return path, nil
+}
+
+func libraryAddItem(path string) menuItem {
+	return menuItem{icon: "+", label: "Add Library Folder", cmd: []string{"library", "add", path}, requires: reqExists}
+}
+
+func libraryRemoveItem(path string) menuItem {
+	return menuItem{icon: "✕", label: "Remove Library Folder", cmd: []string{"library", "remove", path},
+		requires: reqExists, confirm: true,
+		warning: []string{
+			path,
+			"Steam stops listing the games in it; the files stay on disk",
+			"and the folder can be added back later.",
+		}}
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Library view
+// ─────────────────────────────────────────────────────────────────
+
+var (
+	libraryAdd    = key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "add folder"))
+	libraryRemove = key.NewBinding(key.WithKeys("d", "delete"), key.WithHelp("d", "remove"))
+)
+
+type libraryView struct {
+	folders []libraryFolder
+	cursor  int
+	adding  bool   // typing a path
+	input   string // the path being typed
+	err     string
+}
+
+// openLibrary is the "Library Folders" action.
+func (m *model) openLibrary() tea.Cmd {
+	if remoteHost != "" {
+		return m.showToast("Library folders can't be listed over --remote")
+	}
+	folders, err := loadLibraryFolders()
+	if err != nil {
+		m.logError("Could not read the library folders: " + err.Error())
+		return nil
+	}
+	m.library = &libraryView{folders: folders}
+	m.state = stateLibrary
+	return nil
+}
+
+func (m *model) closeLibrary() {
+	m.library = nil
+	m.state = stateMenu
+}
+
+func (m *model) updateLibrary(msg tea.KeyMsg) tea.Cmd {
+	lv := m.library
+	if lv.adding {
+		switch msg.Type {
+		case tea.KeyEsc:
+			lv.adding, lv.input, lv.err = false, "", ""
+		case tea.KeyEnter:
+			path, err := validateLibraryPath(lv.input, lv.folders)
+			if err != nil {
+				lv.err = err.Error()
+				return nil
+			}
+			m.closeLibrary()
+			item := libraryAddItem(path)
+			return m.selectItem(item, actionFor(item, ""))
+		case tea.KeyBackspace:
+			if r := []rune(lv.input); len(r) > 0 {
+				lv.input = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			lv.input += string(msg.Runes)
+		}
+		return nil
+	}
+
+	switch {
+	case key.Matches(msg, keys.Up):
+		if lv.cursor > 0 {
+			lv.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if lv.cursor < len(lv.folders)-1 {
+			lv.cursor++
+		}
+	case key.Matches(msg, libraryAdd):
+		lv.adding, lv.err = true, ""
+	case key.Matches(msg, libraryRemove):
+		if lv.cursor == 0 {
+			lv.err = "the default library can't be removed"
+			return nil
+		}
+		path := lv.folders[lv.cursor].path
+		m.closeLibrary()
+		item := libraryRemoveItem(path)
+		return m.selectItem(item, actionFor(item, ""))
+	case key.Matches(msg, keys.Back):
+		m.closeLibrary()
+	}
+	return nil
+}
+
+func (m model) renderLibrary() string {
+	lv := m.library
+	rows := []string{
+		styleTitle.Render("Library Folders"),
+		styleLogDim.Render("Where Steam installs games. Quit Steam before adding or removing one."),
+		"",
+	}
+	for i, f := range lv.folders {
+		free := "free space unknown"
+		if f.freeKnown {
+			free = strings.TrimSuffix(formatSpeed(float64(f.free)), "/s") + " free"
+		}
+		text := fmt.Sprintf("%s  ·  %d games  ·  %s", tildePath(f.path), f.games, free)
+		if i == 0 {
+			text += "  (default)"
+		}
+		if i == lv.cursor && !lv.adding {
+			rows = append(rows, lipgloss.NewStyle().Foreground(colCursor).Bold(true).Render("▶ "+text))
+		} else {
+			rows = append(rows, "  "+lipgloss.NewStyle().Foreground(colText).Render(text))
+		}
+	}
+
+	if lv.adding {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(colText).Render("Folder: "+lv.input+"█"))
+	}
+	if lv.err != "" {
+		rows = append(rows, "", lipgloss.NewStyle().Foreground(colRed).Render(lv.err))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colAccent).
+		Padding(1, 4)
+
+	return m.placeOverlay(box, strings.Join(rows, "\n"))
+}
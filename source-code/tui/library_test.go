@@ -0,0 +1,227 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testLibraryVDF = `"libraryfolders"
+{
+	"0"
+	{
+		"path"		"/home/deck/.local/share/Steam"
+		"label"		""
+		"apps"
+		{
+			"228980"		"0"
+			"1245620"		"61234567890"
+		}
+	}
+	"1"
+	{
+		"path"		"/mnt/games/SteamLibrary"
+		"apps"
+		{
+			"570"		"31234567890"
+		}
+	}
+	"2"
+	{
+		"path"		"/run/media/deck/SD\\Card"
+		"apps"
+		{
+		}
+	}
+}
+`
+
+func TestParseLibraryFolders(t *testing.T) {
+	tests := []struct {
+		name string
+		vdf  string
+		want []libraryFolder
+	}{
+		{"three folders", testLibraryVDF, []libraryFolder{
+			{path: "/home/deck/.local/share/Steam", games: 2},
+			{path: "/mnt/games/SteamLibrary", games: 1},
+			{path: `/run/media/deck/SD\Card`},
+		}},
+		{"folder without a path", "\"libraryfolders\"\n{\n\"0\"\n{\n\"label\"\t\"x\"\n}\n}\n", nil},
+		{"empty", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLibraryFolders(strings.NewReader(tt.vdf))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("folder %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadLibraryFolders(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	folders, err := loadLibraryFolders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folders) != 1 || folders[0].path != steamDir() {
+		t.Fatalf("before Steam's first start: %+v", folders)
+	}
+
+	extra := filepath.Join(home, "Games")
+	vdf := "\"libraryfolders\"\n{\n\"0\"\n{\n\"path\"\t\"" + home + "\"\n}\n\"1\"\n{\n\"path\"\t\"" + extra + "\"\n}\n}\n"
+	if err := os.MkdirAll(steamappsDir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(libraryFoldersPath(), []byte(vdf), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	folders, err = loadLibraryFolders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folders) != 2 || folders[1].path != extra {
+		t.Fatalf("folders = %+v", folders)
+	}
+	if !folders[0].freeKnown || folders[1].freeKnown {
+		t.Errorf("free space known = %v, %v; want only for the folder that exists", folders[0].freeKnown, folders[1].freeKnown)
+	}
+}
+
+func TestValidateLibraryPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	games := filepath.Join(home, "Games")
+	file := filepath.Join(home, "notes.txt")
+	outside := t.TempDir()
+	for _, p := range []string{games} {
+		if err := os.Mkdir(p, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	existing := []libraryFolder{{path: steamDir()}, {path: games + "/"}}
+
+	tests := []struct {
+		input   string
+		folders []libraryFolder
+		want    string
+		err     string
+	}{
+		{"  " + games + "  ", nil, games, ""},
+		{"~/Games", nil, games, ""},
+		{games + "/../Games/", nil, games, ""},
+		{"", nil, "", "type the folder's path"},
+		{"Games", nil, "", "absolute path"},
+		{"~deck/Games", nil, "", "absolute path"},
+		{filepath.Join(home, "missing"), nil, "", "doesn't exist"},
+		{file, nil, "", "not a directory"},
+		{outside, nil, "", "can't see"},
+		{games, existing, "", "already a library folder"},
+	}
+	for _, tt := range tests {
+		got, err := validateLibraryPath(tt.input, tt.folders)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("validateLibraryPath(%q) error %v, want %q", tt.input, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("validateLibraryPath(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+}
+
+func libraryModel(t *testing.T, folders ...libraryFolder) model {
+	t.Helper()
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.containerStatus = "running"
+	m.library = &libraryView{folders: folders}
+	m.state = stateLibrary
+	return m
+}
+
+func TestLibraryDispatch(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	games := filepath.Join(home, "Games")
+	if err := os.Mkdir(games, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	folders := []libraryFolder{{path: steamDir()}, {path: "/mnt/games/SteamLibrary", games: 3}}
+
+	t.Run("add", func(t *testing.T) {
+		m := libraryModel(t, folders...)
+		m = press(m, "a", "Games", "enter")
+		if m.state != stateLibrary || !strings.Contains(m.library.err, "absolute path") {
+			t.Fatalf("relative path accepted (state %v, err %q)", m.state, m.library.err)
+		}
+		m = press(m, "backspace", "backspace", "backspace", "backspace", "backspace")
+		m = press(m, "~/Games", "enter")
+		if n := countLogged(m, "$ hackeros-steam library add "+games); n != 1 {
+			t.Errorf("add started %d times, want once", n)
+		}
+		if m.library != nil {
+			t.Error("library view left open")
+		}
+	})
+
+	t.Run("add cancelled", func(t *testing.T) {
+		m := libraryModel(t, folders...)
+		m = press(m, "a", "/mnt", "esc")
+		if m.state != stateLibrary || m.library.adding || m.library.input != "" {
+			t.Errorf("esc left adding=%v input=%q", m.library.adding, m.library.input)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		m := libraryModel(t, folders...)
+		m = press(m, "down", "d")
+		if m.state != stateConfirm || m.pendingItem == nil ||
+			strings.Join(m.pendingItem.cmd, " ") != "library remove /mnt/games/SteamLibrary" {
+			t.Fatalf("d didn't ask to remove the folder (state %v)", m.state)
+		}
+		m = press(settle(m), "y")
+		if countLogged(m, "$ hackeros-steam library remove /mnt/games/SteamLibrary") != 1 {
+			t.Error("remove didn't start after confirming")
+		}
+	})
+
+	t.Run("default library", func(t *testing.T) {
+		m := libraryModel(t, folders...)
+		m = press(m, "d")
+		if m.state != stateLibrary || m.library.err == "" {
+			t.Errorf("removing the default library: state %v, err %q", m.state, m.library.err)
+		}
+	})
+}
+
+func TestRenderLibrary(t *testing.T) {
+	t.Setenv("HOME", "/home/deck")
+	m := libraryModel(t,
+		libraryFolder{path: "/home/deck/.local/share/Steam", games: 2, free: 3 << 30, freeKnown: true},
+		libraryFolder{path: "/mnt/games/SteamLibrary", games: 1})
+	out := m.renderLibrary()
+	for _, s := range []string{"~/.local/share/Steam", "2 games", "3.0 GiB free", "(default)", "/mnt/games/SteamLibrary", "free space unknown"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("library view lacks %q:\n%s", s, out)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Shutdown
+//  The goroutines the TUI starts itself — a running command's output
+//  pump and readers, the control socket, the D-Bus signal listener —
+//  hang off appLife's context. Quitting goes through quit rather than
+//  tea.Quit: the context is cancelled first, the producers stop and
+//  are waited for, and only then does bubbletea exit, so nothing is
+//  left blocked on a channel no one reads any more.
+// ─────────────────────────────────────────────────────────────────
+
+// shutdownGrace bounds the wait for goroutines to return on quit.
+const shutdownGrace = 2 * time.Second
+
+type lifecycle struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+func newLifecycle() *lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &lifecycle{ctx: ctx, cancel: cancel}
+}
+
+// appLife is the root of the TUI's goroutines.
+var appLife = newLifecycle()
+
+// goTracked runs fn in a goroutine that shutdown waits for; fn must
+// return soon after ctx is done. Once shutdown has begun nothing is
+// started and goTracked reports false.
+func (l *lifecycle) goTracked(fn func(ctx context.Context)) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stopped {
+		return false
+	}
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		fn(l.ctx)
+	}()
+	return true
+}
+
+// shutdown cancels the context and waits up to grace for the tracked
+// goroutines, reporting whether they all returned. Calling it again
+// only waits.
+func (l *lifecycle) shutdown(grace time.Duration) bool {
+	l.mu.Lock()
+	l.stopped = true
+	l.cancel()
+	l.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(grace):
+		return false
+	}
+}
+
+// quit is tea.Quit, after the tracked goroutines have been stopped.
+func quit() tea.Msg {
+	appLife.shutdown(shutdownGrace)
+	return tea.QuitMsg{}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// noLeaks fails t unless the goroutine count falls back to baseline.
+func noLeaks(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("%d goroutines left, %d before:\n%s", runtime.NumGoroutine(), baseline, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLifecycleShutdown(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	l := newLifecycle()
+	for i := 0; i < 3; i++ {
+		if !l.goTracked(func(ctx context.Context) { <-ctx.Done() }) {
+			t.Fatal("goTracked refused before shutdown")
+		}
+	}
+	if !l.shutdown(time.Second) {
+		t.Fatal("shutdown timed out on goroutines that watch ctx")
+	}
+	if l.goTracked(func(context.Context) { t.Error("started after shutdown") }) {
+		t.Error("goTracked accepted work after shutdown")
+	}
+	if !l.shutdown(time.Second) {
+		t.Error("a second shutdown did not return at once")
+	}
+	noLeaks(t, baseline)
+}
+
+func TestLifecycleShutdownGrace(t *testing.T) {
+	l := newLifecycle()
+	release := make(chan struct{})
+	defer close(release)
+	l.goTracked(func(context.Context) { <-release })
+	start := time.Now()
+	if l.shutdown(50 * time.Millisecond) {
+		t.Error("shutdown reported success with a goroutine still running")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("shutdown waited %v past its grace", d)
+	}
+}
+
+// Quitting mid-command stops the output pump even though nothing
+// reads its channel any more.
+func TestQuitStopsRunningCommand(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	defer func(saved *lifecycle) { appLife = saved }(appLife)
+	appLife = newLifecycle()
+	baseline := runtime.NumGoroutine()
+
+	// Prints until its output pipe is closed.
+	started, ok := runStreamCmd([]string{sh, "-c", "while echo tick; do sleep 0.01; done"}, nil)().(streamStartedMsg)
+	if !ok {
+		t.Fatal("command did not start")
+	}
+	<-started.ch
+	time.Sleep(100 * time.Millisecond) // let the channel fill up
+
+	done := make(chan tea.Msg)
+	go func() { done <- quit() }()
+	select {
+	case msg := <-done:
+		if _, ok := msg.(tea.QuitMsg); !ok {
+			t.Errorf("quit returned %T", msg)
+		}
+	case <-time.After(shutdownGrace + time.Second):
+		t.Fatal("quit did not return")
+	}
+	if _, ok := runStreamCmd([]string{sh, "-c", "true"}, nil)().(cmdDoneMsg); !ok {
+		t.Error("a command started after quit")
+	}
+	noLeaks(t, baseline)
+}
+
+func TestServeControlStopsOnCancel(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	ln, err := listenControl(filepath.Join(t.TempDir(), "ctl.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	returned := make(chan struct{})
+	go func() {
+		serveControl(ctx, ln, func(tea.Msg) {})
+		close(returned)
+	}()
+	cancel()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("serveControl kept accepting after cancel")
+	}
+	noLeaks(t, baseline)
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Instance lock
+//  Only one TUI at a time may change the container. The first one to
+//  start takes an flock on tui.lock in the state dir and writes its
+//  PID there; a second one opens read-only: status, logs and the like
+//  work, actions that change the container are disabled until the
+//  first exits. The kernel drops the flock when a process dies, so a
+//  PID left in an unlocked file is a crashed instance and is taken
+//  over.
+// ─────────────────────────────────────────────────────────────────
+
+const lockFileName = "tui.lock"
+
+// exclusiveActions change the container and need the lock.
+var exclusiveActions = map[string]bool{
+	"create": true, "setup": true, "update": true, "reset": true, "remove": true,
+	"kill": true, "pause": true, "resume": true, "library": true, "teardown": true,
+	"rename": true, "clear-cache": true,
+}
+
+var (
+	instanceLock *os.File // held for the life of the process; nil when another instance has it
+	lockOwner    int      // PID of the instance holding the lock, -1 if it hasn't written one yet; 0 = us
+)
+
+func lockPath() string {
+	return filepath.Join(stateDir(), lockFileName)
+}
+
+// acquireLock takes the lock at path. When another live process has
+// it, f is nil and owner is its PID. stale is the PID a crashed
+// instance left behind, if the lock was taken over from one.
+func acquireLock(path string) (f *os.File, owner, stale int, err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, 0, 0, err
+	}
+	f, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	pid := readLockPID(f)
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, pid, 0, nil
+		}
+		return nil, 0, 0, err
+	}
+	if pid != 0 && pid != os.Getpid() {
+		stale = pid
+	}
+	if err := writeLockPID(f, os.Getpid()); err != nil {
+		releaseLock(f)
+		return nil, 0, 0, err
+	}
+	return f, 0, stale, nil
+}
+
+func readLockPID(f *os.File) int {
+	data, err := io.ReadAll(io.NewSectionReader(f, 0, 32))
+	if err != nil {
+		return 0
+	}
+	pid, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return pid
+}
+
+func writeLockPID(f *os.File, pid int) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := f.WriteAt([]byte(strconv.Itoa(pid)+"\n"), 0)
+	return err
+}
+
+// releaseLock empties the file before unlocking it, which marks a
+// clean exit. The file itself stays: removing it would let a waiting
+// instance lock a file nobody else can see any more.
+func releaseLock(f *os.File) {
+	if f == nil {
+		return
+	}
+	f.Truncate(0)
+	syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	f.Close()
+}
+
+// takeInstanceLock is run at startup; its outcome is noted in the log.
+func takeInstanceLock() {
+	f, owner, stale, err := acquireLock(lockPath())
+	switch {
+	case err != nil:
+		// Without a usable state dir nothing can be coordinated;
+		// carry on as if alone.
+		startupNotes = append(startupNotes, "Could not take "+tildePath(lockPath())+": "+err.Error())
+	case f == nil:
+		lockOwner = owner
+		if owner == 0 {
+			lockOwner = -1
+		}
+	default:
+		instanceLock = f
+		if stale != 0 {
+			startupNotes = append(startupNotes, fmt.Sprintf("Took over the lock of PID %d, which didn't exit cleanly", stale))
+		}
+	}
+}
+
+// retryInstanceLock tries again for a read-only instance, so it can
+// take over once the other one has exited.
+func (m *model) retryInstanceLock() {
+	if lockOwner == 0 {
+		return
+	}
+	f, owner, _, err := acquireLock(lockPath())
+	if err != nil || f == nil {
+		if owner != 0 {
+			lockOwner = owner
+		}
+		return
+	}
+	instanceLock, lockOwner = f, 0
+	m.appendLog(styleLogSuccess.Render("  ✔  The other TUI has exited; all actions are available again."))
+}
+
+// lockHolder names the instance that has the lock.
+func lockHolder() string {
+	if lockOwner > 0 {
+		return fmt.Sprintf("another TUI (PID %d)", lockOwner)
+	}
+	return "another TUI"
+}
+
+// lockReason is the disabledReason for exclusive actions while another
+// instance holds the lock.
+func lockReason(item menuItem) string {
+	if lockOwner == 0 || !exclusiveActions[actionName(item)] {
+		return ""
+	}
+	return lockHolder() + " is managing the container"
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAcquireLockIsExclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", lockFileName)
+	first, owner, stale, err := acquireLock(path)
+	if err != nil || first == nil || owner != 0 || stale != 0 {
+		t.Fatalf("first acquireLock = %v, owner %d, stale %d, %v", first, owner, stale, err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.TrimSpace(string(data)) != strconv.Itoa(os.Getpid()) {
+		t.Errorf("lock file holds %q, want our PID", data)
+	}
+
+	// flock is per open file, so a second open in this process stands
+	// in for another instance.
+	second, owner, _, err := acquireLock(path)
+	if err != nil || second != nil {
+		t.Fatalf("second acquireLock while held = %v, %v", second, err)
+	}
+	if owner != os.Getpid() {
+		t.Errorf("owner = %d, want %d", owner, os.Getpid())
+	}
+
+	releaseLock(first)
+	if data, _ := os.ReadFile(path); len(data) != 0 {
+		t.Errorf("released lock file holds %q, want it emptied", data)
+	}
+	third, _, stale, err := acquireLock(path)
+	if err != nil || third == nil {
+		t.Fatalf("acquireLock after release = %v, %v", third, err)
+	}
+	if stale != 0 {
+		t.Errorf("clean release reported stale PID %d", stale)
+	}
+	releaseLock(third)
+}
+
+func TestAcquireLockTakesOverStale(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		stale    int
+	}{
+		{"crashed instance", "4242\n", 4242},
+		{"empty file", "", 0},
+		{"garbage", "not a pid", 0},
+		{"our own pid", strconv.Itoa(os.Getpid()), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), lockFileName)
+			if err := os.WriteFile(path, []byte(tt.contents), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			f, owner, stale, err := acquireLock(path)
+			if err != nil || f == nil || owner != 0 {
+				t.Fatalf("acquireLock = %v, owner %d, %v", f, owner, err)
+			}
+			defer releaseLock(f)
+			if stale != tt.stale {
+				t.Errorf("stale = %d, want %d", stale, tt.stale)
+			}
+			if data, _ := os.ReadFile(path); string(data) != strconv.Itoa(os.Getpid())+"\n" {
+				t.Errorf("lock file holds %q after takeover", data)
+			}
+		})
+	}
+}
+
+func TestAcquireLockUnusableDir(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if f, _, _, err := acquireLock(filepath.Join(file, lockFileName)); err == nil || f != nil {
+		t.Errorf("acquireLock under a file = %v, %v; want an error", f, err)
+	}
+}
+
+func TestLockReason(t *testing.T) {
+	defer func(saved int) { lockOwner = saved }(lockOwner)
+	tests := []struct {
+		owner  int
+		label  string
+		reason string
+	}{
+		{0, "Remove Container", ""},
+		{4242, "Remove Container", "another TUI (PID 4242) is managing the container"},
+		{4242, "Update Container", "another TUI (PID 4242) is managing the container"},
+		{4242, "Clear Download Cache", "another TUI (PID 4242) is managing the container"},
+		{-1, "Stop Container", "another TUI is managing the container"},
+		{4242, "Container Status", ""},
+		{4242, "Export Logs", ""},
+		{4242, "Launch Steam", ""},
+	}
+	for _, tt := range tests {
+		lockOwner = tt.owner
+		m := initialModel()
+		m.containerStatus = "running"
+		if got := lockReason(menuItems[itemIndex(t, tt.label)]); got != tt.reason {
+			t.Errorf("owner %d, %s: lockReason %q, want %q", tt.owner, tt.label, got, tt.reason)
+		}
+		if tt.reason != "" {
+			if got := m.disabledReason(menuItems[itemIndex(t, tt.label)]); got != tt.reason {
+				t.Errorf("owner %d, %s: disabledReason %q", tt.owner, tt.label, got)
+			}
+		}
+	}
+	lockOwner = 4242
+	if got := lockReason(renameItem("steam-box")); got == "" {
+		t.Error("Rename Container allowed while another TUI holds the lock")
+	}
+}
+
+func TestRetryInstanceLock(t *testing.T) {
+	defer func(f *os.File, owner int) { instanceLock, lockOwner = f, owner }(instanceLock, lockOwner)
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	other, _, _, err := acquireLock(lockPath())
+	if err != nil || other == nil {
+		t.Fatal(err)
+	}
+	instanceLock, lockOwner = nil, 0
+	takeInstanceLock()
+	if instanceLock != nil || lockOwner != os.Getpid() {
+		t.Fatalf("took the lock from a live holder (owner %d)", lockOwner)
+	}
+	m := initialModel()
+	if countLogged(m, "actions that change the container are disabled") != 1 {
+		t.Error("read-only start not noted in the log")
+	}
+
+	m.retryInstanceLock()
+	if instanceLock != nil {
+		t.Fatal("retry took a held lock")
+	}
+	releaseLock(other)
+	m.retryInstanceLock()
+	if instanceLock == nil || lockOwner != 0 {
+		t.Fatalf("retry after the holder exited: lock %v, owner %d", instanceLock, lockOwner)
+	}
+	defer releaseLock(instanceLock)
+	if countLogged(m, "The other TUI has exited") != 1 {
+		t.Error("takeover not logged")
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Log filter
+//  / types an include pattern, \ an exclude pattern; the log shows
+//  only lines that match the first and not the second, updated as you
+//  type. Patterns are Go regular expressions matched against the
+//  uncolored text ((?i) for case-insensitive). An invalid pattern is
+//  reported under the log title and filters nothing until fixed. The
+//  filter only changes the view: exports and copies keep every line.
+// ─────────────────────────────────────────────────────────────────
+
+// filterField is which pattern is being typed.
+type filterField int
+
+const (
+	filterNone filterField = iota
+	filterInclude
+	filterExclude
+)
+
+type logFilter struct {
+	include, exclude string
+	includeRe        *regexp.Regexp
+	excludeRe        *regexp.Regexp
+	includeErr       string
+	excludeErr       string
+
+	editing filterField
+	before  string // the pattern as it was when editing began, for esc
+}
+
+// compilePattern compiles a typed pattern; "" means no pattern.
+func compilePattern(p string) (*regexp.Regexp, string) {
+	if p == "" {
+		return nil, ""
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		if se, ok := err.(*syntax.Error); ok {
+			return nil, fmt.Sprintf("%s: %s", se.Code, se.Expr)
+		}
+		return nil, err.Error()
+	}
+	return re, ""
+}
+
+// set replaces one pattern and recompiles it.
+func (f *logFilter) set(field filterField, p string) {
+	switch field {
+	case filterInclude:
+		f.include = p
+		f.includeRe, f.includeErr = compilePattern(p)
+	case filterExclude:
+		f.exclude = p
+		f.excludeRe, f.excludeErr = compilePattern(p)
+	}
+}
+
+func (f *logFilter) pattern(field filterField) string {
+	if field == filterExclude {
+		return f.exclude
+	}
+	return f.include
+}
+
+// active reports whether any line can be hidden.
+func (f logFilter) active() bool {
+	return f.includeRe != nil || f.excludeRe != nil
+}
+
+// keep reports whether a line passes the filter.
+func (f logFilter) keep(l logLine) bool {
+	if !f.active() {
+		return true
+	}
+	text := stripANSI(l.text)
+	if f.includeRe != nil && !f.includeRe.MatchString(text) {
+		return false
+	}
+	return f.excludeRe == nil || !f.excludeRe.MatchString(text)
+}
+
+// startFilter begins typing the include or exclude pattern.
+func (m *model) startFilter(field filterField) {
+	m.logFilter.editing = field
+	m.logFilter.before = m.logFilter.pattern(field)
+}
+
+// handleFilterKey edits the pattern being typed; every change is
+// applied at once. Enter keeps it, esc puts back what was there.
+func (m *model) handleFilterKey(msg tea.KeyMsg) tea.Cmd {
+	f := &m.logFilter
+	field := f.editing
+	p := f.pattern(field)
+	switch msg.Type {
+	case tea.KeyEnter:
+		f.editing = filterNone
+		return nil
+	case tea.KeyEsc:
+		f.editing = filterNone
+		p = f.before
+	case tea.KeyBackspace:
+		if r := []rune(p); len(r) > 0 {
+			p = string(r[:len(r)-1])
+		}
+	case tea.KeyCtrlU:
+		p = ""
+	case tea.KeyRunes, tea.KeySpace:
+		p += string(msg.Runes)
+	default:
+		return nil
+	}
+	f.set(field, p)
+	m.redrawLog()
+	return nil
+}
+
+// renderFilterLine is the row under the log title showing the
+// patterns, how many lines they leave and any compile error; "" when
+// there is nothing to show.
+func (m model) renderFilterLine(w int) string {
+	f := m.logFilter
+	if f.editing == filterNone && f.include == "" && f.exclude == "" {
+		return ""
+	}
+	dim := lipgloss.NewStyle().Foreground(colDim)
+	cursor := lipgloss.NewStyle().Foreground(colCursor).Render("▏")
+	part := func(field filterField, sigil, p, errText string) string {
+		if p == "" && f.editing != field {
+			return ""
+		}
+		s := dim.Render(sigil+" ") + lipgloss.NewStyle().Foreground(colText).Render(p)
+		if f.editing == field {
+			s += cursor
+		}
+		if errText != "" {
+			s += " " + lipgloss.NewStyle().Foreground(colRed).Render("invalid: "+errText)
+		}
+		return s + "   "
+	}
+	line := part(filterInclude, "/", f.include, f.includeErr) +
+		part(filterExclude, "\\", f.exclude, f.excludeErr)
+	if f.active() {
+		shown := 0
+		for _, l := range m.logLines {
+			if f.keep(l) {
+				shown++
+			}
+		}
+		line += dim.Render(fmt.Sprintf("%d of %d lines", shown, len(m.logLines)))
+	}
+	return lipgloss.NewStyle().
+		Background(lipgloss.Color("#0d0f14")).
+		Width(w).
+		MaxWidth(w).
+		MaxHeight(1).
+		Padding(0, 1).
+		Render(line)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		re      bool
+		err     string
+	}{
+		{"", false, ""},
+		{"error", true, ""},
+		{"(?i)warn|fail", true, ""},
+		{"(", false, "missing closing ): ("},
+		{"a[", false, "missing closing ]: ["},
+	}
+	for _, tt := range tests {
+		re, err := compilePattern(tt.pattern)
+		if (re != nil) != tt.re || err != tt.err {
+			t.Errorf("compilePattern(%q) = %v, %q; want compiled %v, error %q", tt.pattern, re, err, tt.re, tt.err)
+		}
+	}
+}
+
+func TestLogFilterKeep(t *testing.T) {
+	lines := []string{"Downloading steam", "error: disk full", "\x1b[31mERROR\x1b[0m mirror down", "warning: slow mirror"}
+	tests := []struct {
+		name             string
+		include, exclude string
+		want             []int
+	}{
+		{"no filter", "", "", []int{0, 1, 2, 3}},
+		{"include", "error", "", []int{1}},
+		{"case-insensitive include", "(?i)error", "", []int{1, 2}},
+		{"exclude", "", "mirror", []int{0, 1}},
+		{"both", "(?i)error|warn", "disk", []int{2, 3}},
+		{"colors are ignored", "^ERROR mirror", "", []int{2}},
+		{"invalid include filters nothing", "(", "", []int{0, 1, 2, 3}},
+		{"invalid exclude keeps the include", "mirror", "[", []int{2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f logFilter
+			f.set(filterInclude, tt.include)
+			f.set(filterExclude, tt.exclude)
+			var got []int
+			for i, text := range lines {
+				if f.keep(logLine{text: text}) {
+					got = append(got, i)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("kept lines %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderLogLinesFilterKeepsNumbers(t *testing.T) {
+	lines := []logLine{{text: "one"}, {text: "two"}, {text: "three"}}
+	var f logFilter
+	f.set(filterExclude, "^two$")
+	out := stripANSI(renderLogLines(lines, true, false, f.keep))
+	if want := "   1 one\n   3 three"; out != want {
+		t.Errorf("filtered render = %q, want %q", out, want)
+	}
+}
+
+// filterModel is a sized model whose log holds lines.
+func filterModel(lines ...string) model {
+	m := initialModel()
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = next.(model)
+	m.logLines = nil
+	for _, l := range lines {
+		m.appendLog(l)
+	}
+	return m
+}
+
+func TestFilterTyping(t *testing.T) {
+	m := filterModel("fetching manifest", "error: mirror down", "done")
+	m = press(m, "/")
+	if m.logFilter.editing != filterInclude {
+		t.Fatalf("/ did not start the include pattern (editing %v)", m.logFilter.editing)
+	}
+
+	m = press(m, "e", "r", "r")
+	view := m.logViewport.View()
+	if !strings.Contains(view, "mirror down") || strings.Contains(view, "fetching") {
+		t.Errorf("pattern not applied while typing:\n%s", view)
+	}
+	if line := stripANSI(m.renderFilterLine(80)); !strings.Contains(line, "/ err") || !strings.Contains(line, "1 of 3 lines") {
+		t.Errorf("filter line = %q", line)
+	}
+
+	m = press(m, "backspace", "backspace", "backspace")
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = next.(model)
+	if m.logFilter.include != "q" || cmd != nil {
+		t.Fatalf("q while editing: pattern %q, cmd %v; want it typed", m.logFilter.include, cmd)
+	}
+	m = press(m, "esc")
+	if m.logFilter.editing != filterNone || m.logFilter.include != "" {
+		t.Errorf("esc left editing %v, pattern %q; want the empty pattern back", m.logFilter.editing, m.logFilter.include)
+	}
+
+	m = press(m, "/", "d", "o", "n", "e", "enter")
+	if m.logFilter.editing != filterNone || m.logFilter.include != "done" {
+		t.Fatalf("enter did not keep the pattern: editing %v, %q", m.logFilter.editing, m.logFilter.include)
+	}
+	m = press(m, "/", "x", "esc")
+	if m.logFilter.include != "done" {
+		t.Errorf("esc restored %q, want the kept pattern %q", m.logFilter.include, "done")
+	}
+
+	m = press(m, "\\", "(")
+	if line := stripANSI(m.renderFilterLine(120)); !strings.Contains(line, "\\ (") || !strings.Contains(line, "invalid: missing closing )") {
+		t.Errorf("invalid exclude not reported: %q", line)
+	}
+	if m.logFilter.excludeRe != nil {
+		t.Error("invalid exclude pattern compiled")
+	}
+}
+
+func TestFilterFooterWhileEditing(t *testing.T) {
+	m := filterModel("one")
+	if line := m.renderFilterLine(80); line != "" {
+		t.Errorf("filter line without patterns = %q, want none", line)
+	}
+	m = press(m, "/")
+	bindings := m.footerBindings()
+	if len(bindings) < 2 || bindings[0].Help() != filterKeep.Help() || bindings[1].Help() != filterRevert.Help() {
+		t.Errorf("footer while editing = %v", bindings)
+	}
+	m = press(m, "enter")
+	if bindings := m.footerBindings(); len(bindings) > 0 && bindings[0].Help() == filterKeep.Help() {
+		t.Error("footer still shows the filter keys after enter")
+	}
+}
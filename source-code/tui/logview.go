@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Log lines
+//  Each line remembers when it arrived so line numbers and timestamps
+//  can be toggled on the fly. Prefixes are display-only: exports use
+//  the bare text unless cfg.ExportPrefixes asks otherwise. Line
+//  numbers count every line, also while the log filter hides some.
+// ─────────────────────────────────────────────────────────────────
+
+type logLine struct {
+	text string // styled text as shown in the panel
+	at   time.Time
+	run  int // capture ID of the action that printed it, 0 for the TUI's own lines
+}
+
+var styleLogPrefix = lipgloss.NewStyle().Foreground(colDim)
+
+// defaultCopyLines is how many lines "copy last lines" takes unless
+// copy_lines says otherwise.
+const defaultCopyLines = 50
+
+// logPrefix returns the "  12 14:03:22 " gutter for line i (0-based).
+func logPrefix(i int, l logLine, numbers, stamps bool) string {
+	var b strings.Builder
+	if numbers {
+		fmt.Fprintf(&b, "%4d ", i+1)
+	}
+	if stamps {
+		b.WriteString(l.at.Format("15:04:05") + " ")
+	}
+	return b.String()
+}
+
+// toggleLogPrefix flips line numbers or timestamps, keeping the scroll
+// position rather than jumping to the bottom.
+func (m *model) toggleLogPrefix(msg tea.KeyMsg) {
+	if key.Matches(msg, keys.LineNumbers) {
+		m.showLineNumbers = !m.showLineNumbers
+	} else {
+		m.showTimestamps = !m.showTimestamps
+	}
+	m.redrawLog()
+}
+
+// redrawLog re-renders the log in place, keeping the scroll position
+// (clamped to the new content) instead of jumping to the bottom.
+func (m *model) redrawLog() {
+	if !m.sized {
+		return
+	}
+	offset := m.logViewport.YOffset
+	m.logViewport.SetContent(renderLogLines(m.logLines, m.showLineNumbers, m.showTimestamps, m.logFilter.keep))
+	m.logViewport.SetYOffset(offset)
+}
+
+// renderLogLines joins the lines keep lets through for display, with
+// prefixes as toggled.
+func renderLogLines(lines []logLine, numbers, stamps bool, keep func(logLine) bool) string {
+	out := make([]string, 0, len(lines))
+	for i, l := range lines {
+		if !keep(l) {
+			continue
+		}
+		if p := logPrefix(i, l, numbers, stamps); p != "" {
+			out = append(out, styleLogPrefix.Render(p)+l.text)
+		} else {
+			out = append(out, l.text)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// tailLogLines returns the last n lines keep lets through, unstyled and
+// with prefixes as toggled, the way the log panel shows them.
+func tailLogLines(lines []logLine, n int, numbers, stamps bool, keep func(logLine) bool) []string {
+	var out []string
+	for i := len(lines) - 1; i >= 0 && len(out) < n; i-- {
+		if !keep(lines[i]) {
+			continue
+		}
+		out = append(out, logPrefix(i, lines[i], numbers, stamps)+stripANSI(lines[i].text))
+	}
+	slices.Reverse(out)
+	return out
+}
+
+// copyLogTail puts the last cfg.CopyLines visible log lines on the
+// clipboard.
+func (m *model) copyLogTail() tea.Cmd {
+	tail := tailLogLines(m.logLines, cfg.CopyLines, m.showLineNumbers, m.showTimestamps, m.logFilter.keep)
+	if len(tail) == 0 {
+		return m.showToast("No log lines to copy")
+	}
+	what := fmt.Sprintf("the last %d log lines", len(tail))
+	if len(tail) == 1 {
+		what = "the last log line"
+	}
+	return copyToClipboardCmd(strings.Join(tail, "\n")+"\n", what)
+}
+
+// plainLogLines returns the log as unstyled text, for export and copy.
+func plainLogLines(lines []logLine, withPrefixes bool) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		text := stripANSI(l.text)
+		if withPrefixes {
+			text = logPrefix(i, l, true, true) + text
+		}
+		out[i] = text
+	}
+	return out
+}
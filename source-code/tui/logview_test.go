@@ -0,0 +1,148 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLogPrefix(t *testing.T) {
+	at := time.Date(2026, 10, 14, 14, 3, 22, 0, time.Local)
+	tests := []struct {
+		i               int
+		numbers, stamps bool
+		want            string
+	}{
+		{0, false, false, ""},
+		{0, true, false, "   1 "},
+		{41, true, false, "  42 "},
+		{0, false, true, "14:03:22 "},
+		{1233, true, true, "1234 14:03:22 "},
+	}
+	for _, tt := range tests {
+		if got := logPrefix(tt.i, logLine{at: at}, tt.numbers, tt.stamps); got != tt.want {
+			t.Errorf("logPrefix(%d, numbers=%v, stamps=%v) = %q, want %q", tt.i, tt.numbers, tt.stamps, got, tt.want)
+		}
+	}
+}
+
+func TestPrefixToggles(t *testing.T) {
+	tests := []struct {
+		keys    []string
+		numbers bool
+		stamps  bool
+	}{
+		{nil, false, false},
+		{[]string{"#"}, true, false},
+		{[]string{"t"}, false, true},
+		{[]string{"#", "t"}, true, true},
+		{[]string{"#", "t", "#"}, false, true},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		next, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+		m = next.(model)
+		m = press(m, tt.keys...)
+		if m.showLineNumbers != tt.numbers || m.showTimestamps != tt.stamps {
+			t.Errorf("%v: numbers=%v stamps=%v, want %v %v", tt.keys, m.showLineNumbers, m.showTimestamps, tt.numbers, tt.stamps)
+		}
+		view := stripANSI(m.logViewport.View())
+		if hasNumbers := strings.Contains(view, "   1 "); hasNumbers != tt.numbers {
+			t.Errorf("%v: line numbers drawn = %v\n%s", tt.keys, hasNumbers, view)
+		}
+	}
+}
+
+func TestPlainLogLines(t *testing.T) {
+	at := time.Date(2026, 10, 14, 14, 3, 22, 0, time.Local)
+	lines := []logLine{
+		{text: styleLogHeader.Render("  header"), at: at},
+		{text: "plain", at: at},
+	}
+	bare := plainLogLines(lines, false)
+	if strings.Join(bare, "\n") != "  header\nplain" {
+		t.Errorf("plainLogLines without prefixes = %q", bare)
+	}
+	prefixed := plainLogLines(lines, true)
+	if prefixed[1] != "   2 14:03:22 plain" {
+		t.Errorf("plainLogLines with prefixes = %q", prefixed)
+	}
+	for _, l := range append(bare, prefixed...) {
+		if strings.Contains(l, "\x1b[") {
+			t.Errorf("ANSI escape left in %q", l)
+		}
+	}
+}
+
+func TestTailLogLines(t *testing.T) {
+	at := time.Date(2026, 10, 14, 14, 3, 22, 0, time.Local)
+	var lines []logLine
+	for _, s := range []string{"one", "error: two", styleLogError.Render("three"), "four", "error: five"} {
+		lines = append(lines, logLine{text: s, at: at})
+	}
+	var errorsOnly logFilter
+	errorsOnly.set(filterInclude, "error")
+	all := logFilter{}.keep
+	tests := []struct {
+		name            string
+		n               int
+		numbers, stamps bool
+		keep            func(logLine) bool
+		want            string
+	}{
+		{"last two", 2, false, false, all, "four|error: five"},
+		{"color stripped", 3, false, false, all, "three|four|error: five"},
+		{"more than there are", 50, false, false, all, "one|error: two|three|four|error: five"},
+		{"filtered", 50, false, false, errorsOnly.keep, "error: two|error: five"},
+		{"filtered keeps numbering", 1, true, false, errorsOnly.keep, "   5 error: five"},
+		{"timestamps", 1, false, true, all, "14:03:22 error: five"},
+		{"none", 0, false, false, all, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(tailLogLines(lines, tt.n, tt.numbers, tt.stamps, tt.keep), "|")
+			if got != tt.want {
+				t.Errorf("tailLogLines = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := tailLogLines(nil, 50, false, false, all); len(got) != 0 {
+		t.Errorf("empty log = %q", got)
+	}
+}
+
+func TestCopyLogTail(t *testing.T) {
+	m := initialModel()
+	m.logLines = nil
+	if m.copyLogTail(); m.toast != "No log lines to copy" {
+		t.Errorf("empty log: toast %q", m.toast)
+	}
+	m.appendLog("only")
+	if cmd := m.copyLogTail(); cmd == nil {
+		t.Error("a log line to copy, no copy command")
+	}
+}
+
+func TestCopyLinesConfig(t *testing.T) {
+	tests := []struct {
+		content string
+		want    int
+	}{
+		{"", defaultCopyLines},
+		{"copy_lines = 10", 10},
+		{"copy_lines = 0", defaultCopyLines},
+		{"copy_lines = -3", defaultCopyLines},
+	}
+	for _, tt := range tests {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		if err := writeFileAtomic(configPath(), []byte(tt.content+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		c, err := loadConfig()
+		if err != nil || c.CopyLines != tt.want {
+			t.Errorf("%q: CopyLines = %d, %v; want %d", tt.content, c.CopyLines, err, tt.want)
+		}
+	}
+}
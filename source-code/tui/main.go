@@ -1,15 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"net"
 	"os"
-	"os/exec"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/muesli/termenv"
 )
 
 // ─────────────────────────────────────────────────────────────────
@@ -67,6 +74,8 @@ var (
 	styleMenuIcon = lipgloss.NewStyle().
 			Foreground(colSub)
 
+	styleShortcut = lipgloss.NewStyle().Foreground(colDim)
+
 	styleStatusRunning = lipgloss.NewStyle().Foreground(colGreen).Bold(true)
 	styleStatusStopped = lipgloss.NewStyle().Foreground(colYellow).Bold(true)
 	styleStatusMissing = lipgloss.NewStyle().Foreground(colRed).Bold(true)
@@ -97,25 +106,109 @@ var (
 // ─────────────────────────────────────────────────────────────────
 
 type menuItem struct {
-	icon    string
-	label   string
-	section string // empty = same section as previous
-	cmd     []string
-	confirm bool // show confirm dialog before running
+	icon     string
+	label    string
+	section  string // empty = same section as previous
+	cmd      []string
+	env      []string     // KEY=VALUE overrides for this action
+	confirm  bool         // show confirm dialog before running
+	warning  []string     // explanation shown in the confirm dialog
+	doneNote string       // extra line logged after a successful run
+	requires containerReq // container state needed to run
+	needs    backendCap   // engine feature needed to run
+	online   bool         // downloads; disabled while the update server is unreachable
+	policy   concurrency  // whether it may start while another action runs
+
+	// destructive actions change or delete the container and are
+	// refused in safe mode.
+	destructive bool
+
+	// interactive actions read from stdin, so they get the terminal
+	// instead of having their output captured.
+	interactive bool
+
+	// describe, when set, is run before the confirm prompt opens and
+	// its lines replace warning.
+	describe func(engine string) ([]string, error)
+
+	// warningFor, when set, builds warning as the prompt opens, for
+	// lines that name the container: it can be renamed after start.
+	warningFor func() []string
+
+	// preview marks a confirm that only shows what the action will do,
+	// like Update Container's plan. The control socket, which skips
+	// the prompt, offers such items; other confirm items it does not.
+	preview bool
+
+	// run handles actions the TUI performs itself instead of calling
+	// hackeros-steam; cmd is ignored when it is set.
+	run func(m *model) tea.Cmd
+
+	// entersContainer marks a run action that works inside the
+	// container, so a pause disables it like a hackeros-steam call.
+	entersContainer bool
+
+	// script is the actions.d executable run instead of hackeros-steam,
+	// interrupted after timeout.
+	script  string
+	timeout time.Duration
 }
 
 var menuItems = []menuItem{
-	{section: "STEAM", icon: "▶", label: "Launch Steam", cmd: []string{"run"}},
-	{icon: "⬛", label: "Big Picture Mode", cmd: []string{"run", "-gamepadui"}},
-
-	{section: "CONTAINER", icon: "+", label: "Create Container", cmd: []string{"create"}},
-	{icon: "⚙", label: "Setup / Repair Steam", cmd: []string{"setup"}},
-	{icon: "↑", label: "Update Container", cmd: []string{"update"}},
-	{icon: "■", label: "Stop Container", cmd: []string{"kill"}},
-	{icon: "✕", label: "Remove Container", cmd: []string{"--force", "remove"}, confirm: true},
+	{section: "STEAM", icon: "▶", label: "Launch Steam", cmd: []string{"run"}, requires: reqExists},
+	{icon: "⬛", label: "Big Picture Mode", cmd: []string{"run", "-gamepadui"}, requires: reqExists},
+	{icon: "✓", label: "Test Launch", cmd: []string{"test-launch"}, requires: reqExists,
+		doneNote: "Steam can run in the container."},
+	{icon: "⇄", label: "Steam Channel", run: (*model).openChannelMenu},
+	{icon: "↻", label: "Client Auto-Update", run: (*model).openAutoUpdateMenu},
+	{icon: "◈", label: "Steam Overlay", run: (*model).openOverlayMenu},
+	{icon: "◫", label: "Gamescope", run: (*model).openGamescopeMenu},
+	{icon: "▤", label: "MangoHud", run: (*model).openMangohudMenu},
+	{icon: "▣", label: "Launch GPU", run: (*model).openGPUMenu},
+	{icon: "⇆", label: "Toggle GE-Proton", run: (*model).toggleProton},
+	{icon: "☰", label: "Installed Games", run: (*model).openGames, requires: reqExists},
+	{icon: "⛁", label: "Library Folders", run: (*model).openLibrary, requires: reqExists},
+	{icon: "✓", label: "Verify Game Files", run: (*model).verifyAll, requires: reqExists, entersContainer: true},
+	{icon: "⌧", label: "Clear Download Cache", cmd: []string{"clear-cache"}, confirm: true, destructive: true,
+		warning: clearCacheWarning},
+
+	{section: "CONTAINER", icon: "+", label: "Create Container", cmd: []string{"create"}, requires: reqMissing, destructive: true, online: true,
+		doneNote: "Check that Steam starts with Test Launch."},
+	{icon: "⚙", label: "Setup / Repair Steam", cmd: []string{"setup"}, requires: reqExists},
+	{icon: "↑", label: "Update Container", cmd: []string{"update"}, confirm: true, requires: reqExists, online: true,
+		warning: updateWarning, describe: describeUpdate, preview: true},
+	{icon: "⇣", label: "Update Mirror", run: (*model).openMirrorMenu, requires: reqExists},
+	{icon: "⟲", label: "Repair Container", cmd: []string{"--force", "create"}, confirm: true, requires: reqExists, destructive: true,
+		warningFor: func() []string {
+			return []string{
+				"Deletes and recreates the " + containerName + " container,",
+				"then reinstalls Steam. Your Steam library and settings in",
+				"~/.local/share/Steam are kept — they live in your home.",
+				"Use this when neither Setup nor Update fixes the container.",
+			}
+		},
+		doneNote: "Container rebuilt; Steam data in your home directory was kept."},
+	{icon: "⌫", label: "Factory Reset", run: (*model).openResetMenu, requires: reqExists, destructive: true},
+	{icon: "$", label: "Container Environment", run: (*model).openEnvEditor},
+	{icon: "↶", label: "Roll Back Config", run: (*model).rollbackConfig},
+	{icon: "⇪", label: "Export Settings", run: (*model).exportSettings},
+	{icon: "⇫", label: "Import Settings", run: (*model).openImport},
+	{icon: "✎", label: "Rename Container", run: (*model).openRename, requires: reqStopped, destructive: true},
+	{icon: "⏸", label: "Pause Container", cmd: []string{"pause"}, requires: reqRunning, needs: capPause},
+	{icon: "⏵", label: "Resume Container", cmd: []string{"resume"}, requires: reqPaused, needs: capPause},
+	{icon: "■", label: "Stop Container", cmd: []string{"kill"}, requires: reqRunningOrPaused, destructive: true},
+	{icon: "✕", label: "Remove Container", cmd: []string{"--force", "remove"}, confirm: true, requires: reqExists, destructive: true,
+		warning: []string{"This cannot be undone."}, describe: describeRemoval},
+	{icon: "☠", label: "Kill & Remove Container", cmd: []string{"--force", "teardown"}, confirm: true, requires: reqExists, destructive: true,
+		warning: []string{teardownKillLine, "This cannot be undone."}, describe: describeTeardown},
 
 	{section: "INFO", icon: "i", label: "Container Status", cmd: []string{"status"}},
 	{icon: "≡", label: "List All Containers", cmd: []string{"list"}},
+	{icon: "⇌", label: "Network Info", run: (*model).showNetworkInfo, requires: reqExists, policy: concurrent},
+	{icon: "⇩", label: "Export Logs", run: (*model).exportLogs, needs: capLogs},
+	{icon: "◧", label: "Side-by-Side Logs", run: (*model).openSplitLogs, needs: capLogs, policy: concurrent},
+	{icon: "✚", label: "Diagnostics Bundle", run: (*model).diagnosticsBundle},
+	{icon: "⟳", label: "Check Steam Client", run: (*model).checkClient},
 }
 
 // ─────────────────────────────────────────────────────────────────
@@ -123,9 +216,9 @@ var menuItems = []menuItem{
 // ─────────────────────────────────────────────────────────────────
 
 type (
-	cmdOutputMsg  string        // line of output from running command
-	cmdDoneMsg    bool          // true = success, false = error
-	statusDoneMsg string        // "running" | "stopped" | "missing"
+	cmdOutputMsg  string // line of output from running command
+	cmdDoneMsg    bool   // true = success, false = error
+	statusDoneMsg string // "running" | "paused" | "stopped" | "missing" | "unreachable"
 	windowSizeMsg tea.WindowSizeMsg
 )
 
@@ -139,6 +232,29 @@ const (
 	stateMenu viewState = iota
 	stateRunning
 	stateConfirm
+	stateEscalate
+	stateSubmenu
+	stateGames
+	stateInteractive
+	stateEnv
+	stateQuitUpdate
+	stateForceKill
+	statePhrase
+	stateLibrary
+	stateSplitLogs
+	stateRename
+	stateCrash
+	stateCrashReport
+	stateImport
+)
+
+// popup is an informational overlay that can open above any state.
+type popup int
+
+const (
+	popupNone popup = iota
+	popupHistory
+	popupLastError
 )
 
 // ─────────────────────────────────────────────────────────────────
@@ -146,24 +262,105 @@ const (
 // ─────────────────────────────────────────────────────────────────
 
 type model struct {
-	state       viewState
-	cursor      int
-	width       int
-	height      int
-	containerStatus string // "running"|"stopped"|"missing"|"checking"
-	logLines    []string
-	logViewport viewport.Model
-	spinner     spinner.Model
-	busy        bool
-	pendingCmd  []string // command waiting for confirm
+	state           viewState
+	cursor          int
+	cursorWant      string // label of the item last chosen with the cursor
+	width           int
+	height          int
+	sized           bool   // false until real terminal dimensions are known
+	containerStatus string // "running"|"paused"|"stopped"|"missing"|"unreachable"|"checking"
+	cliMissing      bool   // hackeros-steam binary not installed
+	logLines        []logLine
+	showLineNumbers bool
+	showTimestamps  bool
+	focus           focusState
+	logFilter       logFilter
+	resizedAt       time.Time
+	pendingSize     *tea.WindowSizeMsg // held by the resize debounce
+	resizeScheduled bool
+	history         statusHistory
+	popup           popup
+	errorCount      int
+	lastError       *errorRecord
+	backend         string // container engine, "" until detected
+	games           *gameList
+	batch           *uninstallBatch // running batch uninstall, nil when none
+	uninstalling    *uninstallWait  // uninstall Steam hasn't finished, nil when none
+	session         *playSession    // game launched from the TUI, nil when none
+	startFailed     bool            // the last command never started
+	envEditor       *envEditor
+	library         *libraryView
+	splitLogs       *splitLogs
+	stopStream      func() // interrupts the streamed command, nil when none
+	runningCmdline  string // shown in the status bar when show_argv is on
+	auditID         int    // audit record of the running command, 0 = none
+	auditName       string // its action, for the exit record
+	stopID          int    // current Stop Container attempt, see watchStop
+	checkStopped    bool   // next status decides whether to offer a force kill
+	forceKillQueued bool   // force kill once the interrupted stop returns
+	forceKillReason string
+	polling         map[pollFeature]bool // polls in flight
+	statsCPU        string
+	statsMem        string
+	pendingUpdates  int
+	network         netState  // update server reachability
+	netHost         string    // the server last probed
+	quitAfterRun    bool      // quit once the running command has stopped
+	quitReturn      viewState // what esc in the quit warning goes back to
+	startedAt       time.Time // when the running action started
+
+	autorun             *menuItem // pending autorun action, nil once run or cancelled
+	autorunLeft         int       // countdown seconds
+	autorunQuit         bool      // quit when the autorun action succeeds
+	logViewport         viewport.Model
+	spinner             spinner.Model
+	busy                bool
+	pendingItem         *menuItem // action waiting for confirm
+	pendingAction       action    // resolved with pendingItem
+	phraseInput         string    // typed so far at a confirmation phrase
+	renameInput         string    // new container name being typed
+	renameErr           string    // why renameInput was refused
+	importInput         string    // path of the settings file to import
+	importErr           string    // why importInput was refused
+	confirmID           int       // identifies the open prompt's timer
+	promptOpenedAt      time.Time // accept keys are ignored for promptGuard after this
+	confirmDeadline     time.Time // prompt auto-cancels at this time
+	lastAction          action    // resolved from lastItem when it started
+	lastItem            *menuItem // most recently dispatched action
+	toast               string    // transient notice in the status bar
+	toastID             int
+	stream              <-chan tea.Msg // output of the running command
+	runOutput           []string       // raw lines of the running command
+	capture             runCapture
+	progress            float64
+	hasProgress         bool
+	warnings            stderrWarnings // stderr lines of the running action
+	procs               procLog        // spawned processes, for --verbose
+	lastExit            *procExitedMsg // how the action's last process ended
+	crash               *crashReport   // the last crash report, nil before one
+	launchSummary       string         // active launch options, "" for none
+	gamescopeSummary    string         // Launch in Gamescope's options, "" for none
+	files, totalFiles   int            // extraction counters, 0 = not extracting
+	progressBar         progress.Model
+	speeds              sampleRing // recent download speeds for the sparkline
+	versionBeforeUpdate string     // Steam client build when the update started
+	clientVersion       string     // installed Steam client build, "" when unknown
+	submenu             *submenu
 }
 
 func initialModel() model {
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
-	sp.Style = lipgloss.NewStyle().Foreground(colAccent)
+	sp.Style = lipgloss.NewStyle().Foreground(colSpinner)
 
-	vp := viewport.New(60, 20)
+	// Size everything from the terminal right away so output that lands
+	// before the first WindowSizeMsg still has somewhere to go.
+	width, height, err := term.GetSize(os.Stdout.Fd())
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 0, 0
+	}
+
+	vp := viewport.New(logPanelWidth(width), logPanelHeight(height))
 	vp.Style = lipgloss.NewStyle().
 		Background(colBgDeep).
 		Foreground(colText)
@@ -171,11 +368,32 @@ func initialModel() model {
 	m := model{
 		state:           stateMenu,
 		containerStatus: "checking",
+		width:           width,
+		height:          height,
+		sized:           width > 0,
 		spinner:         sp,
 		logViewport:     vp,
+		polling:         map[pollFeature]bool{},
+		progressBar:     progress.New(progress.WithSolidFill(string(colProgress)), progress.WithWidth(progressBarWidth(logPanelWidth(width)))),
+	}
+	m.refreshLaunchSummary()
+	m.appendLog(styleLogHeader.Render("  HackerOS Steam TUI — ready."))
+	m.appendLog(styleLogDim.Render("  Use ↑/↓ to navigate, Enter to execute."))
+	for _, note := range startupNotes {
+		m.appendLog(styleLogInfo.Render("  → " + note))
 	}
-	m.logLines = append(m.logLines, styleLogHeader.Render("  HackerOS Steam TUI — ready."))
-	m.logLines = append(m.logLines, styleLogDim.Render("  Use ↑/↓ to navigate, Enter to execute."))
+	if cfgLoadErr != nil {
+		m.appendLog(styleLogWarning.Render("  ⚠  " + cfgLoadErr.Error() + " — using defaults."))
+	}
+	if lockOwner != 0 {
+		m.appendLog(styleLogWarning.Render("  ⚠  " + lockHolder() + " is running; actions that change the container are disabled here until it exits."))
+	}
+	if remoteHost == "" {
+		cliWatch.check()
+	}
+	m.flushLog()
+	m.applyStartView()
+	m.setupAutorun(cfg.Autorun)
 	return m
 }
 
@@ -184,10 +402,14 @@ func initialModel() model {
 // ─────────────────────────────────────────────────────────────────
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(
-		m.spinner.Tick,
-		checkStatusCmd(),
-	)
+	cmds := []tea.Cmd{checkStatusCmd(), reattachCmd(), detectBackendCmd(), netProbeCmd(), startPolls()}
+	if m.autorun != nil {
+		cmds = append(cmds, autorunTick())
+	}
+	if verboseMode {
+		cmds = append(cmds, waitProcEvents())
+	}
+	return tea.Batch(cmds...)
 }
 
 // ─────────────────────────────────────────────────────────────────
@@ -199,169 +421,584 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 
+	case tea.FocusMsg:
+		m.focus = focusIn
+
+	case tea.BlurMsg:
+		m.focus = focusOut
+
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.logViewport.Width = logPanelWidth(m.width)
-		m.logViewport.Height = logPanelHeight(m.height)
+		// Sizes are recomputed here whatever the state, at most every
+		// resizeInterval.
+		cmds = append(cmds, m.handleResize(msg, time.Now()))
 
-	case tea.KeyMsg:
-		switch m.state {
-		case stateMenu:
-			switch msg.String() {
-			case "q", "ctrl+c":
-				return m, tea.Quit
-			case "up", "k":
-				if m.cursor > 0 {
-					m.cursor--
-				}
-			case "down", "j":
-				if m.cursor < len(menuItems)-1 {
-					m.cursor++
-				}
-			case "enter", " ":
-				if !m.busy {
-					item := menuItems[m.cursor]
-					if item.confirm {
-						m.state = stateConfirm
-						m.pendingCmd = item.cmd
-					} else {
-						cmds = append(cmds, m.execCommand(item.cmd))
-					}
-				}
-			case "r":
-				cmds = append(cmds, checkStatusCmd())
-			}
+	case resizeFlushMsg:
+		m.flushResize(time.Now())
 
-		case stateConfirm:
-			switch msg.String() {
-			case "y", "Y":
-				cmd := m.pendingCmd
-				m.pendingCmd = nil
-				m.state = stateMenu
-				cmds = append(cmds, m.execCommand(cmd))
-			case "n", "N", "q", "esc":
-				m.pendingCmd = nil
-				m.state = stateMenu
-				m.appendLog(styleLogDim.Render("  Aborted."))
-			}
+	case splitLogsMsg:
+		m.handleSplitLogs(msg)
 
-		case stateRunning:
-			switch msg.String() {
-			case "ctrl+c":
-				return m, tea.Quit
-			}
+	case tea.KeyMsg:
+		// Keys are handled before anything else and never wait on
+		// animation state; only keys the active view doesn't consume
+		// fall through to the log viewport.
+		if m.autorun != nil {
+			m.cancelAutorun()
+			return m, nil
 		}
+		return m, m.handleKey(msg)
+
+	case autorunTickMsg:
+		cmds = append(cmds, m.handleAutorunTick())
+
+	case killTimeoutMsg:
+		cmds = append(cmds, m.handleKillTimeout(msg))
+
+	case mirrorsMsg:
+		m.handleMirrors(msg)
+
+	case controlMsg:
+		cmds = append(cmds, m.handleControl(msg))
 
 	case spinner.TickMsg:
+		// Let the tick chain die out once nothing is running; dispatch
+		// starts a fresh one.
+		if !m.busy {
+			return m, nil
+		}
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
-		cmds = append(cmds, cmd)
+		return m, cmd
+
+	case streamStartedMsg:
+		m.stream = msg.ch
+		m.stopStream = msg.stop
+		cmds = append(cmds, waitForStream(m.stream))
 
 	case cmdOutputMsg:
 		line := string(msg)
-		m.appendLog(colorLine(line))
-		cmds = append(cmds, m.spinner.Tick)
+		plain := stripANSI(line)
+		if strings.TrimSpace(plain) == "" {
+			m.captureLine(plain, "")
+		} else {
+			m.captureLine(plain, renderOutputLine(line))
+		}
+		cmds = append(cmds, waitForStream(m.stream))
+
+	case cmdStderrMsg:
+		m.handleStderr(string(msg))
+		cmds = append(cmds, waitForStream(m.stream))
+
+	case procStartedMsg:
+		m.procs.started(msg)
+		cmds = append(cmds, waitForStream(m.stream))
+
+	case procExitedMsg:
+		m.procs.exited(msg)
+		m.lastExit = &msg
+		cmds = append(cmds, waitForStream(m.stream))
+
+	case sideProcMsg:
+		if msg.started != nil {
+			m.procs.started(*msg.started)
+		}
+		if msg.exited != nil {
+			m.procs.exited(*msg.exited)
+		}
+		cmds = append(cmds, waitProcEvents())
+
+	case pluginTimeoutMsg:
+		m.handlePluginTimeout(msg)
+
+	case resumeProbeMsg:
+		cmds = append(cmds, m.handleResumeProbe(msg))
+
+	case progressMsg:
+		m.progress = msg.percent
+		m.files, m.totalFiles = msg.files, msg.totalFiles
+		m.hasProgress = true
+		mirrorProgress(msg)
+		cmds = append(cmds, waitForStream(m.stream))
+
+	case speedMsg:
+		m.speeds.add(msg.bps)
+		mirrorSpeed(msg)
+		cmds = append(cmds, waitForStream(m.stream))
+
+	case cmdStartErrMsg:
+		m.handleStartErr(msg)
+		return m.Update(cmdDoneMsg(false))
 
 	case cmdDoneMsg:
 		ok := bool(msg)
 		m.busy = false
-		m.state = stateMenu
+		if m.state != stateSplitLogs {
+			m.state = stateMenu
+		}
+		m.stream = nil
+		m.stopStream = nil
+		m.runningCmdline = ""
+		m.hasProgress = false
+		m.files, m.totalFiles = 0, 0
+		m.speeds.reset()
+		if isStopItem(m.lastItem) {
+			m.stopFinished()
+		}
+		m.auditDone(ok)
+		if m.lastItem != nil {
+			mirrorDone(m.lastItem.label, ok)
+			if ok {
+				m.recordStatus(m.lastItem.label + ": done")
+			} else {
+				m.recordStatus(m.lastItem.label + ": failed")
+			}
+		}
 		if ok {
 			m.appendLog(styleLogSuccess.Render("  ✔  Done."))
+			if m.lastItem != nil && m.lastItem.doneNote != "" {
+				m.appendLog(styleLogInfo.Render("  → " + m.lastItem.doneNote))
+			}
+			if isUpdateItem(m.lastItem) {
+				m.logUpdateSummary()
+			}
+			if isKnownGoodItem(m.lastItem) {
+				m.recordKnownGood()
+			}
+			if isClearCacheItem(m.lastItem) {
+				m.logReclaimed()
+			}
+			if isRenameItem(m.lastItem) {
+				m.renameFinished()
+			}
 		} else {
-			m.appendLog(styleLogError.Render("  ✖  Command exited with error."))
+			fsErr := detectFSProblem(m.runOutput)
+			switch {
+			case m.startFailed:
+			case fsErr != nil:
+				m.logFSProblem(fsErr)
+			default:
+				m.logError(m.lastItemLabel()+": command exited with error.", m.runOutput...)
+			}
+			// Never escalate on our own — ask first.
+			switch {
+			case m.lastItem == nil, m.batch != nil, m.startFailed, m.forceKillQueued, fsErr != nil:
+			case isPermissionError(m.runOutput):
+				cmds = append(cmds, m.openActionPrompt(stateEscalate, *m.lastItem, m.lastAction))
+			case isUpdateItem(m.lastItem):
+				cmds = append(cmds, probeResumeCmd())
+			case !m.lastItem.interactive && endsWithInputPrompt(m.runOutput):
+				cmds = append(cmds, m.openActionPrompt(stateInteractive, *m.lastItem, m.lastAction))
+			}
+		}
+		if isVerifyItem(m.lastItem) {
+			m.logVerifySummary()
+		}
+		if isTestLaunchItem(m.lastItem) {
+			m.logTestLaunchResult(ok)
+		}
+		if isLaunchItem(m.lastItem) {
+			cmds = append(cmds, m.logLaunchExit())
 		}
 		m.appendLog("")
-		cmds = append(cmds, checkStatusCmd())
+		if m.takeQueuedForceKill() {
+			return m, tea.Batch(append(cmds, m.dispatch(forceKillItem))...)
+		}
+		if m.uninstalling != nil {
+			return m, tea.Batch(append(cmds, m.awaitUninstall(ok))...)
+		}
+		cmds = append(cmds, checkStatusCmd(), m.notifyDone(ok), m.attention(time.Since(m.startedAt)))
+		if m.autorunQuit {
+			m.autorunQuit = false
+			if ok {
+				return m, quit
+			}
+		}
+		if m.quitAfterRun {
+			return m, quit
+		}
+		if m.lastItem != nil {
+			action := m.lastAction.name
+			if hook := hookFor(hookPost, action); hook != "" {
+				result := "success"
+				if !ok {
+					result = "failure"
+				}
+				m.busy = true
+				cmds = append(cmds, runHookCmd(hookPost, action, hook, result))
+			}
+		}
+
+	case hookDoneMsg:
+		cmds = append(cmds, m.handleHookDone(msg))
+
+	case uninstallTickMsg:
+		cmds = append(cmds, m.handleUninstallTick())
+
+	case sessionTickMsg:
+		cmds = append(cmds, m.handleSessionTick())
+
+	case sessionEndedMsg:
+		m.handleSessionEnded(msg)
+
+	case clientCheckMsg:
+		m.handleClientCheck(msg)
+
+	case networkInfoMsg:
+		m.handleNetworkInfo(msg)
+
+	case hdrDetectedMsg:
+		cmds = append(cmds, m.handleHDRDetected(msg))
+
+	case mangohudDetectedMsg:
+		cmds = append(cmds, m.handleMangohudDetected(msg))
+
+	case renameCheckedMsg:
+		cmds = append(cmds, m.handleRenameChecked(msg))
+
+	case crashSavedMsg:
+		cmds = append(cmds, m.handleCrashSaved(msg))
+
+	case pollTickMsg:
+		cmds = append(cmds, m.handlePollTick(msg.feature))
+
+	case statsMsg:
+		m.polling[pollStats] = false
+		if msg.err == nil {
+			m.statsCPU, m.statsMem = msg.cpu, msg.mem
+		}
+
+	case netProbeMsg:
+		m.handleNetProbe(msg)
+
+	case updatesMsg:
+		m.polling[pollUpdateCheck] = false
+		if msg.err == nil {
+			m.pendingUpdates = msg.count
+		}
 
 	case statusDoneMsg:
+		m.polling[pollStatus] = false
+		m.retryInstanceLock()
+		refreshFavorites(steamappsDir())
+		m.clientVersion = readClientVersion()
+		if string(msg) != m.containerStatus {
+			m.recordStatus("Container " + string(msg))
+		}
 		m.containerStatus = string(msg)
+		m.cliMissing = !cliAvailable()
+		m.fixCursor()
+		cmds = append(cmds, m.checkStopResult(string(msg)))
+
+	case logExportedMsg:
+		m.busy = false
+		if msg.err != nil {
+			m.logError("Log export failed: " + msg.err.Error())
+			break
+		}
+		m.appendLog(styleLogSuccess.Render(fmt.Sprintf("  ✔  Exported %d bytes to %s", msg.bytes, msg.path)))
+		cmds = append(cmds, m.showToast("✔ Logs exported"))
+
+	case describedMsg:
+		cmds = append(cmds, m.handleDescribed(msg))
+
+	case gamesLoadedMsg:
+		m.handleGamesLoaded(msg)
+
+	case diagnosticsMsg:
+		m.busy = false
+		if msg.err != nil {
+			m.logError("Could not write diagnostics: " + msg.err.Error())
+		} else {
+			m.appendLog(styleLogSuccess.Render("  ✔  Diagnostics written to " + msg.path))
+		}
+		// The clipboard copy is still useful when the file couldn't be written.
+		cmds = append(cmds, copyToClipboardCmd(msg.bundle, "diagnostics"))
+
+	case channelSwitchedMsg:
+		m.busy = false
+		if msg.err != nil {
+			m.logError("Channel switch failed: " + msg.err.Error())
+			break
+		}
+		m.appendLog(styleLogSuccess.Render("  ✔  Steam channel set to " + channelLabel(msg.channel) + "."))
+		m.appendLog(styleLogDim.Render("  The client will be re-downloaded on next launch."))
+		cmds = append(cmds, m.dispatch(channelUpdateItem()))
+
+	case backendDetectedMsg:
+		m.backend = msg.name
+		m.fixCursor()
+
+	case reattachedMsg:
+		m.logReattach(msg.reply)
+
+	case detachFailedMsg:
+		m.logError("Could not start background watcher: " + msg.err.Error())
+
+	case confirmTickMsg:
+		cmds = append(cmds, m.handleConfirmTick(msg))
+
+	case clipboardMsg:
+		cmds = append(cmds, m.showToast(msg.toast()))
+
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
 	}
 
-	// Update viewport scroll
-	var vpCmd tea.Cmd
-	m.logViewport, vpCmd = m.logViewport.Update(msg)
-	cmds = append(cmds, vpCmd)
+	// Update viewport scroll (mouse wheel etc.)
+	cmds = append(cmds, m.updateViewport(msg))
 
 	return m, tea.Batch(cmds...)
 }
 
+func (m *model) updateViewport(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.logViewport, cmd = m.logViewport.Update(msg)
+	return cmd
+}
+
 // ─────────────────────────────────────────────────────────────────
 //  Exec helpers
 // ─────────────────────────────────────────────────────────────────
 
-func (m *model) execCommand(args []string) tea.Cmd {
+// selectItem is enter on a menu or submenu entry, resolved as a: items
+// that need confirming open the prompt first, the rest are dispatched.
+func (m *model) selectItem(item menuItem, a action) tea.Cmd {
+	if !a.requiresConfirm {
+		return m.dispatchAction(item, a)
+	}
+	if reason := m.disabledReason(item); reason != "" {
+		return m.showToast(item.label + " unavailable: " + reason)
+	}
+	switch {
+	case m.busy:
+		return nil
+	case item.describe != nil:
+		m.busy = true
+		return tea.Batch(describeCmd(item, a, m.engine()), m.spinnerTick())
+	}
+	return m.openActionPrompt(stateConfirm, item, a)
+}
+
+// dispatch is the single entry point for starting an action. While
+// busy, only a concurrent item the running action allows starts, next
+// to it (concurrency.go); anything else is dropped. Otherwise the busy
+// check and the busy flag are set together here, so a burst of enter
+// presses can never start more than one exclusive action.
+func (m *model) dispatchAction(item menuItem, a action) tea.Cmd {
+	if m.busy {
+		if m.runningReason(item) != "" {
+			return nil
+		}
+		return m.dispatchAlongside(item)
+	}
+	if reason := m.disabledReason(item); reason != "" {
+		return m.showToast(item.label + " unavailable: " + reason)
+	}
+	// Built-in actions set busy themselves when they start background
+	// work; opening a submenu, for instance, doesn't.
+	if a.builtin() {
+		m.lastItem, m.lastAction = &item, a
+		cmd := item.run(m)
+		if m.busy {
+			cmd = tea.Batch(cmd, m.spinnerTick())
+		}
+		return cmd
+	}
+	cmd := tea.Batch(m.execAction(item, a), m.spinnerTick(), m.startSession(item))
+	if isStopItem(&item) {
+		cmd = tea.Batch(cmd, m.watchStop())
+	}
+	return cmd
+}
+
+// spinnerTick starts the spinner animation unless reduced motion is on.
+func (m *model) spinnerTick() tea.Cmd {
+	if cfg.ReducedMotion {
+		return nil
+	}
+	return m.spinner.Tick
+}
+
+// dispatch is dispatchAction for an item outside the main menu.
+func (m *model) dispatch(item menuItem) tea.Cmd {
+	return m.dispatchAction(item, actionFor(item, ""))
+}
+
+// execCommand is execAction for an item outside the main menu.
+func (m *model) execCommand(item menuItem) tea.Cmd {
+	return m.execAction(item, actionFor(item, ""))
+}
+
+// execAction starts item's command as resolved in a, after its pre hook.
+func (m *model) execAction(item menuItem, a action) tea.Cmd {
 	m.busy = true
 	m.state = stateRunning
+	m.lastItem = &item
+	m.beginCapture()
+	m.startedAt = time.Now()
+	m.lastExit = nil
+	if isUpdateItem(&item) {
+		m.versionBeforeUpdate = readClientVersion()
+	}
+	m.lastAction = a
+	if hook := hookFor(hookPre, a.name); hook != "" {
+		m.appendLog("")
+		m.appendLog(styleLogDim.Render("  → pre_" + a.name + " hook"))
+		return runHookCmd(hookPre, a.name, hook, "")
+	}
+	return m.startCommand()
+}
+
+// startCommand launches the hackeros-steam process for the last item.
+func (m *model) startCommand() tea.Cmd {
+	item, a := *m.lastItem, m.lastAction
 	m.appendLog("")
-	m.appendLog(styleLogInfo.Render("  $ hackeros-steam " + strings.Join(args, " ")))
+	if item.script != "" {
+		m.appendLog(styleLogInfo.Render("  $ " + tildePath(item.script)))
+	} else {
+		m.appendLog(styleLogInfo.Render("  $ hackeros-steam " + strings.Join(item.cmd, " ")))
+	}
 	m.appendLog("")
-	return runCommandCmd(args)
+	id, err := auditExec(auditAction(item, a), a.argv, a.env)
+	if err != nil {
+		m.logError("Not run: " + err.Error())
+		m.busy = false
+		m.state = stateMenu
+		return nil
+	}
+	m.auditID, m.auditName = id, auditAction(item, a)
+	mirrorStart(item.label)
+	warn := m.noteBinaryChange(a.argv)
+	if !a.streaming {
+		return tea.Batch(m.execInteractive(item, a, a.argv), warn)
+	}
+	m.recordStatus(item.label + ": started")
+	if cfg.ShowArgv {
+		m.runningCmdline = a.commandLine()
+	}
+	return tea.Batch(runStreamCmd(a.argv, a.env), m.startPluginTimer(item), warn)
 }
 
-func (m *model) appendLog(line string) {
-	m.logLines = append(m.logLines, line)
-	m.logViewport.SetContent(strings.Join(m.logLines, "\n"))
-	m.logViewport.GotoBottom()
+// dispatchEscalated re-runs item, resolved as a, through the configured
+// privilege command. The terminal is handed over so pkexec/sudo can
+// prompt for a password.
+func (m *model) dispatchEscalated(item menuItem, a action) tea.Cmd {
+	if m.busy {
+		return nil
+	}
+	argv := escalatedArgv(a.argv)
+	id, err := auditExec(auditAction(item, a), argv, a.env)
+	if err != nil {
+		m.logError("Not run: " + err.Error())
+		return nil
+	}
+	m.auditID, m.auditName = id, auditAction(item, a)
+	return m.execInteractive(item, a, argv)
 }
 
-// runCommandCmd streams output line-by-line via tea.Cmd chain
-func runCommandCmd(args []string) tea.Cmd {
-	fullArgs := append([]string{cli}, args...)
-	return func() tea.Msg {
-		cmd := exec.Command(fullArgs[0], fullArgs[1:]...)
-		cmd.Stderr = nil
+type toastExpiredMsg struct{ id int }
 
-		out, err := cmd.Output()
-		lines := strings.Split(stripANSI(string(out)), "\n")
-		// We can't stream easily inside a single Cmd; send all lines then done.
-		// For real streaming we'd use a channel-based approach below.
-		_ = lines
-		if err != nil {
-			return streamLines(fullArgs, false)
-		}
-		return streamLines(fullArgs, true)
+const toastDuration = 3 * time.Second
+
+// showToast displays text in the status bar until it expires or is
+// replaced by a newer toast.
+func (m *model) showToast(text string) tea.Cmd {
+	m.toastID++
+	m.toast = text
+	m.recordStatus(text)
+	id := m.toastID
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// appendLog adds a line to the log. Until the terminal size is known the
+// lines are only buffered; flushLog pushes them into the viewport.
+func (m *model) appendLog(line string) {
+	m.logLines = append(m.logLines, logLine{text: line, at: time.Now()})
+	m.flushLog()
+}
+
+func (m *model) flushLog() {
+	if !m.sized {
+		return
 	}
+	m.logViewport.SetContent(renderLogLines(m.logLines, m.showLineNumbers, m.showTimestamps, m.logFilter.keep))
+	m.logViewport.GotoBottom()
 }
 
-// streamLines runs command and returns first line as cmdOutputMsg,
-// scheduling subsequent lines recursively.
-func streamLines(args []string, _ bool) tea.Msg {
-	// Use tea.ExecProcess for full terminal hand-off when needed,
-	// but here we collect all output and feed line by line.
-	cmd := exec.Command(args[0], args[1:]...)
-	out, err := cmd.CombinedOutput()
-	lines := strings.Split(stripANSI(string(out)), "\n")
+// commandArgv returns the full argv for a hackeros-steam subcommand.
+func commandArgv(args []string) []string {
+	return append([]string{cli}, args...)
+}
 
-	// Return a batchLines message that pumps lines into the model
-	return batchOutputMsg{lines: lines, success: err == nil}
+// escalatedArgv prefixes argv with the configured privilege command,
+// which may carry its own flags (e.g. "sudo -A").
+func escalatedArgv(argv []string) []string {
+	priv := strings.Fields(cfg.PrivilegeCmd)
+	if len(priv) == 0 {
+		priv = strings.Fields(defaultConfig().PrivilegeCmd)
+	}
+	return append(priv, argv...)
 }
 
-type batchOutputMsg struct {
-	lines   []string
-	success bool
+// commandEnv returns the process environment with overrides applied, or
+// nil (inherit) when there are none.
+func commandEnv(overrides []string) []string {
+	if len(overrides) == 0 {
+		return nil
+	}
+	return append(os.Environ(), overrides...)
 }
 
-func (m model) Update2(msg tea.Msg) (tea.Model, tea.Cmd) { return m, nil } // stub
+// permissionDeniedMarkers are lowercase fragments that indicate a command
+// failed for lack of privileges rather than for any other reason.
+var permissionDeniedMarkers = []string{
+	"permission denied",
+	"operation not permitted",
+	"must be root",
+	"must be run as root",
+	"are you root",
+	"requires root",
+	"eacces",
+}
 
-// We need to handle batchOutputMsg in the real Update — add it:
-func init() {} // placeholder
+func isPermissionError(lines []string) bool {
+	for _, line := range lines {
+		lo := strings.ToLower(line)
+		for _, marker := range permissionDeniedMarkers {
+			if strings.Contains(lo, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
 
 // checkStatusCmd runs `hackeros-steam status` silently
 func checkStatusCmd() tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command(cli, "status")
-		out, _ := cmd.Output()
-		lo := strings.ToLower(string(out))
-		switch {
-		case strings.Contains(lo, "does not exist"), strings.Contains(lo, "not created"):
-			return statusDoneMsg("missing")
-		case strings.Contains(lo, "● running"), strings.Contains(lo, "running"):
-			return statusDoneMsg("running")
-		default:
-			return statusDoneMsg("stopped")
-		}
+		return statusDoneMsg(probeStatus())
+	}
+}
+
+// probeStatus classifies the output of `hackeros-steam status`.
+func probeStatus() string {
+	out, err := auditedOutput("status", hostCommand(commandArgv([]string{"status"}), nil, false), stdoutOf)
+	text := stripANSI(string(out))
+	switch {
+	case isUnreachable(err):
+		return "unreachable"
+	case pat.statusMissing.MatchString(text):
+		return "missing"
+	case pat.statusPaused.MatchString(text):
+		return "paused"
+	case pat.statusRunning.MatchString(text):
+		return "running"
+	default:
+		return "stopped"
 	}
 }
 
@@ -370,21 +1007,59 @@ func checkStatusCmd() tea.Cmd {
 // ─────────────────────────────────────────────────────────────────
 
 func (m model) View() string {
-	if m.width == 0 {
+	if m.width <= 0 || m.height <= 0 {
 		return "Loading..."
 	}
 
-	left := m.renderSidebar()
-	right := m.renderLogPanel()
-
-	content := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+	var content string
+	switch m.paneLayout() {
+	case layoutGrid:
+		content = lipgloss.JoinVertical(lipgloss.Left, m.renderGrid(), m.renderLogPanel())
+	case layoutStacked:
+		content = lipgloss.JoinVertical(lipgloss.Left, m.renderStackedMenu(), m.renderLogPanel())
+	default:
+		content = lipgloss.JoinHorizontal(lipgloss.Top, m.renderSidebar(), m.renderLogPanel())
+	}
 
 	header := m.renderHeader()
-	statusBar := m.renderStatusBar()
+	statusBar := lipgloss.JoinVertical(lipgloss.Left, m.renderStatusBar(), m.renderFooter())
 
 	overlay := ""
-	if m.state == stateConfirm {
+	switch m.state {
+	case stateConfirm:
 		overlay = m.renderConfirmDialog()
+	case stateEscalate:
+		overlay = m.renderEscalateDialog()
+	case stateSubmenu:
+		overlay = m.renderSubmenu()
+	case stateGames:
+		overlay = m.renderGames()
+	case stateInteractive:
+		overlay = m.renderInteractiveDialog()
+	case stateEnv:
+		overlay = m.renderEnvEditor()
+	case stateQuitUpdate:
+		overlay = m.renderQuitUpdateDialog()
+	case stateForceKill:
+		overlay = m.renderForceKillDialog()
+	case statePhrase:
+		overlay = m.renderPhraseDialog()
+	case stateLibrary:
+		overlay = m.renderLibrary()
+	case stateSplitLogs:
+		overlay = m.renderSplitLogs()
+	case stateRename:
+		overlay = m.renderRenameDialog()
+	case stateCrash, stateCrashReport:
+		overlay = m.renderCrash()
+	case stateImport:
+		overlay = m.renderImportDialog()
+	}
+	switch m.popup {
+	case popupHistory:
+		overlay = m.renderHistory()
+	case popupLastError:
+		overlay = m.renderLastError()
 	}
 
 	base := lipgloss.JoinVertical(lipgloss.Left, header, content, statusBar)
@@ -408,123 +1083,233 @@ func (m model) renderHeader() string {
 	title := styleTitle.Render("HackerOS") + lipgloss.NewStyle().Foreground(colText).Bold(true).Render(" Steam")
 	sub := styleSubtitle.Render(" TUI  ·  Distrobox · Arch Linux")
 
+	if v := m.clientHeader(); v != "" {
+		sub += styleSubtitle.Render("  ·  " + v)
+	}
+	if s := m.sessionHeader(); s != "" {
+		sub += "  " + lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render(s)
+	}
+	if safeMode() {
+		sub += "  " + lipgloss.NewStyle().Foreground(colYellow).Bold(true).Render("SAFE MODE")
+	}
+
 	spin := ""
 	if m.busy {
 		spin = "  " + m.spinner.View()
 	}
 
-	left := title + sub + spin
-	right := lipgloss.NewStyle().Foreground(colDim).Render("q quit · r refresh · ↑↓ navigate · enter select")
-
-	gap := m.width - lipgloss.Width(left) - lipgloss.Width(right) - 2
-	if gap < 1 {
-		gap = 1
-	}
-
 	bar := lipgloss.NewStyle().
 		Background(colBg).
 		Foreground(colText).
 		Padding(0, 1).
 		Width(m.width).
-		Render(left + strings.Repeat(" ", gap) + right)
+		MaxHeight(1).
+		Render(title + sub + spin)
 
 	divider := styleDivider.Render(strings.Repeat("─", m.width))
 	return lipgloss.JoinVertical(lipgloss.Left, bar, divider)
 }
 
 func (m model) renderSidebar() string {
-	sideWidth := 28
-	var rows []string
+	rows, _ := m.menuRows(sidebarWidth)
+
+	// Fill remaining height
+	used := len(rows) + 5 // header + statusbar + footer
+	fill := m.height - used - 5
+	for i := 0; i < fill; i++ {
+		rows = append(rows, strings.Repeat(" ", sidebarWidth))
+	}
+
+	body := strings.Join(rows, "\n")
+
+	return lipgloss.NewStyle().
+		Width(sidebarWidth).
+		Height(m.height - 5).
+		Background(colBg).
+		BorderRight(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(colBorder).
+		Render(body)
+}
+
+// menuRows renders the list menu sideWidth cells wide: favorites,
+// section headers and items. cursorRow is the row of the cursor.
+func (m model) menuRows(sideWidth int) (rows []string, cursorRow int) {
+	if bar := renderQuickLaunch(sideWidth); len(bar) > 0 {
+		rows = append(rows, styleSectionLabel.Width(sideWidth).Render(" FAVORITES"))
+		for _, row := range bar {
+			rows = append(rows, lipgloss.NewStyle().Width(sideWidth).Render(row))
+		}
+	}
 
 	currentSection := ""
 	for i, item := range menuItems {
+		if !m.visible(i) {
+			continue
+		}
 		// Section header
-		if item.section != "" && item.section != currentSection {
-			currentSection = item.section
+		if section := sectionOf(i); section != "" && section != currentSection {
+			currentSection = section
 			if len(rows) > 0 {
 				rows = append(rows, "")
 			}
 			rows = append(rows, styleSectionLabel.
 				Width(sideWidth).
-				Render(" "+item.section))
+				Render(" "+section))
+			if m.launchSummary != "" && isLaunchSection(section) {
+				rows = append(rows, styleLogDim.Width(sideWidth).Render(truncate("  "+m.launchSummary, sideWidth)))
+			}
 		}
 
 		icon := styleMenuIcon.Render(item.icon)
-		label := item.label
 
-		if i == m.cursor {
-			row := styleMenuSelected.Render("") +
-				icon + " " +
-				lipgloss.NewStyle().Foreground(colAccent).Bold(true).Render(label)
+		if !m.selectable(i) {
+			if safeMode() && item.destructive {
+				icon = styleMenuIcon.Foreground(colDim).Render("⊘")
+			}
+			row := m.hintedRow("  "+icon+" ", i, styleMenuItem.Foreground(colDim), sideWidth)
+			rows = append(rows, lipgloss.NewStyle().Width(sideWidth).Render(row))
+		} else if i == m.cursor {
+			cursorRow = len(rows)
+			row := m.hintedRow(styleMenuSelected.Render("")+icon+" ", i,
+				lipgloss.NewStyle().Foreground(colCursor).Bold(true), sideWidth)
 			rows = append(rows, lipgloss.NewStyle().
 				Background(lipgloss.Color("#0e2040")).
 				Width(sideWidth).
 				Render(row))
 		} else {
-			row := "  " + icon + " " + styleMenuItem.Render(label)
+			row := m.hintedRow("  "+icon+" ", i, styleMenuItem, sideWidth)
 			rows = append(rows, lipgloss.NewStyle().Width(sideWidth).Render(row))
 		}
+		if m.gamescopeSummary != "" && item.label == "Gamescope" {
+			rows = append(rows, styleLogDim.Width(sideWidth).Render(truncate("  "+m.gamescopeSummary, sideWidth)))
+		}
 	}
-
-	// Fill remaining height
-	used := len(rows) + 4 // header + statusbar
-	fill := m.height - used - 4
-	for i := 0; i < fill; i++ {
-		rows = append(rows, strings.Repeat(" ", sideWidth))
-	}
-
-	body := strings.Join(rows, "\n")
-
-	return lipgloss.NewStyle().
-		Width(sideWidth).
-		Height(m.height-4).
-		Background(colBg).
-		BorderRight(true).
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(colBorder).
-		Render(body)
+	return rows, cursorRow
 }
 
 func logPanelWidth(w int) int {
 	if w < 32 {
 		return 10
 	}
-	return w - 30 // sidebar=28 + border=2
+	return w - sidebarWidth - 2 // border=2
+}
+
+// progressBarWidth scales the title-bar progress bar with the log panel.
+func progressBarWidth(w int) int {
+	return max(10, min(30, w/3))
+}
+
+// logSize returns the log panel's width and height for the current
+// layout.
+func (m model) logSize() (int, int) {
+	switch m.paneLayout() {
+	case layoutGrid:
+		return m.width, max(1, logPanelHeight(m.height)-m.gridHeight())
+	case layoutStacked:
+		return m.width, max(1, logPanelHeight(m.height)-stackedMenuHeight(m.height))
+	}
+	return logPanelWidth(m.width), logPanelHeight(m.height)
+}
+
+// layoutLog sizes the log viewport and progress bar after a resize or a
+// layout change.
+func (m *model) layoutLog() {
+	w, h := m.logSize()
+	m.logViewport.Width = w
+	m.logViewport.Height = h
+	m.progressBar.Width = progressBarWidth(w)
 }
 
 func logPanelHeight(h int) int {
-	if h < 6 {
+	if h < 7 {
 		return 1
 	}
-	return h - 5 // header(2) + statusbar(1) + padding(2)
+	return h - 6 // header(2) + statusbar(2) + footer(1) + title(1)
 }
 
 func (m model) renderLogPanel() string {
-	w := logPanelWidth(m.width)
-	h := logPanelHeight(m.height)
+	w, h := m.logSize()
 
 	m.logViewport.Width = w - 2
 	m.logViewport.Height = h
 
 	// Title bar
+	label := "● ● ●   Output Log"
+	if m.busy && m.hasProgress {
+		bar := m.progressBar.ViewAs(m.progress)
+		if m.totalFiles > 0 {
+			bar = fmt.Sprintf("Extracting %d/%d files  ", m.files, m.totalFiles) + bar
+		}
+		gap := w - 2 - lipgloss.Width(label) - lipgloss.Width(bar)
+		if gap > 0 {
+			label += strings.Repeat(" ", gap) + bar
+		}
+	}
 	title := lipgloss.NewStyle().
 		Foreground(colDim).
 		Background(lipgloss.Color("#0d0f14")).
 		Width(w).
 		Padding(0, 1).
-		Render("● ● ●   Output Log")
+		Render(label)
+
+	if speed := m.renderSpeedLine(w); speed != "" {
+		title = lipgloss.JoinVertical(lipgloss.Left, title, speed)
+		m.logViewport.Height = h - 1
+	}
+	if warn := m.renderWarnings(w); warn != "" && h >= warningsMinHeight {
+		title = lipgloss.JoinVertical(lipgloss.Left, title, warn)
+		m.logViewport.Height -= lipgloss.Height(warn)
+	}
+	if procs := m.renderProcs(w); procs != "" && h >= procMinHeight {
+		title = lipgloss.JoinVertical(lipgloss.Left, title, procs)
+		m.logViewport.Height -= lipgloss.Height(procs)
+	}
+	if filter := m.renderFilterLine(w); filter != "" {
+		title = lipgloss.JoinVertical(lipgloss.Left, title, filter)
+		m.logViewport.Height--
+	}
 
 	panel := lipgloss.NewStyle().
 		Width(w).
-		Height(h).
+		Height(m.logViewport.Height).
 		Background(colBgDeep).
 		Render(m.logViewport.View())
 
 	return lipgloss.JoinVertical(lipgloss.Left, title, panel)
 }
 
+// renderSpeedLine draws the download speed history under the progress
+// bar, or nothing when no speed has been reported.
+func (m model) renderSpeedLine(w int) string {
+	samples := m.speeds.values()
+	if !m.busy || len(samples) == 0 {
+		return ""
+	}
+	text := formatSpeed(m.speeds.last())
+	if !cfg.ReducedMotion {
+		text = lipgloss.NewStyle().Foreground(colGreen).Render(sparkline(samples)) + " " + text
+	}
+	return lipgloss.NewStyle().
+		Foreground(colSub).
+		Background(lipgloss.Color("#0d0f14")).
+		Width(w).
+		Padding(0, 1).
+		Align(lipgloss.Right).
+		Render(text)
+}
+
 func (m model) renderStatusBar() string {
 	status := m.statusString()
+	if s := m.batchStatus(); s != "" {
+		status += lipgloss.NewStyle().Foreground(colAccent).Render("  " + s)
+	}
+	if s := m.pollSummary(); s != "" {
+		status += lipgloss.NewStyle().Foreground(colDim).Render("  " + s)
+	}
+	if s := m.networkIndicator(); s != "" {
+		status += "  " + s
+	}
 
 	left := lipgloss.NewStyle().
 		Foreground(colAccent).
@@ -535,7 +1320,18 @@ func (m model) renderStatusBar() string {
 
 	right := lipgloss.NewStyle().
 		Foreground(colDim).
-		Render("docker.io/archlinux:latest")
+		Render(hostLabel() + m.engine() + " · docker.io/archlinux:latest")
+	if m.busy && m.runningCmdline != "" {
+		room := m.width - lipgloss.Width(left) - lipgloss.Width(sep) - lipgloss.Width(status) - 6
+		right = lipgloss.NewStyle().Foreground(colSub).Render(truncate("$ "+m.runningCmdline, max(room, 8)))
+	}
+	if m.toast != "" {
+		right = lipgloss.NewStyle().Foreground(colGreen).Render(m.toast)
+	}
+	if m.autorun != nil {
+		right = lipgloss.NewStyle().Foreground(colYellow).Render(
+			fmt.Sprintf("%s in %ds — any key cancels", m.autorun.label, m.autorunLeft))
+	}
 
 	gap := m.width - lipgloss.Width(left) - lipgloss.Width(sep) - lipgloss.Width(status) - lipgloss.Width(right) - 4
 	if gap < 1 {
@@ -556,64 +1352,77 @@ func (m model) statusString() string {
 	switch m.containerStatus {
 	case "running":
 		return styleStatusRunning.Render("● Running")
+	case "paused":
+		return styleStatusStopped.Render("⏸ Paused")
 	case "stopped":
 		return styleStatusStopped.Render("○ Stopped")
 	case "missing":
 		return styleStatusMissing.Render("✖ Not Created")
+	case "unreachable":
+		return styleStatusMissing.Render("✖ Host Unreachable")
 	default:
 		return styleStatusCheck.Render("… Checking")
 	}
 }
 
 func (m model) renderConfirmDialog() string {
-	item := ""
-	for _, mi := range menuItems {
-		if len(mi.cmd) > 0 && mi.cmd[len(mi.cmd)-1] == "remove" {
-			item = mi.label
-			break
-		}
-	}
+	item := m.pendingItem
 
-	content := lipgloss.JoinVertical(lipgloss.Center,
+	lines := []string{
 		lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("⚠  Confirm Action"),
 		"",
-		lipgloss.NewStyle().Foreground(colText).Render("Remove container: "+item),
-		lipgloss.NewStyle().Foreground(colSub).Render("This cannot be undone."),
+		lipgloss.NewStyle().Foreground(colText).Render(item.label + ": " + containerName),
+	}
+	for _, w := range item.warning {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colSub).Render(w))
+	}
+	lines = append(lines,
 		"",
 		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[Y]")+" "+
 			lipgloss.NewStyle().Foreground(colText).Render("confirm")+"   "+
 			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[N]")+" "+
 			lipgloss.NewStyle().Foreground(colText).Render("cancel"),
+		m.renderCountdown(),
 	)
 
+	return m.placeOverlay(styleConfirmBox, lipgloss.JoinVertical(lipgloss.Center, lines...))
+}
+
+// placeOverlay draws content in box, centred below the header. A box
+// wider than the terminal is re-flowed with tighter padding rather than
+// clipped, and the top margin shrinks on short terminals.
+func (m model) placeOverlay(box lipgloss.Style, content string) string {
+	rendered := box.Render(content)
+	if lipgloss.Width(rendered) > m.width {
+		rendered = box.Padding(1, 1).Width(max(m.width-2, 10)).Render(content)
+	}
+	// header(2) + status bar(2) + footer(1)
+	pad := max(0, min(2, m.height-5-lipgloss.Height(rendered)))
 	return lipgloss.NewStyle().
 		Width(m.width).
 		Align(lipgloss.Center).
-		Padding(2, 0).
-		Render(styleConfirmBox.Render(content))
+		PaddingTop(pad).
+		PaddingBottom(pad).
+		Render(rendered)
 }
 
-// ─────────────────────────────────────────────────────────────────
-//  ANSI strip
-// ─────────────────────────────────────────────────────────────────
+func (m model) renderEscalateDialog() string {
+	argv := escalatedArgv(m.pendingAction.argv)
 
-func stripANSI(s string) string {
-	var out strings.Builder
-	inEsc := false
-	for _, r := range s {
-		if r == '\x1b' {
-			inEsc = true
-			continue
-		}
-		if inEsc {
-			if r == 'm' {
-				inEsc = false
-			}
-			continue
-		}
-		out.WriteRune(r)
-	}
-	return out.String()
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Foreground(colYellow).Bold(true).Render("⚠  Permission Denied"),
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render("The command needs elevated privileges. Re-run as:"),
+		lipgloss.NewStyle().Foreground(colAccent).Render(strings.Join(argv, " ")),
+		"",
+		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[Y]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("re-run")+"   "+
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[N]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("cancel"),
+		m.renderCountdown(),
+	)
+
+	return m.placeOverlay(styleConfirmBox.BorderForeground(colYellow), content)
 }
 
 // colorLine applies lipgloss color based on line content
@@ -626,9 +1435,7 @@ func colorLine(line string) string {
 		return styleLogError.Render(l)
 	case strings.Contains(l, "⚠") || strings.Contains(l, "warning") || strings.Contains(l, "skipped") || strings.Contains(l, "Warning"):
 		return styleLogWarning.Render(l)
-	case strings.Contains(l, "─ ") || strings.Contains(l, "LAUNCH") || strings.Contains(l, "CREAT") ||
-		strings.Contains(l, "SETUP") || strings.Contains(l, "UPDAT") || strings.Contains(l, "REMOV") ||
-		strings.Contains(l, "STATUS") || strings.Contains(l, "STOP"):
+	case pat.phase.MatchString(l):
 		return styleLogHeader.Render(l)
 	case strings.Contains(l, "→") || strings.Contains(l, "$"):
 		return styleLogInfo.Render(l)
@@ -638,57 +1445,171 @@ func colorLine(line string) string {
 }
 
 // ─────────────────────────────────────────────────────────────────
-//  Main — handle batchOutputMsg in a wrapper model
+//  Main
 // ─────────────────────────────────────────────────────────────────
 
-type appModel struct {
-	inner model
+// programOptions are the bubbletea options for --no-altscreen set or
+// not, and whether they use the alternate screen. Without it the TUI
+// draws over the terminal's normal buffer, so what was on screen when
+// it quit stays in the scrollback.
+func programOptions(noAltScreen bool) (opts []tea.ProgramOption, altScreen bool) {
+	opts = []tea.ProgramOption{tea.WithMouseCellMotion(), tea.WithReportFocus()}
+	if altScreen = !noAltScreen; altScreen {
+		opts = append(opts, tea.WithAltScreen())
+	}
+	return opts, altScreen
 }
 
-func (a appModel) Init() tea.Cmd {
-	return a.inner.Init()
-}
+func main() {
+	daemonMode := flag.Bool("daemon", false, "run the background container watcher instead of the TUI")
+	flag.StringVar(&remoteHost, "remote", "", "manage the container on `user@host` over ssh")
+	flag.StringVar(&progressFile, "progress-file", "", "also read progress from lines appended to `path`")
+	flag.StringVar(&progressOut, "progress-out", "", "mirror progress and status as JSON lines to `path` (a named pipe or file; - for stderr)")
+	flag.StringVar(&autorunFlag, "autorun", "", "start `action` right away: "+autorunNames())
+	safeFlag := flag.Bool("safe", false, "disable actions that change or delete the container")
+	flag.StringVar(&startView, "view", startView, "open on `screen`: "+strings.Join(viewNames, ", "))
+	flag.StringVar(&listenFlag, "listen", "", "accept JSON commands on `unix:/path`")
+	flag.BoolVar(&verboseMode, "verbose", false, "list the processes the TUI spawns, with their PIDs and exit status")
+	noAltScreen := flag.Bool("no-altscreen", false, "draw in the normal screen, leaving the last frame in the scrollback")
+	flag.Parse()
+	if err := validateRemote(remoteHost); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	if progressFile != "" && remoteHost != "" {
+		fmt.Fprintln(os.Stderr, "Error: --progress-file can't be used with --remote")
+		os.Exit(2)
+	}
+	if progressOut != "" {
+		var err error
+		if progressSink, err = openProgressMirror(progressOut); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --progress-out: %v\n", err)
+			os.Exit(2)
+		}
+		defer progressSink.close()
+	}
+	if err := validateView(startView); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	var controlPath string
+	if listenFlag != "" {
+		var err error
+		if controlPath, err = parseListen(listenFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		if *daemonMode {
+			fmt.Fprintln(os.Stderr, "Error: --listen can't be used with --daemon")
+			os.Exit(2)
+		}
+	}
 
-func (a appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case batchOutputMsg:
-		// Feed all lines into the inner model then signal done
-		for _, line := range msg.lines {
-			if strings.TrimSpace(line) == "" {
-				a.inner.appendLog("")
-				continue
-			}
-			a.inner.appendLog(colorLine(line))
+	moved, migrateErr := migrateLegacy()
+	for _, mv := range moved {
+		startupNotes = append(startupNotes, "Moved "+mv)
+	}
+	cfg, cfgLoadErr = loadConfig()
+	var envErr, launchErr, nameErr error
+	if containerName, nameErr = loadContainerName(containerNamePath()); nameErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, nameErr)
+	}
+	if persistentEnv, envErr = readEnvFile(containerEnvPath()); envErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, envErr)
+	}
+	if launch, launchErr = loadLaunch(launchPath()); launchErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, launchErr)
+	}
+	var favErr error
+	if favorites, favErr = loadFavorites(favoritesPath()); favErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, favErr)
+	}
+	if migrateErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, fmt.Errorf("migrating old files: %w", migrateErr))
+	}
+	if remoteHost == "" {
+		plugins, pluginErr := loadPlugins(pluginDir())
+		if pluginErr != nil {
+			cfgLoadErr = errors.Join(cfgLoadErr, pluginErr)
 		}
-		a.inner.busy = false
-		a.inner.state = stateMenu
-		if msg.success {
-			a.inner.appendLog(styleLogSuccess.Render("  ✔  Done."))
-		} else {
-			a.inner.appendLog(styleLogError.Render("  ✖  Command exited with error."))
+		addPlugins(plugins)
+	}
+	var shortcutErr error
+	if actionKeys, shortcutErr = compileShortcuts(cfg.Shortcuts); shortcutErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, shortcutErr)
+	}
+	cfg.SafeMode = cfg.SafeMode || *safeFlag
+	if err := validateAttention(cfg.Attention); err != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, err)
+		cfg.Attention = attentionOff
+	}
+	setupAudit(cfgLoadErr)
+	if autorunFlag != "" {
+		if _, err := autorunItem(autorunFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
 		}
-		a.inner.appendLog("")
-		return a, checkStatusCmd()
+		cfg.Autorun = autorunFlag
+	}
+	var patErr error
+	if pat, patErr = compilePatterns(cfg.Patterns); patErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, patErr)
+	}
+	var pollErr, limitErr error
+	if pollIntervals, pollErr = compilePolls(cfg.Poll); pollErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, pollErr)
+	}
+	if outputLimit, limitErr = compileOutputLimit(cfg.OutputLimit); limitErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, limitErr)
 	}
+	colors, themeErr := compileTheme(cfg.Theme)
+	if themeErr != nil {
+		cfgLoadErr = errors.Join(cfgLoadErr, themeErr)
+	}
+	applyTheme(colors)
 
-	updated, cmd := a.inner.Update(msg)
-	a.inner = updated.(model)
-	return a, cmd
-}
+	if *daemonMode {
+		if err := runDaemon(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if cfg.NoColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
 
-func (a appModel) View() string {
-	return a.inner.View()
-}
+	takeInstanceLock()
+	defer releaseLock(instanceLock)
 
-func main() {
-	app := appModel{inner: initialModel()}
-	p := tea.NewProgram(
-		app,
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
-	if _, err := p.Run(); err != nil {
+	var control net.Listener
+	if controlPath != "" {
+		var err error
+		if control, err = listenControl(controlPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --listen: %v\n", err)
+			os.Exit(1)
+		}
+		defer os.Remove(controlPath)
+		defer control.Close()
+		startupNotes = append(startupNotes, "Listening for commands on "+controlPath)
+	}
+
+	opts, _ := programOptions(*noAltScreen)
+	p := tea.NewProgram(initialModel(), opts...)
+	if control != nil {
+		appLife.goTracked(func(ctx context.Context) { serveControl(ctx, control, p.Send) })
+	}
+	final, err := p.Run()
+	// Also after exits that didn't go through quit.
+	appLife.shutdown(shutdownGrace)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	// A game still running when the TUI quits counts up to now.
+	if fm, ok := final.(model); ok {
+		if save := fm.endSession(time.Now()); save != nil {
+			save()
+		}
+	}
 }
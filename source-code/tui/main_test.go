@@ -0,0 +1,253 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func press(m model, keys ...string) model {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		next, _ := m.Update(msg)
+		m = next.(model)
+	}
+	return m
+}
+
+func tick(m model) model {
+	next, _ := m.Update(spinner.TickMsg{ID: m.spinner.ID()})
+	return next.(model)
+}
+
+// countLogged is how many log lines contain s.
+func countLogged(m model, s string) int {
+	n := 0
+	for _, l := range m.logLines {
+		if strings.Contains(l.text, s) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestKeysInterleavedWithTicks(t *testing.T) {
+	tests := []struct {
+		name   string
+		downs  int
+		ticks  int
+		cursor int
+	}{
+		{"no ticks", 3, 0, 3},
+		{"a tick per key", 3, 1, 3},
+		{"tick bursts", 4, 10, 4},
+		{"past the end", 50, 2, len(menuItems) - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			for i := 0; i < tt.downs; i++ {
+				for j := 0; j < tt.ticks; j++ {
+					m = tick(m)
+				}
+				m = press(m, "down")
+			}
+			if m.cursor != tt.cursor {
+				t.Errorf("cursor = %d, want %d", m.cursor, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestEnterBurstStartsOneCommand(t *testing.T) {
+	for _, ticks := range []int{0, 1, 5} {
+		m := initialModel()
+		for i := 0; i < 10; i++ {
+			m = press(m, "enter")
+			for j := 0; j < ticks; j++ {
+				m = tick(m)
+			}
+		}
+		if !m.busy || m.state != stateRunning {
+			t.Fatalf("ticks=%d: busy=%v state=%v, want a running command", ticks, m.busy, m.state)
+		}
+		if n := countLogged(m, "$ hackeros-steam"); n != 1 {
+			t.Errorf("ticks=%d: %d commands started, want 1", ticks, n)
+		}
+	}
+}
+
+func TestIsPermissionError(t *testing.T) {
+	tests := []struct {
+		lines []string
+		want  bool
+	}{
+		{[]string{"mount: /mnt: Permission denied."}, true},
+		{[]string{"ok", "chown: Operation not permitted"}, true},
+		{[]string{"error: you cannot perform this operation unless you are root.", "This must be run as root"}, true},
+		{[]string{"open /dev/kvm: EACCES"}, true},
+		{[]string{"error: target not found: steam"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := isPermissionError(tt.lines); got != tt.want {
+			t.Errorf("isPermissionError(%q) = %v, want %v", tt.lines, got, tt.want)
+		}
+	}
+}
+
+func TestEscalatedArgv(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	argv := []string{cli, "setup"}
+	tests := []struct {
+		priv string
+		want []string
+	}{
+		{"pkexec", []string{"pkexec", cli, "setup"}},
+		{"sudo -A", []string{"sudo", "-A", cli, "setup"}},
+		{"  ", []string{"pkexec", cli, "setup"}},
+	}
+	for _, tt := range tests {
+		cfg.PrivilegeCmd = tt.priv
+		if got := escalatedArgv(argv); strings.Join(got, "\x00") != strings.Join(tt.want, "\x00") {
+			t.Errorf("escalatedArgv with %q = %q, want %q", tt.priv, got, tt.want)
+		}
+	}
+	if argv[0] != cli || len(argv) != 2 {
+		t.Errorf("escalatedArgv modified its argument: %q", argv)
+	}
+}
+
+func TestLogBufferedUntilSized(t *testing.T) {
+	m := initialModel()
+	m.sized, m.width, m.height = false, 0, 0
+	for _, l := range []string{"one", "two", "three"} {
+		m.appendLog(l)
+	}
+	if n := m.logViewport.TotalLineCount(); n != 0 {
+		t.Fatalf("viewport has %d lines before sizing, want 0", n)
+	}
+	if v := m.View(); v != "Loading..." {
+		t.Errorf("unsized View() = %q", v)
+	}
+
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = next.(model)
+	if !m.sized {
+		t.Fatal("model not sized after WindowSizeMsg")
+	}
+	if !strings.Contains(m.logViewport.View(), "three") {
+		t.Errorf("buffered lines not flushed:\n%s", m.logViewport.View())
+	}
+	if m.View() == "" {
+		t.Error("sized View() is empty")
+	}
+}
+
+func TestRepairContainer(t *testing.T) {
+	idx := itemIndex(t, "Repair Container")
+	repair := menuItems[idx]
+	if got := strings.Join(commandArgv(repair.cmd), " "); got != cli+" --force create" {
+		t.Errorf("repair argv = %q", got)
+	}
+
+	tests := []struct {
+		answer  string
+		started bool
+	}{
+		{"y", true},
+		{"n", false},
+		{"esc", false},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.width, m.height = 120, 40
+		m.containerStatus = "running"
+		m.cursor = idx
+		m = press(m, "enter")
+		if m.state != stateConfirm || m.pendingItem == nil || m.pendingItem.label != repair.label {
+			t.Fatalf("enter on Repair Container did not ask first (state %v)", m.state)
+		}
+		if dialog := m.renderConfirmDialog(); !strings.Contains(dialog, "are kept") {
+			t.Errorf("confirm dialog lacks the explanation:\n%s", dialog)
+		}
+		m = press(settle(m), tt.answer)
+		if started := countLogged(m, "$ hackeros-steam --force create") == 1; started != tt.started {
+			t.Errorf("answer %q: started = %v, want %v", tt.answer, started, tt.started)
+		}
+		if !tt.started {
+			continue
+		}
+		next, _ := m.Update(cmdDoneMsg(true))
+		m = next.(model)
+		if countLogged(m, repair.doneNote) != 1 {
+			t.Errorf("summary %q not logged", repair.doneNote)
+		}
+	}
+}
+
+func TestResizeInEveryState(t *testing.T) {
+	setups := []struct {
+		name  string
+		setup func(m *model)
+		draw  func(m model) string
+	}{
+		{"menu", func(m *model) {}, func(m model) string { return m.View() }},
+		{"confirm", func(m *model) {
+			m.openPrompt(stateConfirm, menuItems[itemIndex(t, "Repair Container")])
+		}, model.renderConfirmDialog},
+		{"escalate", func(m *model) {
+			m.openPrompt(stateEscalate, menuItems[itemIndex(t, "Setup / Repair Steam")])
+		}, model.renderEscalateDialog},
+		{"submenu", func(m *model) { m.openChannelMenu() }, model.renderSubmenu},
+		{"history", func(m *model) { m.popup = popupHistory }, model.renderHistory},
+		{"running", func(m *model) {
+			m.busy, m.state, m.hasProgress, m.progress = true, stateRunning, true, 0.5
+		}, model.renderLogPanel},
+	}
+	sizes := []struct{ w, h int }{{160, 50}, {80, 24}, {50, 14}, {120, 40}}
+
+	t.Setenv("HOME", t.TempDir())
+	for _, s := range setups {
+		t.Run(s.name, func(t *testing.T) {
+			m := initialModel()
+			s.setup(&m)
+			state, popup := m.state, m.popup
+			for _, size := range sizes {
+				next, _ := m.Update(tea.WindowSizeMsg{Width: size.w, Height: size.h})
+				next, _ = next.Update(resizeFlushMsg{}) // the debounce's tick
+				m = next.(model)
+				if m.state != state || m.popup != popup {
+					t.Fatalf("%dx%d: resize changed state to %v (popup %v)", size.w, size.h, m.state, m.popup)
+				}
+				if w, h := m.logSize(); m.logViewport.Width != w || m.logViewport.Height != h {
+					t.Errorf("%dx%d: viewport %dx%d, want %dx%d", size.w, size.h, m.logViewport.Width, m.logViewport.Height, w, h)
+				}
+				if w, _ := m.logSize(); m.progressBar.Width < 10 || m.progressBar.Width > 30 || m.progressBar.Width > w {
+					t.Errorf("%dx%d: progress bar width %d", size.w, size.h, m.progressBar.Width)
+				}
+				out := s.draw(m)
+				if w := lipgloss.Width(out); w > size.w {
+					t.Errorf("%dx%d: drawn %d columns wide", size.w, size.h, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  MangoHud
+//  The "MangoHud" submenu turns the performance overlay on for every
+//  Steam launch: MANGOHUD=1 enables its Vulkan layer, MANGOHUD_CONFIG
+//  picks what it shows. Turning it on is gated on the layer being
+//  installed in the container. Kept in the [mangohud] table of
+//  launch.toml; config may hold any MANGOHUD_CONFIG string.
+// ─────────────────────────────────────────────────────────────────
+
+// mangohudLayerGlob matches the Vulkan layer manifests the mangohud
+// and lib32-mangohud packages install.
+const mangohudLayerGlob = "/usr/share/vulkan/implicit_layer.d/MangoHud*.json"
+
+type mangohudSettings struct {
+	Enabled bool `toml:"enabled"`
+	// Config is passed as MANGOHUD_CONFIG; empty uses MangoHud.conf.
+	Config string `toml:"config"`
+}
+
+// mangohudLayouts are the Layout choices in the order they cycle.
+var mangohudLayouts = []struct{ config, label string }{
+	{"", "MangoHud.conf"},
+	{"fps_only", "FPS only"},
+	{"full", "Everything"},
+}
+
+type mangohudDetectedMsg struct{ err error }
+
+// mangohudEnv is the environment that applies s to a launch.
+func mangohudEnv(s mangohudSettings) []string {
+	if !s.Enabled {
+		return nil
+	}
+	env := []string{"MANGOHUD=1"}
+	if s.Config != "" {
+		env = append(env, "MANGOHUD_CONFIG="+s.Config)
+	}
+	return env
+}
+
+func mangohudLayoutLabel(config string) string {
+	for _, l := range mangohudLayouts {
+		if l.config == config {
+			return l.label
+		}
+	}
+	return "custom"
+}
+
+// nextMangohudLayout is the layout after config; a custom one goes
+// back to the first.
+func nextMangohudLayout(config string) string {
+	for i, l := range mangohudLayouts {
+		if l.config == config {
+			return mangohudLayouts[(i+1)%len(mangohudLayouts)].config
+		}
+	}
+	return mangohudLayouts[0].config
+}
+
+// mangohudStatus is the one-line summary for titles.
+func mangohudStatus(s mangohudSettings) string {
+	if !s.Enabled {
+		return "Off"
+	}
+	return "On, " + mangohudLayoutLabel(s.Config)
+}
+
+// openMangohudMenu is the "MangoHud" action.
+func (m *model) openMangohudMenu() tea.Cmd {
+	s := launch.MangoHud
+	m.openSubmenu(&submenu{
+		title:  "MangoHud — " + mangohudStatus(s),
+		marked: -1,
+		items: []menuItem{
+			{icon: "▤", label: "Overlay: " + onOff(s.Enabled), run: (*model).toggleMangohud},
+			{icon: "◑", label: "Layout: " + mangohudLayoutLabel(s.Config), run: (*model).cycleMangohudLayout},
+		},
+	})
+	return nil
+}
+
+func (m *model) toggleMangohud() tea.Cmd {
+	if launch.MangoHud.Enabled {
+		return m.setMangohud(false)
+	}
+	m.busy = true
+	return detectMangohudCmd()
+}
+
+func (m *model) setMangohud(on bool) tea.Cmd {
+	next := launch
+	next.MangoHud.Enabled = on
+	if err := m.saveLaunch(next); err != nil {
+		return nil
+	}
+	return m.showToast("MangoHud " + mangohudStatus(next.MangoHud))
+}
+
+func (m *model) cycleMangohudLayout() tea.Cmd {
+	next := launch
+	next.MangoHud.Config = nextMangohudLayout(launch.MangoHud.Config)
+	if err := m.saveLaunch(next); err != nil {
+		return nil
+	}
+	m.openMangohudMenu()
+	return nil
+}
+
+func (m *model) handleMangohudDetected(msg mangohudDetectedMsg) tea.Cmd {
+	m.busy = false
+	if msg.err != nil {
+		m.appendLog(styleLogWarning.Render("  ⚠  MangoHud left off: " + msg.err.Error()))
+		return m.showToast("MangoHud not installed")
+	}
+	return m.setMangohud(true)
+}
+
+func detectMangohudCmd() tea.Cmd {
+	return func() tea.Msg {
+		return mangohudDetectedMsg{err: checkMangohud(mangohudInstalled)}
+	}
+}
+
+// checkMangohud returns why the overlay can't be turned on, nil when
+// installed reports the layer present.
+func checkMangohud(installed func() (bool, error)) error {
+	ok, err := installed()
+	switch {
+	case err != nil:
+		return fmt.Errorf("the container couldn't be asked: %w", err)
+	case !ok:
+		return errors.New("it isn't installed in the container; install mangohud and lib32-mangohud")
+	}
+	return nil
+}
+
+// mangohudInstalled looks for the Vulkan layer in the container.
+func mangohudInstalled() (bool, error) {
+	out, err := auditedOutput("MangoHud", hostCommand([]string{"distrobox", "enter", containerName, "--",
+		"sh", "-c", "ls " + mangohudLayerGlob + " 2>/dev/null || true"}, nil, false), stdoutOf)
+	if err != nil {
+		return false, err
+	}
+	return len(out) > 0, nil
+}
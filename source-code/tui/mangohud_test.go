@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMangohudEnv(t *testing.T) {
+	tests := []struct {
+		s    mangohudSettings
+		want string
+	}{
+		{mangohudSettings{}, ""},
+		{mangohudSettings{Config: "fps_only"}, ""},
+		{mangohudSettings{Enabled: true}, "MANGOHUD=1"},
+		{mangohudSettings{Enabled: true, Config: "fps_only"}, "MANGOHUD=1 MANGOHUD_CONFIG=fps_only"},
+		{mangohudSettings{Enabled: true, Config: "position=top-right,gpu_temp"}, "MANGOHUD=1 MANGOHUD_CONFIG=position=top-right,gpu_temp"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(mangohudEnv(tt.s), " "); got != tt.want {
+			t.Errorf("mangohudEnv(%+v) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestLaunchEnvIncludesMangohud(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	launch = launchSettings{MangoHud: mangohudSettings{Enabled: true, Config: "full"}}
+	if got := strings.Join(launchEnv(), " "); got != "MANGOHUD=1 MANGOHUD_CONFIG=full" {
+		t.Errorf("launchEnv = %q", got)
+	}
+}
+
+func TestMangohudLayouts(t *testing.T) {
+	tests := []struct {
+		config, label, next string
+	}{
+		{"", "MangoHud.conf", "fps_only"},
+		{"fps_only", "FPS only", "full"},
+		{"full", "Everything", ""},
+		{"gpu_temp", "custom", ""},
+	}
+	for _, tt := range tests {
+		if got := mangohudLayoutLabel(tt.config); got != tt.label {
+			t.Errorf("mangohudLayoutLabel(%q) = %q, want %q", tt.config, got, tt.label)
+		}
+		if got := nextMangohudLayout(tt.config); got != tt.next {
+			t.Errorf("nextMangohudLayout(%q) = %q, want %q", tt.config, got, tt.next)
+		}
+	}
+}
+
+func TestCheckMangohud(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed bool
+		err       error
+		want      string // in the error; "" for none
+	}{
+		{"installed", true, nil, ""},
+		{"missing", false, nil, "isn't installed"},
+		{"container unreachable", false, errors.New("exit status 125"), "couldn't be asked: exit status 125"},
+	}
+	for _, tt := range tests {
+		err := checkMangohud(func() (bool, error) { return tt.installed, tt.err })
+		if got := ""; err != nil {
+			got = err.Error()
+			if tt.want == "" || !strings.Contains(got, tt.want) {
+				t.Errorf("%s: checkMangohud = %q, want %q", tt.name, got, tt.want)
+			}
+		} else if tt.want != "" {
+			t.Errorf("%s: checkMangohud = nil, want %q", tt.name, tt.want)
+		}
+	}
+}
+
+func TestMangohudToggleGated(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	launch = launchSettings{}
+
+	m := initialModel()
+	if cmd := m.toggleMangohud(); cmd == nil || !m.busy {
+		t.Fatal("turning MangoHud on did not check for it first")
+	}
+	m.handleMangohudDetected(mangohudDetectedMsg{err: errors.New("it isn't installed")})
+	if launch.MangoHud.Enabled || m.busy || countLogged(m, "MangoHud left off") != 1 {
+		t.Errorf("missing MangoHud: enabled %v, busy %v", launch.MangoHud.Enabled, m.busy)
+	}
+
+	m.toggleMangohud()
+	m.handleMangohudDetected(mangohudDetectedMsg{})
+	if !launch.MangoHud.Enabled {
+		t.Fatal("MangoHud not enabled once found")
+	}
+	saved, err := loadLaunch(launchPath())
+	if err != nil || !saved.MangoHud.Enabled {
+		t.Errorf("launch.toml after enabling = %+v, %v", saved, err)
+	}
+	if m.toggleMangohud(); launch.MangoHud.Enabled || m.busy {
+		t.Error("turning MangoHud off checked the container or left it on")
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"os"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Item availability
+//  Items that can't run right now (binary missing, container in the
+//  wrong state) are either dimmed or hidden, per cfg.HideDisabled.
+//  Either way the cursor skips them and dispatch refuses them.
+// ─────────────────────────────────────────────────────────────────
+
+// containerReq is the container state an action needs.
+type containerReq int
+
+const (
+	reqNone            containerReq = iota
+	reqExists                       // container must have been created
+	reqRunning                      // container must be running
+	reqPaused                       // container must be paused
+	reqRunningOrPaused              // container must be up, frozen or not
+	reqMissing                      // container must not exist yet
+	reqStopped                      // container must exist and be stopped
+)
+
+// cliAvailable reports whether the hackeros-steam binary can be run.
+// A remote host can't be checked up front, so it is assumed present.
+func cliAvailable() bool {
+	if remoteHost != "" {
+		return true
+	}
+	info, err := os.Stat(cli)
+	return err == nil && info.Mode()&0o111 != 0
+}
+
+// disabledReason explains why item can't run, or returns "" if it can.
+// While the container state is unknown only the binary is checked.
+func (m model) disabledReason(item menuItem) string {
+	if item.run == nil && item.script == "" && m.cliMissing {
+		return cli + " not found"
+	}
+	if reason := auditReason(item); reason != "" {
+		return reason
+	}
+	if safeMode() && item.destructive {
+		return "disabled in safe mode"
+	}
+	if reason := lockReason(item); reason != "" {
+		return reason
+	}
+	if !backendSupports(m.backend, item.needs) {
+		return "not supported by " + m.backend
+	}
+	if item.online {
+		if reason := m.offlineReason(); reason != "" {
+			return reason
+		}
+	}
+	switch m.containerStatus {
+	case "checking", "unreachable":
+		return ""
+	}
+	switch item.requires {
+	case reqExists:
+		if m.containerStatus == "missing" {
+			return "container not created yet"
+		}
+		// distrobox enter blocks on a frozen container; removing it
+		// works either way.
+		if m.containerStatus == "paused" && (item.run == nil || item.entersContainer) && !item.destructive {
+			return "container is paused"
+		}
+	case reqRunning:
+		if m.containerStatus != "running" {
+			return "container is not running"
+		}
+	case reqPaused:
+		if m.containerStatus != "paused" {
+			return "container is not paused"
+		}
+	case reqRunningOrPaused:
+		if m.containerStatus != "running" && m.containerStatus != "paused" {
+			return "container is not running"
+		}
+	case reqMissing:
+		if m.containerStatus != "missing" {
+			return "container already exists"
+		}
+	case reqStopped:
+		switch m.containerStatus {
+		case "missing":
+			return "container not created yet"
+		case "running", "paused":
+			return "stop the container first"
+		}
+	}
+	return ""
+}
+
+// safeMode reports whether destructive actions are locked out, by
+// --safe or the safe_mode config key.
+func safeMode() bool {
+	return cfg.SafeMode
+}
+
+func (m model) selectable(i int) bool {
+	return i >= 0 && i < len(menuItems) && m.disabledReason(menuItems[i]) == ""
+}
+
+// visible reports whether item i is drawn in the sidebar.
+func (m model) visible(i int) bool {
+	return !cfg.HideDisabled || m.selectable(i)
+}
+
+// moveCursor steps to the next selectable item in direction dir (±1),
+// staying put if there is none.
+func (m *model) moveCursor(dir int) {
+	for i := m.cursor + dir; i >= 0 && i < len(menuItems); i += dir {
+		if m.selectable(i) {
+			m.setCursor(i)
+			return
+		}
+	}
+}
+
+// setCursor puts the cursor on item i as the user's choice, which
+// fixCursor then holds on to.
+func (m *model) setCursor(i int) {
+	m.cursor = i
+	m.cursorWant = menuItems[i].label
+}
+
+// fixCursor re-places the cursor after the menu changed under it. It
+// goes back to the item the user chose, found by label so reordering
+// doesn't matter, once that is selectable again; until then it sits on
+// the nearest selectable item, preferring the one below.
+func (m *model) fixCursor() {
+	want := min(max(m.cursor, 0), len(menuItems)-1)
+	if i := menuIndex(m.cursorWant); i >= 0 {
+		want = i
+	}
+	if i := m.nearestSelectable(want); i >= 0 {
+		m.cursor = i
+	}
+}
+
+// nearestSelectable returns the selectable item closest to i, i itself
+// included, or -1 if nothing can be selected.
+func (m model) nearestSelectable(i int) int {
+	for d := 0; d < len(menuItems); d++ {
+		if m.selectable(i + d) {
+			return i + d
+		}
+		if m.selectable(i - d) {
+			return i - d
+		}
+	}
+	return -1
+}
+
+// menuIndex returns the index of the item labelled label, or -1.
+func menuIndex(label string) int {
+	for i, item := range menuItems {
+		if label != "" && item.label == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// sectionOf returns the section item i belongs to; only the first item
+// of a section names it.
+func sectionOf(i int) string {
+	for ; i >= 0; i-- {
+		if menuItems[i].section != "" {
+			return menuItems[i].section
+		}
+	}
+	return ""
+}
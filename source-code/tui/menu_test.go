@@ -0,0 +1,281 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// itemIndex finds a menu item by label so tests survive menu reordering.
+func itemIndex(t *testing.T, label string) int {
+	t.Helper()
+	for i, item := range menuItems {
+		if item.label == label {
+			return i
+		}
+	}
+	t.Fatalf("no menu item %q", label)
+	return -1
+}
+
+func TestCursorSkipsDisabledItems(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	tests := []struct {
+		status string
+		from   string
+		keys   []string
+		want   string
+	}{
+		{"missing", "Steam Channel", []string{"down"}, "Client Auto-Update"},
+		{"missing", "Client Auto-Update", []string{"down"}, "Steam Overlay"},
+		{"missing", "Steam Overlay", []string{"down"}, "Gamescope"},
+		{"missing", "Gamescope", []string{"down"}, "MangoHud"},
+		{"missing", "MangoHud", []string{"down"}, "Launch GPU"},
+		{"missing", "Launch GPU", []string{"down"}, "Toggle GE-Proton"},
+		{"missing", "Toggle GE-Proton", []string{"down"}, "Clear Download Cache"},
+		{"missing", "Clear Download Cache", []string{"down"}, "Create Container"},
+		{"missing", "Create Container", []string{"down"}, "Container Environment"},
+		{"missing", "Container Environment", []string{"down"}, "Roll Back Config"},
+		{"missing", "Roll Back Config", []string{"down"}, "Export Settings"},
+		{"missing", "Import Settings", []string{"down"}, "Container Status"},
+		{"missing", "Container Environment", []string{"up"}, "Create Container"},
+		{"missing", "Steam Channel", []string{"up"}, "Steam Channel"},
+		{"running", "Setup / Repair Steam", []string{"up"}, "Clear Download Cache"},
+		{"running", "Clear Download Cache", []string{"up"}, "Verify Game Files"},
+		{"missing", "Create Container", []string{"up"}, "Clear Download Cache"},
+		{"running", "Update Container", []string{"down", "down", "down", "down", "down", "down", "down", "down"}, "Pause Container"},
+		{"running", "Pause Container", []string{"down"}, "Stop Container"},
+		{"paused", "Import Settings", []string{"down"}, "Resume Container"},
+		{"paused", "Resume Container", []string{"down"}, "Stop Container"},
+		{"paused", "Launch Steam", []string{"down"}, "Steam Channel"},
+		{"stopped", "Repair Container", []string{"down", "down", "down", "down", "down", "down", "down"}, "Remove Container"},
+		{"stopped", "Import Settings", []string{"down"}, "Rename Container"},
+		{"running", "Import Settings", []string{"down"}, "Pause Container"},
+		{"checking", "Toggle GE-Proton", []string{"down"}, "Installed Games"},
+	}
+	for _, hide := range []bool{false, true} {
+		cfg.HideDisabled = hide
+		for _, tt := range tests {
+			m := initialModel()
+			m.containerStatus = tt.status
+			m.cursor = itemIndex(t, tt.from)
+			m = press(m, tt.keys...)
+			if got := menuItems[m.cursor].label; got != tt.want {
+				t.Errorf("hide=%v %s: %v from %q landed on %q, want %q", hide, tt.status, tt.keys, tt.from, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestPauseGating(t *testing.T) {
+	tests := []struct {
+		status  string
+		backend string
+		label   string
+		reason  string
+	}{
+		{"running", "podman", "Pause Container", ""},
+		{"running", "podman", "Resume Container", "container is not paused"},
+		{"paused", "podman", "Pause Container", "container is not running"},
+		{"paused", "podman", "Resume Container", ""},
+		{"stopped", "podman", "Pause Container", "container is not running"},
+		{"running", "lilipod", "Pause Container", "not supported by lilipod"},
+		{"paused", "podman", "Stop Container", ""},
+		{"stopped", "podman", "Stop Container", "container is not running"},
+		{"paused", "podman", "Launch Steam", "container is paused"},
+		{"paused", "podman", "Remove Container", ""},
+		{"paused", "podman", "Installed Games", ""},
+		{"paused", "podman", "Verify Game Files", "container is paused"},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.containerStatus, m.backend = tt.status, tt.backend
+		if got := m.disabledReason(menuItems[itemIndex(t, tt.label)]); got != tt.reason {
+			t.Errorf("%s on %s, %s: reason %q, want %q", tt.label, tt.backend, tt.status, got, tt.reason)
+		}
+	}
+	m := initialModel()
+	m.containerStatus = "paused"
+	if s := m.statusString(); !strings.Contains(s, "Paused") {
+		t.Errorf("status indicator = %q", s)
+	}
+}
+
+func TestCursorStability(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	tests := []struct {
+		name   string
+		from   string // chosen with the cursor
+		mutate func(m *model)
+		want   string // after the mutation
+		back   func(m *model)
+		again  string // after undoing it
+	}{
+		{"container goes missing and comes back", "Update Container",
+			func(m *model) { m.containerStatus = "missing" }, "Create Container",
+			func(m *model) { m.containerStatus = "running" }, "Update Container"},
+		{"container stops", "Pause Container",
+			func(m *model) { m.containerStatus = "stopped" }, "Rename Container",
+			func(m *model) { m.containerStatus = "running" }, "Pause Container"},
+		{"binary disappears and appears", "Container Status",
+			func(m *model) { m.cliMissing = true }, "Network Info",
+			func(m *model) { m.cliMissing = false }, "Container Status"},
+		{"backend loses a capability", "Export Logs",
+			func(m *model) { m.backend = "lilipod" }, "Network Info",
+			func(m *model) { m.backend = "podman" }, "Export Logs"},
+		{"safe mode", "Repair Container",
+			func(m *model) { cfg.SafeMode = true }, "Update Mirror",
+			func(m *model) { cfg.SafeMode = false }, "Repair Container"},
+		{"hidden items", "Pause Container",
+			func(m *model) { cfg.HideDisabled = true; m.containerStatus = "stopped" }, "Rename Container",
+			func(m *model) { m.containerStatus = "running" }, "Pause Container"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg = defaultConfig()
+			m := initialModel()
+			m.containerStatus, m.backend, m.cliMissing = "running", "podman", false
+			m.setCursor(itemIndex(t, tt.from))
+
+			tt.mutate(&m)
+			m.fixCursor()
+			if got := menuItems[m.cursor].label; got != tt.want {
+				t.Errorf("after the change the cursor is on %q, want %q", got, tt.want)
+			}
+			if !m.selectable(m.cursor) {
+				t.Error("cursor on an unavailable item")
+			}
+			tt.back(&m)
+			m.fixCursor()
+			if got := menuItems[m.cursor].label; got != tt.again {
+				t.Errorf("after undoing it the cursor is on %q, want %q", got, tt.again)
+			}
+		})
+	}
+}
+
+// The cursor follows its item by label when the menu is reordered.
+func TestCursorFollowsReorderedItem(t *testing.T) {
+	saved := menuItems
+	defer func() { menuItems = saved }()
+	menuItems = append([]menuItem(nil), saved...)
+
+	m := initialModel()
+	m.containerStatus = "running"
+	m.setCursor(itemIndex(t, "Container Status"))
+	slices.Reverse(menuItems)
+	m.fixCursor()
+	if got := menuItems[m.cursor].label; got != "Container Status" {
+		t.Errorf("cursor on %q after reordering", got)
+	}
+}
+
+func TestNearestSelectable(t *testing.T) {
+	m := initialModel()
+	m.containerStatus = "running"
+	tests := []struct {
+		from string
+		want string
+	}{
+		{"Update Container", "Update Container"},
+		{"Create Container", "Setup / Repair Steam"}, // below wins a tie
+		{"Resume Container", "Stop Container"},
+	}
+	for _, tt := range tests {
+		if got := m.nearestSelectable(itemIndex(t, tt.from)); menuItems[got].label != tt.want {
+			t.Errorf("nearestSelectable(%q) = %q, want %q", tt.from, menuItems[got].label, tt.want)
+		}
+	}
+	m.cliMissing = true
+	m.containerStatus = "missing"
+	if i := m.nearestSelectable(0); i < 0 || menuItems[i].run == nil {
+		t.Errorf("with the CLI missing: %d", i)
+	}
+}
+
+func TestHiddenItemsAreNotDrawn(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	m := initialModel()
+	m.containerStatus = "missing"
+	remove := itemIndex(t, "Remove Container")
+	for _, hide := range []bool{false, true} {
+		cfg.HideDisabled = hide
+		if got := m.visible(remove); got == hide {
+			t.Errorf("hide=%v: Remove Container visible = %v", hide, got)
+		}
+		if !m.visible(itemIndex(t, "Create Container")) {
+			t.Errorf("hide=%v: an available item is hidden", hide)
+		}
+	}
+}
+
+func TestStatusChangeMovesCursorOffDisabledItem(t *testing.T) {
+	m := initialModel()
+	m.cursor = itemIndex(t, "Launch Steam")
+	next, _ := m.Update(statusDoneMsg("missing"))
+	m = next.(model)
+	if got := menuItems[m.cursor].label; got != "Steam Channel" {
+		t.Errorf("cursor on %q after the container went missing", got)
+	}
+}
+
+func TestDispatchRefusesDisabledItem(t *testing.T) {
+	m := initialModel()
+	m.containerStatus = "missing"
+	m.dispatch(menuItems[itemIndex(t, "Update Container")])
+	if m.busy || m.state != stateMenu {
+		t.Fatalf("disabled item started: busy=%v state=%v", m.busy, m.state)
+	}
+	if m.toast == "" {
+		t.Error("no toast explaining why the item is unavailable")
+	}
+}
+
+func TestSafeModeBlocksDestructiveActions(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	tests := []struct {
+		label   string
+		status  string
+		blocked bool
+	}{
+		{"Create Container", "missing", true},
+		{"Repair Container", "running", true},
+		{"Stop Container", "running", true},
+		{"Remove Container", "stopped", true},
+		{"Clear Download Cache", "running", true},
+		{"Rename Container", "stopped", true},
+		{"Launch Steam", "running", false},
+		{"Update Container", "running", false},
+		{"Container Status", "running", false},
+		{"Export Logs", "running", false},
+	}
+	for _, safe := range []bool{false, true} {
+		cfg.SafeMode = safe
+		for _, tt := range tests {
+			m := initialModel()
+			m.containerStatus = tt.status
+			item := menuItems[itemIndex(t, tt.label)]
+			reason := m.disabledReason(item)
+			if blocked := reason == "disabled in safe mode"; blocked != (safe && tt.blocked) {
+				t.Errorf("safe=%v %s: reason %q", safe, tt.label, reason)
+			}
+			if safe && tt.blocked {
+				m.dispatch(item)
+				if m.busy || countLogged(m, "$ hackeros-steam") != 0 {
+					t.Errorf("%s ran in safe mode", tt.label)
+				}
+			}
+		}
+	}
+}
+
+func TestSafeModeSidebar(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.SafeMode = true
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.containerStatus = "running"
+	if !strings.Contains(m.renderSidebar(), "⊘") || !strings.Contains(m.renderHeader(), "SAFE MODE") {
+		t.Error("safe mode not shown in the sidebar and header")
+	}
+}
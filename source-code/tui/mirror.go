@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bufio"
+	"net/url"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Update mirror
+//  "Update Mirror" lists the servers in the container's pacman
+//  mirrorlist; the one picked is kept in launch.toml and handed to
+//  `hackeros-steam update` in HACKEROS_STEAM_MIRROR, which moves it to
+//  the top of the list before upgrading.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	mirrorEnvVar   = "HACKEROS_STEAM_MIRROR"
+	mirrorlistPath = "/etc/pacman.d/mirrorlist"
+)
+
+type mirror struct {
+	url     string // as in the mirrorlist, with $repo and $arch
+	country string // from the "## Country" line above it, may be ""
+}
+
+// host is the mirror's server name, for labels.
+func (mr mirror) host() string {
+	if u, err := url.Parse(mr.url); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return mr.url
+}
+
+func (mr mirror) label() string {
+	if mr.country == "" {
+		return mr.host()
+	}
+	return mr.country + " — " + mr.host()
+}
+
+type mirrorsMsg struct {
+	mirrors []mirror
+	err     error
+}
+
+// parseMirrorlist returns the servers in a pacman mirrorlist, enabled
+// or commented out, in file order and without duplicates.
+func parseMirrorlist(text string) []mirror {
+	var mirrors []mirror
+	seen := map[string]int{}
+	country := ""
+	sc := bufio.NewScanner(strings.NewReader(text))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if heading, ok := strings.CutPrefix(line, "##"); ok {
+			heading = strings.TrimSpace(heading)
+			// The preamble ("Arch Linux repository mirrorlist",
+			// "Generated on …") and the CLI's marker aren't countries.
+			country = heading
+			if strings.Contains(heading, "mirror") || strings.HasPrefix(heading, "Generated") {
+				country = ""
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimLeft(line, "# "), "=")
+		if !ok || strings.TrimSpace(key) != "Server" {
+			continue
+		}
+		u := strings.TrimSpace(value)
+		if !validMirror(u) {
+			continue
+		}
+		// The preferred mirror is listed twice, the copy at the top has
+		// no country.
+		if i, ok := seen[u]; ok {
+			if mirrors[i].country == "" {
+				mirrors[i].country = country
+			}
+			continue
+		}
+		seen[u] = len(mirrors)
+		mirrors = append(mirrors, mirror{url: u, country: country})
+	}
+	return mirrors
+}
+
+// validMirror accepts http(s) server URLs without characters that
+// would need quoting on the CLI's side.
+func validMirror(u string) bool {
+	if !strings.HasPrefix(u, "https://") && !strings.HasPrefix(u, "http://") {
+		return false
+	}
+	return !strings.ContainsAny(u, " \t'\"\\`;|&")
+}
+
+// mirrorEnv is added to update actions when a mirror is chosen.
+func mirrorEnv() []string {
+	if launch.Mirror == "" {
+		return nil
+	}
+	return []string{mirrorEnvVar + "=" + launch.Mirror}
+}
+
+func listMirrorsCmd() tea.Cmd {
+	return func() tea.Msg {
+		out, err := auditedOutput("Update Mirror", hostCommand([]string{"distrobox", "enter", containerName, "--",
+			"cat", mirrorlistPath}, nil, false), stdoutOf)
+		if err != nil {
+			return mirrorsMsg{err: err}
+		}
+		return mirrorsMsg{mirrors: parseMirrorlist(string(out))}
+	}
+}
+
+// openMirrorMenu is the "Update Mirror" action; the picker opens once
+// the container has reported its mirrorlist.
+func (m *model) openMirrorMenu() tea.Cmd {
+	m.busy = true
+	return listMirrorsCmd()
+}
+
+func (m *model) handleMirrors(msg mirrorsMsg) {
+	m.busy = false
+	if msg.err != nil {
+		m.logError("Could not read the container's mirrorlist: " + msg.err.Error())
+		return
+	}
+	if len(msg.mirrors) == 0 {
+		m.logError("No servers found in " + mirrorlistPath + ".")
+		return
+	}
+	current := "Default"
+	sm := &submenu{
+		note:   "Tried first by Update Container; the rest of the list stays as fallback.",
+		marked: 0,
+		items:  []menuItem{{icon: "◌", label: "Default (mirrorlist order)", run: selectMirrorAction(mirror{})}},
+	}
+	for i, mr := range msg.mirrors {
+		sm.items = append(sm.items, menuItem{icon: "⇣", label: mr.label(), run: selectMirrorAction(mr)})
+		if mr.url == launch.Mirror {
+			sm.marked = i + 1
+			current = mr.host()
+		}
+	}
+	if launch.Mirror != "" && sm.marked == 0 {
+		current = launch.Mirror + " (not in the mirrorlist)"
+		sm.marked = -1
+	}
+	sm.title = "Update Mirror — current: " + current
+	m.openSubmenu(sm)
+}
+
+func selectMirrorAction(mr mirror) func(m *model) tea.Cmd {
+	return func(m *model) tea.Cmd {
+		next := launch
+		next.Mirror = mr.url
+		if err := m.saveLaunch(next); err != nil {
+			return nil
+		}
+		if mr.url == "" {
+			return m.showToast("Updates use the mirrorlist order")
+		}
+		return m.showToast("Updates download from " + mr.host())
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testMirrorlist = `## HackerOS-Steam preferred mirror
+Server = https://mirror.example.de/archlinux/$repo/os/$arch
+##
+## Arch Linux repository mirrorlist
+## Generated on 2026-09-01
+##
+
+## Germany
+Server = https://mirror.example.de/archlinux/$repo/os/$arch
+#Server = http://ftp.example.de/arch/$repo/os/$arch
+
+## Poland
+#Server = https://mirror.example.pl/archlinux/$repo/os/$arch
+Server = ftp://old.example.pl/arch/$repo/os/$arch
+Server = https://bad.example.pl/$repo;rm -rf /
+
+# Server = https://spaced.example.com/$repo/os/$arch
+`
+
+func TestParseMirrorlist(t *testing.T) {
+	want := []mirror{
+		{"https://mirror.example.de/archlinux/$repo/os/$arch", "Germany"},
+		{"http://ftp.example.de/arch/$repo/os/$arch", "Germany"},
+		{"https://mirror.example.pl/archlinux/$repo/os/$arch", "Poland"},
+		{"https://spaced.example.com/$repo/os/$arch", "Poland"},
+	}
+	got := parseMirrorlist(testMirrorlist)
+	if len(got) != len(want) {
+		t.Fatalf("parseMirrorlist = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("mirror %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if got := parseMirrorlist("## Arch Linux repository mirrorlist\n"); len(got) != 0 {
+		t.Errorf("no servers: %+v", got)
+	}
+}
+
+func TestValidMirror(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://mirror.example.de/archlinux/$repo/os/$arch", true},
+		{"http://ftp.example.de/arch/$repo/os/$arch", true},
+		{"ftp://old.example.pl/arch", false},
+		{"https://x.example/'; reboot; '", false},
+		{"https://x.example/a b", false},
+		{"https://x.example/`id`", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := validMirror(tt.url); got != tt.want {
+			t.Errorf("validMirror(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestMirrorLabel(t *testing.T) {
+	tests := []struct {
+		mr   mirror
+		want string
+	}{
+		{mirror{"https://mirror.example.de/archlinux/$repo/os/$arch", "Germany"}, "Germany — mirror.example.de"},
+		{mirror{"https://mirror.example.de/archlinux/$repo/os/$arch", ""}, "mirror.example.de"},
+		{mirror{"not a url", ""}, "not a url"},
+	}
+	for _, tt := range tests {
+		if got := tt.mr.label(); got != tt.want {
+			t.Errorf("label() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+// The chosen mirror reaches update, and only update.
+func TestMirrorReachesUpdateArgv(t *testing.T) {
+	defer func(saved launchSettings, env []envVar) { launch, persistentEnv = saved, env }(launch, persistentEnv)
+	persistentEnv = nil
+	url := "https://mirror.example.de/archlinux/$repo/os/$arch"
+	tests := []struct {
+		mirror string
+		label  string
+		want   string // expected env, joined
+	}{
+		{url, "Update Container", mirrorEnvVar + "=" + url},
+		{"", "Update Container", ""},
+		{url, "Setup / Repair Steam", ""},
+		{url, "Container Status", ""},
+	}
+	for _, tt := range tests {
+		launch = launchSettings{Mirror: tt.mirror}
+		a := menuAction(itemIndex(t, tt.label))
+		if got := strings.Join(a.env, " "); got != tt.want {
+			t.Errorf("mirror %q, %s: env %q, want %q", tt.mirror, tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestHandleMirrors(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	mirrors := parseMirrorlist(testMirrorlist)
+	tests := []struct {
+		name    string
+		current string
+		msg     mirrorsMsg
+		title   string
+		marked  int
+	}{
+		{"default", "", mirrorsMsg{mirrors: mirrors}, "current: Default", 0},
+		{"chosen", mirrors[2].url, mirrorsMsg{mirrors: mirrors}, "current: mirror.example.pl", 3},
+		{"gone from the list", "https://gone.example/$repo", mirrorsMsg{mirrors: mirrors}, "(not in the mirrorlist)", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			launch = launchSettings{Mirror: tt.current}
+			m := initialModel()
+			m.busy = true
+			m.handleMirrors(tt.msg)
+			if m.busy || m.submenu == nil {
+				t.Fatalf("busy=%v, no picker", m.busy)
+			}
+			if !strings.Contains(m.submenu.title, tt.title) || m.submenu.marked != tt.marked {
+				t.Errorf("title %q, marked %d", m.submenu.title, m.submenu.marked)
+			}
+			if len(m.submenu.items) != len(mirrors)+1 {
+				t.Errorf("%d items", len(m.submenu.items))
+			}
+		})
+	}
+
+	for _, msg := range []mirrorsMsg{{err: errors.New("exit status 1")}, {}} {
+		m := initialModel()
+		m.handleMirrors(msg)
+		if m.submenu != nil || m.errorCount != 1 {
+			t.Errorf("%+v: submenu %v, %d errors", msg, m.submenu, m.errorCount)
+		}
+	}
+}
+
+func TestSelectMirrorPersists(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	launch = launchSettings{GPU: "0000:01:00.0"}
+	mr := mirror{"https://mirror.example.de/archlinux/$repo/os/$arch", "Germany"}
+
+	m := initialModel()
+	selectMirrorAction(mr)(&m)
+	saved, err := loadLaunch(launchPath())
+	if err != nil || saved.Mirror != mr.url || saved.GPU != "0000:01:00.0" {
+		t.Errorf("saved %+v, %v", saved, err)
+	}
+	if !strings.Contains(m.toast, "mirror.example.de") {
+		t.Errorf("toast = %q", m.toast)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net"
+	"net/url"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Update server reachability
+//  A TCP connect to the package mirror, repeated on the "network"
+//  poll, tells whether an update can work before one is started.
+//  While the mirror can't be reached, actions that download are
+//  disabled and say why. Over --remote the check is skipped; what
+//  matters there is the remote host's network.
+// ─────────────────────────────────────────────────────────────────
+
+// defaultUpdateHost is used when no mirror has been picked: Arch's
+// GeoIP redirector, the mirrorlist's usual first entry.
+const defaultUpdateHost = "geo.mirror.pkgbuild.com"
+
+const netProbeTimeout = 3 * time.Second
+
+type netState int
+
+const (
+	netUnknown netState = iota
+	netOnline
+	netOffline
+)
+
+type netProbeMsg struct {
+	host string
+	err  error
+}
+
+// updateServer returns the host:port the probe connects to: the
+// preferred mirror when one is set.
+func updateServer() string {
+	host, port := defaultUpdateHost, "443"
+	if u, err := url.Parse(launch.Mirror); err == nil && launch.Mirror != "" && u.Hostname() != "" {
+		host = u.Hostname()
+		if u.Scheme == "http" {
+			port = "80"
+		}
+		if u.Port() != "" {
+			port = u.Port()
+		}
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// netProbeCmd dials the update server once.
+func netProbeCmd() tea.Cmd {
+	if remoteHost != "" {
+		return nil
+	}
+	addr := updateServer()
+	return func() tea.Msg {
+		conn, err := net.DialTimeout("tcp", addr, netProbeTimeout)
+		if err == nil {
+			conn.Close()
+		}
+		host, _, _ := net.SplitHostPort(addr)
+		return netProbeMsg{host: host, err: err}
+	}
+}
+
+func (m *model) handleNetProbe(msg netProbeMsg) {
+	m.polling[pollNetwork] = false
+	m.netHost = msg.host
+	if msg.err != nil {
+		m.network = netOffline
+	} else {
+		m.network = netOnline
+	}
+}
+
+// offlineReason is the disabledReason for actions that download.
+func (m model) offlineReason() string {
+	if m.network != netOffline {
+		return ""
+	}
+	return "can't reach " + m.netHost
+}
+
+// networkIndicator is the status bar mark for the update server.
+func (m model) networkIndicator() string {
+	switch m.network {
+	case netOnline:
+		return lipgloss.NewStyle().Foreground(colGreen).Render("◉ online")
+	case netOffline:
+		return lipgloss.NewStyle().Foreground(colRed).Render("◌ offline")
+	}
+	return ""
+}
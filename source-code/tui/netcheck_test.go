@@ -0,0 +1,105 @@
+package main
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestUpdateServer(t *testing.T) {
+	defer func(saved launchSettings) { launch = saved }(launch)
+	tests := []struct {
+		mirror string
+		want   string
+	}{
+		{"", defaultUpdateHost + ":443"},
+		{"https://mirror.example.de/$repo/os/$arch", "mirror.example.de:443"},
+		{"http://mirror.example.de/$repo/os/$arch", "mirror.example.de:80"},
+		{"https://mirror.example.de:8443/$repo/os/$arch", "mirror.example.de:8443"},
+		{"not a url", defaultUpdateHost + ":443"},
+	}
+	for _, tt := range tests {
+		launch = launchSettings{Mirror: tt.mirror}
+		if got := updateServer(); got != tt.want {
+			t.Errorf("updateServer() with mirror %q = %q, want %q", tt.mirror, got, tt.want)
+		}
+	}
+}
+
+// probeAddr runs netProbeCmd against a mirror on addr.
+func probeAddr(t *testing.T, addr string) netProbeMsg {
+	t.Helper()
+	defer func(saved launchSettings) { launch = saved }(launch)
+	launch = launchSettings{Mirror: "http://" + addr + "/$repo/os/$arch"}
+	return netProbeCmd()().(netProbeMsg)
+}
+
+func TestNetProbe(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip("no loopback:", err)
+	}
+	open := ln.Addr().String()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	refused := closed.Addr().String()
+	closed.Close()
+	defer ln.Close()
+
+	tests := []struct {
+		name      string
+		addr      string
+		state     netState
+		indicator string
+	}{
+		{"reachable", open, netOnline, "online"},
+		{"unreachable", refused, netOffline, "offline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.containerStatus = "running"
+			m.polling[pollNetwork] = true
+			msg := probeAddr(t, tt.addr)
+			if msg.host != "127.0.0.1" {
+				t.Errorf("probed host %q", msg.host)
+			}
+			next, _ := m.Update(msg)
+			m = next.(model)
+			if m.network != tt.state || m.polling[pollNetwork] {
+				t.Fatalf("network = %v (polling %v), want %v", m.network, m.polling[pollNetwork], tt.state)
+			}
+			if got := m.networkIndicator(); !strings.Contains(got, tt.indicator) {
+				t.Errorf("indicator %q, want %q", got, tt.indicator)
+			}
+			update := menuItems[itemIndex(t, "Update Container")]
+			reason := m.disabledReason(update)
+			if offline := tt.state == netOffline; offline != (reason == "can't reach 127.0.0.1") {
+				t.Errorf("Update Container disabled reason %q while %s", reason, tt.name)
+			}
+			if reason := m.disabledReason(menuItems[itemIndex(t, "Setup / Repair Steam")]); reason != "" {
+				t.Errorf("Setup / Repair Steam disabled (%q) though it doesn't download", reason)
+			}
+		})
+	}
+}
+
+func TestNetworkIndicatorUnknown(t *testing.T) {
+	m := initialModel()
+	if got := m.networkIndicator(); got != "" {
+		t.Errorf("indicator before the first probe = %q, want none", got)
+	}
+	if reason := m.offlineReason(); reason != "" {
+		t.Errorf("offlineReason before the first probe = %q", reason)
+	}
+}
+
+func TestNetProbeSkippedRemotely(t *testing.T) {
+	defer func(saved string) { remoteHost = saved }(remoteHost)
+	remoteHost = "deck.local"
+	if netProbeCmd() != nil {
+		t.Error("probe runs over --remote")
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Network info
+//  "Network Info" reads the container's addresses and published ports
+//  from the engine's inspect output, for Remote Play and streaming
+//  setups. distrobox normally shares the host's network, in which case
+//  there is no address of its own to show.
+// ─────────────────────────────────────────────────────────────────
+
+// netAddress is the container's address on one engine network.
+type netAddress struct {
+	network string
+	ip      string
+	gateway string
+}
+
+// portMapping is a container port and where the host publishes it;
+// host is empty for a port that is exposed but not published.
+type portMapping struct {
+	port string // "27036/tcp"
+	host []string
+}
+
+type containerNetwork struct {
+	mode      string // HostConfig.NetworkMode
+	addresses []netAddress
+	ports     []portMapping
+}
+
+type networkInfoMsg struct {
+	info containerNetwork
+	err  error
+}
+
+// hostNetwork reports whether the container shares the host's network
+// namespace.
+func (n containerNetwork) hostNetwork() bool {
+	return n.mode == "host"
+}
+
+// parseNetworkInfo reads the network settings from the JSON array
+// `podman inspect` and `docker inspect` print.
+func parseNetworkInfo(out []byte) (containerNetwork, error) {
+	var inspected []struct {
+		HostConfig struct {
+			NetworkMode string
+		}
+		NetworkSettings struct {
+			IPAddress string
+			Gateway   string
+			Ports     map[string][]struct {
+				HostIp   string
+				HostPort string
+			}
+			Networks map[string]struct {
+				IPAddress string
+				Gateway   string
+			}
+		}
+	}
+	if err := json.Unmarshal(out, &inspected); err != nil {
+		return containerNetwork{}, fmt.Errorf("unreadable inspect output: %w", err)
+	}
+	if len(inspected) == 0 {
+		return containerNetwork{}, errors.New("inspect returned no container")
+	}
+	c := inspected[0]
+	n := containerNetwork{mode: c.HostConfig.NetworkMode}
+	if n.hostNetwork() {
+		return n, nil
+	}
+
+	names := make([]string, 0, len(c.NetworkSettings.Networks))
+	for name := range c.NetworkSettings.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if net := c.NetworkSettings.Networks[name]; net.IPAddress != "" {
+			n.addresses = append(n.addresses, netAddress{network: name, ip: net.IPAddress, gateway: net.Gateway})
+		}
+	}
+	// Old docker reports the default bridge only at the top level.
+	if len(n.addresses) == 0 && c.NetworkSettings.IPAddress != "" {
+		n.addresses = append(n.addresses, netAddress{network: n.mode, ip: c.NetworkSettings.IPAddress, gateway: c.NetworkSettings.Gateway})
+	}
+
+	for port, bindings := range c.NetworkSettings.Ports {
+		p := portMapping{port: port}
+		for _, b := range bindings {
+			ip := b.HostIp
+			if ip == "" {
+				ip = "0.0.0.0"
+			}
+			p.host = append(p.host, ip+":"+b.HostPort)
+		}
+		n.ports = append(n.ports, p)
+	}
+	sort.Slice(n.ports, func(i, j int) bool { return n.ports[i].port < n.ports[j].port })
+	return n, nil
+}
+
+// lines renders n for the log panel; running says whether a missing
+// address is to be expected.
+func (n containerNetwork) lines(running bool) []string {
+	if n.hostNetwork() {
+		return []string{
+			"Shares the host's network: no address of its own, and every",
+			"port Steam opens is open on the host's addresses.",
+		}
+	}
+	out := []string{"Network mode: " + n.mode}
+	switch {
+	case len(n.addresses) > 0:
+		for _, a := range n.addresses {
+			line := fmt.Sprintf("Address: %s on %s", a.ip, a.network)
+			if a.gateway != "" {
+				line += ", gateway " + a.gateway
+			}
+			out = append(out, line)
+		}
+	case !running:
+		out = append(out, "Address: none while the container is stopped")
+	default:
+		out = append(out, "Address: none assigned")
+	}
+	if len(n.ports) == 0 {
+		return append(out, "Ports: none published")
+	}
+	for _, p := range n.ports {
+		if len(p.host) == 0 {
+			out = append(out, "Port "+p.port+": exposed, not published")
+			continue
+		}
+		out = append(out, "Port "+p.port+" → "+strings.Join(p.host, ", "))
+	}
+	return out
+}
+
+// showNetworkInfo is the "Network Info" action.
+func (m *model) showNetworkInfo() tea.Cmd {
+	engine := m.engine()
+	return func() tea.Msg {
+		out, err := auditedOutput("Network Info", hostCommand([]string{engine, "inspect", "--type", "container", containerName}, nil, false), stdoutOf)
+		if err != nil {
+			return networkInfoMsg{err: err}
+		}
+		info, err := parseNetworkInfo(out)
+		return networkInfoMsg{info: info, err: err}
+	}
+}
+
+func (m *model) handleNetworkInfo(msg networkInfoMsg) {
+	m.appendLog(styleLogHeader.Render("  ── Network (" + containerName + ")"))
+	if msg.err != nil {
+		m.logError("Could not read the container's network settings: " + msg.err.Error())
+		m.appendLog("")
+		return
+	}
+	for _, line := range msg.info.lines(m.containerStatus == "running") {
+		m.appendLog(styleLogInfo.Render("  " + line))
+	}
+	m.appendLog("")
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseNetworkInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		inspect string
+		want    string // n.lines(true), joined by " | "
+		err     string
+	}{
+		{"host network", `[{"HostConfig":{"NetworkMode":"host"},"NetworkSettings":{"Networks":{"host":{}}}}]`,
+			"Shares the host's network: no address of its own, and every | port Steam opens is open on the host's addresses.", ""},
+		{"podman bridge with ports",
+			`[{"HostConfig":{"NetworkMode":"bridge"},"NetworkSettings":{
+				"Networks":{"podman":{"IPAddress":"10.88.0.5","Gateway":"10.88.0.1"}},
+				"Ports":{"27036/tcp":[{"HostIp":"","HostPort":"27036"}],"27031/udp":[{"HostIp":"127.0.0.1","HostPort":"27031"},{"HostIp":"::1","HostPort":"27031"}],"8080/tcp":null}}}]`,
+			"Network mode: bridge | Address: 10.88.0.5 on podman, gateway 10.88.0.1 | Port 27031/udp → 127.0.0.1:27031, ::1:27031 | Port 27036/tcp → 0.0.0.0:27036 | Port 8080/tcp: exposed, not published", ""},
+		{"two networks sorted", `[{"HostConfig":{"NetworkMode":"steam"},"NetworkSettings":{"Networks":{"zeta":{"IPAddress":"172.20.0.2"},"alpha":{"IPAddress":"172.19.0.2"},"down":{"IPAddress":""}}}}]`,
+			"Network mode: steam | Address: 172.19.0.2 on alpha | Address: 172.20.0.2 on zeta | Ports: none published", ""},
+		{"old docker top-level address", `[{"HostConfig":{"NetworkMode":"default"},"NetworkSettings":{"IPAddress":"172.17.0.3","Gateway":"172.17.0.1"}}]`,
+			"Network mode: default | Address: 172.17.0.3 on default, gateway 172.17.0.1 | Ports: none published", ""},
+		{"no address", `[{"HostConfig":{"NetworkMode":"bridge"},"NetworkSettings":{}}]`,
+			"Network mode: bridge | Address: none assigned | Ports: none published", ""},
+		{"no container", `[]`, "", "inspect returned no container"},
+		{"not JSON", `Error: no such container`, "", "unreadable inspect output"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := parseNetworkInfo([]byte(tt.inspect))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(n.lines(true), " | "); got != tt.want {
+				t.Errorf("lines =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNetworkLinesStopped(t *testing.T) {
+	n := containerNetwork{mode: "bridge"}
+	if got := n.lines(false)[1]; got != "Address: none while the container is stopped" {
+		t.Errorf("stopped container: %q", got)
+	}
+}
+
+// Network Info may run next to another action, whose busy flag it
+// leaves alone.
+func TestHandleNetworkInfo(t *testing.T) {
+	m := initialModel()
+	m.busy = true
+	m.containerStatus = "running"
+	m.handleNetworkInfo(networkInfoMsg{info: containerNetwork{mode: "host"}})
+	if !m.busy || countLogged(m, "Shares the host's network") != 1 {
+		t.Errorf("host network not reported: %q", plainLogLines(m.logLines, false))
+	}
+
+	m.busy = false
+	m.handleNetworkInfo(networkInfoMsg{err: errors.New("exit status 125")})
+	if m.busy || countLogged(m, "Could not read the container's network settings: exit status 125") != 1 {
+		t.Errorf("inspect failure not reported: %q", plainLogLines(m.logLines, false))
+	}
+}
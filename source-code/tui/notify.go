@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/godbus/dbus/v5"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Desktop notifications
+//  Results of actions that ran long enough for the user to look away
+//  are posted to the session bus. HackerOS' own notification service
+//  is preferred when it is running; it speaks the freedesktop
+//  Notifications interface under its own name. Without a bus,
+//  notify-send is tried, and failing that nothing is shown.
+// ─────────────────────────────────────────────────────────────────
+
+// notifyMinDuration is how long an action must run to be announced.
+const notifyMinDuration = 10 * time.Second
+
+const (
+	notifyIface      = "org.freedesktop.Notifications"
+	notifyActionLogs = "open-logs"
+)
+
+// notifyServices are tried in order: name and object path.
+var notifyServices = []struct {
+	name string
+	path dbus.ObjectPath
+}{
+	{"org.hackeros.Notifications", "/org/hackeros/Notifications"},
+	{"org.freedesktop.Notifications", "/org/freedesktop/Notifications"},
+}
+
+// busCaller is the part of dbus.BusObject the notifier uses.
+type busCaller interface {
+	Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call
+}
+
+type notification struct {
+	summary string
+	body    string
+	failed  bool
+}
+
+// notifyArgs builds the Notify call arguments: app name, replaces id,
+// icon, summary, body, actions, hints, timeout.
+func notifyArgs(n notification) []interface{} {
+	urgency := byte(1) // normal
+	icon := "dialog-information"
+	if n.failed {
+		urgency, icon = 2, "dialog-error" // critical
+	}
+	return []interface{}{
+		"HackerOS Steam", uint32(0), icon, n.summary, n.body,
+		[]string{notifyActionLogs, "Open logs"},
+		map[string]dbus.Variant{"urgency": dbus.MakeVariant(urgency)},
+		int32(-1),
+	}
+}
+
+type notifier struct {
+	obj busCaller
+	mu  sync.Mutex
+	ids map[uint32]bool // notifications we posted, for ActionInvoked
+}
+
+func (nt *notifier) send(n notification) error {
+	var id uint32
+	if err := nt.obj.Call(notifyIface+".Notify", 0, notifyArgs(n)...).Store(&id); err != nil {
+		return err
+	}
+	nt.mu.Lock()
+	nt.ids[id] = true
+	nt.mu.Unlock()
+	return nil
+}
+
+// ours reports whether id is one of our notifications, forgetting it.
+func (nt *notifier) ours(id uint32) bool {
+	nt.mu.Lock()
+	defer nt.mu.Unlock()
+	ok := nt.ids[id]
+	delete(nt.ids, id)
+	return ok
+}
+
+var (
+	busOnce     sync.Once
+	busNotifier *notifier // nil when no notification service is reachable
+)
+
+// connectNotifier finds a notification service on the session bus and
+// starts listening for its action signals.
+func connectNotifier() *notifier {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return nil
+	}
+	for _, svc := range notifyServices {
+		var has bool
+		if err := conn.BusObject().Call("org.freedesktop.DBus.NameHasOwner", 0, svc.name).Store(&has); err != nil || !has {
+			continue
+		}
+		nt := &notifier{obj: conn.Object(svc.name, svc.path), ids: map[uint32]bool{}}
+		listenNotifyActions(conn, nt)
+		return nt
+	}
+	conn.Close()
+	return nil
+}
+
+func listenNotifyActions(conn *dbus.Conn, nt *notifier) {
+	if err := conn.AddMatchSignal(dbus.WithMatchInterface(notifyIface), dbus.WithMatchMember("ActionInvoked")); err != nil {
+		return
+	}
+	sigs := make(chan *dbus.Signal, 8)
+	conn.Signal(sigs)
+	// Closing the connection on quit closes sigs, which ends the loop.
+	context.AfterFunc(appLife.ctx, func() { conn.Close() })
+	appLife.goTracked(func(context.Context) {
+		for sig := range sigs {
+			var id uint32
+			var action string
+			if dbus.Store(sig.Body, &id, &action) != nil || !nt.ours(id) {
+				continue
+			}
+			if action == notifyActionLogs {
+				argv := append(append([]string(nil), logsOpener...), cfg.LogDir)
+				appLife.goTracked(func(ctx context.Context) { nt.openLogs(ctx, argv) })
+			}
+		}
+	})
+}
+
+// logsOpener opens log_dir for the "Open logs" action.
+var logsOpener = []string{"xdg-open"}
+
+// openLogs runs argv and waits for it, or until ctx is done. The user
+// is looking at the desktop rather than the TUI, so a failure is
+// posted as a notification of its own.
+func (nt *notifier) openLogs(ctx context.Context, argv []string) {
+	c := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	err := auditedRun("open logs", c)
+	if err == nil || ctx.Err() != nil {
+		return
+	}
+	reason := err.Error()
+	if c.ProcessState != nil {
+		reason = exitedMsg(c).status()
+	}
+	nt.send(notification{
+		summary: "Could not open the logs",
+		body:    argv[0] + " " + tildePath(argv[len(argv)-1]) + ": " + reason,
+		failed:  true,
+	})
+}
+
+// notifyCmd posts n, falling back to notify-send.
+func notifyCmd(n notification) tea.Cmd {
+	return func() tea.Msg {
+		busOnce.Do(func() { busNotifier = connectNotifier() })
+		if busNotifier != nil && busNotifier.send(n) == nil {
+			return nil
+		}
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			auditedRun("notification", exec.Command("notify-send", "--app-name=HackerOS Steam", n.summary, n.body))
+		}
+		return nil
+	}
+}
+
+// notifyDone announces the result of the last action if it ran long
+// enough and notifications are enabled.
+func (m *model) notifyDone(ok bool) tea.Cmd {
+	if !cfg.Notify || m.lastItem == nil || time.Since(m.startedAt) < notifyMinDuration {
+		return nil
+	}
+	n := notification{summary: m.lastItem.label + " finished", body: "Completed successfully."}
+	if !ok {
+		n = notification{summary: m.lastItem.label + " failed", body: "See the output log for details.", failed: true}
+	}
+	return notifyCmd(n)
+}
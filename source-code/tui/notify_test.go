@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// mockBus records Notify calls and answers with increasing ids.
+type mockBus struct {
+	method string
+	args   []interface{}
+	nextID uint32
+	err    error
+}
+
+func (b *mockBus) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	b.method, b.args = method, args
+	if b.err != nil {
+		return &dbus.Call{Err: b.err}
+	}
+	b.nextID++
+	return &dbus.Call{Body: []interface{}{b.nextID}}
+}
+
+func TestNotifyArgs(t *testing.T) {
+	tests := []struct {
+		n       notification
+		icon    string
+		urgency byte
+	}{
+		{notification{summary: "Update Container finished", body: "Completed successfully."}, "dialog-information", 1},
+		{notification{summary: "Update Container failed", body: "See the log.", failed: true}, "dialog-error", 2},
+	}
+	for _, tt := range tests {
+		args := notifyArgs(tt.n)
+		if len(args) != 8 {
+			t.Fatalf("%d Notify args, want 8", len(args))
+		}
+		if args[0] != "HackerOS Steam" || args[1] != uint32(0) || args[2] != tt.icon ||
+			args[3] != tt.n.summary || args[4] != tt.n.body || args[7] != int32(-1) {
+			t.Errorf("Notify args = %#v", args)
+		}
+		actions := args[5].([]string)
+		if len(actions) != 2 || actions[0] != notifyActionLogs {
+			t.Errorf("actions = %q", actions)
+		}
+		hints := args[6].(map[string]dbus.Variant)
+		if u, ok := hints["urgency"].Value().(byte); !ok || u != tt.urgency {
+			t.Errorf("urgency hint = %v, want %d", hints["urgency"], tt.urgency)
+		}
+	}
+}
+
+func TestNotifierSend(t *testing.T) {
+	bus := &mockBus{}
+	nt := &notifier{obj: bus, ids: map[uint32]bool{}}
+	if err := nt.send(notification{summary: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if bus.method != "org.freedesktop.Notifications.Notify" || bus.args[3] != "a" {
+		t.Errorf("called %s with %v", bus.method, bus.args)
+	}
+	if !nt.ours(1) {
+		t.Error("posted notification not recognized")
+	}
+	if nt.ours(1) || nt.ours(2) {
+		t.Error("notification ids not forgotten / foreign id accepted")
+	}
+
+	bus.err = errors.New("org.freedesktop.DBus.Error.ServiceUnknown")
+	if err := nt.send(notification{summary: "b"}); err == nil {
+		t.Error("send on a failing bus succeeded")
+	}
+}
+
+func TestNotifyDoneThreshold(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	tests := []struct {
+		enabled bool
+		ran     time.Duration
+		item    bool
+		want    bool
+	}{
+		{true, notifyMinDuration + time.Second, true, true},
+		{true, time.Second, true, false},
+		{false, notifyMinDuration + time.Second, true, false},
+		{true, notifyMinDuration + time.Second, false, false},
+	}
+	for _, tt := range tests {
+		cfg.Notify = tt.enabled
+		m := initialModel()
+		if tt.item {
+			m.lastItem = &menuItem{label: "Update Container"}
+		}
+		m.startedAt = time.Now().Add(-tt.ran)
+		if got := m.notifyDone(true) != nil; got != tt.want {
+			t.Errorf("enabled=%v ran=%v item=%v: notify = %v, want %v", tt.enabled, tt.ran, tt.item, got, tt.want)
+		}
+	}
+}
+
+func TestOpenLogs(t *testing.T) {
+	tests := []struct {
+		argv []string
+		body string // of the failure notification, "" for none
+	}{
+		{[]string{"true", "/tmp/logs"}, ""},
+		{[]string{"sh", "-c", "exit 4", "/tmp/logs"}, "sh /tmp/logs: exit 4"},
+		{[]string{"hackeros-no-such-opener", "/tmp/logs"}, "executable file not found"},
+	}
+	for _, tt := range tests {
+		bus := &mockBus{}
+		nt := &notifier{obj: bus, ids: map[uint32]bool{}}
+		nt.openLogs(context.Background(), tt.argv)
+		if tt.body == "" {
+			if bus.method != "" {
+				t.Errorf("%q: posted %#v", tt.argv, bus.args)
+			}
+			continue
+		}
+		if len(bus.args) != 8 || bus.args[3] != "Could not open the logs" || !strings.Contains(bus.args[4].(string), tt.body) {
+			t.Errorf("%q: posted %#v, want a failure with %q", tt.argv, bus.args, tt.body)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Steam overlay
+//  The in-game overlay breaks some games. It is on unless
+//  EnableGameOverlay is "0" in the system block of each account's
+//  userdata/<id>/config/localconfig.vdf; "Steam Overlay" sets it for
+//  every account that has signed in here. Steam rewrites the file
+//  when it exits, so the switch is refused while Steam is running.
+// ─────────────────────────────────────────────────────────────────
+
+// overlaySystemKey is the localconfig.vdf block holding overlayKey.
+const (
+	overlaySystemKey = "userlocalconfigstore/system"
+	overlayKey       = "EnableGameOverlay"
+)
+
+// localConfigPaths are the localconfig.vdf of every account.
+func localConfigPaths() []string {
+	paths, _ := filepath.Glob(filepath.Join(steamDir(), "userdata", "*", "config", "localconfig.vdf"))
+	return paths
+}
+
+// overlayEnabled reads localconfig.vdf content; the overlay is on
+// unless turned off.
+func overlayEnabled(content string) bool {
+	lines := strings.Split(content, "\n")
+	b, ok := vdfIndex(lines)[overlaySystemKey]
+	if !ok {
+		return true
+	}
+	for _, line := range lines[b[0]+1 : b[1]] {
+		if k, v, ok := vdfPair(strings.TrimSpace(line)); ok && strings.EqualFold(k, overlayKey) {
+			return v != "0"
+		}
+	}
+	return true
+}
+
+// withOverlay returns localconfig.vdf content with the overlay set to
+// on, adding the key or the system block where they are missing.
+func withOverlay(content string, on bool) (string, error) {
+	value := "0"
+	if on {
+		value = "1"
+	}
+	lines := strings.Split(content, "\n")
+	idx := vdfIndex(lines)
+	insert := func(at int, block ...string) string {
+		out := append(append(append([]string(nil), lines[:at]...), block...), lines[at:]...)
+		return strings.Join(out, "\n")
+	}
+	pair := func(ind string) string { return ind + `"` + overlayKey + `"		"` + value + `"` }
+
+	if b, ok := idx[overlaySystemKey]; ok {
+		for i := b[0] + 1; i < b[1]; i++ {
+			if k, _, ok := vdfPair(strings.TrimSpace(lines[i])); ok && strings.EqualFold(k, overlayKey) {
+				lines[i] = pair(indentOf(lines[i]))
+				return strings.Join(lines, "\n"), nil
+			}
+		}
+		return insert(b[0]+1, pair(indentOf(lines[b[0]])+"\t")), nil
+	}
+	if b, ok := idx["userlocalconfigstore"]; ok {
+		ind := indentOf(lines[b[0]]) + "\t"
+		return insert(b[0]+1, ind+`"system"`, ind+"{", pair(ind+"\t"), ind+"}"), nil
+	}
+	return "", errors.New("localconfig.vdf has no settings yet; start Steam once first")
+}
+
+// overlayState is the setting across accounts: "On", "Off", "Mixed",
+// or "" when no account has signed in yet.
+func overlayState(paths []string) string {
+	on, off := 0, 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if overlayEnabled(string(data)) {
+			on++
+		} else {
+			off++
+		}
+	}
+	switch {
+	case on > 0 && off > 0:
+		return "Mixed"
+	case on > 0:
+		return "On"
+	case off > 0:
+		return "Off"
+	}
+	return ""
+}
+
+// setOverlay writes the setting into each of paths.
+func setOverlay(paths []string, on bool) error {
+	var errs []error
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			var content string
+			if content, err = withOverlay(string(data), on); err == nil {
+				err = writeFileAtomic(path, []byte(content), 0o644)
+			}
+		}
+		if err != nil {
+			errs = append(errs, errors.New(tildePath(path)+": "+err.Error()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// openOverlayMenu is the "Steam Overlay" action.
+func (m *model) openOverlayMenu() tea.Cmd {
+	if remoteHost != "" {
+		return m.showToast("Steam's settings can't be changed over --remote")
+	}
+	current := overlayState(localConfigPaths())
+	if current == "" {
+		return m.showToast("No Steam account has signed in yet")
+	}
+	sm := &submenu{
+		title:  "Steam Overlay — current: " + current,
+		note:   "Applies to every account signed in on this machine.",
+		marked: -1,
+		items: []menuItem{
+			{icon: "◈", label: "On — Shift+Tab in games", run: setOverlayAction(true)},
+			{icon: "◇", label: "Off — no in-game overlay", run: setOverlayAction(false)},
+		},
+	}
+	switch current {
+	case "On":
+		sm.marked = 0
+	case "Off":
+		sm.marked = 1
+	}
+	m.openSubmenu(sm)
+	return nil
+}
+
+func setOverlayAction(on bool) func(m *model) tea.Cmd {
+	return func(m *model) tea.Cmd {
+		if steamClientRunning("/proc") {
+			return m.showToast("Quit Steam first: it rewrites localconfig.vdf when it exits")
+		}
+		if err := setOverlay(localConfigPaths(), on); err != nil {
+			m.logError("Steam overlay not fully set: " + err.Error())
+			return nil
+		}
+		m.appendLog(styleLogSuccess.Render("  ✔  Steam in-game overlay: " + onOff(on) + "."))
+		m.appendLog(styleLogDim.Render("  Takes effect the next time Steam starts."))
+		return m.showToast("Steam overlay " + strings.ToLower(onOff(on)))
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const localConfigOn = `"UserLocalConfigStore"
+{
+	"system"
+	{
+		"EnableGameOverlay"		"1"
+		"InGameOverlayShortcutKey"		"Shift	KEY_TAB"
+	}
+	"friends"
+	{
+	}
+}`
+
+const localConfigNoSystem = `"UserLocalConfigStore"
+{
+	"friends"
+	{
+	}
+}`
+
+func TestOverlayEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"on", localConfigOn, true},
+		{"off", strings.Replace(localConfigOn, `"EnableGameOverlay"		"1"`, `"EnableGameOverlay"		"0"`, 1), false},
+		{"key missing", strings.Replace(localConfigOn, `"EnableGameOverlay"		"1"`, "", 1), true},
+		{"no system block", localConfigNoSystem, true},
+		{"empty file", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overlayEnabled(tt.content); got != tt.want {
+				t.Errorf("overlayEnabled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithOverlay(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		on      bool
+	}{
+		{"turn off", localConfigOn, false},
+		{"turn on", strings.Replace(localConfigOn, `"1"`, `"0"`, 1), true},
+		{"add the key", strings.Replace(localConfigOn, `"EnableGameOverlay"		"1"`, "", 1), false},
+		{"add the system block", localConfigNoSystem, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withOverlay(tt.content, tt.on)
+			if err != nil {
+				t.Fatalf("withOverlay: %v", err)
+			}
+			if overlayEnabled(got) != tt.on {
+				t.Errorf("overlay not %s:\n%s", onOff(tt.on), got)
+			}
+			if n := strings.Count(got, overlayKey); n != 1 {
+				t.Errorf("%s appears %d times:\n%s", overlayKey, n, got)
+			}
+			if !strings.Contains(got, `"friends"`) {
+				t.Errorf("other settings lost:\n%s", got)
+			}
+		})
+	}
+	if _, err := withOverlay("", false); err == nil {
+		t.Error("withOverlay on an empty file succeeded, want error")
+	}
+}
+
+func TestOverlayState(t *testing.T) {
+	dir := t.TempDir()
+	on := filepath.Join(dir, "on.vdf")
+	off := filepath.Join(dir, "off.vdf")
+	if err := os.WriteFile(on, []byte(localConfigOn), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(off, []byte(strings.Replace(localConfigOn, `"1"`, `"0"`, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		paths []string
+		want  string
+	}{
+		{[]string{on}, "On"},
+		{[]string{off}, "Off"},
+		{[]string{on, off}, "Mixed"},
+		{[]string{filepath.Join(dir, "missing.vdf")}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := overlayState(tt.paths); got != tt.want {
+			t.Errorf("overlayState(%q) = %q, want %q", tt.paths, got, tt.want)
+		}
+	}
+}
+
+func TestSetOverlay(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.vdf")
+	empty := filepath.Join(dir, "empty.vdf")
+	if err := os.WriteFile(good, []byte(localConfigOn), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(empty, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := setOverlay([]string{good, empty}, false)
+	if err == nil || !strings.Contains(err.Error(), "empty.vdf") {
+		t.Errorf("setOverlay error = %v, want one naming empty.vdf", err)
+	}
+	if got := overlayState([]string{good}); got != "Off" {
+		t.Errorf("good.vdf is %q after the failure elsewhere, want Off", got)
+	}
+}
+
+func TestOpenOverlayMenu(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	m := initialModel()
+	if m.openOverlayMenu(); m.submenu != nil || !strings.Contains(m.toast, "signed in") {
+		t.Fatalf("no accounts: submenu %v, toast %q", m.submenu != nil, m.toast)
+	}
+
+	path := filepath.Join(steamDir(), "userdata", "1234", "config", "localconfig.vdf")
+	mkdirs(t, filepath.Dir(path))
+	if err := os.WriteFile(path, []byte(localConfigOn), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.openOverlayMenu()
+	if m.submenu == nil || m.submenu.marked != 0 || !strings.Contains(m.submenu.title, "current: On") {
+		t.Fatalf("submenu = %+v", m.submenu)
+	}
+	if steamClientRunning("/proc") {
+		t.Skip("a Steam client is running here")
+	}
+	m.submenu.items[1].run(&m)
+	if got := overlayState(localConfigPaths()); got != "Off" {
+		t.Errorf("overlay %q after choosing Off", got)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Pane layout
+//  The list layout puts the menu on the left and the output pane on
+//  the right while the terminal is wide enough for both; below that
+//  they stack, a window of the menu that follows the cursor above a
+//  full-width log. layout = "grid" has a stacked layout of its own.
+//  ↑/↓ keep moving the menu and pgup/pgdn the log in all of them.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	sidebarWidth = 28
+	minLogWidth  = 40
+	// twoPaneMinWidth fits the sidebar, its border and a usable log.
+	twoPaneMinWidth = sidebarWidth + 2 + minLogWidth
+	// stackedMenuMin is the fewest menu rows the stacked layout shows.
+	stackedMenuMin = 3
+)
+
+type paneLayout int
+
+const (
+	layoutTwoPane paneLayout = iota // menu left, output right
+	layoutStacked                   // menu above the output
+	layoutGrid                      // grid above the output
+)
+
+// paneLayoutFor picks the layout for the layout setting at width.
+func paneLayoutFor(layout string, width int) paneLayout {
+	switch {
+	case layout == "grid" && width >= gridMinWidth:
+		return layoutGrid
+	case width >= twoPaneMinWidth:
+		return layoutTwoPane
+	}
+	return layoutStacked
+}
+
+func (m model) paneLayout() paneLayout {
+	return paneLayoutFor(cfg.Layout, m.width)
+}
+
+// stackedMenuHeight is the height of the stacked menu, bottom border
+// included: a third of the room, at least stackedMenuMin rows.
+func stackedMenuHeight(height int) int {
+	return max(stackedMenuMin, logPanelHeight(height)/3) + 1
+}
+
+// menuWindow is the first of n rows shown when cursor must be among
+// the visible ones.
+func menuWindow(cursor, n, visible int) int {
+	if n <= visible {
+		return 0
+	}
+	return min(max(cursor-visible/2, 0), n-visible)
+}
+
+func (m model) renderStackedMenu() string {
+	rows, cursorRow := m.menuRows(m.width)
+	visible := stackedMenuHeight(m.height) - 1
+	start := menuWindow(cursorRow, len(rows), visible)
+	rows = rows[start:min(start+visible, len(rows))]
+	for len(rows) < visible {
+		rows = append(rows, "")
+	}
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(visible).
+		Background(colBg).
+		BorderBottom(true).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(colBorder).
+		Render(strings.Join(rows, "\n"))
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPaneLayoutFor(t *testing.T) {
+	tests := []struct {
+		layout string
+		width  int
+		want   paneLayout
+	}{
+		{"list", 120, layoutTwoPane},
+		{"list", twoPaneMinWidth, layoutTwoPane},
+		{"list", twoPaneMinWidth - 1, layoutStacked},
+		{"list", 40, layoutStacked},
+		{"grid", 120, layoutGrid},
+		{"grid", gridMinWidth, layoutGrid},
+		{"grid", gridMinWidth - 1, layoutStacked},
+		{"", 80, layoutTwoPane},
+	}
+	for _, tt := range tests {
+		if got := paneLayoutFor(tt.layout, tt.width); got != tt.want {
+			t.Errorf("paneLayoutFor(%q, %d) = %v, want %v", tt.layout, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestMenuWindow(t *testing.T) {
+	tests := []struct {
+		cursor, n, visible, want int
+	}{
+		{0, 5, 10, 0},
+		{4, 5, 10, 0},
+		{0, 30, 8, 0},
+		{3, 30, 8, 0},
+		{10, 30, 8, 6},
+		{29, 30, 8, 22},
+	}
+	for _, tt := range tests {
+		got := menuWindow(tt.cursor, tt.n, tt.visible)
+		if got != tt.want {
+			t.Errorf("menuWindow(%d, %d, %d) = %d, want %d", tt.cursor, tt.n, tt.visible, got, tt.want)
+		}
+		if tt.cursor < got || tt.cursor >= got+tt.visible {
+			t.Errorf("menuWindow(%d, %d, %d): cursor outside the window", tt.cursor, tt.n, tt.visible)
+		}
+	}
+}
+
+func TestStackedMenuHeight(t *testing.T) {
+	tests := []struct{ height, want int }{
+		{14, stackedMenuMin + 1},
+		{24, 7},
+		{40, 12},
+	}
+	for _, tt := range tests {
+		if got := stackedMenuHeight(tt.height); got != tt.want {
+			t.Errorf("stackedMenuHeight(%d) = %d, want %d", tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestStackedLayout(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.Layout, cfg.HideDisabled = "list", false
+	t.Setenv("HOME", t.TempDir())
+
+	m := initialModel()
+	m.containerStatus = "running"
+	next, _ := m.Update(tea.WindowSizeMsg{Width: 60, Height: 24})
+	next, _ = next.Update(resizeFlushMsg{})
+	m = next.(model)
+	if m.paneLayout() != layoutStacked {
+		t.Fatalf("60 wide: layout %v, want stacked", m.paneLayout())
+	}
+	if w, h := m.logSize(); w != 60 || h != logPanelHeight(24)-stackedMenuHeight(24) {
+		t.Errorf("stacked log is %dx%d", w, h)
+	}
+
+	for i := 0; i < len(menuItems); i++ {
+		m = press(m, "down")
+	}
+	last := menuItems[m.cursor].label
+	menu := stripANSI(m.renderStackedMenu())
+	if !strings.Contains(menu, last) || strings.Contains(menu, "Launch Steam") {
+		t.Errorf("window doesn't follow the cursor to %q:\n%s", last, menu)
+	}
+	if h := lipgloss.Height(m.renderStackedMenu()); h != stackedMenuHeight(24) {
+		t.Errorf("stacked menu is %d rows, want %d", h, stackedMenuHeight(24))
+	}
+	if w := lipgloss.Width(m.View()); w > 60 {
+		t.Errorf("view is %d wide", w)
+	}
+
+	// Back to two panes once there is room.
+	next, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	next, _ = next.Update(resizeFlushMsg{})
+	m = next.(model)
+	if m.paneLayout() != layoutTwoPane || m.logViewport.Width != logPanelWidth(120) {
+		t.Errorf("120 wide: layout %v, log %d wide", m.paneLayout(), m.logViewport.Width)
+	}
+	if got := menuItems[m.cursor].label; got != last {
+		t.Errorf("cursor moved to %q on the layout change", got)
+	}
+}
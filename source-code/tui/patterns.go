@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Output patterns
+//  Everything the TUI recognises in command output is a regex that
+//  [patterns] in config.toml can override, so localized output from
+//  the container tool can still be parsed. Compiled once at startup.
+// ─────────────────────────────────────────────────────────────────
+
+type patternSpec struct {
+	def    string
+	groups int // capture groups the parser reads
+}
+
+var patternSpecs = map[string]patternSpec{
+	// "Progress: 42%", the CLI's "[ 2/6] ███░░ 33%" step bar, or any "N%".
+	"progress": {`(\d{1,3}(?:\.\d+)?)\s*%`, 1},
+	// pacman's "(12/345) upgrading foo" step counter.
+	"step": {`^\s*\((\d+)/(\d+)\)`, 2},
+	// "Extracting 1234/5678 files".
+	"files": {`(?i)extract\w*\D*?(\d+)\s*/\s*(\d+)\s*files?`, 2},
+	// Transfer rates such as "12.3 MiB/s"; the unit must be one of
+	// B, KB, MB, GB, KiB, MiB, GiB.
+	"speed": {`(\d+(?:\.\d+)?)\s*([KMG]i?B|B)/s`, 2},
+	// Section headers printed by hackeros-steam ("┌─ UPDATING CONTAINER").
+	"phase": {`─ |LAUNCH|CREAT|SETUP|UPDAT|REMOV|STATUS|STOP`, 0},
+	// `hackeros-steam status` classification.
+	"status_missing": {`(?i)does not exist|not created`, 0},
+	"status_running": {`(?i)running`, 0},
+	"status_paused":  {`(?i)paused`, 0},
+}
+
+type outputPatterns struct {
+	progress, step, files, speed, phase *regexp.Regexp
+	statusMissing, statusRunning        *regexp.Regexp
+	statusPaused                        *regexp.Regexp
+}
+
+var pat = mustDefaultPatterns()
+
+func mustDefaultPatterns() outputPatterns {
+	p, err := compilePatterns(nil)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// compilePatterns builds the pattern set from the defaults and the user
+// overrides. Invalid overrides are reported and the default is kept.
+func compilePatterns(overrides map[string]string) (outputPatterns, error) {
+	var problems []string
+	for name := range overrides {
+		if _, ok := patternSpecs[name]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown pattern %q", name))
+		}
+	}
+
+	compiled := map[string]*regexp.Regexp{}
+	for name, spec := range patternSpecs {
+		src := spec.def
+		if o, ok := overrides[name]; ok && o != "" {
+			re, err := regexp.Compile(o)
+			switch {
+			case err != nil:
+				problems = append(problems, fmt.Sprintf("pattern %s: %v", name, err))
+			case re.NumSubexp() < spec.groups:
+				problems = append(problems, fmt.Sprintf("pattern %s: needs %d capture group(s)", name, spec.groups))
+			default:
+				src = o
+			}
+		}
+		compiled[name] = regexp.MustCompile(src)
+	}
+
+	p := outputPatterns{
+		progress:      compiled["progress"],
+		step:          compiled["step"],
+		files:         compiled["files"],
+		speed:         compiled["speed"],
+		phase:         compiled["phase"],
+		statusMissing: compiled["status_missing"],
+		statusRunning: compiled["status_running"],
+		statusPaused:  compiled["status_paused"],
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return p, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return p, nil
+}
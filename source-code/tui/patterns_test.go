@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompilePatternsValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		errPart   string // "" = no error
+	}{
+		{"defaults", nil, ""},
+		{"valid override", map[string]string{"progress": `Postęp:\s*(\d+)%`}, ""},
+		{"empty override keeps default", map[string]string{"progress": ""}, ""},
+		{"bad regex", map[string]string{"phase": `(`}, "pattern phase"},
+		{"missing groups", map[string]string{"step": `\((\d+)\)`}, "needs 2 capture group(s)"},
+		{"unknown name", map[string]string{"percent": `x`}, `unknown pattern "percent"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := compilePatterns(tt.overrides)
+			if tt.errPart == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.errPart != "" && (err == nil || !strings.Contains(err.Error(), tt.errPart)) {
+				t.Fatalf("err = %v, want one mentioning %q", err, tt.errPart)
+			}
+			// Invalid overrides fall back to the default.
+			if p.progress == nil || p.step == nil || p.phase == nil || p.statusRunning == nil {
+				t.Fatal("a pattern is nil")
+			}
+		})
+	}
+}
+
+func TestCustomPatterns(t *testing.T) {
+	defer func(saved outputPatterns) { pat = saved }(pat)
+	var err error
+	pat, err = compilePatterns(map[string]string{
+		"progress":       `Postęp:\s*(\d+)\s*proc`,
+		"step":           `^krok (\d+) z (\d+)`,
+		"files":          `Rozpakowywanie (\d+)/(\d+) plików`,
+		"speed":          `(\d+(?:\.\d+)?)\s*(MiB|KiB)/sek`,
+		"status_missing": `nie istnieje`,
+		"status_running": `działa`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	progress := []struct {
+		line string
+		want float64
+		ok   bool
+	}{
+		{"Postęp: 40 proc", 0.4, true},
+		{"krok 1 z 4", 0.25, true},
+		{"Progress: 40%", 0, false},
+	}
+	for _, tt := range progress {
+		if got, ok := parseProgress(tt.line); ok != tt.ok || got != tt.want {
+			t.Errorf("parseProgress(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+	if cur, total, ok := parseFileCount("Rozpakowywanie 3/9 plików"); !ok || cur != 3 || total != 9 {
+		t.Errorf("parseFileCount = %d, %d, %v", cur, total, ok)
+	}
+	if bps, ok := parseSpeed("2 MiB/sek"); !ok || bps != 2<<20 {
+		t.Errorf("parseSpeed = %v, %v", bps, ok)
+	}
+	if !pat.statusMissing.MatchString("Kontener nie istnieje") || pat.statusMissing.MatchString("does not exist") {
+		t.Error("status_missing override not applied")
+	}
+	if !pat.statusRunning.MatchString("● działa") {
+		t.Error("status_running override not applied")
+	}
+}
+
+func TestSpeedPatternWithUnknownUnit(t *testing.T) {
+	defer func(saved outputPatterns) { pat = saved }(pat)
+	var err error
+	if pat, err = compilePatterns(map[string]string{"speed": `(\d+) (\w+)/s`}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := parseSpeed("12 parsecs/s"); ok {
+		t.Error("parseSpeed accepted an unknown unit")
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Confirmation phrase
+//  Actions listed in phrase_actions are confirmed by typing
+//  confirm_phrase, matched exactly, instead of pressing y. An entry
+//  is an action name ("remove"), a command ("reset --wipe-data") or a
+//  menu label.
+//
+//    phrase_actions = ["remove", "reset --wipe-data"]
+//    confirm_phrase = "DELETE"
+// ─────────────────────────────────────────────────────────────────
+
+const defaultConfirmPhrase = "DELETE"
+
+var (
+	phraseSubmit = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm"))
+	phraseCancel = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel"))
+)
+
+// needsPhrase reports whether item is confirmed by typing the phrase.
+func needsPhrase(item menuItem) bool {
+	if cfg.ConfirmPhrase == "" {
+		return false
+	}
+	command := strings.Join(item.cmd, " ")
+	name := actionName(item)
+	for _, entry := range cfg.PhraseActions {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if entry == name || entry == item.label || strings.HasSuffix(" "+command, " "+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// phraseMatches is the exact, case-sensitive comparison.
+func phraseMatches(typed string) bool {
+	return cfg.ConfirmPhrase != "" && typed == cfg.ConfirmPhrase
+}
+
+func (m *model) handlePhraseKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, phraseSubmit):
+		if !phraseMatches(m.phraseInput) {
+			m.resetPromptTimer()
+			return m.showToast("Type " + cfg.ConfirmPhrase + " exactly to confirm")
+		}
+		item := m.pendingItem
+		m.pendingItem = nil
+		m.phraseInput = ""
+		m.state = stateMenu
+		return m.dispatchAction(*item, m.pendingAction)
+	case key.Matches(msg, phraseCancel):
+		m.pendingItem = nil
+		m.phraseInput = ""
+		m.state = stateMenu
+		m.appendLog(styleLogDim.Render("  Aborted."))
+	case msg.Type == tea.KeyBackspace:
+		if r := []rune(m.phraseInput); len(r) > 0 {
+			m.phraseInput = string(r[:len(r)-1])
+		}
+		m.resetPromptTimer()
+	case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+		m.phraseInput += string(msg.Runes)
+		m.resetPromptTimer()
+	}
+	return nil
+}
+
+func (m model) renderPhraseDialog() string {
+	item := m.pendingItem
+
+	lines := []string{
+		lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("⚠  Confirm Action"),
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render(item.label + ": " + containerName),
+	}
+	for _, w := range item.warning {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colSub).Render(w))
+	}
+	inputStyle := lipgloss.NewStyle().Foreground(colText)
+	if phraseMatches(m.phraseInput) {
+		inputStyle = inputStyle.Foreground(colGreen)
+	}
+	lines = append(lines,
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render("Type ")+
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render(cfg.ConfirmPhrase)+
+			lipgloss.NewStyle().Foreground(colText).Render(" to confirm:"),
+		lipgloss.NewStyle().Foreground(colAccent).Render("> ")+inputStyle.Render(m.phraseInput)+
+			lipgloss.NewStyle().Foreground(colCursor).Render("▏"),
+		"",
+		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[Enter]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("confirm")+"   "+
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[Esc]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("cancel"),
+		m.renderCountdown(),
+	)
+
+	return m.placeOverlay(styleConfirmBox, lipgloss.JoinVertical(lipgloss.Center, lines...))
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNeedsPhrase(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	remove := menuItems[itemIndex(t, "Remove Container")]
+	repair := menuItems[itemIndex(t, "Repair Container")]
+	tests := []struct {
+		name    string
+		actions []string
+		phrase  string
+		item    menuItem
+		want    bool
+	}{
+		{"off by default", nil, defaultConfirmPhrase, remove, false},
+		{"action name", []string{"remove"}, "DELETE", remove, true},
+		{"other action", []string{"remove"}, "DELETE", repair, false},
+		{"command", []string{"reset --wipe-data"}, "DELETE", resetWipeAll, true},
+		{"command is not a prefix match", []string{"reset --wipe-data"}, "DELETE", resetKeepData, false},
+		{"action name covers both resets", []string{"reset"}, "DELETE", resetKeepData, true},
+		{"label", []string{"Repair Container"}, "DELETE", repair, true},
+		{"blank entries", []string{" ", ""}, "DELETE", remove, false},
+		{"no phrase", []string{"remove"}, "", remove, false},
+	}
+	for _, tt := range tests {
+		cfg.PhraseActions, cfg.ConfirmPhrase = tt.actions, tt.phrase
+		if got := needsPhrase(tt.item); got != tt.want {
+			t.Errorf("%s: needsPhrase(%q) = %v, want %v", tt.name, tt.item.label, got, tt.want)
+		}
+	}
+}
+
+func TestPhraseGating(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	tests := []struct {
+		name    string
+		phrase  string
+		keys    []string
+		started bool
+		open    bool
+	}{
+		{"exact", "DELETE", []string{"D", "E", "L", "E", "T", "E", "enter"}, true, false},
+		{"typed at once", "DELETE", []string{"DELETE", "enter"}, true, false},
+		{"wrong case", "DELETE", []string{"delete", "enter"}, false, true},
+		{"a y is not enough", "DELETE", []string{"y"}, false, true},
+		{"short", "DELETE", []string{"DELET", "enter"}, false, true},
+		{"corrected", "DELETE", []string{"DELETF", "backspace", "E", "enter"}, true, false},
+		{"trailing space", "DELETE", []string{"DELETE ", "enter"}, false, true},
+		{"non-ASCII", "USUŃ", []string{"USUŃ", "enter"}, true, false},
+		{"y in the phrase", "YES", []string{"Y", "E", "S", "enter"}, true, false},
+		{"cancelled", "DELETE", []string{"DELETE", "esc"}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.PhraseActions, cfg.ConfirmPhrase = []string{"remove"}, tt.phrase
+			m := initialModel()
+			m.width, m.height = 120, 40
+			m.containerStatus = "running"
+			m.openPrompt(stateConfirm, menuItems[itemIndex(t, "Remove Container")])
+			if m.state != statePhrase {
+				t.Fatalf("state %v, want the phrase prompt", m.state)
+			}
+			if !strings.Contains(m.renderPhraseDialog(), tt.phrase) {
+				t.Error("dialog doesn't name the phrase")
+			}
+			// Typing starts right away; only the enter is held back.
+			keys := tt.keys
+			m = press(m, keys[:len(keys)-1]...)
+			m = press(settle(m), keys[len(keys)-1])
+			if started := countLogged(m, "$ hackeros-steam --force remove") == 1; started != tt.started {
+				t.Errorf("started = %v, want %v (typed %q)", started, tt.started, m.phraseInput)
+			}
+			if open := m.state == statePhrase; open != tt.open {
+				t.Errorf("prompt open = %v, want %v", open, tt.open)
+			}
+		})
+	}
+}
+
+func TestPhraseEnterGuarded(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.PhraseActions, cfg.ConfirmPhrase = []string{"remove"}, "DELETE"
+	m := initialModel()
+	m.containerStatus = "running"
+	m.openPrompt(stateConfirm, menuItems[itemIndex(t, "Remove Container")])
+	m.phraseInput = "DELETE"
+	m = press(m, "enter")
+	if m.state != statePhrase {
+		t.Error("an enter right after the prompt opened confirmed it")
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Plugin actions (actions.d)
+//  Executables in actions.d next to config.toml become menu actions.
+//  Their label and section come from "# key: value" lines near the top
+//  of the script, or from a <script>.toml beside it, which wins:
+//
+//    #!/bin/sh
+//    # label: Clear Shader Cache
+//    # category: Tools
+//    # confirm: true
+//
+//  Keys: label, category, icon, confirm, destructive (off in safe
+//  mode), timeout ("10m"). A plugin runs like any other action, output
+//  streamed, and is interrupted once its timeout passes. Scripts others
+//  can write to are refused.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	pluginDirName        = "actions.d"
+	pluginHeaderLines    = 30
+	defaultPluginSection = "PLUGINS"
+	defaultPluginTimeout = 30 * time.Minute
+)
+
+// pluginMeta is what a plugin says about itself.
+type pluginMeta struct {
+	Label       string `toml:"label"`
+	Category    string `toml:"category"`
+	Icon        string `toml:"icon"`
+	Confirm     bool   `toml:"confirm"`
+	Destructive bool   `toml:"destructive"`
+	Timeout     string `toml:"timeout"`
+}
+
+// pluginTimeoutMsg fires when the plugin started as capture run may
+// have run too long.
+type pluginTimeoutMsg struct {
+	run     int
+	timeout time.Duration
+}
+
+var reHeaderKey = regexp.MustCompile(`^#\s*([a-z_]+)\s*:\s*(.*?)\s*$`)
+
+func pluginDir() string {
+	return filepath.Join(configDir(), pluginDirName)
+}
+
+// parsePluginHeader reads "# key: value" lines from the top of a
+// script into meta; unknown keys are ignored.
+func parsePluginHeader(r io.Reader, meta *pluginMeta) error {
+	sc := bufio.NewScanner(r)
+	for n := 0; n < pluginHeaderLines && sc.Scan(); n++ {
+		m := reHeaderKey.FindStringSubmatch(strings.TrimSpace(sc.Text()))
+		if m == nil {
+			continue
+		}
+		value := m[2]
+		switch m[1] {
+		case "label":
+			meta.Label = value
+		case "category":
+			meta.Category = value
+		case "icon":
+			meta.Icon = value
+		case "timeout":
+			meta.Timeout = value
+		case "confirm", "destructive":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%s: %q is not true or false", m[1], value)
+			}
+			if m[1] == "confirm" {
+				meta.Confirm = b
+			} else {
+				meta.Destructive = b
+			}
+		}
+	}
+	return sc.Err()
+}
+
+// pluginItem builds the menu item for the script at path.
+func pluginItem(path string, meta pluginMeta) (menuItem, error) {
+	timeout := defaultPluginTimeout
+	if meta.Timeout != "" {
+		d, err := time.ParseDuration(meta.Timeout)
+		if err != nil || d <= 0 {
+			return menuItem{}, fmt.Errorf("timeout: %q is not a duration like \"10m\"", meta.Timeout)
+		}
+		timeout = d
+	}
+	label := meta.Label
+	if label == "" {
+		label = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	icon := meta.Icon
+	if icon == "" {
+		icon = "⚙"
+	}
+	section := strings.ToUpper(strings.TrimSpace(meta.Category))
+	if section == "" {
+		section = defaultPluginSection
+	}
+	return menuItem{
+		icon:        icon,
+		label:       label,
+		section:     section,
+		script:      path,
+		timeout:     timeout,
+		confirm:     meta.Confirm,
+		destructive: meta.Destructive,
+		env:         []string{"HACKEROS_STEAM_CONTAINER=" + containerName, "HACKEROS_STEAM_CLI=" + cli},
+	}, nil
+}
+
+// checkPluginFile refuses what shouldn't run: non-executables and
+// scripts someone else could have changed.
+func checkPluginFile(info fs.FileInfo) error {
+	switch {
+	case !info.Mode().IsRegular():
+		return errors.New("not a regular file")
+	case info.Mode()&0o111 == 0:
+		return errors.New("not executable (chmod +x)")
+	case info.Mode()&0o022 != 0:
+		return errors.New("writable by group or others; refusing to run it")
+	}
+	return nil
+}
+
+// loadPlugins returns the actions in dir, sorted by section and label.
+// A missing dir is not an error; a broken plugin is skipped and named
+// in the error.
+func loadPlugins(dir string) ([]menuItem, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("plugins %s: %w", dir, err)
+	}
+	var items []menuItem
+	var errs []error
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".toml") || strings.HasSuffix(name, "~") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		item, err := loadPlugin(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %w", name, err))
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].section != items[j].section {
+			return items[i].section < items[j].section
+		}
+		return items[i].label < items[j].label
+	})
+	return items, errors.Join(errs...)
+}
+
+func loadPlugin(path string) (menuItem, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return menuItem{}, err
+	}
+	if err := checkPluginFile(info); err != nil {
+		return menuItem{}, err
+	}
+	var meta pluginMeta
+	f, err := os.Open(path)
+	if err != nil {
+		return menuItem{}, err
+	}
+	err = parsePluginHeader(f, &meta)
+	f.Close()
+	if err != nil {
+		return menuItem{}, err
+	}
+	if _, err := toml.DecodeFile(path+".toml", &meta); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return menuItem{}, err
+	}
+	return pluginItem(path, meta)
+}
+
+// addPlugins places plugin items in the menu: after the last item of
+// an existing section with the same name, or in new sections at the
+// end. Only the first item of a section carries its name.
+func addPlugins(plugins []menuItem) {
+	for _, p := range plugins {
+		at := -1
+		for i := range menuItems {
+			if sectionOf(i) == p.section {
+				at = i + 1
+			}
+		}
+		if at < 0 {
+			menuItems = append(menuItems, p)
+			continue
+		}
+		p.section = ""
+		menuItems = append(menuItems[:at], append([]menuItem{p}, menuItems[at:]...)...)
+	}
+}
+
+// startPluginTimer arms the timeout of the plugin that just started.
+func (m *model) startPluginTimer(item menuItem) tea.Cmd {
+	if item.script == "" || item.timeout <= 0 {
+		return nil
+	}
+	run, d := m.capture.id, item.timeout
+	return tea.Tick(d, func(time.Time) tea.Msg { return pluginTimeoutMsg{run: run, timeout: d} })
+}
+
+// handlePluginTimeout interrupts the plugin if it is still running.
+func (m *model) handlePluginTimeout(msg pluginTimeoutMsg) {
+	if !m.busy || m.capture.id != msg.run || m.stopStream == nil {
+		return
+	}
+	m.appendLog(styleLogWarning.Render("  ⚠  " + m.lastItemLabel() + " timed out after " + msg.timeout.String() + "; interrupting it."))
+	m.stopStream()
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePluginHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    pluginMeta
+		errPart string
+	}{
+		{"full header", "#!/bin/sh\n# label: Clear Shader Cache\n# category: Tools\n# icon: ✧\n# confirm: true\n# destructive: yes\n",
+			pluginMeta{}, `destructive: "yes" is not true or false`},
+		{"all keys", "#!/bin/sh\n# label: Clear Shader Cache\n#category:Tools\n# icon: ✧\n# confirm: true\n# destructive: false\n# timeout: 10m\n",
+			pluginMeta{Label: "Clear Shader Cache", Category: "Tools", Icon: "✧", Confirm: true, Timeout: "10m"}, ""},
+		{"unknown keys and prose", "#!/bin/sh\n# author: someone\n# This script: cleans up\n# label: Tidy\necho hi\n",
+			pluginMeta{Label: "Tidy"}, ""},
+		{"past the header", "#!/bin/sh\n" + strings.Repeat("echo\n", pluginHeaderLines) + "# label: Too Late\n",
+			pluginMeta{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var meta pluginMeta
+			err := parsePluginHeader(strings.NewReader(tt.script), &meta)
+			if tt.errPart != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+					t.Errorf("err = %v, want %q", err, tt.errPart)
+				}
+				return
+			}
+			if err != nil || meta != tt.want {
+				t.Errorf("meta = %+v, %v; want %+v", meta, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPluginItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		meta    pluginMeta
+		label   string
+		section string
+		timeout time.Duration
+		errPart string
+	}{
+		{"defaults", "/a/clear-cache.sh", pluginMeta{}, "clear-cache", defaultPluginSection, defaultPluginTimeout, ""},
+		{"labelled", "/a/x", pluginMeta{Label: "Clear Cache", Category: " Tools ", Timeout: "90s"}, "Clear Cache", "TOOLS", 90 * time.Second, ""},
+		{"bad timeout", "/a/x", pluginMeta{Timeout: "soon"}, "", "", 0, "not a duration"},
+		{"zero timeout", "/a/x", pluginMeta{Timeout: "0s"}, "", "", 0, "not a duration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := pluginItem(tt.path, tt.meta)
+			if tt.errPart != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+					t.Errorf("err = %v", err)
+				}
+				return
+			}
+			if err != nil || item.label != tt.label || item.section != tt.section || item.timeout != tt.timeout || item.script != tt.path {
+				t.Errorf("item = %+v, %v", item, err)
+			}
+		})
+	}
+}
+
+// writePlugin creates an actions.d script with mode perm.
+func writePlugin(t *testing.T, dir, name, body string, perm os.FileMode) string {
+	t.Helper()
+	os.MkdirAll(dir, 0o755)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(body), perm); err != nil {
+		t.Fatal(err)
+	}
+	os.Chmod(path, perm) // past the umask
+	return path
+}
+
+func TestLoadPlugins(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), pluginDirName)
+	writePlugin(t, dir, "shaders.sh", "#!/bin/sh\n# label: Clear Shader Cache\n# category: Tools\n", 0o755)
+	writePlugin(t, dir, "backup", "#!/bin/sh\n# label: Old Label\n", 0o700)
+	writePlugin(t, dir, "backup.toml", "label = \"Back Up Saves\"\ncategory = \"Container\"\nconfirm = true\n", 0o644)
+	writePlugin(t, dir, "zz-info", "#!/bin/sh\n", 0o755)
+	writePlugin(t, dir, "readme.txt", "notes\n", 0o644)
+	writePlugin(t, dir, "shared", "#!/bin/sh\n", 0o777)
+	writePlugin(t, dir, ".hidden", "#!/bin/sh\n", 0o755)
+	writePlugin(t, dir, "shaders.sh~", "#!/bin/sh\n", 0o755)
+
+	items, err := loadPlugins(dir)
+	var got []string
+	for _, it := range items {
+		got = append(got, it.section+"/"+it.label)
+	}
+	if want := "CONTAINER/Back Up Saves|PLUGINS/zz-info|TOOLS/Clear Shader Cache"; strings.Join(got, "|") != want {
+		t.Errorf("plugins = %q, want %q", got, want)
+	}
+	if len(items) > 0 && !items[0].confirm {
+		t.Error("companion file's confirm ignored")
+	}
+	for _, part := range []string{"plugin readme.txt: not executable", "plugin shared: writable by group or others"} {
+		if err == nil || !strings.Contains(err.Error(), part) {
+			t.Errorf("err = %v, want it to mention %q", err, part)
+		}
+	}
+
+	if items, err := loadPlugins(filepath.Join(dir, "missing")); items != nil || err != nil {
+		t.Errorf("missing dir: %v, %v", items, err)
+	}
+}
+
+func TestAddPlugins(t *testing.T) {
+	saved := menuItems
+	defer func() { menuItems = saved }()
+	menuItems = append([]menuItem(nil), saved...)
+
+	addPlugins([]menuItem{
+		{label: "Back Up Saves", section: "CONTAINER", script: "/p/backup"},
+		{label: "Clear Shader Cache", section: "TOOLS", script: "/p/shaders"},
+		{label: "Defrag", section: "TOOLS", script: "/p/defrag"},
+	})
+	backup := itemIndex(t, "Back Up Saves")
+	if sectionOf(backup) != "CONTAINER" || menuItems[backup].section != "" || sectionOf(backup+1) == "CONTAINER" {
+		t.Errorf("Back Up Saves at %d in %q", backup, sectionOf(backup))
+	}
+	shaders, defrag := itemIndex(t, "Clear Shader Cache"), itemIndex(t, "Defrag")
+	if menuItems[shaders].section != "TOOLS" || menuItems[defrag].section != "" || defrag != shaders+1 || defrag != len(menuItems)-1 {
+		t.Errorf("TOOLS section: %d %q, %d %q", shaders, menuItems[shaders].section, defrag, menuItems[defrag].section)
+	}
+}
+
+func TestPluginRuns(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), pluginDirName)
+	path := writePlugin(t, dir, "hello", "#!/bin/sh\necho \"hello from $HACKEROS_STEAM_CONTAINER\"\n", 0o755)
+	item, err := loadPlugin(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := actionFor(item, "")
+	if strings.Join(a.argv, " ") != path || !a.streaming {
+		t.Fatalf("action = %+v", a)
+	}
+	var out []string
+	for _, msg := range runStreamEnv(a.argv, a.env) {
+		if line, ok := msg.(cmdOutputMsg); ok {
+			out = append(out, string(line))
+		}
+	}
+	if strings.Join(out, "|") != "hello from "+containerName {
+		t.Errorf("output %q", out)
+	}
+
+	// The CLI being absent doesn't matter to a plugin.
+	m := initialModel()
+	m.cliMissing = true
+	if reason := m.disabledReason(item); reason != "" {
+		t.Errorf("disabled: %s", reason)
+	}
+}
+
+func TestPluginTimeout(t *testing.T) {
+	item := menuItem{label: "Slow", script: "/p/slow", timeout: time.Minute}
+	tests := []struct {
+		name        string
+		busy        bool
+		staleRun    bool
+		interrupted bool
+	}{
+		{"still running", true, false, true},
+		{"finished", false, false, false},
+		{"a later run", true, true, false},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.busy, m.lastItem = tt.busy, &item
+		m.beginCapture()
+		stops := 0
+		m.stopStream = func() { stops++ }
+		if m.startPluginTimer(item) == nil {
+			t.Fatal("no timer armed")
+		}
+		msg := pluginTimeoutMsg{run: m.capture.id, timeout: time.Minute}
+		if tt.staleRun {
+			msg.run--
+		}
+		m.handlePluginTimeout(msg)
+		if (stops == 1) != tt.interrupted || (countLogged(m, "Slow timed out after 1m0s") == 1) != tt.interrupted {
+			t.Errorf("%s: stops=%d", tt.name, stops)
+		}
+	}
+	if (&model{}).startPluginTimer(menuItems[0]) != nil {
+		t.Error("timer armed for a built-in item")
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Background polls
+//  Each feature that refreshes on its own has an interval under [poll]
+//  in config.toml, as a Go duration ("5s", "6h"); "0" turns it off.
+//  Every poll re-arms its own tick, and a poll still in flight is not
+//  started again.
+// ─────────────────────────────────────────────────────────────────
+
+type pollFeature int
+
+const (
+	pollStatus      pollFeature = iota // container state
+	pollStats                          // CPU/memory while running
+	pollUpdateCheck                    // pending package updates
+	pollNetwork                        // update server reachability
+)
+
+type pollSpec struct {
+	name string
+	def  time.Duration
+	min  time.Duration // shortest allowed, to keep the host responsive
+}
+
+var pollSpecs = map[pollFeature]pollSpec{
+	pollStatus:      {"status", 10 * time.Second, time.Second},
+	pollStats:       {"stats", 5 * time.Second, time.Second},
+	pollUpdateCheck: {"update_check", 6 * time.Hour, time.Minute},
+	pollNetwork:     {"network", time.Minute, 5 * time.Second},
+}
+
+// pollIntervals holds the validated interval per feature; 0 is off.
+var pollIntervals = mustDefaultPolls()
+
+func mustDefaultPolls() map[pollFeature]time.Duration {
+	p, err := compilePolls(nil)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// compilePolls validates the [poll] overrides. A bad value is reported
+// and the default is kept.
+func compilePolls(overrides map[string]string) (map[pollFeature]time.Duration, error) {
+	byName := map[string]pollFeature{}
+	for f, spec := range pollSpecs {
+		byName[spec.name] = f
+	}
+	var problems []string
+	for name := range overrides {
+		if _, ok := byName[name]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown poll %q", name))
+		}
+	}
+
+	out := map[pollFeature]time.Duration{}
+	for f, spec := range pollSpecs {
+		out[f] = spec.def
+		o, ok := overrides[spec.name]
+		if !ok || o == "" {
+			continue
+		}
+		if o == "0" {
+			out[f] = 0
+			continue
+		}
+		d, err := time.ParseDuration(o)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("poll %s: %v", spec.name, err))
+		case d < spec.min:
+			problems = append(problems, fmt.Sprintf("poll %s: %s is shorter than %s", spec.name, d, spec.min))
+		default:
+			out[f] = d
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return out, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return out, nil
+}
+
+type (
+	pollTickMsg struct{ feature pollFeature }
+
+	statsMsg struct {
+		cpu, mem string
+		err      error
+	}
+
+	updatesMsg struct {
+		count int
+		err   error
+	}
+)
+
+func pollTick(f pollFeature) tea.Cmd {
+	d := pollIntervals[f]
+	if d <= 0 {
+		return nil
+	}
+	return tea.Tick(d, func(time.Time) tea.Msg { return pollTickMsg{feature: f} })
+}
+
+// startPolls arms every enabled poll.
+func startPolls() tea.Cmd {
+	return tea.Batch(pollTick(pollStatus), pollTick(pollStats), pollTick(pollUpdateCheck), pollTick(pollNetwork))
+}
+
+// handlePollTick runs the feature's poll unless it would clash with a
+// running action or an earlier poll, then re-arms the tick.
+func (m *model) handlePollTick(f pollFeature) tea.Cmd {
+	next := pollTick(f)
+	if m.busy || m.polling[f] {
+		return next
+	}
+	var run tea.Cmd
+	switch f {
+	case pollStatus:
+		run = checkStatusCmd()
+	case pollStats:
+		if m.containerStatus == "running" {
+			run = statsCmd(m.engine())
+		}
+	case pollUpdateCheck:
+		// distrobox enter would start a stopped container.
+		if m.containerStatus == "running" {
+			run = updateCheckCmd()
+		}
+	case pollNetwork:
+		run = netProbeCmd()
+	}
+	if run == nil {
+		return next
+	}
+	m.polling[f] = true
+	return tea.Batch(run, next)
+}
+
+func statsCmd(engine string) tea.Cmd {
+	return func() tea.Msg {
+		out, err := auditedOutput("stats", hostCommand([]string{engine, "stats", "--no-stream", "--format",
+			"{{.CPUPerc}}\t{{.MemUsage}}", containerName}, nil, false), stdoutOf)
+		if err != nil {
+			return statsMsg{err: err}
+		}
+		cpu, mem, _ := strings.Cut(strings.TrimSpace(string(out)), "\t")
+		return statsMsg{cpu: cpu, mem: mem}
+	}
+}
+
+// updateCheckCmd counts pending updates. checkupdates (pacman-contrib)
+// refreshes a private copy of the sync database; plain pacman -Qu only
+// knows what the last sync saw.
+func updateCheckCmd() tea.Cmd {
+	return func() tea.Msg {
+		out, err := auditedOutput("update count", hostCommand([]string{"distrobox", "enter", containerName, "--",
+			"sh", "-c", "checkupdates 2>/dev/null || pacman -Qu 2>/dev/null"}, nil, false), stdoutOf)
+		n := 0
+		for _, line := range strings.Split(string(out), "\n") {
+			if strings.TrimSpace(line) != "" {
+				n++
+			}
+		}
+		// Both tools exit non-zero when nothing is pending.
+		if n > 0 {
+			err = nil
+		}
+		return updatesMsg{count: n, err: err}
+	}
+}
+
+// pollSummary renders the stats and update count for the status bar.
+func (m model) pollSummary() string {
+	var parts []string
+	if m.containerStatus == "running" && m.statsCPU != "" {
+		parts = append(parts, "CPU "+m.statsCPU+" · "+m.statsMem)
+	}
+	if m.pendingUpdates > 0 {
+		parts = append(parts, fmt.Sprintf("↑ %d updates", m.pendingUpdates))
+	}
+	return strings.Join(parts, "  ")
+}
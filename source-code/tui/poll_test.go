@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompilePolls(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		want      map[pollFeature]time.Duration
+		wantErr   string
+	}{
+		{"defaults", nil, map[pollFeature]time.Duration{
+			pollStatus: 10 * time.Second, pollStats: 5 * time.Second, pollUpdateCheck: 6 * time.Hour}, ""},
+		{"overrides", map[string]string{"status": "5s", "stats": "2s", "update_check": "12h"}, map[pollFeature]time.Duration{
+			pollStatus: 5 * time.Second, pollStats: 2 * time.Second, pollUpdateCheck: 12 * time.Hour}, ""},
+		{"disabled", map[string]string{"stats": "0", "update_check": "0"}, map[pollFeature]time.Duration{
+			pollStatus: 10 * time.Second, pollStats: 0, pollUpdateCheck: 0}, ""},
+		{"empty keeps default", map[string]string{"status": ""}, map[pollFeature]time.Duration{
+			pollStatus: 10 * time.Second, pollStats: 5 * time.Second, pollUpdateCheck: 6 * time.Hour}, ""},
+		{"too short", map[string]string{"status": "100ms"}, map[pollFeature]time.Duration{
+			pollStatus: 10 * time.Second, pollStats: 5 * time.Second, pollUpdateCheck: 6 * time.Hour}, "shorter than 1s"},
+		{"garbage", map[string]string{"stats": "often"}, map[pollFeature]time.Duration{
+			pollStatus: 10 * time.Second, pollStats: 5 * time.Second, pollUpdateCheck: 6 * time.Hour}, "poll stats"},
+		{"unknown", map[string]string{"weather": "1m"}, map[pollFeature]time.Duration{
+			pollStatus: 10 * time.Second, pollStats: 5 * time.Second, pollUpdateCheck: 6 * time.Hour}, `unknown poll "weather"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compilePolls(tt.overrides)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			for f, d := range tt.want {
+				if got[f] != d {
+					t.Errorf("%s = %v, want %v", pollSpecs[f].name, got[f], d)
+				}
+			}
+		})
+	}
+}
+
+// Each poll ticks at its own interval; a disabled one never ticks.
+func TestPollCadence(t *testing.T) {
+	defer func(saved map[pollFeature]time.Duration) { pollIntervals = saved }(pollIntervals)
+	pollIntervals = map[pollFeature]time.Duration{
+		pollStatus:      30 * time.Millisecond,
+		pollStats:       80 * time.Millisecond,
+		pollUpdateCheck: 0,
+	}
+
+	if pollTick(pollUpdateCheck) != nil {
+		t.Error("disabled poll was armed")
+	}
+	for _, f := range []pollFeature{pollStatus, pollStats} {
+		want := pollIntervals[f]
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			msg, ok := pollTick(f)().(pollTickMsg)
+			if !ok || msg.feature != f {
+				t.Fatalf("%s tick = %#v", pollSpecs[f].name, msg)
+			}
+		}
+		if got := time.Since(start); got < 3*want || got > 3*want+200*time.Millisecond {
+			t.Errorf("%s: three ticks took %v, want about %v", pollSpecs[f].name, got, 3*want)
+		}
+	}
+}
+
+func TestHandlePollTick(t *testing.T) {
+	defer func(saved map[pollFeature]time.Duration) { pollIntervals = saved }(pollIntervals)
+	tests := []struct {
+		name     string
+		feature  pollFeature
+		status   string
+		busy     bool
+		inFlight bool
+		off      bool
+		runs     bool
+		rearmed  bool
+	}{
+		{"status", pollStatus, "stopped", false, false, false, true, true},
+		{"busy skips", pollStatus, "running", true, false, false, false, true},
+		{"in flight skips", pollStatus, "running", false, true, false, false, true},
+		{"stats while running", pollStats, "running", false, false, false, true, true},
+		{"stats while stopped", pollStats, "stopped", false, false, false, false, true},
+		{"update check while stopped", pollUpdateCheck, "stopped", false, false, false, false, true},
+		{"update check", pollUpdateCheck, "running", false, false, false, true, true},
+		{"turned off", pollStats, "running", false, false, true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pollIntervals = mustDefaultPolls()
+			if tt.off {
+				pollIntervals[tt.feature] = 0
+			}
+			m := initialModel()
+			m.containerStatus, m.busy = tt.status, tt.busy
+			m.polling[tt.feature] = tt.inFlight
+			cmd := m.handlePollTick(tt.feature)
+			if started := m.polling[tt.feature] && !tt.inFlight; started != tt.runs {
+				t.Errorf("poll started = %v, want %v", started, tt.runs)
+			}
+			if (cmd != nil) != (tt.runs || tt.rearmed) {
+				t.Errorf("cmd = %v", cmd)
+			}
+		})
+	}
+}
+
+func TestPollResults(t *testing.T) {
+	m := initialModel()
+	m.containerStatus = "running"
+	m.polling[pollStats], m.polling[pollUpdateCheck] = true, true
+
+	next, _ := m.Update(statsMsg{cpu: "12.5%", mem: "1.2GiB / 8GiB"})
+	m = next.(model)
+	next, _ = m.Update(updatesMsg{count: 3})
+	m = next.(model)
+	if m.polling[pollStats] || m.polling[pollUpdateCheck] {
+		t.Error("finished polls still marked in flight")
+	}
+	if got := m.pollSummary(); got != "CPU 12.5% · 1.2GiB / 8GiB  ↑ 3 updates" {
+		t.Errorf("pollSummary = %q", got)
+	}
+
+	next, _ = m.Update(statsMsg{err: errors.New("podman stats failed")})
+	m = next.(model)
+	if m.statsCPU != "12.5%" {
+		t.Error("failed poll cleared the last stats")
+	}
+	m.containerStatus = "stopped"
+	if got := m.pollSummary(); got != "↑ 3 updates" {
+		t.Errorf("stopped pollSummary = %q", got)
+	}
+}
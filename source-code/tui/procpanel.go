@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Process panel
+//  With --verbose the log panel lists the processes the TUI spawned
+//  for its actions: PID, command, when it started and when it exited
+//  with what status. A row that keeps saying "running" after its
+//  action is over points at a hung or orphaned child. Start and exit
+//  arrive as messages on the action's stream, verbose or not; the exit
+//  also tells a crash from a quit (crash.go). Probes, polls and
+//  commands handed the terminal run outside any stream; with --verbose
+//  they report on procEvents instead, and are listed the same way.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	procKept      = 20 // processes remembered
+	procRows      = 3  // shown in the panel
+	procMinHeight = 12 // log height below which the panel is left out
+)
+
+// verboseMode is --verbose.
+var verboseMode bool
+
+type (
+	procStartedMsg struct {
+		pid  int
+		argv []string
+		at   time.Time
+	}
+
+	procExitedMsg struct {
+		pid    int
+		code   int            // exit code, -1 when killed by a signal
+		signal syscall.Signal // 0 unless killed by a signal
+		at     time.Time
+	}
+
+	// sideProcMsg is a start or an exit of a command run outside an
+	// action's stream. It only feeds the panel: lastExit stays the
+	// action's.
+	sideProcMsg struct {
+		started *procStartedMsg
+		exited  *procExitedMsg
+	}
+)
+
+// procEvents carries sideProcMsg to the TUI. Reports are dropped when
+// it's full rather than hold up a probe.
+var procEvents = make(chan sideProcMsg, 64)
+
+// reportProc queues msg for the panel in verbose mode.
+func reportProc(msg sideProcMsg) {
+	if !verboseMode {
+		return
+	}
+	select {
+	case procEvents <- msg:
+	default:
+	}
+}
+
+// waitProcEvents delivers the next report; Update re-arms it.
+func waitProcEvents() tea.Cmd {
+	return func() tea.Msg { return <-procEvents }
+}
+
+// runTracked is c.Run with the start and exit reported.
+func runTracked(c *exec.Cmd) error {
+	if err := c.Start(); err != nil {
+		return err
+	}
+	reportProc(sideProcMsg{started: &procStartedMsg{pid: c.Process.Pid, argv: c.Args, at: time.Now()}})
+	err := c.Wait()
+	exited := exitedMsg(c)
+	reportProc(sideProcMsg{exited: &exited})
+	return err
+}
+
+// procEntry is one spawned process.
+type procEntry struct {
+	pid     int
+	command string
+	started time.Time
+	exited  time.Time // zero while running
+	status  string
+}
+
+// procLog records process lifecycle events, newest last.
+type procLog struct {
+	entries []procEntry
+}
+
+func (l *procLog) started(msg procStartedMsg) {
+	l.entries = append(l.entries, procEntry{pid: msg.pid, command: shellJoin(msg.argv), started: msg.at})
+	if len(l.entries) > procKept {
+		l.entries = l.entries[len(l.entries)-procKept:]
+	}
+}
+
+// exited completes the running entry for msg.pid; an exit whose start
+// was never seen gets an entry of its own.
+func (l *procLog) exited(msg procExitedMsg) {
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		if e := &l.entries[i]; e.pid == msg.pid && e.exited.IsZero() {
+			e.exited, e.status = msg.at, msg.status()
+			return
+		}
+	}
+	l.entries = append(l.entries, procEntry{pid: msg.pid, command: "?", exited: msg.at, status: msg.status()})
+}
+
+// running is how many recorded processes haven't exited.
+func (l procLog) running() int {
+	n := 0
+	for _, e := range l.entries {
+		if e.exited.IsZero() {
+			n++
+		}
+	}
+	return n
+}
+
+// describe is the panel row of e at now, without the command.
+func (e procEntry) describe(now time.Time) string {
+	const clock = "15:04:05"
+	switch {
+	case e.exited.IsZero():
+		return fmt.Sprintf("%7d  %s  running %s", e.pid, e.started.Format(clock), now.Sub(e.started).Round(time.Second))
+	case e.started.IsZero():
+		return fmt.Sprintf("%7d  ?–%s  %s", e.pid, e.exited.Format(clock), e.status)
+	}
+	return fmt.Sprintf("%7d  %s–%s  %s", e.pid, e.started.Format(clock), e.exited.Format(clock), e.status)
+}
+
+// exitedMsg is the exit of cmd, which has been waited for.
+func exitedMsg(cmd *exec.Cmd) procExitedMsg {
+	msg := procExitedMsg{pid: cmd.Process.Pid, code: -1, at: time.Now()}
+	if ps := cmd.ProcessState; ps != nil {
+		msg.code = ps.ExitCode()
+		if ws, ok := ps.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			msg.signal = ws.Signal()
+		}
+	}
+	return msg
+}
+
+// status is "exit 0", "signal segmentation fault" and the like.
+func (msg procExitedMsg) status() string {
+	if msg.signal != 0 {
+		return "signal " + msg.signal.String()
+	}
+	return fmt.Sprintf("exit %d", msg.code)
+}
+
+// renderProcs draws the process panel w cells wide, or nothing outside
+// verbose mode or before anything ran.
+func (m model) renderProcs(w int) string {
+	if !verboseMode || len(m.procs.entries) == 0 {
+		return ""
+	}
+	style := lipgloss.NewStyle().Background(lipgloss.Color("#0d0f14")).Width(w).Padding(0, 1)
+	head := fmt.Sprintf("⚙ %d process", len(m.procs.entries))
+	if len(m.procs.entries) != 1 {
+		head += "es"
+	}
+	if n := m.procs.running(); n > 0 {
+		head += fmt.Sprintf(", %d running", n)
+	}
+	rows := []string{style.Foreground(colSub).Bold(true).Render(head)}
+	now := time.Now()
+	for _, e := range m.procs.entries[max(len(m.procs.entries)-procRows, 0):] {
+		text := e.describe(now) + "  " + e.command
+		fg := colDim
+		if e.exited.IsZero() {
+			fg = colText
+		} else if !strings.HasSuffix(e.status, " 0") {
+			fg = colYellow
+		}
+		rows = append(rows, style.Foreground(fg).Render(truncate(text, max(w-2, 2))))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestProcLog(t *testing.T) {
+	at := time.Date(2026, 10, 14, 15, 30, 0, 0, time.Local)
+	start := func(pid int) procStartedMsg {
+		return procStartedMsg{pid: pid, argv: []string{cli, "run"}, at: at}
+	}
+	exit := func(pid, code int, sig syscall.Signal) procExitedMsg {
+		return procExitedMsg{pid: pid, code: code, signal: sig, at: at.Add(time.Minute)}
+	}
+	tests := []struct {
+		name    string
+		events  []any
+		want    string // pid:status of each entry, "" status while running
+		running int
+	}{
+		{"one run", []any{start(10), exit(10, 0, 0)}, "10:exit 0", 0},
+		{"still running", []any{start(10)}, "10:", 1},
+		{"two at once", []any{start(10), start(11), exit(11, 1, 0)}, "10: 11:exit 1", 1},
+		{"pid reused", []any{start(10), exit(10, 0, 0), start(10), exit(10, -1, syscall.SIGKILL)}, "10:exit 0 10:signal killed", 0},
+		{"exit without a start", []any{exit(12, 2, 0)}, "12:exit 2", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var l procLog
+			for _, ev := range tt.events {
+				switch ev := ev.(type) {
+				case procStartedMsg:
+					l.started(ev)
+				case procExitedMsg:
+					l.exited(ev)
+				}
+			}
+			var got []string
+			for _, e := range l.entries {
+				got = append(got, fmt.Sprintf("%d:%s", e.pid, e.status))
+			}
+			if strings.Join(got, " ") != tt.want || l.running() != tt.running {
+				t.Errorf("entries %q running %d, want %q running %d", got, l.running(), tt.want, tt.running)
+			}
+		})
+	}
+}
+
+func TestProcLogKeepsTheLatest(t *testing.T) {
+	var l procLog
+	for pid := 1; pid <= procKept+5; pid++ {
+		l.started(procStartedMsg{pid: pid, argv: []string{"true"}})
+	}
+	if len(l.entries) != procKept || l.entries[0].pid != 6 {
+		t.Errorf("kept %d entries from pid %d, want %d from 6", len(l.entries), l.entries[0].pid, procKept)
+	}
+}
+
+func TestProcEntryDescribe(t *testing.T) {
+	at := time.Date(2026, 10, 14, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		e    procEntry
+		want string
+	}{
+		{procEntry{pid: 4242, started: at}, "   4242  15:30:00  running 1m30s"},
+		{procEntry{pid: 4242, started: at, exited: at.Add(time.Minute), status: "exit 0"}, "   4242  15:30:00–15:31:00  exit 0"},
+		{procEntry{pid: 7, exited: at, status: "signal interrupt"}, "      7  ?–15:30:00  signal interrupt"},
+	}
+	for _, tt := range tests {
+		if got := tt.e.describe(at.Add(90 * time.Second)); got != tt.want {
+			t.Errorf("describe = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestExitedMsg(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	tests := []struct {
+		script string
+		code   int
+		signal syscall.Signal
+		want   string
+	}{
+		{"exit 0", 0, 0, "exit 0"},
+		{"exit 3", 3, 0, "exit 3"},
+		{"kill -TERM $$", -1, syscall.SIGTERM, "signal terminated"},
+	}
+	for _, tt := range tests {
+		cmd := exec.Command(sh, "-c", tt.script)
+		cmd.Run()
+		msg := exitedMsg(cmd)
+		if msg.code != tt.code || msg.signal != tt.signal || msg.status() != tt.want {
+			t.Errorf("%q: exit %d signal %v %q, want %d %v %q", tt.script, msg.code, msg.signal, msg.status(), tt.code, tt.signal, tt.want)
+		}
+	}
+}
+
+func TestStreamReportsProcesses(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	defer func(saved bool) { verboseMode = saved }(verboseMode)
+	// Sent without --verbose too: the exit tells a crash from a quit.
+	for _, verbose := range []bool{false, true} {
+		verboseMode = verbose
+		ch := make(chan tea.Msg, 64)
+		go streamCommand(context.Background(), []string{sh, "-c", "exit 2"}, nil, ch, nil)
+		var started *procStartedMsg
+		var exited *procExitedMsg
+		for msg := range ch {
+			switch msg := msg.(type) {
+			case procStartedMsg:
+				started = &msg
+			case procExitedMsg:
+				exited = &msg
+			}
+		}
+		if started == nil || exited == nil || started.pid <= 0 || exited.pid != started.pid {
+			t.Fatalf("events started=%+v exited=%+v", started, exited)
+		}
+		if exited.status() != "exit 2" || started.argv[0] != sh {
+			t.Errorf("verbose=%v: exit %q for %q", verbose, exited.status(), started.argv)
+		}
+	}
+}
+
+func TestProbesReportProcesses(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	defer func(saved bool) { verboseMode = saved }(verboseMode)
+	drain := func() (reports []sideProcMsg) {
+		for {
+			select {
+			case msg := <-procEvents:
+				reports = append(reports, msg)
+			default:
+				return reports
+			}
+		}
+	}
+	drain()
+
+	verboseMode = false
+	auditedRun("probe", exec.Command("sh", "-c", "exit 0"))
+	if reports := drain(); len(reports) != 0 {
+		t.Errorf("reported without --verbose: %+v", reports)
+	}
+
+	verboseMode = true
+	auditedRun("probe", exec.Command("sh", "-c", "exit 4"))
+	reports := drain()
+	if len(reports) != 2 || reports[0].started == nil || reports[1].exited == nil {
+		t.Fatalf("reports = %+v", reports)
+	}
+	m := initialModel()
+	for _, msg := range reports {
+		updated, _ := m.Update(msg)
+		m = updated.(model)
+	}
+	if len(m.procs.entries) != 1 || m.procs.entries[0].status != "exit 4" || m.lastExit != nil {
+		t.Errorf("entries %+v, lastExit %v", m.procs.entries, m.lastExit)
+	}
+}
+
+func TestRenderProcs(t *testing.T) {
+	defer func(saved bool) { verboseMode = saved }(verboseMode)
+	m := initialModel()
+	m.procs.started(procStartedMsg{pid: 10, argv: []string{cli, "update"}, at: time.Now()})
+	m.procs.started(procStartedMsg{pid: 11, argv: []string{cli, "status"}, at: time.Now()})
+	m.procs.exited(procExitedMsg{pid: 11, code: 1, at: time.Now()})
+
+	verboseMode = false
+	if out := m.renderProcs(80); out != "" {
+		t.Errorf("panel drawn without --verbose:\n%s", out)
+	}
+	verboseMode = true
+	out := stripANSI(m.renderProcs(80))
+	if !strings.Contains(out, "⚙ 2 processes, 1 running") || !strings.Contains(out, cli+" update") || !strings.Contains(out, "exit 1") {
+		t.Errorf("panel:\n%s", out)
+	}
+
+	// The panel takes its rows from the log, and fits above it.
+	m.width, m.height = 120, 40
+	next, _ := m.Update(procExitedMsg{pid: 10, at: time.Now()})
+	m = next.(model)
+	if m.procs.running() != 0 {
+		t.Error("exit message not recorded")
+	}
+	if !strings.Contains(stripANSI(m.renderLogPanel()), "⚙ 2 processes") {
+		t.Error("log panel lacks the process panel")
+	}
+}
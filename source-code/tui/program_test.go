@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestProgramOptions(t *testing.T) {
+	tests := []struct {
+		noAltScreen bool
+		altScreen   bool
+		options     int
+	}{
+		{false, true, 3},
+		{true, false, 2},
+	}
+	for _, tt := range tests {
+		opts, altScreen := programOptions(tt.noAltScreen)
+		if altScreen != tt.altScreen || len(opts) != tt.options {
+			t.Errorf("programOptions(%v) = %d options, alt screen %v; want %d, %v",
+				tt.noAltScreen, len(opts), altScreen, tt.options, tt.altScreen)
+		}
+	}
+}
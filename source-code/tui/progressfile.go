@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"os"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Progress file (--progress-file path)
+//  Some setups report progress to a file instead of stdout. The file is
+//  polled on the progress tick and its new lines go through the same
+//  parsers as command output. Truncation restarts from the top; a file
+//  replaced under the same name (rotation) is reopened.
+// ─────────────────────────────────────────────────────────────────
+
+// maxTailRead bounds how much is read per poll; the rest waits for the
+// next tick.
+const maxTailRead = 1 << 20
+
+var progressFile string // empty = progress comes from stdout only
+
+type fileTailer struct {
+	path    string
+	f       *os.File
+	info    os.FileInfo
+	off     int64
+	partial []byte
+}
+
+// newFileTailer starts tailing path from its current end, so progress
+// left over from an earlier run is ignored. A file that doesn't exist
+// yet is read from the start once it appears.
+func newFileTailer(path string) *fileTailer {
+	t := &fileTailer{path: path}
+	if t.reopen() {
+		t.off = t.info.Size()
+	}
+	return t
+}
+
+func (t *fileTailer) reopen() bool {
+	t.close()
+	f, err := os.Open(t.path)
+	if err != nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return false
+	}
+	t.f, t.info, t.off, t.partial = f, info, 0, nil
+	return true
+}
+
+func (t *fileTailer) close() {
+	if t.f != nil {
+		t.f.Close()
+		t.f = nil
+	}
+}
+
+// poll returns the complete lines written since the last call. A line
+// is ended by \n or \r, since progress bars redraw with \r.
+func (t *fileTailer) poll() []string {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return nil // gone for now; picked up again when it reappears
+	}
+	if t.f == nil || !os.SameFile(info, t.info) {
+		if !t.reopen() {
+			return nil
+		}
+	}
+	size := info.Size()
+	if size < t.off {
+		t.off, t.partial = 0, nil // truncated
+	}
+	if size == t.off {
+		return nil
+	}
+
+	buf := make([]byte, min(size-t.off, maxTailRead))
+	n, _ := t.f.ReadAt(buf, t.off)
+	t.off += int64(n)
+	data := append(t.partial, buf[:n]...)
+
+	var lines []string
+	for {
+		i := bytes.IndexAny(data, "\r\n")
+		if i < 0 {
+			break
+		}
+		if i > 0 {
+			lines = append(lines, stripANSI(string(data[:i])))
+		}
+		data = data[i+1:]
+	}
+	t.partial = append([]byte(nil), data...)
+	return lines
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileTailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.log")
+	write := func(flag int, s string) {
+		t.Helper()
+		f, err := os.OpenFile(path, flag|os.O_WRONLY|os.O_CREATE, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.WriteString(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	appendTo := func(s string) { write(os.O_APPEND, s) }
+
+	write(os.O_TRUNC, "Progress: 99% (from an earlier run)\n")
+	tail := newFileTailer(path)
+	defer tail.close()
+
+	steps := []struct {
+		name  string
+		do    func()
+		lines []string
+	}{
+		{"old content skipped", func() {}, nil},
+		{"appended lines", func() { appendTo("Progress: 10%\nProgress: 20%\n") }, []string{"Progress: 10%", "Progress: 20%"}},
+		{"partial line held back", func() { appendTo("Progress: 3") }, nil},
+		{"partial line completed", func() { appendTo("0%\n") }, []string{"Progress: 30%"}},
+		{"carriage returns", func() { appendTo("40%\r50%\r\n") }, []string{"40%", "50%"}},
+		{"ANSI stripped", func() { appendTo("\x1b[32m60%\x1b[0m\n") }, []string{"60%"}},
+		{"nothing new", func() {}, nil},
+		{"truncated", func() { write(os.O_TRUNC, "5%\n") }, []string{"5%"}},
+		{"rotated", func() {
+			if err := os.Rename(path, path+".1"); err != nil {
+				t.Fatal(err)
+			}
+		}, nil},
+		{"recreated", func() { write(os.O_TRUNC, "1%\n") }, []string{"1%"}},
+	}
+	for _, s := range steps {
+		s.do()
+		got := tail.poll()
+		if strings.Join(got, "|") != strings.Join(s.lines, "|") {
+			t.Errorf("%s: poll() = %q, want %q", s.name, got, s.lines)
+		}
+	}
+}
+
+func TestFileTailerMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "later.log")
+	tail := newFileTailer(path)
+	defer tail.close()
+	if got := tail.poll(); got != nil {
+		t.Fatalf("poll() on a missing file = %q", got)
+	}
+	if err := os.WriteFile(path, []byte("Progress: 7%\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := tail.poll(); len(got) != 1 || got[0] != "Progress: 7%" {
+		t.Errorf("file created later: poll() = %q", got)
+	}
+}
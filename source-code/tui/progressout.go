@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"time"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Progress mirror (--progress-out path|-)
+//  For a GUI that wraps the TUI: the progress, speed and status the
+//  TUI parses are also written as JSON lines, to a named pipe, a file
+//  or, with "-", stderr. One object per line, with an "event" field:
+//
+//    {"event":"start","action":"Update Container","time":"…"}
+//    {"event":"progress","percent":0.42,"files":3,"total_files":10,…}
+//    {"event":"speed","bps":1048576,…}
+//    {"event":"status","text":"Container running",…}
+//    {"event":"done","action":"Update Container","ok":true,…}
+//
+//  A pipe is written without blocking: with no reader, or a reader
+//  that has fallen behind, events are dropped rather than stalling the
+//  TUI, and the pipe is reopened once a reader comes back.
+// ─────────────────────────────────────────────────────────────────
+
+var progressOut string // empty = no mirror
+
+// progressEvent is one JSON line. Fields that don't apply to an event
+// are left out.
+type progressEvent struct {
+	Event      string   `json:"event"`
+	Action     string   `json:"action,omitempty"`
+	Percent    *float64 `json:"percent,omitempty"`
+	Files      int      `json:"files,omitempty"`
+	TotalFiles int      `json:"total_files,omitempty"`
+	BPS        *float64 `json:"bps,omitempty"`
+	Text       string   `json:"text,omitempty"`
+	OK         *bool    `json:"ok,omitempty"`
+	Time       string   `json:"time"`
+}
+
+type progressMirror struct {
+	path string // "" when w is fixed (stderr)
+	w    io.Writer
+	f    *os.File
+	fifo bool
+}
+
+var progressSink *progressMirror // nil = off
+
+// openProgressMirror checks target at startup. A path that doesn't
+// exist is created as a plain file; a pipe is only opened when there
+// is something to write.
+func openProgressMirror(target string) (*progressMirror, error) {
+	if target == "-" {
+		return &progressMirror{w: os.Stderr}, nil
+	}
+	info, err := os.Stat(target)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		return &progressMirror{path: target, w: f, f: f}, nil
+	case err != nil:
+		return nil, err
+	case info.Mode()&os.ModeNamedPipe != 0:
+		return &progressMirror{path: target, fifo: true}, nil
+	case info.Mode().IsRegular():
+		f, err := os.OpenFile(target, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &progressMirror{path: target, w: f, f: f}, nil
+	}
+	return nil, fmt.Errorf("%s is neither a file nor a named pipe", target)
+}
+
+// writer returns where to write, opening the pipe if a reader has it
+// open; nil means the event is dropped.
+func (p *progressMirror) writer() io.Writer {
+	if p.w != nil || !p.fifo {
+		return p.w
+	}
+	// O_NONBLOCK makes the open fail with ENXIO while nobody reads,
+	// instead of waiting for a reader.
+	f, err := os.OpenFile(p.path, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil
+	}
+	p.f, p.w = f, f
+	return f
+}
+
+func (p *progressMirror) emit(ev progressEvent) {
+	if p == nil {
+		return
+	}
+	w := p.writer()
+	if w == nil {
+		return
+	}
+	ev.Time = time.Now().Format(time.RFC3339)
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	if _, err := w.Write(append(line, '\n')); err != nil && p.fifo {
+		// The reader went away (EPIPE) or is full (EAGAIN); on EAGAIN
+		// the line may be half written, so start over on a fresh open.
+		p.close()
+	}
+}
+
+func (p *progressMirror) close() {
+	if p == nil || p.f == nil {
+		return
+	}
+	p.f.Close()
+	p.f = nil
+	if p.path != "" {
+		p.w = nil
+	}
+}
+
+func mirrorStart(label string) {
+	progressSink.emit(progressEvent{Event: "start", Action: label})
+}
+
+func mirrorProgress(msg progressMsg) {
+	progressSink.emit(progressEvent{Event: "progress", Percent: &msg.percent, Files: msg.files, TotalFiles: msg.totalFiles})
+}
+
+func mirrorSpeed(msg speedMsg) {
+	progressSink.emit(progressEvent{Event: "speed", BPS: &msg.bps})
+}
+
+func mirrorStatus(text string) {
+	progressSink.emit(progressEvent{Event: "status", Text: text})
+}
+
+func mirrorDone(label string, ok bool) {
+	progressSink.emit(progressEvent{Event: "done", Action: label, OK: &ok})
+}
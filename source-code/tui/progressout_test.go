@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// readEvents decodes the JSON lines written to path.
+func readEvents(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var ev map[string]any
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("line %q: %v", line, err)
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+func TestProgressMirrorEvents(t *testing.T) {
+	defer func(saved *progressMirror) { progressSink = saved }(progressSink)
+	path := filepath.Join(t.TempDir(), "progress.jsonl")
+	var err error
+	if progressSink, err = openProgressMirror(path); err != nil {
+		t.Fatal(err)
+	}
+	defer progressSink.close()
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.containerStatus = "running"
+	m = confirmUpdate(t, m)
+	for _, msg := range []any{
+		progressMsg{percent: 0.42, files: 3, totalFiles: 10},
+		speedMsg{bps: 1 << 20},
+		cmdDoneMsg(true),
+	} {
+		next, _ := m.Update(msg)
+		m = next.(model)
+	}
+
+	want := []map[string]any{
+		{"event": "start", "action": "Update Container"},
+		{"event": "status", "text": "Update Container: started"},
+		{"event": "progress", "percent": 0.42, "files": 3.0, "total_files": 10.0},
+		{"event": "speed", "bps": float64(1 << 20)},
+		{"event": "done", "action": "Update Container", "ok": true},
+		{"event": "status", "text": "Update Container: done"},
+	}
+	got := readEvents(t, path)
+	if len(got) != len(want) {
+		t.Fatalf("%d events, want %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if _, err := time.Parse(time.RFC3339, got[i]["time"].(string)); err != nil {
+			t.Errorf("event %d time: %v", i, err)
+		}
+		delete(got[i], "time")
+		if len(got[i]) != len(w) {
+			t.Errorf("event %d = %v, want %v", i, got[i], w)
+			continue
+		}
+		for k, v := range w {
+			if got[i][k] != v {
+				t.Errorf("event %d %s = %v, want %v", i, k, got[i][k], v)
+			}
+		}
+	}
+}
+
+func TestProgressMirrorZeroValues(t *testing.T) {
+	defer func(saved *progressMirror) { progressSink = saved }(progressSink)
+	path := filepath.Join(t.TempDir(), "progress.jsonl")
+	progressSink, _ = openProgressMirror(path)
+	defer progressSink.close()
+	mirrorProgress(progressMsg{})
+	mirrorDone("Stop Container", false)
+
+	got := readEvents(t, path)
+	if len(got) != 2 || got[0]["percent"] != 0.0 || got[1]["ok"] != false {
+		t.Errorf("0%% and failure must still be sent: %v", got)
+	}
+}
+
+func TestOpenProgressMirror(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.jsonl")
+	if err := os.WriteFile(existing, []byte("{\"event\":\"old\"}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		target string
+		fails  bool
+	}{
+		{"stderr", "-", false},
+		{"new file", filepath.Join(dir, "new.jsonl"), false},
+		{"existing file", existing, false},
+		{"directory", dir, true},
+		{"missing parent", filepath.Join(dir, "no", "such.jsonl"), true},
+	}
+	for _, tt := range tests {
+		p, err := openProgressMirror(tt.target)
+		if (err != nil) != tt.fails {
+			t.Errorf("%s: openProgressMirror error %v, want failure %v", tt.name, err, tt.fails)
+			continue
+		}
+		if err == nil && p.writer() == nil {
+			t.Errorf("%s: no writer", tt.name)
+		}
+		if tt.target != "-" {
+			p.close()
+		}
+	}
+	if got := readEvents(t, existing); len(got) != 1 {
+		t.Errorf("opening an existing file truncated it: %v", got)
+	}
+}
+
+func TestProgressMirrorPipe(t *testing.T) {
+	fifo := filepath.Join(t.TempDir(), "progress.fifo")
+	if err := syscall.Mkfifo(fifo, 0o600); err != nil {
+		t.Skip("no named pipes:", err)
+	}
+	p, err := openProgressMirror(fifo)
+	if err != nil || !p.fifo {
+		t.Fatalf("openProgressMirror(fifo) = %+v, %v", p, err)
+	}
+	defer p.close()
+
+	// No reader: the event is dropped without blocking.
+	done := make(chan struct{})
+	go func() {
+		p.emit(progressEvent{Event: "status", Text: "nobody listens"})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("emit blocked without a reader")
+	}
+
+	r, err := os.OpenFile(fifo, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.emit(progressEvent{Event: "status", Text: "heard"})
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil || !strings.Contains(line, `"text":"heard"`) {
+		t.Errorf("reader got %q, %v", line, err)
+	}
+
+	// The reader goes away: the write fails and the pipe is reopened
+	// for the next one.
+	r.Close()
+	p.emit(progressEvent{Event: "status", Text: "gone"})
+	if p.f != nil {
+		t.Error("pipe kept open after its reader left")
+	}
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Global Proton
+//  "Toggle GE-Proton" flips Steam's default compatibility tool between
+//  the newest Valve Proton and the newest GE-Proton installed in
+//  compatibilitytools.d. The default is entry "0" of CompatToolMapping
+//  in config/config.vdf; Steam rewrites that file when it exits, so
+//  the switch is refused while Steam is running.
+// ─────────────────────────────────────────────────────────────────
+
+// compatToolKey is the config.vdf block holding the tool mapping.
+const compatToolKey = "installconfigstore/software/valve/steam"
+
+var (
+	// reGEProton matches current names (GE-Proton9-20) and the old
+	// scheme (Proton-6.21-GE-2).
+	reGEProton    = regexp.MustCompile(`^(?:GE-Proton(\d+)-(\d+)|Proton-(\d+)\.(\d+)-GE-(\d+))$`)
+	reValveProton = regexp.MustCompile(`^Proton (\d+)\.(\d+)(?: \(Beta\))?$`)
+)
+
+// compatTool is an installed tool: Steam's internal name and the
+// version it is ordered by.
+type compatTool struct {
+	name    string
+	version []int
+}
+
+func steamConfigPath() string {
+	return filepath.Join(steamDir(), "config", "config.vdf")
+}
+
+func compatToolsDir() string {
+	return filepath.Join(steamDir(), "compatibilitytools.d")
+}
+
+func newestFirst(tools []compatTool) {
+	sort.SliceStable(tools, func(i, j int) bool {
+		a, b := tools[i].version, tools[j].version
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] > b[k]
+			}
+		}
+		return len(a) > len(b)
+	})
+}
+
+func atois(parts []string) []int {
+	var out []int
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(p)
+		out = append(out, n)
+	}
+	return out
+}
+
+// geProtonTools lists the GE-Proton builds in dir, newest first. A
+// build is a directory with a compatibilitytool.vdf; GE names it after
+// the directory.
+func geProtonTools(dir string) []compatTool {
+	entries, _ := os.ReadDir(dir)
+	var tools []compatTool
+	for _, e := range entries {
+		m := reGEProton.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, e.Name(), "compatibilitytool.vdf")); err != nil {
+			continue
+		}
+		tools = append(tools, compatTool{name: e.Name(), version: atois(m[1:])})
+	}
+	newestFirst(tools)
+	return tools
+}
+
+// valveProtonName is Steam's name for "Proton X.Y": proton_9 for 9.0,
+// proton_63 for 6.3.
+func valveProtonName(major, minor int) string {
+	if minor == 0 {
+		return fmt.Sprintf("proton_%d", major)
+	}
+	return fmt.Sprintf("proton_%d%d", major, minor)
+}
+
+// valveProtonTools lists the Valve Proton releases installed in the
+// given steamapps/common directories, newest first. Experimental is
+// left out: it isn't a release to settle on.
+func valveProtonTools(commonDirs []string) []compatTool {
+	seen := map[string]bool{}
+	var tools []compatTool
+	for _, dir := range commonDirs {
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			m := reValveProton.FindStringSubmatch(e.Name())
+			if m == nil || !e.IsDir() {
+				continue
+			}
+			v := atois(m[1:])
+			name := valveProtonName(v[0], v[1])
+			if !seen[name] {
+				seen[name] = true
+				tools = append(tools, compatTool{name: name, version: v})
+			}
+		}
+	}
+	newestFirst(tools)
+	return tools
+}
+
+func isGEProton(name string) bool {
+	return reGEProton.MatchString(name)
+}
+
+// protonToggleTarget picks the tool to switch to from current.
+func protonToggleTarget(current string, ge, valve []compatTool) (string, error) {
+	if isGEProton(current) {
+		if len(valve) == 0 {
+			return "", errors.New("no Valve Proton is installed; install one from Steam's Library → Tools")
+		}
+		return valve[0].name, nil
+	}
+	if len(ge) == 0 {
+		return "", fmt.Errorf("no GE-Proton found in %s", tildePath(compatToolsDir()))
+	}
+	return ge[0].name, nil
+}
+
+// vdfIndex maps the lowercased path of every block ("a/b/c") to the
+// lines of its opening and closing brace; the first block wins.
+func vdfIndex(lines []string) map[string][2]int {
+	idx := map[string][2]int{}
+	var stack []string
+	var opens []int
+	pending := ""
+	for i, raw := range lines {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "{":
+			stack = append(stack, strings.ToLower(pending))
+			opens = append(opens, i)
+			pending = ""
+		case line == "}":
+			if len(stack) == 0 {
+				continue
+			}
+			path := strings.Join(stack, "/")
+			if _, seen := idx[path]; !seen {
+				idx[path] = [2]int{opens[len(opens)-1], i}
+			}
+			stack, opens = stack[:len(stack)-1], opens[:len(opens)-1]
+		case strings.Count(line, `"`) == 2:
+			pending = strings.Trim(line, `"`)
+		}
+	}
+	return idx
+}
+
+func indentOf(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// globalCompatTool returns the default tool named in config.vdf, ""
+// when none is set (Steam then uses its own default).
+func globalCompatTool(content string) string {
+	lines := strings.Split(content, "\n")
+	b, ok := vdfIndex(lines)[compatToolKey+"/compattoolmapping/0"]
+	if !ok {
+		return ""
+	}
+	for _, line := range lines[b[0]+1 : b[1]] {
+		if k, v, ok := vdfPair(strings.TrimSpace(line)); ok && strings.EqualFold(k, "name") {
+			return v
+		}
+	}
+	return ""
+}
+
+// withGlobalCompatTool returns config.vdf content with the default tool
+// set to name, adding the mapping where it is missing.
+func withGlobalCompatTool(content, name string) (string, error) {
+	lines := strings.Split(content, "\n")
+	idx := vdfIndex(lines)
+	insert := func(at int, block ...string) string {
+		out := append(append(append([]string(nil), lines[:at]...), block...), lines[at:]...)
+		return strings.Join(out, "\n")
+	}
+	entry := func(ind string) []string {
+		return []string{
+			ind + `"0"`, ind + "{",
+			ind + "\t" + `"name"		"` + name + `"`,
+			ind + "\t" + `"config"		""`,
+			ind + "\t" + `"priority"		"75"`,
+			ind + "}",
+		}
+	}
+
+	if b, ok := idx[compatToolKey+"/compattoolmapping/0"]; ok {
+		ind := indentOf(lines[b[0]]) + "\t"
+		for i := b[0] + 1; i < b[1]; i++ {
+			if k, _, ok := vdfPair(strings.TrimSpace(lines[i])); ok && strings.EqualFold(k, "name") {
+				lines[i] = indentOf(lines[i]) + `"name"		"` + name + `"`
+				return strings.Join(lines, "\n"), nil
+			}
+		}
+		return insert(b[0]+1, ind+`"name"		"`+name+`"`), nil
+	}
+	if b, ok := idx[compatToolKey+"/compattoolmapping"]; ok {
+		return insert(b[0]+1, entry(indentOf(lines[b[0]])+"\t")...), nil
+	}
+	if b, ok := idx[compatToolKey]; ok {
+		ind := indentOf(lines[b[0]]) + "\t"
+		block := append([]string{ind + `"CompatToolMapping"`, ind + "{"}, entry(ind+"\t")...)
+		return insert(b[0]+1, append(block, ind+"}")...), nil
+	}
+	return "", errors.New("config.vdf has no Steam settings yet; start Steam once first")
+}
+
+// steamClientRunning reports whether a Steam client process is up;
+// the container's processes show in the host's /proc.
+func steamClientRunning(proc string) bool {
+	dirs, _ := filepath.Glob(filepath.Join(proc, "[0-9]*", "cmdline"))
+	for _, path := range dirs {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		argv0, _, _ := bytes.Cut(b, []byte{0})
+		if bytes.HasSuffix(argv0, []byte("ubuntu12_32/steam")) {
+			return true
+		}
+	}
+	return false
+}
+
+// libraryCommonDirs are steamapps/common of every library folder.
+func libraryCommonDirs() []string {
+	folders, err := loadLibraryFolders()
+	if err != nil {
+		folders = []libraryFolder{{path: steamDir()}}
+	}
+	dirs := make([]string, len(folders))
+	for i, f := range folders {
+		dirs[i] = filepath.Join(f.path, "steamapps", "common")
+	}
+	return dirs
+}
+
+// toggleProton is the "Toggle GE-Proton" action.
+func (m *model) toggleProton() tea.Cmd {
+	if remoteHost != "" {
+		return m.showToast("Steam's settings can't be changed over --remote")
+	}
+	if steamClientRunning("/proc") {
+		return m.showToast("Quit Steam first: it rewrites config.vdf when it exits")
+	}
+	path := steamConfigPath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.logError("Could not read " + tildePath(path) + ": " + err.Error())
+		return nil
+	}
+	current := globalCompatTool(string(data))
+	target, err := protonToggleTarget(current, geProtonTools(compatToolsDir()), valveProtonTools(libraryCommonDirs()))
+	if err != nil {
+		m.logError("Proton not switched: " + err.Error())
+		return nil
+	}
+	content, err := withGlobalCompatTool(string(data), target)
+	if err == nil {
+		err = writeFileAtomic(path, []byte(content), 0o644)
+	}
+	if err != nil {
+		m.logError("Could not write " + tildePath(path) + ": " + err.Error())
+		return nil
+	}
+	was := current
+	if was == "" {
+		was = "Steam's default"
+	}
+	m.appendLog(styleLogSuccess.Render("  ✔  Default compatibility tool: " + target + " (was " + was + ")."))
+	m.appendLog(styleLogDim.Render("  Games without a tool of their own use it from the next launch."))
+	m.refreshLaunchSummary()
+	return m.showToast("Proton: " + target)
+}
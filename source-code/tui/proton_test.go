@@ -0,0 +1,247 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func toolNames(tools []compatTool) []string {
+	var names []string
+	for _, t := range tools {
+		names = append(names, t.name)
+	}
+	return names
+}
+
+func mkdirs(t *testing.T, dirs ...string) {
+	t.Helper()
+	for _, d := range dirs {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGEProtonTools(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"GE-Proton9-2", "GE-Proton9-20", "GE-Proton10-1", "Proton-6.21-GE-2", "GE-Proton8-32"} {
+		mkdirs(t, filepath.Join(dir, name))
+		if err := os.WriteFile(filepath.Join(dir, name, "compatibilitytool.vdf"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdirs(t, filepath.Join(dir, "GE-Proton9-25"), filepath.Join(dir, "Luxtorpeda"))
+
+	want := []string{"GE-Proton10-1", "GE-Proton9-20", "GE-Proton9-2", "GE-Proton8-32", "Proton-6.21-GE-2"}
+	if got := toolNames(geProtonTools(dir)); !slices.Equal(got, want) {
+		t.Errorf("geProtonTools = %q, want %q", got, want)
+	}
+	if got := geProtonTools(filepath.Join(dir, "missing")); len(got) != 0 {
+		t.Errorf("missing dir gave %q", toolNames(got))
+	}
+}
+
+func TestValveProtonTools(t *testing.T) {
+	lib1, lib2 := t.TempDir(), t.TempDir()
+	mkdirs(t,
+		filepath.Join(lib1, "Proton 8.0"),
+		filepath.Join(lib1, "Proton - Experimental"),
+		filepath.Join(lib1, "Proton 6.3"),
+		filepath.Join(lib2, "Proton 9.0 (Beta)"),
+		filepath.Join(lib2, "Proton 8.0"),
+		filepath.Join(lib2, "Portal 2"),
+	)
+	if err := os.WriteFile(filepath.Join(lib2, "Proton 7.0"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"proton_9", "proton_8", "proton_63"}
+	if got := toolNames(valveProtonTools([]string{lib1, lib2, filepath.Join(lib1, "missing")})); !slices.Equal(got, want) {
+		t.Errorf("valveProtonTools = %q, want %q", got, want)
+	}
+}
+
+func TestValveProtonName(t *testing.T) {
+	tests := []struct {
+		major, minor int
+		want         string
+	}{
+		{9, 0, "proton_9"},
+		{6, 3, "proton_63"},
+		{5, 13, "proton_513"},
+	}
+	for _, tt := range tests {
+		if got := valveProtonName(tt.major, tt.minor); got != tt.want {
+			t.Errorf("valveProtonName(%d, %d) = %q, want %q", tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
+
+func TestProtonToggleTarget(t *testing.T) {
+	ge := []compatTool{{name: "GE-Proton9-20"}, {name: "GE-Proton8-32"}}
+	valve := []compatTool{{name: "proton_9"}, {name: "proton_8"}}
+	tests := []struct {
+		current   string
+		ge, valve []compatTool
+		want      string
+		err       string
+	}{
+		{"", ge, valve, "GE-Proton9-20", ""},
+		{"proton_8", ge, valve, "GE-Proton9-20", ""},
+		{"proton_experimental", ge, valve, "GE-Proton9-20", ""},
+		{"GE-Proton8-32", ge, valve, "proton_9", ""},
+		{"Proton-6.21-GE-2", ge, valve, "proton_9", ""},
+		{"proton_9", nil, valve, "", "no GE-Proton found"},
+		{"GE-Proton9-20", ge, nil, "", "no Valve Proton is installed"},
+	}
+	for _, tt := range tests {
+		got, err := protonToggleTarget(tt.current, tt.ge, tt.valve)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("protonToggleTarget(%q) error %v, want %q", tt.current, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("protonToggleTarget(%q) = %q, %v; want %q", tt.current, got, err, tt.want)
+		}
+	}
+}
+
+const vdfWithMapping = `"InstallConfigStore"
+{
+	"Software"
+	{
+		"Valve"
+		{
+			"Steam"
+			{
+				"CompatToolMapping"
+				{
+					"0"
+					{
+						"name"		"proton_9"
+						"config"		""
+						"priority"		"75"
+					}
+					"1245620"
+					{
+						"name"		"GE-Proton8-32"
+					}
+				}
+			}
+		}
+	}
+}`
+
+const vdfWithoutMapping = `"InstallConfigStore"
+{
+	"Software"
+	{
+		"Valve"
+		{
+			"Steam"
+			{
+				"AutoUpdateWindowEnabled"		"0"
+			}
+		}
+	}
+}`
+
+func TestGlobalCompatTool(t *testing.T) {
+	emptyMapping := strings.Replace(vdfWithoutMapping, `"AutoUpdateWindowEnabled"		"0"`,
+		"\"CompatToolMapping\"\n\t\t\t\t{\n\t\t\t\t}", 1)
+	noName := strings.Replace(vdfWithMapping, "\"name\"\t\t\"proton_9\"\n", "", 1)
+	tests := []struct {
+		name    string
+		content string
+		current string
+	}{
+		{"mapping", vdfWithMapping, "proton_9"},
+		{"no mapping", vdfWithoutMapping, ""},
+		{"empty mapping", emptyMapping, ""},
+		{"entry without a name", noName, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := globalCompatTool(tt.content); got != tt.current {
+				t.Fatalf("globalCompatTool = %q, want %q", got, tt.current)
+			}
+			out, err := withGlobalCompatTool(tt.content, "GE-Proton9-20")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := globalCompatTool(out); got != "GE-Proton9-20" {
+				t.Errorf("after setting: %q\n%s", got, out)
+			}
+			if idx := vdfIndex(strings.Split(out, "\n")); len(idx) < len(vdfIndex(strings.Split(tt.content, "\n"))) {
+				t.Errorf("blocks lost:\n%s", out)
+			}
+			if strings.Contains(tt.content, "GE-Proton8-32") && !strings.Contains(out, "GE-Proton8-32") {
+				t.Error("a game's own tool was dropped")
+			}
+		})
+	}
+	if _, err := withGlobalCompatTool(`"InstallConfigStore"`+"\n{\n}", "GE-Proton9-20"); err == nil {
+		t.Error("config.vdf without Steam settings accepted")
+	}
+}
+
+func TestSteamClientRunning(t *testing.T) {
+	proc := t.TempDir()
+	cmdline := func(pid, argv string) {
+		mkdirs(t, filepath.Join(proc, pid))
+		if err := os.WriteFile(filepath.Join(proc, pid, "cmdline"), []byte(argv), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmdline("1", "/sbin/init\x00")
+	cmdline("200", "/usr/bin/steam\x00-silent\x00")
+	if steamClientRunning(proc) {
+		t.Error("the launcher script counted as the client")
+	}
+	cmdline("300", "/home/deck/.local/share/Steam/ubuntu12_32/steam\x00-srt-logger\x00")
+	if !steamClientRunning(proc) {
+		t.Error("running client not found")
+	}
+}
+
+func TestToggleProton(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	ge := filepath.Join(compatToolsDir(), "GE-Proton9-20")
+	mkdirs(t, ge, filepath.Join(steamappsDir(), "common", "Proton 9.0"), filepath.Dir(steamConfigPath()))
+	if err := os.WriteFile(filepath.Join(ge, "compatibilitytool.vdf"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(steamConfigPath(), []byte(vdfWithoutMapping), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := initialModel()
+	for _, want := range []string{"GE-Proton9-20", "proton_9", "GE-Proton9-20"} {
+		m.cursor = itemIndex(t, "Toggle GE-Proton")
+		m = press(m, "enter")
+		data, _ := os.ReadFile(steamConfigPath())
+		if got := globalCompatTool(string(data)); got != want {
+			t.Fatalf("default tool %q, want %q", got, want)
+		}
+		if m.toast != "Proton: "+want {
+			t.Errorf("toast %q", m.toast)
+		}
+	}
+	if countLogged(m, "(was Steam's default)") != 1 {
+		t.Error("first switch didn't say what it replaced")
+	}
+
+	if err := os.RemoveAll(compatToolsDir()); err != nil {
+		t.Fatal(err)
+	}
+	m = press(m, "enter") // now on proton_9
+	m = press(m, "enter")
+	if m.lastError == nil || !strings.Contains(m.lastError.text, "no GE-Proton found") {
+		t.Errorf("last error %+v", m.lastError)
+	}
+}
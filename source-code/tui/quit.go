@@ -0,0 +1,64 @@
+package main
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Quitting during an update
+//  Killing pacman halfway can leave the container with a broken
+//  package database, so ctrl+c during an update asks first. Cancelling
+//  interrupts the update and quits once it has stopped; quitting anyway
+//  keeps the old behaviour. Every quit key goes through requestQuit,
+//  whichever screen or popup is open while the update runs.
+// ─────────────────────────────────────────────────────────────────
+
+// requestQuit handles ctrl+c and q.
+func (m *model) requestQuit() tea.Cmd {
+	if isUpdateItem(m.lastItem) && m.stopStream != nil {
+		m.quitReturn = m.state
+		m.state = stateQuitUpdate
+		m.popup = popupNone
+		m.promptOpenedAt = time.Now()
+		return nil
+	}
+	return quit
+}
+
+func (m *model) handleQuitUpdateKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.CancelAndQuit):
+		m.state = stateRunning
+		m.quitAfterRun = true
+		m.stopStream()
+		m.appendLog(styleLogWarning.Render("  ⚠  Cancelling the update; quitting once it has stopped…"))
+	case key.Matches(msg, keys.QuitAnyway):
+		return quit
+	case key.Matches(msg, keys.Back):
+		m.state = m.quitReturn
+	}
+	return nil
+}
+
+func (m model) renderQuitUpdateDialog() string {
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("⚠  Update in progress"),
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render("Quitting now may leave the container half-updated"),
+		lipgloss.NewStyle().Foreground(colText).Render("and its package database corrupted."),
+		lipgloss.NewStyle().Foreground(colSub).Render("Cancelling the update first is recommended."),
+		"",
+		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[C]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("cancel update and quit")+"   "+
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[Q]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("quit anyway")+"   "+
+			lipgloss.NewStyle().Foreground(colDim).Bold(true).Render("[Esc]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("keep updating"),
+	)
+
+	return m.placeOverlay(styleConfirmBox, content)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// isQuit runs cmd, against a lifecycle of its own so that quitting
+// doesn't shut down the one later tests use.
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	defer func(saved *lifecycle) { appLife = saved }(appLife)
+	appLife = newLifecycle()
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+// runningModel is a model with item running as a streamed command;
+// stops counts calls to its stop function.
+func runningModel(item menuItem, streamed bool) (model, *int) {
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.busy, m.state = true, stateRunning
+	m.lastItem = &item
+	stops := new(int)
+	if streamed {
+		m.stopStream = func() { *stops++ }
+	}
+	return m, stops
+}
+
+func TestRequestQuit(t *testing.T) {
+	update := menuItems[itemIndex(t, "Update Container")]
+	setup := menuItems[itemIndex(t, "Setup / Repair Steam")]
+	tests := []struct {
+		name     string
+		item     menuItem
+		streamed bool
+		warn     bool
+	}{
+		{"streamed update", update, true, true},
+		{"update in the terminal", update, false, false},
+		{"other action", setup, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := runningModel(tt.item, tt.streamed)
+			cmd := m.requestQuit()
+			if tt.warn {
+				if cmd != nil || m.state != stateQuitUpdate {
+					t.Fatalf("state %v, want the update warning", m.state)
+				}
+				if view := m.renderQuitUpdateDialog(); !strings.Contains(view, "cancel update and quit") {
+					t.Errorf("dialog:\n%s", view)
+				}
+				return
+			}
+			if !isQuit(cmd) {
+				t.Errorf("ctrl+c did not quit (state %v)", m.state)
+			}
+		})
+	}
+}
+
+// ctrl+c warns about the running update whatever is open above it, and
+// esc goes back to that screen.
+func TestQuitKeysDuringUpdate(t *testing.T) {
+	update := menuItems[itemIndex(t, "Update Container")]
+	ctrlC := tea.KeyMsg{Type: tea.KeyCtrlC}
+	tests := []struct {
+		name  string
+		state viewState
+		popup popup
+		key   tea.KeyMsg
+	}{
+		{"running", stateRunning, popupNone, ctrlC},
+		{"menu q", stateMenu, popupNone, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}},
+		{"submenu", stateSubmenu, popupNone, ctrlC},
+		{"games", stateGames, popupNone, ctrlC},
+		{"env", stateEnv, popupNone, ctrlC},
+		{"library", stateLibrary, popupNone, ctrlC},
+		{"split logs", stateSplitLogs, popupNone, ctrlC},
+		{"rename", stateRename, popupNone, ctrlC},
+		{"crash", stateCrash, popupNone, ctrlC},
+		{"import", stateImport, popupNone, ctrlC},
+		{"history", stateRunning, popupHistory, ctrlC},
+		{"last error", stateRunning, popupLastError, ctrlC},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := runningModel(update, true)
+			m.state, m.popup = tt.state, tt.popup
+			next, cmd := m.Update(tt.key)
+			m = next.(model)
+			if isQuit(cmd) || m.state != stateQuitUpdate || m.popup != popupNone {
+				t.Fatalf("quit=%v state=%v popup=%v, want the update warning", isQuit(cmd), m.state, m.popup)
+			}
+			next, _ = settle(m).Update(tea.KeyMsg{Type: tea.KeyEsc})
+			if m = next.(model); m.state != tt.state {
+				t.Errorf("esc went back to %v, want %v", m.state, tt.state)
+			}
+		})
+	}
+}
+
+func TestQuitUpdateChoices(t *testing.T) {
+	update := menuItems[itemIndex(t, "Update Container")]
+	tests := []struct {
+		key       string
+		stops     int
+		quitNow   bool
+		state     viewState
+		quitAfter bool
+	}{
+		{"c", 1, false, stateRunning, true},
+		{"q", 0, true, stateQuitUpdate, false},
+		{"esc", 0, false, stateRunning, false},
+		{"x", 0, false, stateQuitUpdate, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			m, stops := runningModel(update, true)
+			m.requestQuit()
+			var cmd tea.Cmd
+			if tt.key == "esc" {
+				cmd = m.handleQuitUpdateKey(tea.KeyMsg{Type: tea.KeyEsc})
+			} else {
+				cmd = m.handleQuitUpdateKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+			}
+			if isQuit(cmd) != tt.quitNow || *stops != tt.stops || m.state != tt.state || m.quitAfterRun != tt.quitAfter {
+				t.Fatalf("quit=%v stops=%d state=%v quitAfter=%v", isQuit(cmd), *stops, m.state, m.quitAfterRun)
+			}
+			if !tt.quitAfter {
+				return
+			}
+			_, cmd = m.Update(cmdDoneMsg(false))
+			if !isQuit(cmd) {
+				t.Error("did not quit once the cancelled update stopped")
+			}
+		})
+	}
+}
+
+// Closing stop sends SIGINT to the command's process group.
+func TestStreamCommandStop(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	ch := make(chan tea.Msg, 64)
+	stop := make(chan struct{})
+	// sh runs a trap only once its foreground command is done, and a
+	// SIGINT landing while it forks sleep never reaches sleep, so sleep
+	// is waited for in the background, where wait returns at once.
+	script := `trap 'echo interrupted; kill $!; exit 130' INT; echo started; sleep 5 >/dev/null 2>&1 & wait; echo finished`
+	go streamCommand(context.Background(), []string{sh, "-c", script}, nil, ch, stop)
+
+	start := time.Now()
+	var lines []string
+	var ok, done bool
+	for msg := range ch {
+		switch msg := msg.(type) {
+		case cmdOutputMsg:
+			lines = append(lines, string(msg))
+			if string(msg) == "started" {
+				close(stop)
+			}
+		case cmdDoneMsg:
+			ok, done = bool(msg), true
+		}
+	}
+	if !done || ok {
+		t.Errorf("done=%v ok=%v, want a failed run", done, ok)
+	}
+	if !strings.Contains(strings.Join(lines, "\n"), "interrupted") {
+		t.Errorf("trap did not run: %q", lines)
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Errorf("stop took %v", time.Since(start))
+	}
+}
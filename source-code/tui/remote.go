@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Remote host (--remote user@host)
+//  Every container command is wrapped in ssh so a headless box can be
+//  managed from here. Output still streams through the ssh pipe.
+// ─────────────────────────────────────────────────────────────────
+
+// sshUnreachable is the exit status ssh uses for its own failures
+// (connection refused, auth failure, unknown host, ...).
+const sshUnreachable = 255
+
+var remoteHost string // empty = run locally
+
+func validateRemote(host string) error {
+	switch {
+	case host == "":
+		return nil
+	case strings.HasPrefix(host, "-"):
+		return fmt.Errorf("invalid --remote %q: must be user@host", host)
+	case strings.ContainsAny(host, " \t\n"):
+		return fmt.Errorf("invalid --remote %q: must not contain whitespace", host)
+	}
+	return nil
+}
+
+// sshArgv wraps argv (with env assignments) into an ssh invocation. The
+// remote command is a single quoted string since ssh hands it to the
+// remote shell. tty forces a terminal for password prompts.
+func sshArgv(host string, argv, env []string, tty bool) []string {
+	out := []string{"ssh"}
+	if tty {
+		out = append(out, "-t")
+	} else {
+		out = append(out, "-o", "BatchMode=yes")
+	}
+	remote := shellJoin(argv)
+	if len(env) > 0 {
+		remote = "env " + shellCommandLine(env, argv)
+	}
+	return append(out, host, "--", remote)
+}
+
+// hostArgv returns the argv and environment overrides to execute locally
+// for argv, routed through ssh when a remote host is set.
+func hostArgv(argv, env []string, tty bool) ([]string, []string) {
+	if remoteHost == "" {
+		return argv, env
+	}
+	return sshArgv(remoteHost, argv, env, tty), nil
+}
+
+// hostCommand builds the exec.Cmd for argv on the managed host.
+func hostCommand(argv, env []string, tty bool) *exec.Cmd {
+	env = append(containerNameEnv(argv), env...)
+	argv, env = hostArgv(argv, env, tty)
+	c := exec.Command(argv[0], argv[1:]...)
+	c.Env = commandEnv(env)
+	return c
+}
+
+// hostLabel prefixes status-bar text with the remote host, if any.
+func hostLabel() string {
+	if remoteHost == "" {
+		return ""
+	}
+	return remoteHost + " · "
+}
+
+// isUnreachable reports whether err is ssh failing to reach the host
+// rather than the remote command failing.
+func isUnreachable(err error) bool {
+	var exitErr *exec.ExitError
+	return remoteHost != "" && errors.As(err, &exitErr) && exitErr.ExitCode() == sshUnreachable
+}
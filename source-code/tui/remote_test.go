@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestValidateRemote(t *testing.T) {
+	tests := []struct {
+		host string
+		ok   bool
+	}{
+		{"", true},
+		{"gamer@steambox", true},
+		{"steambox.lan", true},
+		{"-oProxyCommand=evil", false},
+		{"gamer@steam box", false},
+		{"host\n", false},
+	}
+	for _, tt := range tests {
+		if err := validateRemote(tt.host); (err == nil) != tt.ok {
+			t.Errorf("validateRemote(%q) = %v, want ok=%v", tt.host, err, tt.ok)
+		}
+	}
+}
+
+func TestSSHArgv(t *testing.T) {
+	tests := []struct {
+		name string
+		argv []string
+		env  []string
+		tty  bool
+		want []string
+	}{
+		{
+			"batch",
+			[]string{"hackeros-steam", "update"}, nil, false,
+			[]string{"ssh", "-o", "BatchMode=yes", "me@box", "--", "hackeros-steam update"},
+		},
+		{
+			"tty",
+			[]string{"pkexec", "hackeros-steam", "setup"}, nil, true,
+			[]string{"ssh", "-t", "me@box", "--", "pkexec hackeros-steam setup"},
+		},
+		{
+			"quoted args",
+			[]string{"hackeros-steam", "run", "it's here"}, nil, false,
+			[]string{"ssh", "-o", "BatchMode=yes", "me@box", "--", `hackeros-steam run 'it'\''s here'`},
+		},
+		{
+			"env",
+			[]string{"hackeros-steam", "run"}, []string{"MANGOHUD=1"}, false,
+			[]string{"ssh", "-o", "BatchMode=yes", "me@box", "--", "env MANGOHUD=1 hackeros-steam run"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sshArgv("me@box", tt.argv, tt.env, tt.tty)
+			if strings.Join(got, "\x00") != strings.Join(tt.want, "\x00") {
+				t.Errorf("sshArgv = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostArgv(t *testing.T) {
+	defer func(saved string) { remoteHost = saved }(remoteHost)
+	argv, env := []string{"hackeros-steam", "status"}, []string{"A=1"}
+
+	remoteHost = ""
+	if got, gotEnv := hostArgv(argv, env, false); got[0] != "hackeros-steam" || len(gotEnv) != 1 {
+		t.Errorf("local hostArgv = %q, %q", got, gotEnv)
+	}
+	remoteHost = "me@box"
+	got, gotEnv := hostArgv(argv, env, false)
+	if got[0] != "ssh" || gotEnv != nil {
+		t.Errorf("remote hostArgv = %q, %q", got, gotEnv)
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	defer func(saved string) { remoteHost = saved }(remoteHost)
+	exit := func(code string) error { return exec.Command("sh", "-c", "exit "+code).Run() }
+
+	tests := []struct {
+		remote string
+		err    error
+		want   bool
+	}{
+		{"me@box", exit("255"), true},
+		{"me@box", exit("1"), false},
+		{"me@box", nil, false},
+		{"", exit("255"), false},
+	}
+	for _, tt := range tests {
+		remoteHost = tt.remote
+		if got := isUnreachable(tt.err); got != tt.want {
+			t.Errorf("isUnreachable(%v) with remote %q = %v, want %v", tt.err, tt.remote, got, tt.want)
+		}
+	}
+}
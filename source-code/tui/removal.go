@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Removal summary
+//  Before "Remove Container" is confirmed the engine is asked what the
+//  container holds, so the prompt can say exactly what goes and what
+//  stays. If that fails the prompt keeps its generic warning.
+//  "Kill & Remove Container" shows the same summary, after a line on
+//  what the kill does.
+// ─────────────────────────────────────────────────────────────────
+
+type describedMsg struct {
+	item   menuItem
+	action action
+	lines  []string
+	err    error
+}
+
+// containerFacts is what `<engine> ps --size` says about the container.
+type containerFacts struct {
+	image   string
+	size    string // writable layer, e.g. "1.2GB (virtual 3.4GB)"
+	created string
+}
+
+// removalPSFormat is understood by both podman and docker.
+const removalPSFormat = "{{.Image}}\t{{.Size}}\t{{.CreatedAt}}"
+
+func parseContainerFacts(out string) (containerFacts, error) {
+	line := strings.TrimSpace(out)
+	if line == "" {
+		return containerFacts{}, errors.New("container not found")
+	}
+	line, _, _ = strings.Cut(line, "\n")
+	f := strings.Split(line, "\t")
+	if len(f) != 3 {
+		return containerFacts{}, fmt.Errorf("unexpected output %q", line)
+	}
+	return containerFacts{image: f[0], size: f[1], created: f[2]}, nil
+}
+
+// removalLines renders the summary shown in the prompt.
+func removalLines(c containerFacts, games []game) []string {
+	kept := "~/.local/share/Steam (Steam, settings and games) is kept."
+	if len(games) > 0 {
+		kept = fmt.Sprintf("Kept: %d games (%s) and settings in ~/.local/share/Steam.",
+			len(games), strings.TrimSuffix(formatSpeed(float64(totalSize(games))), "/s"))
+	}
+	return []string{
+		"Deleted: the container and everything installed into it",
+		"  image " + c.image + ", created " + c.created,
+		"  container data " + c.size,
+		kept,
+		"This cannot be undone.",
+	}
+}
+
+// describeRemoval gathers the removal summary.
+func describeRemoval(engine string) ([]string, error) {
+	out, err := auditedOutput("Remove Container", hostCommand([]string{engine, "ps", "-a", "--size",
+		"--filter", "name=^" + containerName + "$", "--format", removalPSFormat}, nil, false), stdoutOf)
+	if err != nil {
+		return nil, err
+	}
+	facts, err := parseContainerFacts(string(out))
+	if err != nil {
+		return nil, err
+	}
+	var games []game
+	if remoteHost == "" {
+		games, _ = loadGames(filepath.Join(steamDir(), "steamapps"))
+	}
+	return removalLines(facts, games), nil
+}
+
+// teardownKillLine leads the "Kill & Remove Container" prompt.
+const teardownKillLine = "Killed first if running: Steam and any game stop at once, unsaved progress is lost"
+
+// describeTeardown is describeRemoval for "Kill & Remove Container".
+func describeTeardown(engine string) ([]string, error) {
+	lines, err := describeRemoval(engine)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{teardownKillLine}, lines...), nil
+}
+
+// describeCmd runs item.describe before the confirmation prompt opens.
+func describeCmd(item menuItem, a action, engine string) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := item.describe(engine)
+		return describedMsg{item: item, action: a, lines: lines, err: err}
+	}
+}
+
+// handleDescribed opens the prompt with the detailed summary, or with
+// the item's own warning when the engine couldn't be asked.
+func (m *model) handleDescribed(msg describedMsg) tea.Cmd {
+	m.busy = false
+	item := msg.item
+	if msg.err == nil {
+		item.warning = msg.lines
+	} else {
+		item.warning = append(append([]string(nil), item.warning...),
+			"(Could not inspect the container: "+msg.err.Error()+")")
+	}
+	return m.openActionPrompt(stateConfirm, item, msg.action)
+}
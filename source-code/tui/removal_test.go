@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseContainerFacts(t *testing.T) {
+	tests := []struct {
+		out     string
+		want    containerFacts
+		wantErr bool
+	}{
+		{"docker.io/library/archlinux:latest\t1.2GB (virtual 3.4GB)\t2026-09-01 10:00:00 +0000 UTC\n",
+			containerFacts{image: "docker.io/library/archlinux:latest", size: "1.2GB (virtual 3.4GB)", created: "2026-09-01 10:00:00 +0000 UTC"}, false},
+		{"a\tb\tc\nd\te\tf\n", containerFacts{image: "a", size: "b", created: "c"}, false},
+		{"", containerFacts{}, true},
+		{"just one field\n", containerFacts{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseContainerFacts(tt.out)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseContainerFacts(%q) = %+v, %v", tt.out, got, err)
+		}
+	}
+}
+
+func TestRemovalLines(t *testing.T) {
+	facts := containerFacts{image: "archlinux:latest", size: "1.2GB", created: "yesterday"}
+	tests := []struct {
+		games []game
+		kept  string
+	}{
+		{nil, "~/.local/share/Steam (Steam, settings and games) is kept."},
+		{[]game{{appID: "1", size: 1 << 30}, {appID: "2", size: 1 << 30}}, "Kept: 2 games (2.0 GiB) and settings in ~/.local/share/Steam."},
+	}
+	for _, tt := range tests {
+		lines := removalLines(facts, tt.games)
+		text := strings.Join(lines, "\n")
+		for _, want := range []string{"image archlinux:latest, created yesterday", "container data 1.2GB", tt.kept, "This cannot be undone."} {
+			if !strings.Contains(text, want) {
+				t.Errorf("removal lines lack %q:\n%s", want, text)
+			}
+		}
+	}
+}
+
+func TestRemovalConfirmSelection(t *testing.T) {
+	remove, a := menuItems[itemIndex(t, "Remove Container")], menuAction(itemIndex(t, "Remove Container"))
+	tests := []struct {
+		name    string
+		msg     describedMsg
+		want    []string
+		notWant string
+	}{
+		{
+			"detailed",
+			describedMsg{item: remove, action: a, lines: []string{"Deleted: the container", "Kept: 3 games"}},
+			[]string{"Deleted: the container", "Kept: 3 games"},
+			"Could not inspect",
+		},
+		{
+			"generic on inspect failure",
+			describedMsg{item: remove, action: a, err: errors.New("exit status 125")},
+			[]string{"This cannot be undone.", "(Could not inspect the container: exit status 125)"},
+			"Deleted:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.width, m.height = 120, 40
+			m.busy = true
+			next, _ := m.Update(tt.msg)
+			m = next.(model)
+			if m.busy || m.state != stateConfirm || m.pendingItem == nil {
+				t.Fatalf("busy=%v state=%v: prompt not opened", m.busy, m.state)
+			}
+			if strings.Join(m.pendingItem.warning, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("warning = %q, want %q", m.pendingItem.warning, tt.want)
+			}
+			if dialog := m.renderConfirmDialog(); strings.Contains(dialog, tt.notWant) {
+				t.Errorf("dialog shows %q:\n%s", tt.notWant, dialog)
+			}
+		})
+	}
+	if len(remove.warning) != 1 {
+		t.Errorf("the menu item's own warning was modified: %q", remove.warning)
+	}
+}
+
+func TestRemoveAsksEngineFirst(t *testing.T) {
+	for _, label := range []string{"Remove Container", "Kill & Remove Container"} {
+		m := initialModel()
+		m.containerStatus = "stopped"
+		m.cursor = itemIndex(t, label)
+		m = press(m, "enter")
+		if m.state != stateMenu || !m.busy {
+			t.Errorf("%s: state=%v busy=%v: prompt opened before the engine was asked", label, m.state, m.busy)
+		}
+	}
+}
+
+func TestTeardown(t *testing.T) {
+	teardown, a := menuItems[itemIndex(t, "Kill & Remove Container")], menuAction(itemIndex(t, "Kill & Remove Container"))
+	tests := []struct {
+		name  string
+		lines []string
+		err   error
+		want  []string
+	}{
+		{"detailed", []string{teardownKillLine, "Deleted: the container"}, nil,
+			[]string{teardownKillLine, "Deleted: the container"}},
+		{"generic on inspect failure", nil, errors.New("exit status 125"),
+			[]string{teardownKillLine, "This cannot be undone.", "(Could not inspect the container: exit status 125)"}},
+	}
+	for _, tt := range tests {
+		for _, status := range []string{"running", "paused", "stopped"} {
+			m := initialModel()
+			m.width, m.height = 120, 40
+			m.containerStatus = status
+			if reason := m.disabledReason(teardown); reason != "" {
+				t.Fatalf("disabled while %s: %s", status, reason)
+			}
+			m.busy = true
+			next, _ := m.Update(describedMsg{item: teardown, action: a, lines: tt.lines, err: tt.err})
+			m = next.(model)
+			if m.state != stateConfirm || strings.Join(m.pendingItem.warning, "\n") != strings.Join(tt.want, "\n") {
+				t.Fatalf("%s, %s: state %v, warning %q", tt.name, status, m.state, m.pendingItem.warning)
+			}
+			m = press(settle(m), "y")
+			if countLogged(m, "$ hackeros-steam --force teardown") != 1 {
+				t.Errorf("%s, %s: teardown didn't start", tt.name, status)
+			}
+		}
+	}
+	m := initialModel()
+	m.containerStatus = "missing"
+	if m.disabledReason(teardown) == "" {
+		t.Error("Kill & Remove Container enabled without a container")
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Container name
+//  The container is HackerOS-Steam unless "Rename Container" gave it
+//  another name, which is kept in container.name next to the config.
+//  hackeros-steam reads that file too, so it and the GUI follow a
+//  rename; the TUI also sets HACKEROS_STEAM_CONTAINER on every call,
+//  which is what reaches a --remote host. Renaming needs the container
+//  stopped: distrobox can't follow a running one to its new name.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	defaultContainerName  = "HackerOS-Steam"
+	containerNameFileName = "container.name"
+	containerNameEnvVar   = "HACKEROS_STEAM_CONTAINER"
+)
+
+// containerName is the container every action works on.
+var containerName = defaultContainerName
+
+// reContainerName is what podman and docker accept as a name.
+var reContainerName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// maxContainerName keeps the name usable as the container's hostname.
+const maxContainerName = 63
+
+var (
+	renameSubmit = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "rename"))
+	renameCancel = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel"))
+)
+
+type renameCheckedMsg struct {
+	name string
+	err  error // why the name can't be taken
+}
+
+func containerNamePath() string {
+	return filepath.Join(configDir(), containerNameFileName)
+}
+
+func validateContainerName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("enter a name")
+	case len(name) > maxContainerName:
+		return fmt.Errorf("at most %d characters", maxContainerName)
+	case !reContainerName.MatchString(name):
+		return errors.New("use letters, digits, '_', '.' and '-', starting with a letter or digit")
+	case name == containerName:
+		return errors.New("that is the current name")
+	}
+	return nil
+}
+
+// loadContainerName reads path; a missing file keeps the default.
+func loadContainerName(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return defaultContainerName, nil
+	}
+	if err != nil {
+		return defaultContainerName, err
+	}
+	name := strings.TrimSpace(string(data))
+	if name == "" {
+		return defaultContainerName, nil
+	}
+	if !reContainerName.MatchString(name) || len(name) > maxContainerName {
+		return defaultContainerName, fmt.Errorf("%s: %q is not a valid container name", path, name)
+	}
+	return name, nil
+}
+
+// saveContainerName stores name; the default removes the file.
+func saveContainerName(path, name string) error {
+	if name == defaultContainerName {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return writeFileAtomic(path, []byte(name+"\n"), 0o644)
+}
+
+// containerNameEnv tells a hackeros-steam in argv which container to
+// use; nothing while the name is the default.
+func containerNameEnv(argv []string) []string {
+	if containerName == defaultContainerName || !slices.Contains(argv, cli) {
+		return nil
+	}
+	return []string{containerNameEnvVar + "=" + containerName}
+}
+
+// renameItem is the action that renames the container to name.
+func renameItem(name string) menuItem {
+	return menuItem{icon: "✎", label: "Rename Container", cmd: []string{"rename", name}, requires: reqStopped, destructive: true}
+}
+
+func isRenameItem(item *menuItem) bool {
+	return item != nil && len(item.cmd) == 2 && item.cmd[0] == "rename"
+}
+
+// openRename is the "Rename Container" action.
+func (m *model) openRename() tea.Cmd {
+	m.renameInput, m.renameErr = "", ""
+	m.state = stateRename
+	return nil
+}
+
+func (m *model) handleRenameKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, renameSubmit):
+		if m.busy {
+			return nil
+		}
+		if err := validateContainerName(m.renameInput); err != nil {
+			m.renameErr = err.Error()
+			return nil
+		}
+		m.busy = true
+		return tea.Batch(checkNameFreeCmd(m.engine(), m.renameInput), m.spinnerTick())
+	case key.Matches(msg, renameCancel):
+		m.state = stateMenu
+	case msg.Type == tea.KeyBackspace:
+		if r := []rune(m.renameInput); len(r) > 0 {
+			m.renameInput = string(r[:len(r)-1])
+		}
+		m.renameErr = ""
+	case msg.Type == tea.KeyRunes:
+		m.renameInput += string(msg.Runes)
+		m.renameErr = ""
+	}
+	return nil
+}
+
+// checkNameFreeCmd asks the engine whether a container already has
+// the name.
+func checkNameFreeCmd(engine, name string) tea.Cmd {
+	return func() tea.Msg {
+		if auditedRun("Rename Container", hostCommand([]string{engine, "inspect", "--type", "container", name}, nil, false)) == nil {
+			return renameCheckedMsg{name: name, err: fmt.Errorf("a container named %s already exists", name)}
+		}
+		return renameCheckedMsg{name: name}
+	}
+}
+
+func (m *model) handleRenameChecked(msg renameCheckedMsg) tea.Cmd {
+	m.busy = false
+	if m.state != stateRename {
+		return nil
+	}
+	if msg.err != nil {
+		m.renameErr = msg.err.Error()
+		return nil
+	}
+	m.state = stateMenu
+	return m.dispatch(renameItem(msg.name))
+}
+
+// renameFinished makes the new name current once the CLI has renamed
+// the container.
+func (m *model) renameFinished() {
+	name := m.lastItem.cmd[1]
+	if err := saveContainerName(containerNamePath(), name); err != nil {
+		m.logError("The container is now " + name + ", but the name could not be saved: " + err.Error())
+	}
+	containerName = name
+	m.appendLog(styleLogInfo.Render("  → Actions now use the container " + name + "."))
+}
+
+func (m model) renderRenameDialog() string {
+	lines := []string{
+		lipgloss.NewStyle().Foreground(colAccent).Bold(true).Render("✎  Rename Container"),
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render("New name for " + containerName + ":"),
+		lipgloss.NewStyle().Foreground(colAccent).Render("> ") +
+			lipgloss.NewStyle().Foreground(colText).Render(m.renameInput) +
+			lipgloss.NewStyle().Foreground(colCursor).Render("▏"),
+	}
+	if m.renameErr != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colRed).Render(m.renameErr))
+	}
+	if m.busy {
+		lines = append(lines, styleLogDim.Render(m.spinner.View()+" Checking the name…"))
+	}
+	lines = append(lines,
+		"",
+		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[Enter]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("rename")+"   "+
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[Esc]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("cancel"),
+	)
+	return m.placeOverlay(styleConfirmBox, lipgloss.JoinVertical(lipgloss.Center, lines...))
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateContainerName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string // in the error; "" for valid
+	}{
+		{"Steam-2", ""},
+		{"games.box_1", ""},
+		{"", "enter a name"},
+		{"-steam", "starting with a letter or digit"},
+		{"steam box", "use letters"},
+		{"stéam", "use letters"},
+		{strings.Repeat("a", maxContainerName), ""},
+		{strings.Repeat("a", maxContainerName+1), "at most 63"},
+		{defaultContainerName, "current name"},
+	}
+	for _, tt := range tests {
+		err := validateContainerName(tt.name)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("validateContainerName(%q) = %v, want nil", tt.name, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("validateContainerName(%q) = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestLoadContainerName(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string // "" = no file
+		want    string
+		wantErr bool
+	}{
+		{"no file", "", defaultContainerName, false},
+		{"renamed", "Steam-2\n", "Steam-2", false},
+		{"blank", "  \n", defaultContainerName, false},
+		{"invalid", "steam box\n", defaultContainerName, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), containerNameFileName)
+			if tt.file != "" {
+				os.WriteFile(path, []byte(tt.file), 0o644)
+			}
+			got, err := loadContainerName(path)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf("loadContainerName = %q, %v; want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSaveContainerName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg", containerNameFileName)
+	if err := saveContainerName(path, "Steam-2"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := loadContainerName(path); got != "Steam-2" {
+		t.Errorf("saved name read back as %q", got)
+	}
+	if err := saveContainerName(path, defaultContainerName); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("going back to the default kept the file: %v", err)
+	}
+}
+
+func TestContainerNameEnv(t *testing.T) {
+	defer func(saved string) { containerName = saved }(containerName)
+	containerName = defaultContainerName
+	if env := containerNameEnv([]string{cli, "run"}); env != nil {
+		t.Errorf("default name set %q", env)
+	}
+	containerName = "Steam-2"
+	if got := strings.Join(containerNameEnv([]string{"pkexec", cli, "setup"}), " "); got != containerNameEnvVar+"=Steam-2" {
+		t.Errorf("renamed env = %q", got)
+	}
+	if env := containerNameEnv([]string{"xdg-open", "/tmp"}); env != nil {
+		t.Errorf("set for a command that isn't hackeros-steam: %q", env)
+	}
+}
+
+func TestRenameNeedsStoppedContainer(t *testing.T) {
+	tests := []struct {
+		status, reason string
+	}{
+		{"stopped", ""},
+		{"running", "stop the container first"},
+		{"paused", "stop the container first"},
+		{"missing", "container not created yet"},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.containerStatus = tt.status
+		if got := m.disabledReason(menuItems[itemIndex(t, "Rename Container")]); got != tt.reason {
+			t.Errorf("%s: reason %q, want %q", tt.status, got, tt.reason)
+		}
+	}
+}
+
+func TestRenameDispatch(t *testing.T) {
+	defer func(saved string) { containerName = saved }(containerName)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	containerName = defaultContainerName
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.containerStatus = "stopped"
+	m.cursor = itemIndex(t, "Rename Container")
+	m = press(m, "enter")
+	if m.state != stateRename {
+		t.Fatalf("enter opened state %v", m.state)
+	}
+	m = press(m, "b", "a", "d", " ", "enter")
+	if m.busy || !strings.Contains(m.renameErr, "use letters") {
+		t.Fatalf("invalid name: busy %v, error %q", m.busy, m.renameErr)
+	}
+	m = press(m, "backspace")
+	if m.renameErr != "" {
+		t.Error("editing the name kept the error")
+	}
+
+	// A name the engine already knows is refused; "true" answers yes.
+	m.backend = "true"
+	m = press(m, "enter")
+	if !m.busy {
+		t.Fatal("a valid name was not checked")
+	}
+	if !strings.Contains(m.renderRenameDialog(), "Checking the name") {
+		t.Error("dialog doesn't say the name is being checked")
+	}
+	msg := checkNameFreeCmd(m.engine(), m.renameInput)().(renameCheckedMsg)
+	m.handleRenameChecked(msg)
+	if m.state != stateRename || !strings.Contains(m.renameErr, "already exists") {
+		t.Fatalf("taken name: state %v, error %q", m.state, m.renameErr)
+	}
+
+	m.backend = "false"
+	m.handleRenameChecked(checkNameFreeCmd(m.engine(), m.renameInput)().(renameCheckedMsg))
+	if m.state == stateRename || countLogged(m, "$ hackeros-steam rename bad") != 1 {
+		t.Fatalf("free name not renamed (state %v): %q", m.state, plainLogLines(m.logLines, false))
+	}
+	next, _ := m.Update(cmdDoneMsg(true))
+	m = next.(model)
+	if containerName != "bad" {
+		t.Errorf("containerName = %q after the rename", containerName)
+	}
+	if got, _ := loadContainerName(containerNamePath()); got != "bad" {
+		t.Errorf("saved name = %q", got)
+	}
+}
+
+func TestRenameCancel(t *testing.T) {
+	m := initialModel()
+	m.containerStatus = "stopped"
+	m.openRename()
+	m = press(m, "x", "esc")
+	if m.state != stateMenu || m.busy {
+		t.Errorf("esc left state %v, busy %v", m.state, m.busy)
+	}
+	// A check that comes back after esc does nothing.
+	m.handleRenameChecked(renameCheckedMsg{name: "x"})
+	if countLogged(m, "$ hackeros-steam rename") != 0 {
+		t.Error("a cancelled rename ran")
+	}
+}
+
+func TestWarningsNameTheRenamedContainer(t *testing.T) {
+	defer func(saved string) { containerName = saved }(containerName)
+	containerName = "steam-box"
+	for _, item := range []menuItem{menuItems[itemIndex(t, "Repair Container")], resetKeepData, resetWipeAll} {
+		m := initialModel()
+		m.width, m.height = 120, 40
+		m.openPrompt(stateConfirm, item)
+		if dialog := stripANSI(m.renderConfirmDialog()); !strings.Contains(dialog, "the steam-box container") {
+			t.Errorf("%s prompt doesn't name the renamed container:\n%s", item.label, dialog)
+		}
+	}
+}
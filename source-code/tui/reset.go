@@ -0,0 +1,54 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Factory reset
+//  `hackeros-steam reset` rebuilds the container from the image.
+//  Steam's own files live in $HOME, so they survive unless the wipe
+//  variant is picked, which passes --wipe-data.
+// ─────────────────────────────────────────────────────────────────
+
+var (
+	resetKeepData = menuItem{
+		icon: "⟲", label: "Factory Reset (keep game data)", cmd: []string{"reset"},
+		confirm: true, requires: reqExists, destructive: true,
+		warningFor: func() []string {
+			return []string{
+				"Wiped:  the " + containerName + " container — installed packages,",
+				"        /etc changes and anything outside your home.",
+				"Kept:   ~/.local/share/Steam — games, saves, login, settings.",
+				"Kept:   container.env and the TUI's own config.",
+			}
+		},
+		doneNote: "Container reset; Steam data in your home directory was kept.",
+	}
+	resetWipeAll = menuItem{
+		icon: "✕", label: "Factory Reset (wipe everything)", cmd: []string{"reset", "--wipe-data"},
+		confirm: true, requires: reqExists, destructive: true,
+		warningFor: func() []string {
+			return []string{
+				"Wiped:  the " + containerName + " container — installed packages,",
+				"        /etc changes and anything outside your home.",
+				"Wiped:  ~/.local/share/Steam and ~/.steam — every installed game,",
+				"        local saves, the Steam login and client settings.",
+				"Kept:   container.env and the TUI's own config.",
+				"Cloud saves are only safe if Steam Cloud has synced them.",
+			}
+		},
+		doneNote: "Container reset and Steam data deleted; log in to Steam again.",
+	}
+)
+
+// openResetMenu is the "Factory Reset" action.
+func (m *model) openResetMenu() tea.Cmd {
+	m.openSubmenu(&submenu{
+		title:  "Factory Reset — " + containerName,
+		note:   "⚠ The container is deleted and rebuilt from the image.",
+		marked: -1,
+		items:  []menuItem{resetKeepData, resetWipeAll},
+	})
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResetArgv(t *testing.T) {
+	tests := []struct {
+		item menuItem
+		want string
+	}{
+		{resetKeepData, cli + " reset"},
+		{resetWipeAll, cli + " reset --wipe-data"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(commandArgv(tt.item.cmd), " "); got != tt.want {
+			t.Errorf("%s argv = %q, want %q", tt.item.label, got, tt.want)
+		}
+		if !tt.item.confirm || !tt.item.destructive || tt.item.requires != reqExists {
+			t.Errorf("%s is not guarded: %+v", tt.item.label, tt.item)
+		}
+	}
+}
+
+func TestResetConfirmation(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	tests := []struct {
+		name    string
+		keys    []string // after opening the reset submenu
+		pending string   // item in the confirm prompt
+		shows   []string
+		answer  string
+		started string // logged command, "" = none
+	}{
+		{"keep data", []string{"enter"}, resetKeepData.label,
+			[]string{"Kept:   ~/.local/share/Steam"}, "y", "$ hackeros-steam reset"},
+		{"wipe everything", []string{"down", "enter"}, resetWipeAll.label,
+			[]string{"Wiped:  ~/.local/share/Steam", "Cloud saves"}, "y", "$ hackeros-steam reset --wipe-data"},
+		{"declined", []string{"down", "enter"}, resetWipeAll.label, nil, "n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.SafeMode = false
+			m := initialModel()
+			m.width, m.height = 120, 40
+			m.containerStatus = "stopped"
+			m.cursor = itemIndex(t, "Factory Reset")
+			m = press(m, "enter")
+			if m.state != stateSubmenu {
+				t.Fatalf("Factory Reset opened state %v", m.state)
+			}
+			m = press(m, tt.keys...)
+			if m.state != stateConfirm || m.pendingItem == nil || m.pendingItem.label != tt.pending {
+				t.Fatalf("state %v, pending %v; want a confirm for %q", m.state, m.pendingItem, tt.pending)
+			}
+			dialog := m.renderConfirmDialog()
+			for _, s := range tt.shows {
+				if !strings.Contains(dialog, s) {
+					t.Errorf("dialog lacks %q:\n%s", s, dialog)
+				}
+			}
+			m = press(settle(m), tt.answer)
+			if tt.started == "" {
+				if m.busy || countLogged(m, "$ hackeros-steam reset") != 0 {
+					t.Error("declined reset ran")
+				}
+				return
+			}
+			// The keep-data argv is a prefix of the wipe one.
+			if countLogged(m, tt.started) != 1 || (tt.pending == resetKeepData.label && countLogged(m, "--wipe-data") != 0) {
+				t.Errorf("log: %q", plainLogLines(m.logLines, false))
+			}
+		})
+	}
+}
+
+func TestResetBlockedInSafeMode(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	cfg.SafeMode = true
+	m := initialModel()
+	m.containerStatus = "stopped"
+	if reason := m.disabledReason(menuItems[itemIndex(t, "Factory Reset")]); reason == "" {
+		t.Error("Factory Reset allowed in safe mode")
+	}
+	if reason := m.disabledReason(resetWipeAll); reason == "" {
+		t.Error("wipe variant allowed in safe mode")
+	}
+}
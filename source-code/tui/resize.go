@@ -0,0 +1,67 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Resize debounce
+//  Dragging a window edge sends a size message per pixel step, and
+//  each one re-wraps the whole log. A size is applied at once when
+//  the last one was a while ago; sizes arriving quicker than that are
+//  held, and only the latest is applied when the interval is up, so
+//  the final size always lands.
+// ─────────────────────────────────────────────────────────────────
+
+// resizeInterval bounds layout recomputation to five a second.
+const resizeInterval = 200 * time.Millisecond
+
+type resizeFlushMsg struct{}
+
+// handleResize applies msg now or holds it for the pending flush.
+func (m *model) handleResize(msg tea.WindowSizeMsg, now time.Time) tea.Cmd {
+	if !m.sized || (!m.resizeScheduled && now.Sub(m.resizedAt) >= resizeInterval) {
+		m.applySize(msg.Width, msg.Height)
+		m.resizedAt = now
+		return nil
+	}
+	m.pendingSize = &msg
+	if m.resizeScheduled {
+		return nil
+	}
+	m.resizeScheduled = true
+	return tea.Tick(max(resizeInterval-now.Sub(m.resizedAt), 0), func(time.Time) tea.Msg {
+		return resizeFlushMsg{}
+	})
+}
+
+// flushResize applies the latest held size.
+func (m *model) flushResize(now time.Time) {
+	m.resizeScheduled = false
+	if p := m.pendingSize; p != nil {
+		m.pendingSize = nil
+		m.applySize(p.Width, p.Height)
+		m.resizedAt = now
+	}
+}
+
+// applySize recomputes the layout for a new terminal size. Dialogs and
+// the submenu measure themselves from m.width/m.height when drawn.
+func (m *model) applySize(width, height int) {
+	follow := !m.sized || m.logViewport.AtBottom()
+	m.width = width
+	m.height = height
+	m.layoutLog()
+	m.sized = m.width > 0 && m.height > 0
+	if follow {
+		m.flushLog()
+	} else {
+		m.redrawLog()
+	}
+	if m.splitLogs != nil {
+		m.resizeSplit()
+	}
+	m.resizeCrash()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestResizeBurst(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		every   time.Duration
+		layouts int // applySize calls while the burst lasts
+	}{
+		{"single", 1, 0, 1},
+		{"drag", 100, 5 * time.Millisecond, 3},            // 0.5s: now, at 200ms, at 400ms
+		{"same instant", 50, 0, 1},                        // only the first, the rest wait for the tick
+		{"slow", 4, resizeInterval + time.Millisecond, 4}, // each applied at once
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			start := time.Now()
+			m.handleResize(tea.WindowSizeMsg{Width: 100, Height: 30}, start.Add(-time.Hour))
+			layouts := 0
+			width := m.width
+			now := start
+			for i := 0; i < tt.count; i++ {
+				now = start.Add(time.Duration(i) * tt.every)
+				// The tick fires once resizeInterval has passed.
+				if m.resizeScheduled && now.Sub(m.resizedAt) >= resizeInterval {
+					m.flushResize(now)
+				}
+				if m.width != width {
+					layouts++
+					width = m.width
+				}
+				if cmd := m.handleResize(tea.WindowSizeMsg{Width: 120 + i, Height: 40}, now); cmd != nil && !m.resizeScheduled {
+					t.Fatal("tick returned without being scheduled")
+				}
+				if m.width != width {
+					layouts++
+					width = m.width
+				}
+			}
+			if layouts != tt.layouts {
+				t.Errorf("%d layouts for %d sizes, want %d", layouts, tt.count, tt.layouts)
+			}
+			// The tick after the burst applies the last size.
+			m.flushResize(now.Add(resizeInterval))
+			if want := 120 + tt.count - 1; m.width != want || m.height != 40 {
+				t.Errorf("final size %dx%d, want %dx40", m.width, m.height, want)
+			}
+			if m.pendingSize != nil || m.resizeScheduled {
+				t.Error("a size is still held after the flush")
+			}
+		})
+	}
+}
+
+func TestFirstSizeAppliedAtOnce(t *testing.T) {
+	m := initialModel()
+	m.sized, m.width, m.height = false, 0, 0
+	now := time.Now()
+	m.resizedAt = now
+	if cmd := m.handleResize(tea.WindowSizeMsg{Width: 80, Height: 24}, now); cmd != nil || !m.sized || m.width != 80 {
+		t.Errorf("first size held: sized=%v width=%d", m.sized, m.width)
+	}
+}
+
+func TestResizeTickScheduledOnce(t *testing.T) {
+	m := initialModel()
+	now := time.Now()
+	m.handleResize(tea.WindowSizeMsg{Width: 100, Height: 30}, now.Add(-time.Hour))
+	ticks := 0
+	for i := 0; i < 10; i++ {
+		if m.handleResize(tea.WindowSizeMsg{Width: 90 + i, Height: 30}, now.Add(time.Duration(i)*time.Millisecond)) != nil {
+			ticks++
+		}
+	}
+	if ticks != 1 {
+		t.Errorf("%d ticks scheduled, want 1", ticks)
+	}
+	next, _ := m.Update(resizeFlushMsg{})
+	if got := next.(model); got.width != 99 {
+		t.Errorf("width after the flush %d, want 99", got.width)
+	}
+}
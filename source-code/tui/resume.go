@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Resume an interrupted update
+//  When an update is cancelled or fails partway, the user is offered
+//  `update --resume`, which skips distrobox-upgrade if it already ran
+//  and reuses what pacman downloaded. A hackeros-steam too old to know
+//  the flag gets a full update offered instead, and is told so.
+// ─────────────────────────────────────────────────────────────────
+
+// resumeProbeMsg reports whether the installed CLI can resume updates.
+type resumeProbeMsg struct{ supported bool }
+
+// probeResumeCmd looks for --resume in the CLI's help; a CLI that
+// can't be asked counts as unable.
+func probeResumeCmd() tea.Cmd {
+	return func() tea.Msg {
+		out, _ := auditedOutput("Resume Update", hostCommand(commandArgv([]string{"--help"}), nil, false), stdoutOf)
+		return resumeProbeMsg{supported: strings.Contains(stripANSI(string(out)), "update [--resume]")}
+	}
+}
+
+// retryUpdateItem is what is offered after an interrupted update.
+func retryUpdateItem(resumable bool) menuItem {
+	if resumable {
+		return menuItem{
+			icon: "↻", label: "Resume Update", cmd: []string{"update", "--resume"}, requires: reqExists, confirm: true, online: true,
+			warning: []string{
+				"The update was interrupted.",
+				"Resuming skips the steps that already finished and",
+				"keeps the packages that were downloaded.",
+			},
+		}
+	}
+	return menuItem{
+		icon: "↻", label: "Update Container", cmd: []string{"update"}, requires: reqExists, confirm: true, online: true,
+		warning: []string{
+			"The update was interrupted.",
+			"This hackeros-steam can't resume updates, so it",
+			"will start over from the beginning.",
+		},
+	}
+}
+
+// handleResumeProbe offers to retry the update that just failed,
+// unless something else happened in the meantime.
+func (m *model) handleResumeProbe(msg resumeProbeMsg) tea.Cmd {
+	if m.busy || m.state != stateMenu {
+		return nil
+	}
+	if !msg.supported {
+		m.appendLog(styleLogDim.Render("  hackeros-steam can't resume updates; a retry runs the whole update again."))
+	}
+	return m.openPrompt(stateConfirm, retryUpdateItem(msg.supported))
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRetryUpdateItem(t *testing.T) {
+	tests := []struct {
+		resumable bool
+		label     string
+		argv      string
+		warning   string
+	}{
+		{true, "Resume Update", cli + " update --resume", "skips the steps that already finished"},
+		{false, "Update Container", cli + " update", "will start over from the beginning"},
+	}
+	for _, tt := range tests {
+		item := retryUpdateItem(tt.resumable)
+		if item.label != tt.label || strings.Join(commandArgv(item.cmd), " ") != tt.argv || !item.confirm {
+			t.Errorf("resumable=%v: %q %q confirm=%v", tt.resumable, item.label, item.cmd, item.confirm)
+		}
+		if !strings.Contains(strings.Join(item.warning, " "), tt.warning) {
+			t.Errorf("resumable=%v: warning %q", tt.resumable, item.warning)
+		}
+		if !isUpdateItem(&item) {
+			t.Errorf("resumable=%v: not an update item", tt.resumable)
+		}
+	}
+}
+
+func TestHandleResumeProbe(t *testing.T) {
+	tests := []struct {
+		name      string
+		supported bool
+		busy      bool
+		state     viewState
+		offered   string // label of the prompt, "" = none
+	}{
+		{"resumable", true, false, stateMenu, "Resume Update"},
+		{"old CLI", false, false, stateMenu, "Update Container"},
+		{"something else started", true, true, stateRunning, ""},
+		{"another prompt is open", true, false, stateEscalate, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.busy, m.state = tt.busy, tt.state
+			m.handleResumeProbe(resumeProbeMsg{supported: tt.supported})
+			if tt.offered == "" {
+				if m.state != tt.state || (m.pendingItem != nil && tt.state == stateMenu) {
+					t.Errorf("state %v, pending %v", m.state, m.pendingItem)
+				}
+				return
+			}
+			if m.state != stateConfirm || m.pendingItem == nil || m.pendingItem.label != tt.offered {
+				t.Fatalf("state %v, pending %v", m.state, m.pendingItem)
+			}
+			if told := countLogged(m, "can't resume updates") == 1; told == tt.supported {
+				t.Errorf("fallback note logged = %v", told)
+			}
+		})
+	}
+}
+
+// A failed update asks the CLI whether it can resume; accepting the
+// offer runs update --resume.
+func TestResumeAfterFailedUpdate(t *testing.T) {
+	m := initialModel()
+	m.containerStatus = "running"
+	update := menuItems[itemIndex(t, "Update Container")]
+	m.busy, m.state, m.lastItem = true, stateRunning, &update
+	m.beginCapture()
+	m.runOutput = []string{"error: failed retrieving file 'steam.pkg.tar.zst'"}
+	next, cmd := m.Update(cmdDoneMsg(false))
+	m = next.(model)
+	if m.state != stateMenu || cmd == nil {
+		t.Fatalf("state %v, cmd %v", m.state, cmd)
+	}
+
+	m.handleResumeProbe(resumeProbeMsg{supported: true})
+	m = press(settle(m), "y")
+	if countLogged(m, "$ hackeros-steam update --resume") != 1 {
+		t.Errorf("log %q", plainLogLines(m.logLines, false))
+	}
+}
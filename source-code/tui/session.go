@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Play sessions
+//  Launching a game from the TUI starts a session. Steam runs every
+//  game under a launcher whose command line carries AppId=<id>; the
+//  container's processes are visible from the host, so /proc shows when
+//  the game is up and when it has gone. The time in between is shown in
+//  the header and added to the game's total in playtime.toml.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	playtimeFileName = "playtime.toml"
+	sessionTick      = time.Second
+	sessionProbe     = 5 * time.Second // how often /proc is scanned
+	sessionStartWait = 5 * time.Minute // give up if the game never shows
+)
+
+type playSession struct {
+	appID    string
+	name     string
+	launched time.Time
+	started  time.Time // first seen running; zero while starting
+	probed   time.Time
+}
+
+type sessionTickMsg struct{}
+
+// sessionEndedMsg reports a finished session and the game's new total.
+type sessionEndedMsg struct {
+	name     string
+	played   time.Duration
+	total    time.Duration
+	err      error
+	timedOut bool // the game never appeared
+}
+
+type playtimeFile struct {
+	Seconds map[string]int64 `toml:"seconds"` // by AppID
+}
+
+func playtimePath() string {
+	return filepath.Join(stateDir(), playtimeFileName)
+}
+
+// loadPlaytime reads the totals; a missing file is not an error.
+func loadPlaytime(path string) (map[string]int64, error) {
+	var f playtimeFile
+	if _, err := toml.DecodeFile(path, &f); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("playtime %s: %w", path, err)
+	}
+	if f.Seconds == nil {
+		f.Seconds = map[string]int64{}
+	}
+	return f.Seconds, nil
+}
+
+// addPlaytime adds d to appID's total in path and returns the new total.
+func addPlaytime(path, appID string, d time.Duration) (time.Duration, error) {
+	totals, err := loadPlaytime(path)
+	if err != nil {
+		return 0, err
+	}
+	totals[appID] += int64(d / time.Second)
+	var b bytes.Buffer
+	if err := toml.NewEncoder(&b).Encode(playtimeFile{Seconds: totals}); err != nil {
+		return 0, err
+	}
+	return time.Duration(totals[appID]) * time.Second, writeFileAtomic(path, b.Bytes(), 0o644)
+}
+
+// launchedAppID returns the game item starts, if it launches one.
+func launchedAppID(item menuItem) (string, bool) {
+	for i, arg := range item.cmd {
+		if arg == "-applaunch" && i+1 < len(item.cmd) {
+			return item.cmd[i+1], true
+		}
+	}
+	return "", false
+}
+
+// gameRunning reports whether a process under proc was started by
+// Steam for appID.
+func gameRunning(proc, appID string) bool {
+	want := []byte("AppId=" + appID + "\x00")
+	dirs, _ := filepath.Glob(filepath.Join(proc, "[0-9]*", "cmdline"))
+	for _, path := range dirs {
+		b, err := os.ReadFile(path)
+		if err == nil && bytes.Contains(append(b, 0), want) {
+			return true
+		}
+	}
+	return false
+}
+
+// startSession begins timing item's game, replacing any earlier session
+// that never got going.
+func (m *model) startSession(item menuItem) tea.Cmd {
+	appID, ok := launchedAppID(item)
+	if !ok || remoteHost != "" {
+		return nil
+	}
+	if m.session != nil && !m.session.started.IsZero() {
+		return nil // one game at a time; the running one keeps its session
+	}
+	first := m.session == nil
+	m.session = &playSession{appID: appID, name: strings.TrimPrefix(item.label, "Launch "), launched: time.Now()}
+	if first {
+		return sessionTickCmd()
+	}
+	return nil
+}
+
+func sessionTickCmd() tea.Cmd {
+	return tea.Tick(sessionTick, func(time.Time) tea.Msg { return sessionTickMsg{} })
+}
+
+// handleSessionTick redraws the timer and, every sessionProbe, checks
+// whether the game is still running.
+func (m *model) handleSessionTick() tea.Cmd {
+	s := m.session
+	if s == nil {
+		return nil
+	}
+	now := time.Now()
+	if now.Sub(s.probed) < sessionProbe {
+		return sessionTickCmd()
+	}
+	s.probed = now
+	running := m.containerStatus == "running" && gameRunning("/proc", s.appID)
+	switch {
+	case running && s.started.IsZero():
+		s.started = now
+		m.recordStatus(s.name + ": session started")
+	case !running && !s.started.IsZero():
+		return m.endSession(now)
+	case !running && now.Sub(s.launched) > sessionStartWait:
+		m.session = nil
+		return func() tea.Msg { return sessionEndedMsg{name: s.name, timedOut: true} }
+	}
+	return sessionTickCmd()
+}
+
+// endSession records the session that ran until now.
+func (m *model) endSession(now time.Time) tea.Cmd {
+	s := m.session
+	m.session = nil
+	if s == nil || s.started.IsZero() {
+		return nil
+	}
+	played := now.Sub(s.started)
+	return func() tea.Msg {
+		total, err := addPlaytime(playtimePath(), s.appID, played)
+		return sessionEndedMsg{name: s.name, played: played, total: total, err: err}
+	}
+}
+
+func (m *model) handleSessionEnded(msg sessionEndedMsg) {
+	switch {
+	case msg.timedOut:
+		m.appendLog(styleLogDim.Render("  " + msg.name + " didn't start within " + sessionStartWait.String() + "; not timing it."))
+	case msg.err != nil:
+		m.logError("Could not save playtime: " + msg.err.Error())
+	default:
+		m.appendLog(styleLogInfo.Render(fmt.Sprintf("  ⏱  %s: played %s, %s in total",
+			msg.name, formatClock(msg.played), formatPlaytime(msg.total))))
+		m.recordStatus(msg.name + ": session ended after " + formatClock(msg.played))
+	}
+}
+
+// sessionHeader is the header timer, "" without a session.
+func (m model) sessionHeader() string {
+	s := m.session
+	switch {
+	case s == nil:
+		return ""
+	case s.started.IsZero():
+		return "▶ " + truncate(s.name, 24) + " starting…"
+	}
+	return "▶ " + truncate(s.name, 24) + " " + formatClock(time.Since(s.started))
+}
+
+// formatClock renders d as 1:02:03.
+func formatClock(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// formatPlaytime renders a total as "12h 03m".
+func formatPlaytime(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddPlaytimeAccumulates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", playtimeFileName)
+	steps := []struct {
+		appID  string
+		played time.Duration
+		total  time.Duration
+	}{
+		{"570", 90 * time.Second, 90 * time.Second},
+		{"570", 30*time.Minute + 400*time.Millisecond, 31*time.Minute + 30*time.Second},
+		{"620", time.Hour, time.Hour},
+		{"570", 0, 31*time.Minute + 30*time.Second},
+	}
+	for i, s := range steps {
+		total, err := addPlaytime(path, s.appID, s.played)
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if total != s.total {
+			t.Errorf("step %d: total %v, want %v", i, total, s.total)
+		}
+	}
+	totals, err := loadPlaytime(path)
+	if err != nil || totals["570"] != 1890 || totals["620"] != 3600 {
+		t.Errorf("loadPlaytime = %v, %v", totals, err)
+	}
+
+	if totals, err := loadPlaytime(filepath.Join(t.TempDir(), "none.toml")); err != nil || len(totals) != 0 {
+		t.Errorf("missing file: %v, %v", totals, err)
+	}
+	os.WriteFile(path, []byte("seconds = [\n"), 0o644)
+	if _, err := addPlaytime(path, "570", time.Minute); err == nil {
+		t.Error("malformed file was overwritten")
+	}
+}
+
+func TestLaunchedAppID(t *testing.T) {
+	tests := []struct {
+		cmd  []string
+		want string
+		ok   bool
+	}{
+		{[]string{"run", "-applaunch", "570"}, "570", true},
+		{[]string{"run", "-gamepadui", "-applaunch", "620"}, "620", true},
+		{[]string{"run"}, "", false},
+		{[]string{"run", "-applaunch"}, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := launchedAppID(menuItem{cmd: tt.cmd})
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("launchedAppID(%q) = %q, %v", tt.cmd, got, ok)
+		}
+	}
+}
+
+func TestGameRunning(t *testing.T) {
+	proc := t.TempDir()
+	procs := map[string]string{
+		"1":    "/sbin/init\x00",
+		"4242": "/home/u/.steam/ubuntu12_32/reaper\x00SteamLaunch\x00AppId=570\x00--\x00dota2\x00",
+		"4300": "/bin/sh\x00-c\x00echo AppId=5700\x00",
+		"self": "AppId=620\x00", // not a pid
+	}
+	for pid, cmdline := range procs {
+		os.MkdirAll(filepath.Join(proc, pid), 0o755)
+		os.WriteFile(filepath.Join(proc, pid, "cmdline"), []byte(cmdline), 0o644)
+	}
+	tests := []struct {
+		appID string
+		want  bool
+	}{
+		{"570", true},
+		{"57", false},
+		{"620", false},
+		{"440", false},
+	}
+	for _, tt := range tests {
+		if got := gameRunning(proc, tt.appID); got != tt.want {
+			t.Errorf("gameRunning(%q) = %v, want %v", tt.appID, got, tt.want)
+		}
+	}
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	defer func(saved string) { remoteHost = saved }(remoteHost)
+	remoteHost = ""
+	item := menuItem{label: "Launch Dota 2", cmd: []string{"run", "-applaunch", "570"}}
+
+	m := initialModel()
+	if cmd := m.startSession(menuItem{label: "Launch Steam", cmd: []string{"run"}}); cmd != nil || m.session != nil {
+		t.Fatal("Steam itself started a session")
+	}
+	if cmd := m.startSession(item); cmd == nil || m.session == nil || m.session.name != "Dota 2" {
+		t.Fatalf("session = %+v", m.session)
+	}
+	if h := m.sessionHeader(); h != "▶ Dota 2 starting…" {
+		t.Errorf("starting header = %q", h)
+	}
+
+	// Between probes the timer just redraws.
+	m.session.probed = time.Now()
+	if cmd := m.handleSessionTick(); cmd == nil || m.session == nil {
+		t.Fatal("tick between probes ended the session")
+	}
+
+	// The game came up ten minutes ago and has now exited.
+	m.session.started = time.Now().Add(-10 * time.Minute)
+	m.session.probed = time.Time{}
+	if !strings.HasPrefix(m.sessionHeader(), "▶ Dota 2 0:10:0") {
+		t.Errorf("running header = %q", m.sessionHeader())
+	}
+	m.containerStatus = "stopped"
+	cmd := m.handleSessionTick()
+	if m.session != nil || cmd == nil {
+		t.Fatalf("exit not noticed: session %+v", m.session)
+	}
+	ended := cmd().(sessionEndedMsg)
+	if ended.err != nil || ended.played < 10*time.Minute || ended.total != ended.played.Truncate(time.Second) {
+		t.Errorf("ended = %+v", ended)
+	}
+	m.handleSessionEnded(ended)
+	if countLogged(m, "Dota 2: played 0:10:0") != 1 {
+		t.Errorf("log: %q", plainLogLines(m.logLines, false))
+	}
+	if m.sessionHeader() != "" {
+		t.Error("header still shows a session")
+	}
+}
+
+func TestSessionStartTimeout(t *testing.T) {
+	m := initialModel()
+	m.session = &playSession{appID: "570", name: "Dota 2", launched: time.Now().Add(-sessionStartWait - time.Second)}
+	cmd := m.handleSessionTick()
+	if m.session != nil || cmd == nil {
+		t.Fatal("session kept waiting")
+	}
+	msg := cmd().(sessionEndedMsg)
+	if !msg.timedOut {
+		t.Fatalf("msg = %+v", msg)
+	}
+	m.handleSessionEnded(msg)
+	if countLogged(m, "didn't start within") != 1 {
+		t.Error("timeout not logged")
+	}
+}
+
+func TestSessionKeepsRunningGame(t *testing.T) {
+	m := initialModel()
+	started := time.Now().Add(-time.Minute)
+	m.session = &playSession{appID: "570", name: "Dota 2", started: started}
+	m.startSession(menuItem{label: "Launch Portal 2", cmd: []string{"run", "-applaunch", "620"}})
+	if m.session.appID != "570" || m.session.started != started {
+		t.Errorf("second launch replaced the running session: %+v", m.session)
+	}
+}
+
+func TestFormatPlaytime(t *testing.T) {
+	tests := []struct {
+		d     time.Duration
+		clock string
+		total string
+	}{
+		{0, "0:00:00", "0m"},
+		{59*time.Second + 600*time.Millisecond, "0:01:00", "0m"},
+		{62 * time.Minute, "1:02:00", "1h 02m"},
+		{12*time.Hour + 3*time.Minute + 4*time.Second, "12:03:04", "12h 03m"},
+	}
+	for _, tt := range tests {
+		if got := formatClock(tt.d); got != tt.clock {
+			t.Errorf("formatClock(%v) = %q, want %q", tt.d, got, tt.clock)
+		}
+		if got := formatPlaytime(tt.d); got != tt.total {
+			t.Errorf("formatPlaytime(%v) = %q, want %q", tt.d, got, tt.total)
+		}
+	}
+}
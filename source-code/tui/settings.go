@@ -0,0 +1,443 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Settings transfer
+//  "Export Settings" puts the TUI's own files — config.toml with its
+//  shortcuts, launch.toml, favorites.toml, container.env, the
+//  container name and the playtime totals — into one TOML file in
+//  log_dir. "Import Settings" reads such a file on another machine:
+//  every file in it is checked before anything is written, the files
+//  it replaces are copied to before-import/ in the state directory
+//  first, and playtime is merged rather than replaced. Files the export
+//  didn't have are left alone. config.toml is replaced whole, so the
+//  confirmation spells out every hook, privilege command or safeguard
+//  the new one changes.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	settingsFormat  = "hackeros-steam-settings"
+	settingsVersion = 1
+)
+
+// settingsFile is a file an export carries.
+type settingsFile struct {
+	name  string
+	path  string
+	check func(data []byte) error // rejects content the TUI can't load
+	merge bool                    // merged into the live file, not replacing it
+}
+
+// settingsBundle is the exported file.
+type settingsBundle struct {
+	Format   string            `toml:"format"`
+	Version  int               `toml:"version"`
+	Exported time.Time         `toml:"exported"`
+	Files    map[string]string `toml:"files"`
+}
+
+var (
+	importSubmit = key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "import"))
+	importCancel = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel"))
+)
+
+func settingsFiles() []settingsFile {
+	decodes := func(v any) func([]byte) error {
+		return func(data []byte) error {
+			_, err := toml.Decode(string(data), v)
+			return err
+		}
+	}
+	return []settingsFile{
+		{name: configFileName, path: configPath(), check: decodes(&config{})},
+		{name: launchFileName, path: launchPath(), check: decodes(&launchSettings{})},
+		{name: favoritesFileName, path: favoritesPath(), check: decodes(&favoritesFile{})},
+		{name: containerEnvFileName, path: containerEnvPath(), check: func(data []byte) error {
+			_, err := parseEnv(bytes.NewReader(data), containerEnvFileName)
+			return err
+		}},
+		{name: containerNameFileName, path: containerNamePath(), check: func(data []byte) error {
+			name := strings.TrimSpace(string(data))
+			if !reContainerName.MatchString(name) || len(name) > maxContainerName {
+				return fmt.Errorf("%q is not a valid container name", name)
+			}
+			return nil
+		}},
+		{name: playtimeFileName, path: playtimePath(), check: decodes(&playtimeFile{}), merge: true},
+	}
+}
+
+func settingsBackupDir() string {
+	return filepath.Join(stateDir(), "before-import")
+}
+
+// buildSettingsBundle collects the files that exist.
+func buildSettingsBundle(files []settingsFile, at time.Time) ([]byte, error) {
+	bundle := settingsBundle{Format: settingsFormat, Version: settingsVersion, Exported: at, Files: map[string]string{}}
+	for _, f := range files {
+		data, err := os.ReadFile(f.path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		bundle.Files[f.name] = string(data)
+	}
+	var b bytes.Buffer
+	b.WriteString("# HackerOS Steam TUI settings; load with Import Settings.\n")
+	if err := toml.NewEncoder(&b).Encode(bundle); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// parseSettingsBundle reads an export and checks every file in it.
+func parseSettingsBundle(data []byte, files []settingsFile) (settingsBundle, error) {
+	var bundle settingsBundle
+	if _, err := toml.Decode(string(data), &bundle); err != nil {
+		return bundle, fmt.Errorf("not a settings export: %w", err)
+	}
+	switch {
+	case bundle.Format != settingsFormat:
+		return bundle, errors.New("not a settings export (no format = \"" + settingsFormat + "\")")
+	case bundle.Version > settingsVersion:
+		return bundle, fmt.Errorf("exported by a newer hackeros-steam (version %d); this one reads version %d", bundle.Version, settingsVersion)
+	case bundle.Version < 1:
+		return bundle, fmt.Errorf("unknown settings version %d", bundle.Version)
+	case len(bundle.Files) == 0:
+		return bundle, errors.New("the export holds no files")
+	}
+	known := map[string]settingsFile{}
+	for _, f := range files {
+		known[f.name] = f
+	}
+	for name, content := range bundle.Files {
+		f, ok := known[name]
+		if !ok {
+			return bundle, fmt.Errorf("unknown file %q in the export", name)
+		}
+		if err := f.check([]byte(content)); err != nil {
+			return bundle, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return bundle, nil
+}
+
+// settingsChanges lists, in files order, the files an import of bundle
+// would write because they are missing or differ.
+func settingsChanges(bundle settingsBundle, files []settingsFile) (replaced, merged []string) {
+	for _, f := range files {
+		content, ok := bundle.Files[f.name]
+		if !ok {
+			continue
+		}
+		if data, err := os.ReadFile(f.path); err == nil && string(data) == content {
+			continue
+		}
+		if f.merge {
+			merged = append(merged, f.name)
+		} else {
+			replaced = append(replaced, f.name)
+		}
+	}
+	return replaced, merged
+}
+
+// riskyConfigKeys are the config.toml keys that run commands or turn a
+// safeguard off. An import spells out the ones it changes, since a
+// replaced config.toml takes effect unseen on the next start.
+var riskyConfigKeys = []string{"privilege_cmd", "hooks", "autorun", "safe_mode", "audit", "audit_log"}
+
+// riskyConfigChanges lists how imported changes live's risky keys, one
+// line per key or hook: `hooks.pre_update = "make backup"`.
+func riskyConfigChanges(live, imported string) []string {
+	var from, to map[string]any
+	toml.Decode(live, &from)
+	toml.Decode(imported, &to)
+	var out []string
+	change := func(key string, was, now any, wasSet, nowSet bool) {
+		switch {
+		case !nowSet && wasSet:
+			out = append(out, key+" is removed")
+		case nowSet && (!wasSet || fmt.Sprint(was) != fmt.Sprint(now)):
+			out = append(out, key+" = "+tomlValue(now))
+		}
+	}
+	for _, key := range riskyConfigKeys {
+		was, wasSet := from[key]
+		now, nowSet := to[key]
+		wasTable, _ := was.(map[string]any)
+		nowTable, _ := now.(map[string]any)
+		if wasTable == nil && nowTable == nil {
+			change(key, was, now, wasSet, nowSet)
+			continue
+		}
+		names := map[string]bool{}
+		for name := range wasTable {
+			names[name] = true
+		}
+		for name := range nowTable {
+			names[name] = true
+		}
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+		for _, name := range sorted {
+			w, ws := wasTable[name]
+			n, ns := nowTable[name]
+			change(key+"."+name, w, n, ws, ns)
+		}
+	}
+	return out
+}
+
+// tomlValue writes v the way config.toml spells it.
+func tomlValue(v any) string {
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
+
+// mergePlaytime keeps the larger total of each game.
+func mergePlaytime(live, imported map[string]int64) map[string]int64 {
+	out := map[string]int64{}
+	for id, s := range live {
+		out[id] = s
+	}
+	for id, s := range imported {
+		out[id] = max(out[id], s)
+	}
+	return out
+}
+
+// applySettingsBundle writes bundle over files, copying each live file
+// it changes into backup first.
+func applySettingsBundle(bundle settingsBundle, files []settingsFile, backup string) error {
+	for _, f := range files {
+		content, ok := bundle.Files[f.name]
+		if !ok {
+			continue
+		}
+		data := []byte(content)
+		live, err := os.ReadFile(f.path)
+		switch {
+		case err == nil && bytes.Equal(live, data):
+			continue
+		case err == nil:
+			if err := writeFileAtomic(filepath.Join(backup, f.name), live, 0o600); err != nil {
+				return fmt.Errorf("backing up %s: %w", f.name, err)
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+		if f.merge {
+			var in, cur playtimeFile
+			toml.Decode(content, &in)
+			toml.Decode(string(live), &cur)
+			var b bytes.Buffer
+			if err := toml.NewEncoder(&b).Encode(playtimeFile{Seconds: mergePlaytime(cur.Seconds, in.Seconds)}); err != nil {
+				return err
+			}
+			data = b.Bytes()
+		}
+		perm := os.FileMode(0o644)
+		if f.name == containerEnvFileName {
+			perm = 0o600
+		}
+		if err := writeFileAtomic(f.path, data, perm); err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// exportSettings is the "Export Settings" action.
+func (m *model) exportSettings() tea.Cmd {
+	now := time.Now()
+	data, err := buildSettingsBundle(settingsFiles(), now)
+	if err != nil {
+		m.logError("Settings export failed: " + err.Error())
+		return nil
+	}
+	// The bundle can hold container.env, which is kept 0600 too.
+	f, err := createExportFile(cfg.LogDir, "hackeros-steam-settings-"+now.Format("20060102-150405")+".toml", 0o600)
+	if err == nil {
+		_, err = f.Write(data)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}
+	if err != nil {
+		m.logError("Settings export failed: " + err.Error())
+		return nil
+	}
+	m.appendLog(styleLogSuccess.Render("  ✔  Settings exported to " + tildePath(f.Name())))
+	if len(persistentEnv) > 0 {
+		m.appendLog(styleLogWarning.Render("  ⚠  It includes container.env; keep it private if that holds tokens."))
+	}
+	return m.showToast("✔ Settings exported")
+}
+
+// latestSettingsExport is the newest export in dir, "" when there is
+// none.
+func latestSettingsExport(dir string) string {
+	paths, _ := filepath.Glob(filepath.Join(dir, "hackeros-steam-settings-*.toml"))
+	sort.Strings(paths)
+	if len(paths) == 0 {
+		return ""
+	}
+	return paths[len(paths)-1]
+}
+
+// expandHome undoes tildePath.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// openImport is the "Import Settings" action.
+func (m *model) openImport() tea.Cmd {
+	m.importInput, m.importErr = "", ""
+	if path := latestSettingsExport(cfg.LogDir); path != "" {
+		m.importInput = tildePath(path)
+	}
+	m.state = stateImport
+	return nil
+}
+
+func (m *model) handleImportKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, importSubmit):
+		return m.readImport()
+	case key.Matches(msg, importCancel):
+		m.state = stateMenu
+	case msg.Type == tea.KeyBackspace:
+		if r := []rune(m.importInput); len(r) > 0 {
+			m.importInput = string(r[:len(r)-1])
+		}
+		m.importErr = ""
+	case msg.Type == tea.KeyRunes:
+		m.importInput += string(msg.Runes)
+		m.importErr = ""
+	}
+	return nil
+}
+
+// readImport checks the file typed in and asks before it is applied.
+func (m *model) readImport() tea.Cmd {
+	path := expandHome(strings.TrimSpace(m.importInput))
+	if path == "" {
+		m.importErr = "enter the path of an exported settings file"
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.importErr = err.Error()
+		return nil
+	}
+	files := settingsFiles()
+	bundle, err := parseSettingsBundle(data, files)
+	if err != nil {
+		m.importErr = err.Error()
+		return nil
+	}
+	replaced, merged := settingsChanges(bundle, files)
+	if len(replaced) == 0 && len(merged) == 0 {
+		m.state = stateMenu
+		return m.showToast("These settings are already in place")
+	}
+	warning := []string{"From " + filepath.Base(path) + ", exported " + bundle.Exported.Local().Format("2006-01-02 15:04") + "."}
+	if len(replaced) > 0 {
+		warning = append(warning, "Overwrites "+strings.Join(replaced, ", ")+".")
+	}
+	if content, ok := bundle.Files[configFileName]; ok {
+		live, _ := os.ReadFile(configPath())
+		if risky := riskyConfigChanges(string(live), content); len(risky) > 0 {
+			warning = append(warning, "config.toml changes what runs on the next start:")
+			for _, line := range risky {
+				warning = append(warning, "  "+line)
+			}
+		}
+	}
+	if len(merged) > 0 {
+		warning = append(warning, "Merges "+strings.Join(merged, ", ")+", keeping each game's larger total.")
+	}
+	warning = append(warning, "The current files are kept in "+tildePath(settingsBackupDir())+".")
+	m.state = stateMenu
+	return m.openPrompt(stateConfirm, menuItem{
+		label:   "Import Settings",
+		run:     importSettingsAction(bundle),
+		warning: warning,
+	})
+}
+
+func importSettingsAction(bundle settingsBundle) func(m *model) tea.Cmd {
+	return func(m *model) tea.Cmd {
+		if err := applySettingsBundle(bundle, settingsFiles(), settingsBackupDir()); err != nil {
+			m.logError("Settings import stopped: " + err.Error())
+			return nil
+		}
+		if s, err := loadLaunch(launchPath()); err == nil {
+			launch = s
+		}
+		if vars, err := readEnvFile(containerEnvPath()); err == nil {
+			persistentEnv = vars
+		}
+		if favs, err := loadFavorites(favoritesPath()); err == nil {
+			favorites = favs
+		}
+		m.refreshLaunchSummary()
+		m.appendLog(styleLogSuccess.Render("  ✔  Settings imported."))
+		m.appendLog(styleLogDim.Render("  config.toml and the container name take effect the next time the TUI starts."))
+		return m.showToast("✔ Settings imported")
+	}
+}
+
+func (m model) renderImportDialog() string {
+	lines := []string{
+		lipgloss.NewStyle().Foreground(colAccent).Bold(true).Render("⇪  Import Settings"),
+		"",
+		lipgloss.NewStyle().Foreground(colText).Render("Settings file exported by Export Settings:"),
+		lipgloss.NewStyle().Foreground(colAccent).Render("> ") +
+			lipgloss.NewStyle().Foreground(colText).Render(m.importInput) +
+			lipgloss.NewStyle().Foreground(colCursor).Render("▏"),
+	}
+	if m.importErr != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colRed).Render(m.importErr))
+	}
+	lines = append(lines,
+		"",
+		lipgloss.NewStyle().Foreground(colGreen).Bold(true).Render("[Enter]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("check and import")+"   "+
+			lipgloss.NewStyle().Foreground(colRed).Bold(true).Render("[Esc]")+" "+
+			lipgloss.NewStyle().Foreground(colText).Render("cancel"),
+	)
+	return m.placeOverlay(styleConfirmBox, lipgloss.JoinVertical(lipgloss.Center, lines...))
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// settingsIn is settingsFiles moved into dir.
+func settingsIn(dir string) []settingsFile {
+	files := settingsFiles()
+	for i := range files {
+		files[i].path = filepath.Join(dir, files[i].name)
+	}
+	return files
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSettingsRoundTrip(t *testing.T) {
+	from, to, backup := t.TempDir(), t.TempDir(), filepath.Join(t.TempDir(), "before-import")
+	writeFiles(t, from, map[string]string{
+		configFileName:        "layout = \"grid\"\n",
+		launchFileName:        "gpu = \"0000:01:00.0\"\n",
+		containerEnvFileName:  "PROTON_LOG=1\n",
+		containerNameFileName: "steam-box\n",
+		playtimeFileName:      "[seconds]\n570 = 100\n620 = 50\n",
+	})
+	writeFiles(t, to, map[string]string{
+		configFileName:   "layout = \"list\"\n",
+		playtimeFileName: "[seconds]\n570 = 300\n440 = 10\n",
+	})
+
+	data, err := buildSettingsBundle(settingsIn(from), time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("buildSettingsBundle: %v", err)
+	}
+	bundle, err := parseSettingsBundle(data, settingsIn(to))
+	if err != nil {
+		t.Fatalf("parseSettingsBundle: %v\n%s", err, data)
+	}
+	if _, ok := bundle.Files[favoritesFileName]; ok || len(bundle.Files) != 5 {
+		t.Errorf("exported %d files, want the 5 that exist", len(bundle.Files))
+	}
+
+	replaced, merged := settingsChanges(bundle, settingsIn(to))
+	if want := []string{configFileName, launchFileName, containerEnvFileName, containerNameFileName}; !slices.Equal(replaced, want) {
+		t.Errorf("replaced = %q, want %q", replaced, want)
+	}
+	if !slices.Equal(merged, []string{playtimeFileName}) {
+		t.Errorf("merged = %q", merged)
+	}
+
+	if err := applySettingsBundle(bundle, settingsIn(to), backup); err != nil {
+		t.Fatalf("applySettingsBundle: %v", err)
+	}
+	for name, want := range map[string]string{
+		configFileName:        "layout = \"grid\"\n",
+		containerNameFileName: "steam-box\n",
+	} {
+		if got, _ := os.ReadFile(filepath.Join(to, name)); string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	var pt playtimeFile
+	if _, err := toml.DecodeFile(filepath.Join(to, playtimeFileName), &pt); err != nil {
+		t.Fatal(err)
+	}
+	if len(pt.Seconds) != 3 || pt.Seconds["570"] != 300 || pt.Seconds["620"] != 50 || pt.Seconds["440"] != 10 {
+		t.Errorf("merged playtime %v, want 570:300 620:50 440:10", pt.Seconds)
+	}
+	if got, _ := os.ReadFile(filepath.Join(backup, configFileName)); string(got) != "layout = \"list\"\n" {
+		t.Errorf("backup of config.toml = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(backup, launchFileName)); err == nil {
+		t.Error("backed up launch.toml, which didn't exist before")
+	}
+
+	if replaced, merged := settingsChanges(bundle, settingsIn(to)); len(replaced) != 0 || len(merged) != 1 {
+		t.Errorf("after the import: replaced %q merged %q", replaced, merged)
+	}
+}
+
+func TestExportSettingsIsPrivate(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	cfg.LogDir = filepath.Join(home, "logs")
+	mkdirs(t, filepath.Dir(containerEnvPath()))
+	if err := writeEnvFile(containerEnvPath(), []envVar{{"STEAM_TOKEN", "s3cret"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := initialModel()
+	m.exportSettings()
+	matches, _ := filepath.Glob(filepath.Join(cfg.LogDir, "hackeros-steam-settings-*.toml"))
+	if len(matches) != 1 {
+		t.Fatalf("exports %q", matches)
+	}
+	fi, err := os.Stat(matches[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0o600 {
+		t.Errorf("export mode %v, want 0600", fi.Mode().Perm())
+	}
+}
+
+func TestParseSettingsBundle(t *testing.T) {
+	header := "format = \"" + settingsFormat + "\"\n"
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"valid", header + "version = 1\n[files]\n\"launch.toml\" = \"gpu = \\\"x\\\"\"\n", ""},
+		{"not toml", "{", "not a settings export"},
+		{"other toml", "layout = \"grid\"\n", "no format"},
+		{"newer version", header + "version = 2\n[files]\n\"launch.toml\" = \"\"\n", "newer hackeros-steam (version 2)"},
+		{"no version", header + "[files]\n\"launch.toml\" = \"\"\n", "unknown settings version 0"},
+		{"empty", header + "version = 1\n", "holds no files"},
+		{"unknown file", header + "version = 1\n[files]\n\"secrets.txt\" = \"\"\n", "unknown file \"secrets.txt\""},
+		{"bad content", header + "version = 1\n[files]\n\"launch.toml\" = \"gpu = [\"\n", "launch.toml:"},
+		{"bad container name", header + "version = 1\n[files]\n\"container.name\" = \"-x\"\n", "not a valid container name"},
+		{"bad env", header + "version = 1\n[files]\n\"container.env\" = \"nope\"\n", "expected NAME=value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSettingsBundle([]byte(tt.data), settingsFiles())
+			switch {
+			case tt.err == "" && err != nil:
+				t.Errorf("error %v, want none", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Errorf("error %v, want one containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestRiskyConfigChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		live     string
+		imported string
+		want     []string
+	}{
+		{"layout only", "layout = \"list\"\n", "layout = \"grid\"\n", nil},
+		{"same hooks", "[hooks]\npre_update = \"true\"\n", "[hooks]\npre_update = \"true\"\n", nil},
+		{"privilege command", "", "privilege_cmd = \"sh -c 'curl x | sh'\"\n",
+			[]string{`privilege_cmd = "sh -c 'curl x | sh'"`}},
+		{"hooks", "[hooks]\npre_update = \"true\"\npost_run = \"notify\"\n", "[hooks]\npre_update = \"make backup\"\npre_run = \"id\"\n",
+			[]string{`hooks.post_run is removed`, `hooks.pre_run = "id"`, `hooks.pre_update = "make backup"`}},
+		{"safeguards off", "safe_mode = true\naudit = true\n", "safe_mode = false\n",
+			[]string{"safe_mode = false", "audit is removed"}},
+		{"autorun", "", "autorun = \"Remove Container\"\n", []string{`autorun = "Remove Container"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := riskyConfigChanges(tt.live, tt.imported); !slices.Equal(got, tt.want) {
+				t.Errorf("riskyConfigChanges = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePlaytime(t *testing.T) {
+	got := mergePlaytime(map[string]int64{"1": 10, "2": 50}, map[string]int64{"2": 20, "3": 5})
+	if len(got) != 3 || got["1"] != 10 || got["2"] != 50 || got["3"] != 5 {
+		t.Errorf("mergePlaytime = %v", got)
+	}
+	if got := mergePlaytime(nil, nil); len(got) != 0 {
+		t.Errorf("mergePlaytime(nil, nil) = %v", got)
+	}
+}
+
+func TestLatestSettingsExport(t *testing.T) {
+	dir := t.TempDir()
+	if got := latestSettingsExport(dir); got != "" {
+		t.Errorf("empty dir: %q", got)
+	}
+	writeFiles(t, dir, map[string]string{
+		"hackeros-steam-settings-20261014-153000.toml": "",
+		"hackeros-steam-settings-20261013-090000.toml": "",
+		"hackeros-steam-20261015-000000.log":           "",
+	})
+	if got := filepath.Base(latestSettingsExport(dir)); got != "hackeros-steam-settings-20261014-153000.toml" {
+		t.Errorf("latest = %q", got)
+	}
+}
+
+func TestImportDialog(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	defer func(saved launchSettings, env []envVar) { launch, persistentEnv = saved, env }(launch, persistentEnv)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, ".state"))
+	cfg.LogDir = filepath.Join(home, "logs")
+
+	src := t.TempDir()
+	writeFiles(t, src, map[string]string{
+		launchFileName: "gpu = \"0000:01:00.0\"\n",
+		configFileName: "layout = \"list\"\n[hooks]\npre_update = \"make backup\"\n",
+	})
+	data, err := buildSettingsBundle(settingsIn(src), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mkdirs(t, cfg.LogDir)
+	export := filepath.Join(cfg.LogDir, "hackeros-steam-settings-20261014-153000.toml")
+	writeFiles(t, cfg.LogDir, map[string]string{filepath.Base(export): string(data), "junk.toml": "x"})
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.openImport()
+	if m.state != stateImport || m.importInput != tildePath(export) {
+		t.Fatalf("state %v input %q, want the latest export offered", m.state, m.importInput)
+	}
+
+	m.importInput = "~/logs/junk.toml"
+	if m = press(m, "enter"); m.state != stateImport || !strings.Contains(m.importErr, "not a settings export") {
+		t.Fatalf("junk accepted: state %v err %q", m.state, m.importErr)
+	}
+	if m = press(m, "x"); m.importErr != "" {
+		t.Error("error kept while typing")
+	}
+
+	m.importInput = tildePath(export)
+	m = press(m, "enter")
+	if m.state != stateConfirm {
+		t.Fatalf("state %v, want the overwrite warning", m.state)
+	}
+	for _, want := range []string{"Overwrites config.toml, launch.toml", `hooks.pre_update = "make backup"`} {
+		if dialog := stripANSI(m.renderConfirmDialog()); !strings.Contains(dialog, want) {
+			t.Errorf("confirm dialog lacks %q:\n%s", want, dialog)
+		}
+	}
+	m = press(settle(m), "y")
+	if launch.GPU != "0000:01:00.0" || countLogged(m, "Settings imported") != 1 {
+		t.Errorf("import not applied: gpu %q", launch.GPU)
+	}
+
+	m.openImport()
+	m = press(m, "enter")
+	if m.state != stateMenu || !strings.Contains(m.toast, "already in place") {
+		t.Errorf("second import: state %v toast %q", m.state, m.toast)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import "strings"
+
+// ─────────────────────────────────────────────────────────────────
+//  Shell quoting
+// ─────────────────────────────────────────────────────────────────
+
+// shellQuote returns s quoted for a POSIX shell. Words made only of safe
+// characters are left bare so the result stays readable.
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range s {
+		if !isShellSafe(r) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func isShellSafe(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	}
+	return strings.ContainsRune("@%+=:,./_-", r)
+}
+
+// shellJoin quotes each word and joins them into a runnable command line.
+func shellJoin(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = shellQuote(w)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellCommandLine renders env assignments followed by argv, e.g.
+// `FOO='a b' /usr/bin/hackeros-steam run`.
+func shellCommandLine(env, argv []string) string {
+	var parts []string
+	for _, kv := range env {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		parts = append(parts, name+"="+shellQuote(value))
+	}
+	if line := shellJoin(argv); line != "" {
+		parts = append(parts, line)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+var trickyWords = []string{
+	"",
+	"plain",
+	"with space",
+	"it's",
+	`"double"`,
+	"$HOME",
+	"`date`",
+	"semi;colon",
+	"back\\slash",
+	"tab\there",
+	"line\nbreak",
+	"glob*?[a]",
+	"''",
+	"--flag=a b",
+	"ünïcode",
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"", "''"},
+		{"run", "run"},
+		{"/usr/bin/hackeros-steam", "/usr/bin/hackeros-steam"},
+		{"--force", "--force"},
+		{"a b", "'a b'"},
+		{"it's", `'it'\''s'`},
+		{"$HOME", "'$HOME'"},
+	}
+	for _, tt := range tests {
+		if got := shellQuote(tt.in); got != tt.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+// The shell must hand every word back unchanged.
+func TestShellJoinRoundTrip(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	for _, w := range trickyWords {
+		out, err := exec.Command(sh, "-c", "printf '%s\\036' "+shellJoin([]string{w})).Output()
+		if err != nil {
+			t.Fatalf("%q: %v", w, err)
+		}
+		if got := strings.TrimSuffix(string(out), "\036"); got != w {
+			t.Errorf("round trip of %q gave %q", w, got)
+		}
+	}
+}
+
+func TestShellCommandLine(t *testing.T) {
+	tests := []struct {
+		env, argv []string
+		want      string
+	}{
+		{nil, []string{cli, "run"}, cli + " run"},
+		{[]string{"FOO=a b"}, []string{cli, "run"}, "FOO='a b' " + cli + " run"},
+		{[]string{"EMPTY=", "bad"}, []string{cli}, "EMPTY='' " + cli},
+		{[]string{"X=1"}, nil, "X=1"},
+	}
+	for _, tt := range tests {
+		if got := shellCommandLine(tt.env, tt.argv); got != tt.want {
+			t.Errorf("shellCommandLine(%q, %q) = %s, want %s", tt.env, tt.argv, got, tt.want)
+		}
+	}
+}
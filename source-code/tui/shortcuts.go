@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Action shortcuts
+//  The most used actions have a key of their own in the menu, shown
+//  at the right of the item so it is learned by seeing it. [shortcuts]
+//  in config.toml maps a menu label to another key, or "" for none:
+//
+//    [shortcuts]
+//    "Launch Steam" = "L"
+//    "Export Logs" = ""
+// ─────────────────────────────────────────────────────────────────
+
+// defaultShortcuts are capitals, which the menu's own keys leave free.
+var defaultShortcuts = map[string]string{
+	"Launch Steam":      "S",
+	"Big Picture Mode":  "B",
+	"Toggle GE-Proton":  "G",
+	"Installed Games":   "I",
+	"Library Folders":   "L",
+	"Verify Game Files": "V",
+	"Update Container":  "U",
+	"Pause Container":   "P",
+	"Stop Container":    "K",
+	"Export Logs":       "E",
+	"Side-by-Side Logs": "O",
+	"Network Info":      "N",
+}
+
+// actionKeys is the active shortcut of each menu label.
+var actionKeys = mustDefaultShortcuts()
+
+func mustDefaultShortcuts() map[string]key.Binding {
+	b, err := compileShortcuts(nil)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// menuKeys are the bindings the menu already handles, which a
+// shortcut can't take.
+func menuKeys() []key.Binding {
+	return []key.Binding{keys.Up, keys.Down, keys.Left, keys.Right, keys.Layout, keys.ANSI, keys.Select,
+		keys.Refresh, keys.Copy, keys.Detach, keys.ToggleHidden, keys.LineNumbers, keys.Include, keys.Exclude, keys.CopyTail, keys.History,
+		keys.LastError, keys.Timestamps, keys.PageUp, keys.PageDown, keys.Quit, keys.ForceQuit}
+}
+
+func keyTaken(k string) bool {
+	if len(k) == 1 && k[0] >= '1' && k[0] <= '9' {
+		return true // favorites
+	}
+	for _, b := range menuKeys() {
+		for _, have := range b.Keys() {
+			if have == k {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compileShortcuts applies the [shortcuts] overrides to the defaults.
+// Labels are checked against the menu, keys against the menu's own
+// keys and each other; a bad entry is reported and skipped.
+func compileShortcuts(overrides map[string]string) (map[string]key.Binding, error) {
+	merged := map[string]string{}
+	for label, k := range defaultShortcuts {
+		merged[label] = k
+	}
+	var problems []string
+	for label, k := range overrides {
+		if menuIndex(label) < 0 {
+			problems = append(problems, fmt.Sprintf("shortcuts: no menu item %q", label))
+			continue
+		}
+		merged[label] = strings.TrimSpace(k)
+	}
+
+	labels := make([]string, 0, len(merged))
+	for label := range merged {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	out := map[string]key.Binding{}
+	owner := map[string]string{}
+	for _, label := range labels {
+		k := merged[label]
+		switch {
+		case k == "":
+			continue
+		case keyTaken(k):
+			problems = append(problems, fmt.Sprintf("shortcuts: %q for %s is already a menu key", k, label))
+			continue
+		case owner[k] != "":
+			problems = append(problems, fmt.Sprintf("shortcuts: %q is set for both %s and %s", k, owner[k], label))
+			continue
+		}
+		owner[k] = label
+		out[label] = key.NewBinding(key.WithKeys(k), key.WithHelp(k, label))
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return out, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return out, nil
+}
+
+// shortcutHint is the key shown next to a menu item, "" if it has none.
+func shortcutHint(label string) string {
+	b, ok := actionKeys[label]
+	if !ok {
+		return ""
+	}
+	return b.Help().Key
+}
+
+// shortcutItem returns the menu item whose shortcut msg is.
+func shortcutItem(msg tea.KeyMsg) (int, bool) {
+	for label, b := range actionKeys {
+		if key.Matches(msg, b) {
+			if i := menuIndex(label); i >= 0 {
+				return i, true
+			}
+		}
+	}
+	return -1, false
+}
+
+// layoutHint fits label and hint into room cells: the label, cut if it
+// has to be, and the gap plus hint that right-align the key.
+func layoutHint(label, hint string, room int) (string, string) {
+	if hint == "" {
+		return truncate(label, room), ""
+	}
+	hw := ansi.StringWidth(hint)
+	label = truncate(label, max(room-hw-1, 1))
+	gap := max(room-ansi.StringWidth(label)-hw, 1)
+	return label, strings.Repeat(" ", gap) + hint
+}
+
+// styleRowProgress is the percentage that stands in for the shortcut
+// of the running item.
+var styleRowProgress = lipgloss.NewStyle().Foreground(colAccent).Bold(true)
+
+// hintedRow is menu item i as a row width cells wide: prefix (cursor,
+// icon), the label in style and, at the right edge one cell in, the
+// item's shortcut — or its progress while it runs.
+func (m model) hintedRow(prefix string, i int, style lipgloss.Style, width int) string {
+	item := menuItems[i]
+	hint, hintStyle := shortcutHint(item.label), styleShortcut
+	if i == m.progressItem() {
+		hint, hintStyle = fmt.Sprintf("%d%%", int(m.progress*100)), styleRowProgress
+	}
+	room := width - 1 - lipgloss.Width(prefix) - lipgloss.Width(style.Render(""))
+	label, hint := layoutHint(item.label, hint, room)
+	return prefix + style.Render(label) + hintStyle.Render(hint)
+}
+
+// progressItem is the menu index of the running action while it
+// reports progress, or -1. An action started from a submenu or the
+// control socket, with no item of its own in the menu, has none.
+func (m model) progressItem() int {
+	if !m.busy || !m.hasProgress || m.lastItem == nil {
+		return -1
+	}
+	for i, item := range menuItems {
+		if item.label == m.lastItem.label && slices.Equal(item.cmd, m.lastItem.cmd) {
+			return i
+		}
+	}
+	return -1
+}
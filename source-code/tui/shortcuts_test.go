@@ -0,0 +1,244 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCompileShortcuts(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		want      map[string]string // label → key; labels not listed keep their default
+		problem   string
+	}{
+		{"defaults", nil, nil, ""},
+		{"rebound", map[string]string{"Launch Steam": "L", "Library Folders": "F"},
+			map[string]string{"Launch Steam": "L", "Library Folders": "F"}, ""},
+		{"removed", map[string]string{"Export Logs": ""}, map[string]string{"Export Logs": ""}, ""},
+		{"trimmed", map[string]string{"Export Logs": " X "}, map[string]string{"Export Logs": "X"}, ""},
+		{"unknown label", map[string]string{"Launch Stean": "X"}, nil, `no menu item "Launch Stean"`},
+		{"menu key", map[string]string{"Export Logs": "q"}, map[string]string{"Export Logs": ""}, `"q" for Export Logs is already a menu key`},
+		{"favorite digit", map[string]string{"Export Logs": "3"}, map[string]string{"Export Logs": ""}, "already a menu key"},
+		{"duplicate", map[string]string{"Export Logs": "S"}, map[string]string{"Export Logs": "S", "Launch Steam": ""},
+			`"S" is set for both Export Logs and Launch Steam`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compileShortcuts(tt.overrides)
+			if (err != nil) != (tt.problem != "") || err != nil && !strings.Contains(err.Error(), tt.problem) {
+				t.Fatalf("error %v, want %q", err, tt.problem)
+			}
+			for label, def := range defaultShortcuts {
+				want, ok := tt.want[label]
+				if !ok {
+					want = def
+				}
+				if have := got[label].Help().Key; have != want {
+					t.Errorf("%s bound to %q, want %q", label, have, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultShortcutsAreFree(t *testing.T) {
+	for label, k := range defaultShortcuts {
+		if menuIndex(label) < 0 {
+			t.Errorf("default shortcut for missing menu item %q", label)
+		}
+		if keyTaken(k) {
+			t.Errorf("default shortcut %q for %s clashes with a menu key", k, label)
+		}
+	}
+}
+
+func TestLayoutHint(t *testing.T) {
+	tests := []struct {
+		label, hint string
+		room        int
+		wantLabel   string
+		wantHint    string
+	}{
+		{"Launch Steam", "S", 20, "Launch Steam", "       S"},
+		{"Launch Steam", "", 20, "Launch Steam", ""},
+		{"Verify Game Files", "V", 12, "Verify Ga…", " V"},
+		{"Verify Game Files", "ctrl+v", 12, "Veri…", " ctrl+v"},
+		{"原神を起動", "G", 12, "原神を起動", " G"},
+		{"原神を起動", "G", 10, "原神を…", "  G"},
+		{"Launch Steam", "ß", 14, "Launch Steam", " ß"},
+	}
+	for _, tt := range tests {
+		label, hint := layoutHint(tt.label, tt.hint, tt.room)
+		if label != tt.wantLabel || hint != tt.wantHint {
+			t.Errorf("layoutHint(%q, %q, %d) = %q, %q; want %q, %q",
+				tt.label, tt.hint, tt.room, label, hint, tt.wantLabel, tt.wantHint)
+		}
+		if w := lipgloss.Width(label + hint); tt.hint != "" && w != tt.room {
+			t.Errorf("layoutHint(%q, %q, %d) fills %d cells", tt.label, tt.hint, tt.room, w)
+		}
+	}
+}
+
+// menuRow is the rendered sidebar line of a menu item.
+func menuRow(t *testing.T, m model, label string) string {
+	t.Helper()
+	for _, line := range strings.Split(m.renderSidebar(), "\n") {
+		if strings.Contains(line, label) {
+			row, _, _ := strings.Cut(line, "│") // the log panel's border
+			return row
+		}
+	}
+	t.Fatalf("sidebar has no %q row", label)
+	return ""
+}
+
+// cutLabels are the menu labels too long for the sidebar, as shown.
+var cutLabels = map[string]string{
+	"Kill & Remove Container": "Kill & Remove Contai…",
+}
+
+func TestShortcutHintMatchesBinding(t *testing.T) {
+	defer func(saved map[string]key.Binding) { actionKeys = saved }(actionKeys)
+	keymaps := []map[string]string{
+		nil,
+		{"Launch Steam": "L", "Library Folders": "F", "Export Logs": ""},
+	}
+	for _, overrides := range keymaps {
+		var err error
+		if actionKeys, err = compileShortcuts(overrides); err != nil {
+			t.Fatal(err)
+		}
+		m := initialModel()
+		m.width, m.height = 120, 40
+		m.containerStatus = "running"
+		width := lipgloss.Width(menuRow(t, m, "Launch Steam"))
+		for _, item := range menuItems {
+			if item.section != "" && item.label == "" {
+				continue
+			}
+			shown := item.label
+			if cut, ok := cutLabels[item.label]; ok {
+				shown = cut
+			}
+			row := menuRow(t, m, shown)
+			b, bound := actionKeys[item.label]
+			hint := ""
+			if bound {
+				hint = b.Help().Key
+			}
+			if hint == "" {
+				if got := strings.TrimRight(row, " "); !strings.HasSuffix(got, shown) {
+					t.Errorf("%s has no shortcut but its row ends %q", item.label, got)
+				}
+				continue
+			}
+			if got := strings.TrimRight(row, " "); !strings.HasSuffix(got, " "+hint) {
+				t.Errorf("%s row %q doesn't end with its key %q", item.label, got, hint)
+			}
+			if w := lipgloss.Width(row); w != width {
+				t.Errorf("%s row is %d cells, Launch Steam's %d", item.label, w, width)
+			}
+		}
+	}
+}
+
+func TestShortcutRunsAction(t *testing.T) {
+	tests := []struct {
+		status  string
+		key     string
+		started string
+		cursor  string
+		toast   string
+	}{
+		{"running", "U", "", "Update Container", ""}, // previews first
+		{"running", "K", "$ hackeros-steam kill", "Stop Container", ""},
+		{"stopped", "K", "", "Launch Steam", "Stop Container unavailable: container is not running"},
+		{"running", "x", "", "Launch Steam", ""},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.width, m.height = 120, 40
+		m.containerStatus = tt.status
+		m = press(m, tt.key)
+		if tt.started != "" && countLogged(m, tt.started) != 1 {
+			t.Errorf("%s while %s didn't start %q", tt.key, tt.status, tt.started)
+		}
+		if tt.started == "" && countLogged(m, "$ hackeros-steam") != 0 {
+			t.Errorf("%s while %s started a command", tt.key, tt.status)
+		}
+		if got := menuItems[m.cursor].label; got != tt.cursor {
+			t.Errorf("%s while %s: cursor on %q, want %q", tt.key, tt.status, got, tt.cursor)
+		}
+		if m.toast != tt.toast {
+			t.Errorf("%s while %s: toast %q, want %q", tt.key, tt.status, m.toast, tt.toast)
+		}
+	}
+}
+
+func TestProgressItem(t *testing.T) {
+	update := menuItems[itemIndex(t, "Update Container")]
+	tests := []struct {
+		name     string
+		busy     bool
+		progress bool
+		item     *menuItem
+		want     int
+	}{
+		{"idle", false, true, &update, -1},
+		{"no progress yet", true, false, &update, -1},
+		{"update", true, true, &update, itemIndex(t, "Update Container")},
+		{"verify", true, true, &menuItems[itemIndex(t, "Verify Game Files")], itemIndex(t, "Verify Game Files")},
+		{"not in the menu", true, true, &menuItem{label: "Uninstall Portal", cmd: []string{"uninstall", "400"}}, -1},
+		{"same label, other command", true, true, &menuItem{label: "Update Container", cmd: []string{"update", "--plan"}}, -1},
+		{"nothing ran", true, true, nil, -1},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.busy, m.hasProgress, m.lastItem = tt.busy, tt.progress, tt.item
+		if got := m.progressItem(); got != tt.want {
+			t.Errorf("%s: progressItem = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProgressShownOnItsRow(t *testing.T) {
+	defer func(saved config) { cfg = saved }(cfg)
+	for _, layout := range []string{"list", "grid"} {
+		t.Run(layout, func(t *testing.T) {
+			cfg.Layout = layout
+			m := confirmUpdate(t, initialModel())
+			m.width, m.height = 120, 40
+			next, _ := m.Update(progressMsg{percent: 0.42, files: 3, totalFiles: 10})
+			m = next.(model)
+
+			draw, want := m.renderSidebar, "Update Container  42%"
+			if layout == "grid" {
+				draw, want = m.renderGrid, "Update Contain… 42%" // in place of the U
+			}
+			var rows []string
+			for _, line := range strings.Split(stripANSI(draw()), "\n") {
+				if strings.Contains(line, "42%") {
+					rows = append(rows, line)
+				}
+			}
+			if len(rows) != 1 || !strings.Contains(rows[0], want) {
+				t.Fatalf("42%% drawn on %q, want it in %q", rows, want)
+			}
+
+			next, _ = m.Update(cmdDoneMsg(true))
+			m = next.(model)
+			if layout == "grid" {
+				draw = m.renderGrid
+			} else {
+				draw = m.renderSidebar
+			}
+			if out := stripANSI(draw()); strings.Contains(out, "%") {
+				t.Errorf("percentage left after the update finished:\n%s", out)
+			}
+		})
+	}
+}
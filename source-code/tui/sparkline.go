@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Download speed sparkline
+// ─────────────────────────────────────────────────────────────────
+
+const speedSamples = 32
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sampleRing keeps the most recent speed samples, oldest first.
+type sampleRing struct {
+	buf []float64
+}
+
+func (r *sampleRing) add(v float64) {
+	r.buf = append(r.buf, v)
+	if len(r.buf) > speedSamples {
+		r.buf = r.buf[len(r.buf)-speedSamples:]
+	}
+}
+
+func (r *sampleRing) reset()            { r.buf = r.buf[:0] }
+func (r *sampleRing) values() []float64 { return r.buf }
+
+func (r *sampleRing) last() float64 {
+	if len(r.buf) == 0 {
+		return 0
+	}
+	return r.buf[len(r.buf)-1]
+}
+
+// sparkline scales values to the block characters, the highest sample
+// drawing a full block.
+func sparkline(values []float64) string {
+	max := 0.0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if max > 0 {
+			idx = int(v / max * float64(len(sparkBlocks)-1))
+		}
+		if idx < 0 {
+			idx = 0
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+var speedUnits = map[string]float64{
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+}
+
+// parseSpeed returns the transfer rate in bytes per second.
+func parseSpeed(line string) (float64, bool) {
+	m := pat.speed.FindStringSubmatch(line)
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	unit, known := speedUnits[m[2]]
+	if err != nil || !known {
+		return 0, false
+	}
+	return v * unit, true
+}
+
+// formatSpeed renders bytes per second in binary units.
+func formatSpeed(bps float64) string {
+	switch {
+	case bps >= 1<<30:
+		return fmt.Sprintf("%.1f GiB/s", bps/(1<<30))
+	case bps >= 1<<20:
+		return fmt.Sprintf("%.1f MiB/s", bps/(1<<20))
+	case bps >= 1<<10:
+		return fmt.Sprintf("%.1f KiB/s", bps/(1<<10))
+	default:
+		return fmt.Sprintf("%.0f B/s", bps)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   string
+	}{
+		{nil, ""},
+		{[]float64{0, 0, 0}, "▁▁▁"},
+		{[]float64{5, 5}, "██"},
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, "▁▂▃▄▅▆▇█"},
+		{[]float64{7, 3.5, 0}, "█▄▁"},
+	}
+	for _, tt := range tests {
+		if got := sparkline(tt.values); got != tt.want {
+			t.Errorf("sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestSampleRingIsBounded(t *testing.T) {
+	var r sampleRing
+	for i := 1; i <= speedSamples+10; i++ {
+		r.add(float64(i))
+	}
+	if n := len(r.values()); n != speedSamples {
+		t.Fatalf("ring holds %d samples, want %d", n, speedSamples)
+	}
+	if first := r.values()[0]; first != 11 {
+		t.Errorf("oldest sample = %v, want 11", first)
+	}
+	if r.last() != speedSamples+10 {
+		t.Errorf("last = %v, want %d", r.last(), speedSamples+10)
+	}
+	r.reset()
+	if len(r.values()) != 0 || r.last() != 0 {
+		t.Errorf("reset left %v", r.values())
+	}
+}
+
+func TestParseSpeed(t *testing.T) {
+	tests := []struct {
+		line string
+		want float64
+		ok   bool
+	}{
+		{"downloading 12.5 MiB/s", 12.5 * (1 << 20), true},
+		{"850KB/s eta 3s", 850e3, true},
+		{"1 GiB/s", 1 << 30, true},
+		{"300 B/s", 300, true},
+		{"Progress: 42%", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseSpeed(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseSpeed(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatSpeed(t *testing.T) {
+	tests := []struct {
+		bps  float64
+		want string
+	}{
+		{512, "512 B/s"},
+		{1536, "1.5 KiB/s"},
+		{3 << 20, "3.0 MiB/s"},
+		{2.5 * (1 << 30), "2.5 GiB/s"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.bps), func(t *testing.T) {
+			if got := formatSpeed(tt.bps); got != tt.want {
+				t.Errorf("formatSpeed(%v) = %q, want %q", tt.bps, got, tt.want)
+			}
+		})
+	}
+}
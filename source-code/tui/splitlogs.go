@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Side-by-side logs
+//  The container's log (`<engine> logs`) on the left and the Steam
+//  client's console log on the right, each scrolled on its own; tab
+//  moves between them. A source that can't be read says why in its
+//  pane and the other one still works.
+// ─────────────────────────────────────────────────────────────────
+
+// splitLogLines is how much of each log is loaded.
+const splitLogLines = 1000
+
+// steamLogNames are the client's console logs, newest naming first.
+var steamLogNames = []string{"console-linux.txt", "console_log.txt", "stderr.txt"}
+
+var (
+	splitFocus  = key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "other pane"))
+	splitReload = key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reload"))
+)
+
+type logPane struct {
+	title string
+	lines []string
+	vp    viewport.Model
+	err   string // why the source is unavailable, "" when loaded
+}
+
+// wrap lays the lines out at the viewport's width; the viewport itself
+// would let long lines spill over the pane.
+func (p *logPane) wrap() {
+	bottom := p.vp.AtBottom()
+	wrapped := make([]string, len(p.lines))
+	for i, l := range p.lines {
+		wrapped[i] = ansi.Hardwrap(l, p.vp.Width, true)
+	}
+	p.vp.SetContent(strings.Join(wrapped, "\n"))
+	if bottom {
+		p.vp.GotoBottom()
+	}
+}
+
+type splitLogs struct {
+	panes   [2]logPane
+	focus   int
+	loading bool
+}
+
+type splitLogsMsg struct {
+	container, steam       []string
+	containerErr, steamErr error
+	steamPath              string
+}
+
+// splitWidths divides total columns between the two panes; the left
+// one gets the odd column.
+func splitWidths(total int) (int, int) {
+	left := (total + 1) / 2
+	return left, total - left
+}
+
+// paneInner is the text area of a pane of the given outer size: the
+// border takes a cell on each side, the title a row.
+func paneInner(width, height int) (int, int) {
+	return max(width-2, 1), max(height-3, 1)
+}
+
+// splitSize is the room the view has: the screen minus header, status
+// bar and footer.
+func (m model) splitSize() (int, int) {
+	return m.width, max(m.height-5, 4)
+}
+
+func (m *model) resizeSplit() {
+	sl := m.splitLogs
+	w, h := m.splitSize()
+	lw, rw := splitWidths(w)
+	for i, pw := range []int{lw, rw} {
+		iw, ih := paneInner(pw, h)
+		p := &sl.panes[i]
+		if p.vp.Width != iw || p.vp.Height != ih {
+			p.vp.Width, p.vp.Height = iw, ih
+			p.wrap()
+		}
+	}
+}
+
+// tailLines keeps the last n lines of data.
+func tailLines(data string, n int) []string {
+	lines := strings.Split(strings.TrimRight(data, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// steamLogPath returns the client's console log, or an error naming
+// where it was looked for.
+func steamLogPath(dir string) (string, error) {
+	for _, name := range steamLogNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no Steam log in %s yet; it is written once Steam has run", tildePath(dir))
+}
+
+func loadSplitLogsCmd(engine string) tea.Cmd {
+	return func() tea.Msg {
+		var msg splitLogsMsg
+		out, err := auditedOutput("Side-by-Side Logs", hostCommand([]string{engine, "logs", "--tail", fmt.Sprint(splitLogLines), containerName}, nil, false), combinedTailOf)
+		if err != nil {
+			msg.containerErr = fmt.Errorf("%s logs failed: %w", engine, err)
+			if strings.TrimSpace(out) != "" {
+				msg.containerErr = fmt.Errorf("%s logs: %s", engine, strings.TrimSpace(tailLines(out, 1)[0]))
+			}
+		} else {
+			msg.container = tailLines(stripANSI(out), splitLogLines)
+		}
+
+		if remoteHost != "" {
+			msg.steamErr = errors.New("the Steam log can't be read over --remote")
+			return msg
+		}
+		path, err := steamLogPath(filepath.Join(steamDir(), "logs"))
+		if err != nil {
+			msg.steamErr = err
+			return msg
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			msg.steamErr = err
+			return msg
+		}
+		msg.steamPath = path
+		msg.steam = tailLines(string(bytes.ToValidUTF8(data, []byte("?"))), splitLogLines)
+		return msg
+	}
+}
+
+// openSplitLogs is the "Side-by-Side Logs" action.
+func (m *model) openSplitLogs() tea.Cmd {
+	m.splitLogs = &splitLogs{loading: true, panes: [2]logPane{
+		{title: "Container " + containerName, vp: viewport.New(1, 1)},
+		{title: "Steam client", vp: viewport.New(1, 1)},
+	}}
+	m.resizeSplit()
+	m.state = stateSplitLogs
+	return loadSplitLogsCmd(m.engine())
+}
+
+func (m *model) handleSplitLogs(msg splitLogsMsg) {
+	sl := m.splitLogs
+	if sl == nil {
+		return
+	}
+	sl.loading = false
+	fill := func(p *logPane, lines []string, err error) {
+		p.err, p.lines = "", nil
+		if err != nil {
+			p.err = err.Error()
+		} else if p.lines = lines; len(lines) == 1 && lines[0] == "" {
+			p.lines = []string{"(empty)"}
+		}
+		p.wrap()
+		p.vp.GotoBottom()
+	}
+	fill(&sl.panes[0], msg.container, msg.containerErr)
+	fill(&sl.panes[1], msg.steam, msg.steamErr)
+	if msg.steamPath != "" {
+		sl.panes[1].title = "Steam · " + filepath.Base(msg.steamPath)
+	}
+}
+
+func (m *model) closeSplitLogs() {
+	m.splitLogs = nil
+	m.state = m.idleState()
+}
+
+func (m *model) updateSplitLogs(msg tea.KeyMsg) tea.Cmd {
+	sl := m.splitLogs
+	switch {
+	case key.Matches(msg, splitFocus):
+		sl.focus = 1 - sl.focus
+	case key.Matches(msg, splitReload):
+		sl.loading = true
+		return loadSplitLogsCmd(m.engine())
+	case key.Matches(msg, keys.Back):
+		m.closeSplitLogs()
+	default:
+		p := &sl.panes[sl.focus]
+		var cmd tea.Cmd
+		p.vp, cmd = p.vp.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+func (m model) renderSplitLogs() string {
+	sl := m.splitLogs
+	w, h := m.splitSize()
+	lw, rw := splitWidths(w)
+	var panes []string
+	for i, pw := range []int{lw, rw} {
+		p := sl.panes[i]
+		border := colDim
+		titleStyle := styleLogDim
+		if i == sl.focus {
+			border = colAccent
+			titleStyle = lipgloss.NewStyle().Foreground(colAccent).Bold(true)
+		}
+		iw, ih := paneInner(pw, h)
+		body := p.vp.View()
+		switch {
+		case sl.loading && p.lines == nil && p.err == "":
+			body = styleLogDim.Render("Loading…")
+		case p.err != "":
+			body = lipgloss.NewStyle().Foreground(colRed).Width(iw).Render("Unavailable: " + p.err)
+		}
+		title := p.title
+		if p.err == "" && p.vp.TotalLineCount() > ih {
+			title += fmt.Sprintf("  %3.0f%%", p.vp.ScrollPercent()*100)
+		}
+		panes = append(panes, lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(border).
+			Width(iw).
+			Height(ih+1).
+			Render(titleStyle.Render(truncate(title, iw))+"\n"+body))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, panes...)
+}
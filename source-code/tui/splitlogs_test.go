@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSplitWidths(t *testing.T) {
+	tests := []struct {
+		total, left, right int
+	}{
+		{120, 60, 60},
+		{81, 41, 40},
+		{1, 1, 0},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		l, r := splitWidths(tt.total)
+		if l != tt.left || r != tt.right {
+			t.Errorf("splitWidths(%d) = %d, %d; want %d, %d", tt.total, l, r, tt.left, tt.right)
+		}
+	}
+}
+
+func TestPaneInner(t *testing.T) {
+	tests := []struct {
+		w, h, iw, ih int
+	}{
+		{60, 35, 58, 32},
+		{2, 3, 1, 1},
+		{0, 0, 1, 1},
+	}
+	for _, tt := range tests {
+		iw, ih := paneInner(tt.w, tt.h)
+		if iw != tt.iw || ih != tt.ih {
+			t.Errorf("paneInner(%d, %d) = %d, %d; want %d, %d", tt.w, tt.h, iw, ih, tt.iw, tt.ih)
+		}
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		data string
+		n    int
+		want []string
+	}{
+		{"a\nb\nc\n", 2, []string{"b", "c"}},
+		{"a\nb\n", 5, []string{"a", "b"}},
+		{"", 5, []string{""}},
+	}
+	for _, tt := range tests {
+		if got := tailLines(tt.data, tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("tailLines(%q, %d) = %q, want %q", tt.data, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestSteamLogPath(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := steamLogPath(dir); err == nil || !strings.Contains(err.Error(), "once Steam has run") {
+		t.Errorf("empty dir: %v", err)
+	}
+	for _, name := range []string{"stderr.txt", "console_log.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := steamLogPath(dir); err != nil || filepath.Base(got) != "console_log.txt" {
+		t.Errorf("steamLogPath = %q, %v; want console_log.txt", got, err)
+	}
+}
+
+func numbered(prefix string, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%s %d", prefix, i+1)
+	}
+	return lines
+}
+
+func splitModel(t *testing.T, width, height int, msg splitLogsMsg) model {
+	t.Helper()
+	m := initialModel()
+	m.width, m.height = width, height
+	m.openSplitLogs()
+	if m.state != stateSplitLogs || !m.splitLogs.loading {
+		t.Fatalf("state %v after opening", m.state)
+	}
+	next, _ := m.Update(msg)
+	return next.(model)
+}
+
+func TestSplitLogsLayout(t *testing.T) {
+	for _, size := range []struct{ w, h int }{{120, 40}, {81, 24}, {50, 14}} {
+		m := splitModel(t, size.w, size.h, splitLogsMsg{container: numbered("c", 50), steam: numbered("s", 50)})
+		lw, rw := splitWidths(size.w)
+		_, h := m.splitSize()
+		for i, pw := range []int{lw, rw} {
+			iw, ih := paneInner(pw, h)
+			if vp := m.splitLogs.panes[i].vp; vp.Width != iw || vp.Height != ih {
+				t.Errorf("%dx%d: pane %d is %dx%d, want %dx%d", size.w, size.h, i, vp.Width, vp.Height, iw, ih)
+			}
+		}
+		out := m.renderSplitLogs()
+		if w := lipgloss.Width(out); w != size.w {
+			t.Errorf("%dx%d: drawn %d columns wide", size.w, size.h, w)
+		}
+		if hh := lipgloss.Height(out); hh != h {
+			t.Errorf("%dx%d: drawn %d rows high, want %d", size.w, size.h, hh, h)
+		}
+	}
+}
+
+func TestSplitLogsScrollIndependently(t *testing.T) {
+	m := splitModel(t, 120, 40, splitLogsMsg{container: numbered("container", 200), steam: numbered("steam", 200)})
+	left, right := &m.splitLogs.panes[0], &m.splitLogs.panes[1]
+	if !left.vp.AtBottom() || !right.vp.AtBottom() {
+		t.Fatal("panes don't start at the newest lines")
+	}
+	m = press(m, "up", "up", "up")
+	left, right = &m.splitLogs.panes[0], &m.splitLogs.panes[1]
+	if left.vp.AtBottom() || !right.vp.AtBottom() {
+		t.Errorf("up scrolled left=%v right=%v, want only the focused left pane", !left.vp.AtBottom(), !right.vp.AtBottom())
+	}
+	leftAt := left.vp.YOffset
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = next.(model)
+	if m.splitLogs.focus != 1 {
+		t.Fatalf("focus %d after tab", m.splitLogs.focus)
+	}
+	m = press(m, "up")
+	if m.splitLogs.panes[0].vp.YOffset != leftAt || m.splitLogs.panes[1].vp.AtBottom() {
+		t.Error("scrolling the right pane moved the left one, or didn't scroll")
+	}
+
+	next, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	next, _ = next.Update(resizeFlushMsg{})
+	m = next.(model)
+	if m.splitLogs.panes[1].vp.AtBottom() {
+		t.Error("resize jumped the scrolled pane to the bottom")
+	}
+	m = press(m, "esc")
+	if m.state != stateMenu || m.splitLogs != nil {
+		t.Errorf("esc left state %v", m.state)
+	}
+}
+
+func TestSplitLogsOneSourceUnavailable(t *testing.T) {
+	m := splitModel(t, 120, 40, splitLogsMsg{
+		container: []string{"container says hi"},
+		steamErr:  errors.New("no Steam log in ~/.local/share/Steam/logs yet"),
+	})
+	out := m.renderSplitLogs()
+	for _, s := range []string{"container says hi", "Unavailable: no Steam log"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("view lacks %q:\n%s", s, out)
+		}
+	}
+
+	m = splitModel(t, 120, 40, splitLogsMsg{
+		containerErr: errors.New("podman logs: no container with name"),
+		steam:        []string{"[2026-10-14 15:00:00] Startup"},
+		steamPath:    "/home/deck/.local/share/Steam/logs/console-linux.txt",
+	})
+	out = m.renderSplitLogs()
+	for _, s := range []string{"Unavailable: podman logs", "Startup", "Steam · console-linux.txt"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("view lacks %q:\n%s", s, out)
+		}
+	}
+
+	m = splitModel(t, 120, 40, splitLogsMsg{container: []string{""}, steam: []string{"x"}})
+	if !strings.Contains(m.renderSplitLogs(), "(empty)") {
+		t.Error("an empty container log isn't marked")
+	}
+}
+
+func TestSplitLogsWrapLongLines(t *testing.T) {
+	m := splitModel(t, 60, 24, splitLogsMsg{container: []string{strings.Repeat("x", 200)}, steam: []string{"ok"}})
+	if w := lipgloss.Width(m.renderSplitLogs()); w != 60 {
+		t.Errorf("a long line widened the view to %d", w)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Start failures
+//  A command that never started — missing, not executable, a broken
+//  path — gets an explanation and a fix instead of the raw error, and
+//  is reported apart from a command that ran and failed.
+// ─────────────────────────────────────────────────────────────────
+
+// cmdStartErrMsg ends an action whose command couldn't be started.
+type cmdStartErrMsg struct{ lines []string }
+
+// isStartError reports whether err came from starting a command rather
+// than from the command exiting with a failure.
+func isStartError(err error) bool {
+	var exitErr *exec.ExitError
+	return err != nil && !errors.As(err, &exitErr)
+}
+
+// binaryFix is the advice for a missing program, by name.
+var binaryFix = map[string]string{
+	filepath.Base(cli): "Reinstall the HackerOS-Steam package, which provides " + cli + ".",
+	"distrobox":        "Install distrobox (and podman or docker) on the host.",
+	"ssh":              "Install the OpenSSH client to use --remote.",
+	"pkexec":           "Install polkit, or set privilege_cmd (e.g. \"sudo\") in config.toml.",
+	"sudo":             "Install sudo, or set privilege_cmd (e.g. \"pkexec\") in config.toml.",
+}
+
+// startErrorLines explains why argv couldn't start; the first line is
+// the problem, the rest how to fix it.
+func startErrorLines(argv []string, err error) []string {
+	bin := argv[0]
+	name := filepath.Base(bin)
+	fix := binaryFix[name]
+
+	switch {
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		if fix == "" {
+			fix = "Install " + name + ", or check that it is on PATH."
+		}
+		return []string{"Could not start " + name + ": " + bin + " was not found.", fix}
+	case errors.Is(err, syscall.ENOTDIR):
+		return []string{"Could not start " + name + ": part of the path " + bin + " is not a directory.",
+			"Check the path; a file is standing where a directory should be."}
+	case errors.Is(err, fs.ErrPermission):
+		return []string{"Could not start " + name + ": permission denied.",
+			"Make it executable with: chmod +x " + bin,
+			"If it already is, check that its filesystem isn't mounted noexec."}
+	case errors.Is(err, syscall.ENOEXEC):
+		return []string{"Could not start " + name + ": " + bin + " is not a program this system can run.",
+			"It may be built for another architecture, or a script without a #! line."}
+	}
+	return []string{"Could not start " + name + ": " + err.Error()}
+}
+
+func (m *model) handleStartErr(msg cmdStartErrMsg) {
+	m.logError(msg.lines[0], msg.lines[1:]...)
+	for _, line := range msg.lines[1:] {
+		m.appendLog(styleLogInfo.Render("  → " + line))
+	}
+	m.startFailed = true
+}
+
+// interactiveDone ends a command that had the terminal.
+func interactiveDone(argv []string, err error) tea.Msg {
+	if isStartError(err) {
+		return cmdStartErrMsg{lines: startErrorLines(argv, err)}
+	}
+	return cmdDoneMsg(err == nil)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startErr tries to start argv and returns why it couldn't.
+func startErr(t *testing.T, argv ...string) error {
+	t.Helper()
+	err := exec.Command(argv[0], argv[1:]...).Start()
+	if err == nil {
+		t.Fatalf("%q started", argv)
+	}
+	return err
+}
+
+func TestStartErrorLines(t *testing.T) {
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "plain")
+	os.WriteFile(plain, []byte("#!/bin/sh\n"), 0o644)
+	noShebang := filepath.Join(dir, "garbage")
+	os.WriteFile(noShebang, []byte{0x7f, 'E', 'L', 'F', 0, 0, 0, 0}, 0o755)
+
+	tests := []struct {
+		name string
+		argv []string
+		want []string
+	}{
+		{"not on PATH", []string{"hackeros-no-such-tool"}, []string{
+			"Could not start hackeros-no-such-tool: hackeros-no-such-tool was not found.",
+			"Install hackeros-no-such-tool, or check that it is on PATH."}},
+		{"missing CLI", []string{filepath.Join(dir, "bin", filepath.Base(cli))}, []string{
+			"was not found.", "Reinstall the HackerOS-Steam package"}},
+		{"missing distrobox", []string{filepath.Join(dir, "distrobox")}, []string{"Install distrobox"}},
+		{"not executable", []string{plain}, []string{"permission denied.", "chmod +x " + plain}},
+		{"file in the path", []string{filepath.Join(plain, "tool")}, []string{"is not a directory."}},
+		{"not a program", []string{noShebang}, []string{"is not a program this system can run."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := startErr(t, tt.argv...)
+			if !isStartError(err) {
+				t.Fatalf("isStartError(%v) = false", err)
+			}
+			got := strings.Join(startErrorLines(tt.argv, err), "\n")
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("lines lack %q:\n%s", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIsStartError(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	if exitErr := exec.Command(sh, "-c", "exit 3").Run(); isStartError(exitErr) {
+		t.Errorf("a failing run is a start error: %v", exitErr)
+	}
+	if isStartError(nil) {
+		t.Error("nil is a start error")
+	}
+}
+
+func TestStreamReportsStartError(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "hackeros-steam")
+	msgs := runStream([]string{missing, "status"})
+	if len(msgs) != 1 {
+		t.Fatalf("messages: %v", msgs)
+	}
+	msg, ok := msgs[0].(cmdStartErrMsg)
+	if !ok || !strings.Contains(msg.lines[0], "was not found") {
+		t.Fatalf("got %#v", msgs[0])
+	}
+
+	// The model reports it once, as a start failure, without offering a
+	// rerun with privileges for what is a missing file.
+	m := initialModel()
+	item := menuItem{label: "Container Status", cmd: []string{"status"}}
+	m.busy, m.state, m.lastItem = true, stateRunning, &item
+	m.beginCapture()
+	next, _ := m.Update(tea.Msg(msg))
+	m = next.(model)
+	if m.busy || m.state != stateMenu {
+		t.Errorf("busy=%v state=%v", m.busy, m.state)
+	}
+	if m.errorCount != 1 || countLogged(m, "command exited with error") != 0 {
+		t.Errorf("errorCount=%d, log %q", m.errorCount, plainLogLines(m.logLines, false))
+	}
+	if countLogged(m, "→ Reinstall the HackerOS-Steam package") != 1 {
+		t.Errorf("fix not shown: %q", plainLogLines(m.logLines, false))
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Steam client files
+//  distrobox shares $HOME with the host, so the Steam install inside
+//  the container lives in the user's own ~/.local/share/Steam.
+// ─────────────────────────────────────────────────────────────────
+
+func steamDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".local", "share", "Steam")
+}
+
+// steamappsDir holds the manifests of the installed games.
+func steamappsDir() string {
+	return filepath.Join(steamDir(), "steamapps")
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Update channel
+//  The Steam client opts into the beta when package/beta names a beta
+//  branch; without the file it follows stable.
+// ─────────────────────────────────────────────────────────────────
+
+type steamChannel string
+
+const (
+	channelStable steamChannel = "stable"
+	channelBeta   steamChannel = "beta"
+
+	steamBetaBranch = "publicbeta"
+)
+
+type channelSwitchedMsg struct {
+	channel steamChannel
+	err     error
+}
+
+func betaFilePath() string {
+	return filepath.Join(steamDir(), "package", "beta")
+}
+
+func currentChannel() steamChannel {
+	data, err := os.ReadFile(betaFilePath())
+	if err != nil || strings.TrimSpace(string(data)) == "" {
+		return channelStable
+	}
+	return channelBeta
+}
+
+func setChannel(ch steamChannel) error {
+	path := betaFilePath()
+	if ch == channelStable {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(steamBetaBranch+"\n"), 0o644)
+}
+
+func channelLabel(ch steamChannel) string {
+	if ch == channelBeta {
+		return "Beta"
+	}
+	return "Stable"
+}
+
+// openChannelMenu is the "Steam Channel" action.
+func (m *model) openChannelMenu() tea.Cmd {
+	current := currentChannel()
+	sm := &submenu{
+		title:  "Steam Channel — current: " + channelLabel(current),
+		note:   "⚠ Switching re-downloads the Steam client and runs an update.",
+		marked: 0,
+		items: []menuItem{
+			{icon: "●", label: "Stable", run: switchChannelAction(channelStable)},
+			{icon: "β", label: "Beta", run: switchChannelAction(channelBeta)},
+		},
+	}
+	if current == channelBeta {
+		sm.marked = 1
+	}
+	m.openSubmenu(sm)
+	return nil
+}
+
+func switchChannelAction(ch steamChannel) func(m *model) tea.Cmd {
+	return func(m *model) tea.Cmd {
+		if ch == currentChannel() {
+			m.appendLog(styleLogDim.Render("  Already on the " + channelLabel(ch) + " channel."))
+			return nil
+		}
+		m.busy = true
+		return func() tea.Msg {
+			return channelSwitchedMsg{channel: ch, err: setChannel(ch)}
+		}
+	}
+}
+
+// channelUpdateItem is Update Container as run after a switch: the
+// submenu already warned about the re-download, so it isn't asked again.
+func channelUpdateItem() menuItem {
+	item := menuItems[menuIndex("Update Container")]
+	item.confirm, item.describe = false, nil
+	return item
+}
+
+// ─────────────────────────────────────────────────────────────────
+//  Client auto-update
+//  Valve's steam.cfg next to the client stops the bootstrapper from
+//  updating Steam on launch. Turning auto-update back on removes only
+//  the two keys this sets; anything else in the file is kept.
+// ─────────────────────────────────────────────────────────────────
+
+// steamCfgInhibit are the steam.cfg settings that hold updates off.
+var steamCfgInhibit = []string{"BootStrapperInhibitAll=enable", "BootStrapperForceSelfUpdate=disable"}
+
+func steamCfgPath() string {
+	return filepath.Join(steamDir(), "steam.cfg")
+}
+
+// autoUpdateEnabled reads steam.cfg content; updates are on unless
+// the bootstrapper is inhibited.
+func autoUpdateEnabled(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		k, v, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok && strings.EqualFold(strings.TrimSpace(k), "BootStrapperInhibitAll") &&
+			strings.EqualFold(strings.TrimSpace(v), "enable") {
+			return false
+		}
+	}
+	return true
+}
+
+// withAutoUpdate returns steam.cfg content with auto-update set to on.
+func withAutoUpdate(content string, on bool) string {
+	var kept []string
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		k, _, _ := strings.Cut(strings.TrimSpace(line), "=")
+		switch strings.ToLower(strings.TrimSpace(k)) {
+		case "bootstrapperinhibitall", "bootstrapperforceselfupdate":
+			continue
+		}
+		if line != "" {
+			kept = append(kept, line)
+		}
+	}
+	if !on {
+		kept = append(kept, steamCfgInhibit...)
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	return strings.Join(kept, "\n") + "\n"
+}
+
+func readSteamCfg() string {
+	data, _ := os.ReadFile(steamCfgPath())
+	return string(data)
+}
+
+// setAutoUpdate writes steam.cfg, removing it once nothing is left.
+func setAutoUpdate(on bool) error {
+	content := withAutoUpdate(readSteamCfg(), on)
+	if content == "" {
+		if err := os.Remove(steamCfgPath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(steamDir(), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(steamCfgPath(), []byte(content), 0o644)
+}
+
+// openAutoUpdateMenu is the "Client Auto-Update" action.
+func (m *model) openAutoUpdateMenu() tea.Cmd {
+	if remoteHost != "" {
+		return m.showToast("Steam's settings can't be changed over --remote")
+	}
+	current := autoUpdateEnabled(readSteamCfg())
+	sm := &submenu{
+		title:  "Steam Client Auto-Update — current: " + onOff(current),
+		note:   "Off keeps the client at its version until turned back on.",
+		marked: 1,
+		items: []menuItem{
+			{icon: "↻", label: "On — update on launch", run: setAutoUpdateAction(true)},
+			{icon: "⏸", label: "Off — keep the current client", run: setAutoUpdateAction(false)},
+		},
+	}
+	if current {
+		sm.marked = 0
+	}
+	m.openSubmenu(sm)
+	return nil
+}
+
+func setAutoUpdateAction(on bool) func(m *model) tea.Cmd {
+	return func(m *model) tea.Cmd {
+		if err := setAutoUpdate(on); err != nil {
+			m.logError("Could not write " + tildePath(steamCfgPath()) + ": " + err.Error())
+			return nil
+		}
+		m.appendLog(styleLogSuccess.Render("  ✔  Steam client auto-update: " + onOff(on) + "."))
+		if !on {
+			m.appendLog(styleLogDim.Render("  Steam stays at its current version; Check Steam Client shows when one is out."))
+		}
+		return m.showToast("Client auto-update " + strings.ToLower(onOff(on)))
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSetChannel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tests := []struct {
+		ch       steamChannel
+		contents string // "" = no beta file
+	}{
+		{channelBeta, steamBetaBranch + "\n"},
+		{channelBeta, steamBetaBranch + "\n"},
+		{channelStable, ""},
+		{channelStable, ""},
+		{channelBeta, steamBetaBranch + "\n"},
+	}
+	for i, tt := range tests {
+		if err := setChannel(tt.ch); err != nil {
+			t.Fatalf("step %d: setChannel(%s): %v", i, tt.ch, err)
+		}
+		data, err := os.ReadFile(betaFilePath())
+		if tt.contents == "" {
+			if !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("step %d: beta file still present (%q, %v)", i, data, err)
+			}
+		} else if string(data) != tt.contents {
+			t.Errorf("step %d: beta file = %q, want %q", i, data, tt.contents)
+		}
+		if got := currentChannel(); got != tt.ch {
+			t.Errorf("step %d: currentChannel() = %s, want %s", i, got, tt.ch)
+		}
+	}
+}
+
+func TestCurrentChannelIgnoresBlankFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := setChannel(channelBeta); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(betaFilePath(), []byte("  \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := currentChannel(); got != channelStable {
+		t.Errorf("currentChannel() with a blank beta file = %s, want stable", got)
+	}
+}
+
+func TestChannelSwitchRunsUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		updates int
+	}{
+		{"switched", nil, 1},
+		{"failed", errors.New("read-only file system"), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.busy = true
+			next, _ := m.Update(channelSwitchedMsg{channel: channelBeta, err: tt.err})
+			m = next.(model)
+			if n := countLogged(m, "$ hackeros-steam update"); n != tt.updates {
+				t.Errorf("%d updates started, want %d", n, tt.updates)
+			}
+			if update := menuItems[itemIndex(t, "Update Container")]; tt.updates == 1 && (m.lastItem == nil || m.lastItem.doneNote != update.doneNote || !m.lastItem.online) {
+				t.Errorf("ran %+v, not the Update Container item", m.lastItem)
+			}
+			if running := m.state == stateRunning; running != (tt.updates == 1) || m.busy != running {
+				t.Errorf("state=%v busy=%v after switch", m.state, m.busy)
+			}
+			if tt.err != nil && countLogged(m, tt.err.Error()) != 1 {
+				t.Errorf("error not logged: %q", strings.Join(plainLogLines(m.logLines, false), "\n"))
+			}
+		})
+	}
+}
+
+func TestAutoUpdateConfig(t *testing.T) {
+	inhibited := "BootStrapperInhibitAll=enable\nBootStrapperForceSelfUpdate=disable\n"
+	tests := []struct {
+		name    string
+		content string
+		enabled bool
+		on      string // withAutoUpdate(content, true)
+		off     string // withAutoUpdate(content, false)
+	}{
+		{"no file", "", true, "", inhibited},
+		{"inhibited", inhibited, false, "", inhibited},
+		{"spaced and lower case", " bootstrapperinhibitall = Enable \n", false, "", inhibited},
+		{"inhibit disabled", "BootStrapperInhibitAll=disable\n", true, "", inhibited},
+		{"other keys kept", "CheckForUpdatesMinimumDelay=60\n" + inhibited, false,
+			"CheckForUpdatesMinimumDelay=60\n", "CheckForUpdatesMinimumDelay=60\n" + inhibited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := autoUpdateEnabled(tt.content); got != tt.enabled {
+				t.Errorf("autoUpdateEnabled = %v, want %v", got, tt.enabled)
+			}
+			if got := withAutoUpdate(tt.content, true); got != tt.on {
+				t.Errorf("on: %q, want %q", got, tt.on)
+			}
+			off := withAutoUpdate(tt.content, false)
+			if off != tt.off {
+				t.Errorf("off: %q, want %q", off, tt.off)
+			}
+			if autoUpdateEnabled(off) {
+				t.Error("off content still reads as enabled")
+			}
+		})
+	}
+}
+
+func TestSetAutoUpdate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	steps := []struct {
+		on     bool
+		exists bool
+	}{
+		{false, true},
+		{false, true},
+		{true, false},
+		{true, false},
+	}
+	for i, s := range steps {
+		if err := setAutoUpdate(s.on); err != nil {
+			t.Fatalf("step %d: setAutoUpdate(%v): %v", i, s.on, err)
+		}
+		_, err := os.Stat(steamCfgPath())
+		if exists := err == nil; exists != s.exists {
+			t.Errorf("step %d: steam.cfg exists = %v, want %v", i, exists, s.exists)
+		}
+		if got := autoUpdateEnabled(readSteamCfg()); got != s.on {
+			t.Errorf("step %d: enabled = %v, want %v", i, got, s.on)
+		}
+	}
+
+	if err := os.WriteFile(steamCfgPath(), []byte("CheckForUpdatesMinimumDelay=60\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := setAutoUpdate(true); err != nil {
+		t.Fatal(err)
+	}
+	if got := readSteamCfg(); got != "CheckForUpdatesMinimumDelay=60\n" {
+		t.Errorf("turning auto-update on rewrote steam.cfg to %q", got)
+	}
+}
+
+func TestAutoUpdateMenu(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.cursor = itemIndex(t, "Client Auto-Update")
+	m = press(m, "enter")
+	if m.state != stateSubmenu || !strings.Contains(m.submenu.title, "current: On") || m.submenu.marked != 0 {
+		t.Fatalf("state %v, submenu %+v", m.state, m.submenu)
+	}
+	m = press(m, "down", "enter")
+	if autoUpdateEnabled(readSteamCfg()) {
+		t.Error("choosing Off left auto-update on")
+	}
+	if !strings.Contains(m.toast, "off") {
+		t.Errorf("toast %q", m.toast)
+	}
+	m = press(m, "enter")
+	if !strings.Contains(m.submenu.title, "current: Off") || m.submenu.marked != 1 {
+		t.Errorf("reopened submenu %+v", m.submenu)
+	}
+}
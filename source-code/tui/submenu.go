@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Submenus
+// ─────────────────────────────────────────────────────────────────
+
+// submenu is a small list of actions shown as an overlay, e.g. the
+// Steam channel picker. Its items are dispatched like menu items.
+type submenu struct {
+	title  string
+	note   string // optional hint/warning under the title
+	items  []menuItem
+	marked int // index rendered with a "●" (current value), -1 = none
+	cursor int
+}
+
+func (m *model) openSubmenu(sm *submenu) {
+	m.submenu = sm
+	m.state = stateSubmenu
+}
+
+func (m *model) closeSubmenu() {
+	m.submenu = nil
+	m.state = stateMenu
+}
+
+func (m *model) updateSubmenu(msg tea.KeyMsg) tea.Cmd {
+	sm := m.submenu
+	switch {
+	case key.Matches(msg, keys.Up):
+		if sm.cursor > 0 {
+			sm.cursor--
+		}
+	case key.Matches(msg, keys.Down):
+		if sm.cursor < len(sm.items)-1 {
+			sm.cursor++
+		}
+	case key.Matches(msg, keys.Select):
+		item := sm.items[sm.cursor]
+		m.closeSubmenu()
+		return m.selectItem(item, actionFor(item, ""))
+	case key.Matches(msg, keys.Back):
+		m.closeSubmenu()
+	}
+	return nil
+}
+
+// submenuWindow returns the range of n items to draw so that cursor
+// is shown in at most rows lines.
+func submenuWindow(n, cursor, rows int) (int, int) {
+	rows = max(rows, 3)
+	if n <= rows {
+		return 0, n
+	}
+	first := min(max(cursor-rows/2, 0), n-rows)
+	return first, first + rows
+}
+
+func (m model) renderSubmenu() string {
+	sm := m.submenu
+	var rows []string
+	rows = append(rows, lipgloss.NewStyle().Foreground(colAccent).Bold(true).Render(sm.title))
+	if sm.note != "" {
+		rows = append(rows, lipgloss.NewStyle().Foreground(colYellow).Render(sm.note))
+	}
+	rows = append(rows, "")
+
+	// Long lists (mirrors, say) scroll with the cursor.
+	first, last := submenuWindow(len(sm.items), sm.cursor, m.height-12)
+	if first > 0 {
+		rows = append(rows, styleLogDim.Render(fmt.Sprintf("    ↑ %d more", first)))
+	}
+	for i := first; i < last; i++ {
+		item := sm.items[i]
+		mark := "  "
+		if i == sm.marked {
+			mark = lipgloss.NewStyle().Foreground(colGreen).Render("● ")
+		}
+		label := styleMenuIcon.Render(item.icon) + " " + item.label
+		if i == sm.cursor {
+			rows = append(rows, mark+lipgloss.NewStyle().Foreground(colCursor).Bold(true).Render("▶ "+label))
+		} else {
+			rows = append(rows, mark+"  "+lipgloss.NewStyle().Foreground(colText).Render(label))
+		}
+	}
+	if last < len(sm.items) {
+		rows = append(rows, styleLogDim.Render(fmt.Sprintf("    ↓ %d more", len(sm.items)-last)))
+	}
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colAccent).
+		Padding(1, 4)
+
+	return m.placeOverlay(box, strings.Join(rows, "\n"))
+}
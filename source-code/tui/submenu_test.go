@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSubmenuKeys(t *testing.T) {
+	picked := ""
+	pick := func(label string) func(m *model) tea.Cmd {
+		return func(m *model) tea.Cmd { picked = label; return nil }
+	}
+	tests := []struct {
+		keys   []string
+		picked string
+		open   bool
+	}{
+		{[]string{"enter"}, "a", false},
+		{[]string{"down", "enter"}, "b", false},
+		{[]string{"down", "down", "down", "enter"}, "c", false},
+		{[]string{"down", "up", "up", "enter"}, "a", false},
+		{[]string{"down"}, "", true},
+		{[]string{"down", "esc"}, "", false},
+	}
+	for _, tt := range tests {
+		picked = ""
+		m := initialModel()
+		m.openSubmenu(&submenu{title: "t", marked: -1, items: []menuItem{
+			{label: "a", run: pick("a")},
+			{label: "b", run: pick("b")},
+			{label: "c", run: pick("c")},
+		}})
+		m = press(m, tt.keys...)
+		if picked != tt.picked {
+			t.Errorf("%v: picked %q, want %q", tt.keys, picked, tt.picked)
+		}
+		if open := m.state == stateSubmenu; open != tt.open {
+			t.Errorf("%v: submenu open = %v, want %v", tt.keys, open, tt.open)
+		}
+	}
+}
+
+func TestSubmenuWindow(t *testing.T) {
+	tests := []struct {
+		n, cursor, rows int
+		first, last     int
+	}{
+		{5, 0, 10, 0, 5},
+		{5, 4, 5, 0, 5},
+		{50, 0, 10, 0, 10},
+		{50, 20, 10, 15, 25},
+		{50, 49, 10, 40, 50},
+		{50, 10, 1, 9, 12}, // never fewer than three rows
+	}
+	for _, tt := range tests {
+		first, last := submenuWindow(tt.n, tt.cursor, tt.rows)
+		if first != tt.first || last != tt.last {
+			t.Errorf("submenuWindow(%d, %d, %d) = %d, %d; want %d, %d",
+				tt.n, tt.cursor, tt.rows, first, last, tt.first, tt.last)
+		}
+		if tt.cursor < first || tt.cursor >= last {
+			t.Errorf("cursor %d outside %d..%d", tt.cursor, first, last)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  System config
+//  Managed installs put defaults in /etc/hackeros/steam-tui.toml. It
+//  takes the same keys as config.toml and sits under it: the user's
+//  file wins, except for the keys the system file lists in locked,
+//  which keep the system's value whatever the user sets. A locked
+//  table (hooks, theme, …) is locked as a whole.
+//
+//    safe_mode = true
+//    audit = true
+//    locked = ["safe_mode", "audit", "audit_log"]
+// ─────────────────────────────────────────────────────────────────
+
+// systemConfigPath is the system-wide config file.
+var systemConfigPath = "/etc/hackeros/steam-tui.toml"
+
+// lockedKeys are the keys the system config locked, for the toggles
+// that would change them at runtime.
+var lockedKeys = map[string]bool{}
+
+// configLocked reports whether the system config locked key.
+func configLocked(key string) bool {
+	return lockedKeys[key]
+}
+
+// configField finds the field of c whose TOML key is key.
+func configField(c *config, key string) (reflect.Value, bool) {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("toml"), ",")
+		if name == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// decodeLayer decodes the file at path over c; a missing file leaves c
+// alone and reports ok = false.
+func decodeLayer(path string, c *config) (toml.MetaData, bool, error) {
+	md, err := toml.DecodeFile(path, c)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return md, false, nil
+	case err != nil:
+		return md, false, fmt.Errorf("config %s: %w", path, err)
+	}
+	return md, true, nil
+}
+
+// layerConfig is the defaults, then the system file, then the user's
+// file, with the system's locked keys put back. notes say which user
+// settings were overruled. A file that doesn't parse is skipped and
+// reported; the other layers still apply.
+func layerConfig(systemPath, userPath string) (c config, locked []string, notes []string, err error) {
+	c = defaultConfig()
+	var errs []error
+	_, hasSystem, sysErr := decodeLayer(systemPath, &c)
+	errs = append(errs, sysErr)
+
+	// A second copy keeps the system's values, maps included, apart
+	// from what the user's file merges into c.
+	system := defaultConfig()
+	if hasSystem {
+		decodeLayer(systemPath, &system)
+	}
+	for _, key := range system.Locked {
+		if _, ok := configField(&system, key); !ok || key == "locked" {
+			errs = append(errs, fmt.Errorf("config %s: locked: %q is not a config key", systemPath, key))
+			continue
+		}
+		locked = append(locked, key)
+	}
+
+	md, _, userErr := decodeLayer(userPath, &c)
+	errs = append(errs, userErr)
+	if userErr != nil {
+		// A half-decoded user file isn't trusted; start over from the
+		// system layer.
+		c = defaultConfig()
+		decodeLayer(systemPath, &c)
+	}
+
+	c.Locked = system.Locked
+	for _, key := range locked {
+		dst, _ := configField(&c, key)
+		src, _ := configField(&system, key)
+		dst.Set(src)
+		if md.IsDefined(key) {
+			notes = append(notes, fmt.Sprintf("%s is set by %s; the value in your config is ignored", key, systemPath))
+		}
+	}
+	sort.Strings(notes)
+	return c, locked, notes, errors.Join(errs...)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// configLayers writes the system and user files; "" leaves one out.
+func configLayers(t *testing.T, system, user string) (systemPath, userPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	systemPath, userPath = filepath.Join(dir, "steam-tui.toml"), filepath.Join(dir, "config.toml")
+	for path, data := range map[string]string{systemPath: system, userPath: user} {
+		if data == "" {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return systemPath, userPath
+}
+
+func TestLayerConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		system, user string
+		check        func(c config) bool
+		locked       string
+		notes        int
+		err          string
+	}{
+		{"defaults only", "", "",
+			func(c config) bool { return c.Layout == "list" && !c.SafeMode && c.PrivilegeCmd == "pkexec" }, "", 0, ""},
+		{"system sets defaults", "layout = \"grid\"\nnotify = false\n", "",
+			func(c config) bool { return c.Layout == "grid" && !c.Notify }, "", 0, ""},
+		{"user overrides an unlocked key", "layout = \"grid\"\n", "layout = \"list\"\n",
+			func(c config) bool { return c.Layout == "list" }, "", 0, ""},
+		{"keys neither file sets keep defaults", "layout = \"grid\"\n", "notify = false\n",
+			func(c config) bool { return c.Layout == "grid" && !c.Notify && c.ConfirmTimeout == 30 }, "", 0, ""},
+		{"locked key wins", "safe_mode = true\nlocked = [\"safe_mode\"]\n", "safe_mode = false\nlayout = \"grid\"\n",
+			func(c config) bool { return c.SafeMode && c.Layout == "grid" }, "safe_mode", 1, ""},
+		{"locked default", "locked = [\"privilege_cmd\"]\n", "privilege_cmd = \"sudo -A\"\n",
+			func(c config) bool { return c.PrivilegeCmd == "pkexec" }, "privilege_cmd", 1, ""},
+		{"locked table is locked whole", "locked = [\"hooks\"]\n[hooks]\npre_run = \"/usr/lib/hackeros/check\"\n",
+			"[hooks]\npre_run = \"true\"\npost_update = \"notify-send done\"\n",
+			func(c config) bool { return len(c.Hooks) == 1 && c.Hooks["pre_run"] == "/usr/lib/hackeros/check" }, "hooks", 1, ""},
+		{"locked but unset by the user", "audit = true\nlocked = [\"audit\"]\n", "layout = \"grid\"\n",
+			func(c config) bool { return c.Audit }, "audit", 0, ""},
+		{"user can't change the locks", "safe_mode = true\nlocked = [\"safe_mode\"]\n", "locked = []\nsafe_mode = false\n",
+			func(c config) bool { return c.SafeMode && len(c.Locked) == 1 }, "safe_mode", 1, ""},
+		{"unknown locked key", "locked = [\"safe_mode\", \"launch_speed\"]\n", "",
+			func(c config) bool { return true }, "safe_mode", 0, `"launch_speed" is not a config key`},
+		{"broken user file keeps the system layer", "layout = \"grid\"\nsafe_mode = true\nlocked = [\"safe_mode\"]\n", "notify = false\nlayout = [\n",
+			func(c config) bool { return c.Layout == "grid" && c.SafeMode && c.Notify }, "safe_mode", 0, "config.toml"},
+		{"broken system file", "layout = [\n", "notify = false\n",
+			func(c config) bool { return !c.Notify && len(c.Locked) == 0 }, "", 0, "steam-tui.toml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			systemPath, userPath := configLayers(t, tt.system, tt.user)
+			c, locked, notes, err := layerConfig(systemPath, userPath)
+			if got := ""; err != nil || tt.err != "" {
+				if err != nil {
+					got = err.Error()
+				}
+				if tt.err == "" || !strings.Contains(got, tt.err) {
+					t.Errorf("error = %q, want %q", got, tt.err)
+				}
+			}
+			if !tt.check(c) {
+				t.Errorf("merged config = %+v", c)
+			}
+			if got := strings.Join(locked, ","); got != tt.locked {
+				t.Errorf("locked = %q, want %q", got, tt.locked)
+			}
+			if len(notes) != tt.notes {
+				t.Errorf("notes = %q, want %d", notes, tt.notes)
+			}
+			for _, n := range notes {
+				if !strings.Contains(n, systemPath) {
+					t.Errorf("note %q doesn't name the system config", n)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfigRecordsLocks(t *testing.T) {
+	defer func(path string, locked map[string]bool, notes []string) {
+		systemConfigPath, lockedKeys, startupNotes = path, locked, notes
+	}(systemConfigPath, lockedKeys, startupNotes)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	systemPath, _ := configLayers(t, "layout = \"grid\"\nlocked = [\"layout\"]\n", "")
+	systemConfigPath = systemPath
+	if err := writeFileAtomic(configPath(), []byte("layout = \"list\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	startupNotes = nil
+
+	c, err := loadConfig()
+	if err != nil || c.Layout != "grid" {
+		t.Fatalf("loadConfig = %q, %v; want the locked grid layout", c.Layout, err)
+	}
+	if !configLocked("layout") || configLocked("safe_mode") {
+		t.Errorf("lockedKeys = %v", lockedKeys)
+	}
+	if len(startupNotes) != 1 || !strings.Contains(startupNotes[0], "layout is set by") {
+		t.Errorf("startup notes = %q", startupNotes)
+	}
+}
+
+func TestLockedToggleRefused(t *testing.T) {
+	defer func(saved config, locked map[string]bool) { cfg, lockedKeys = saved, locked }(cfg, lockedKeys)
+	cfg.Layout = "grid"
+	lockedKeys = map[string]bool{"layout": true}
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m = press(m, "g")
+	if cfg.Layout != "grid" || !strings.Contains(m.toast, "set by the system config") {
+		t.Errorf("locked layout toggled to %q (toast %q)", cfg.Layout, m.toast)
+	}
+
+	lockedKeys = map[string]bool{}
+	m = press(m, "g")
+	if cfg.Layout != "list" {
+		t.Errorf("unlocked layout stayed %q", cfg.Layout)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Test launch
+//  `hackeros-steam test-launch` starts Steam silently in the
+//  container, waits for the client to come up and shuts it down again,
+//  which tells whether Steam can run at all without starting a
+//  session. When it fails, the output is matched against the usual
+//  causes so the log can say what to try.
+// ─────────────────────────────────────────────────────────────────
+
+// testLaunchCause is a known reason for a failed test launch.
+type testLaunchCause struct {
+	markers []string // lowercase fragments of the output
+	problem string
+	advice  string
+}
+
+// testLaunchCauses are tried in order; the first match wins.
+var testLaunchCauses = []testLaunchCause{
+	{[]string{"steam is not installed"}, "Steam is not installed in the container.",
+		"Run Setup / Repair Steam."},
+	{[]string{"could not find required opengl entry point", "libgl error", "glx: failed", "failed to load driver"},
+		"The graphics driver could not be loaded.",
+		"Check Launch GPU, or run Setup / Repair Steam to reinstall the 32-bit drivers."},
+	{[]string{"cannot open shared object file", "error while loading shared libraries"},
+		"A library Steam needs is missing.",
+		"Run Setup / Repair Steam; if that doesn't help, Update Container."},
+	{[]string{"cannot open display", "unable to open display", "no display"},
+		"Steam could not reach the display.",
+		"Start the TUI from a graphical session; DISPLAY or WAYLAND_DISPLAY must be set."},
+	{[]string{"timed out after"},
+		"The client didn't come up in time.",
+		"A first start downloads the client; try again once the network is fast enough."},
+}
+
+func isTestLaunchItem(item *menuItem) bool {
+	return item != nil && actionName(*item) == "test-launch"
+}
+
+// diagnoseTestLaunch returns the cause of a failed run, nil if the
+// output doesn't match a known one.
+func diagnoseTestLaunch(lines []string) *testLaunchCause {
+	for i := range testLaunchCauses {
+		c := &testLaunchCauses[i]
+		for _, line := range lines {
+			lo := strings.ToLower(stripANSI(line))
+			for _, marker := range c.markers {
+				if strings.Contains(lo, marker) {
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// logTestLaunchResult adds what a failed test launch likely means.
+func (m *model) logTestLaunchResult(ok bool) {
+	if ok {
+		return
+	}
+	if c := diagnoseTestLaunch(m.runOutput); c != nil {
+		m.appendLog(styleLogWarning.Render("  ⚠  " + c.problem))
+		m.appendLog(styleLogInfo.Render("  → " + c.advice))
+	}
+}
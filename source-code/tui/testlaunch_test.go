@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiagnoseTestLaunch(t *testing.T) {
+	tests := []struct {
+		name    string
+		lines   []string
+		problem string // "" = no known cause
+	}{
+		{"not installed", []string{"✖  Steam is not installed in the container!"}, "Steam is not installed in the container."},
+		{"opengl", []string{"Last lines of Steam's output:", "Could not find required OpenGL entry point 'glGetError'!"},
+			"The graphics driver could not be loaded."},
+		{"mesa", []string{"libGL error: failed to load driver: iris"}, "The graphics driver could not be loaded."},
+		{"library", []string{"steam: error while loading shared libraries: libX11.so.6: cannot open shared object file"},
+			"A library Steam needs is missing."},
+		{"display", []string{"Error: cannot open display: :0"}, "Steam could not reach the display."},
+		{"timeout", []string{"Timed out after 180s waiting for the client to start."}, "The client didn't come up in time."},
+		{"colored", []string{"\x1b[31mUnable to open display\x1b[0m"}, "Steam could not reach the display."},
+		{"first cause wins", []string{"cannot open display", "Steam is not installed in the container!"},
+			"Steam is not installed in the container."},
+		{"unknown", []string{"Steam exited before the client started.", "segfault"}, ""},
+		{"no output", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := diagnoseTestLaunch(tt.lines)
+			got := ""
+			if c != nil {
+				got = c.problem
+			}
+			if got != tt.problem {
+				t.Errorf("diagnoseTestLaunch(%q) = %q, want %q", tt.lines, got, tt.problem)
+			}
+		})
+	}
+}
+
+func TestTestLaunch(t *testing.T) {
+	item := menuItems[itemIndex(t, "Test Launch")]
+	if args := strings.Join(item.cmd, " "); args != "test-launch" || !isTestLaunchItem(&item) {
+		t.Fatalf("Test Launch runs %q", args)
+	}
+	if isTestLaunchItem(nil) || isTestLaunchItem(&menuItems[itemIndex(t, "Launch Steam")]) {
+		t.Error("other items taken for Test Launch")
+	}
+	tests := []struct {
+		name   string
+		output []string
+		ok     bool
+		want   []string
+		absent []string
+	}{
+		{"passed", []string{"Client started (PID 4242); shutting it down..."}, true,
+			[]string{"Steam can run in the container."}, []string{"⚠"}},
+		{"driver", []string{"libGL error: failed to load driver: radeonsi"}, false,
+			[]string{"The graphics driver could not be loaded.", "→ Check Launch GPU"}, []string{"Steam can run"}},
+		{"unknown failure", []string{"Steam exited before the client started."}, false,
+			[]string{"Test Launch: command exited with error."}, []string{"→ Run", "→ Check"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.width, m.height = 120, 40
+			m.containerStatus = "running"
+			m.cursor = itemIndex(t, "Test Launch")
+			m = press(m, "enter")
+			if countLogged(m, "$ hackeros-steam test-launch") != 1 {
+				t.Fatal("test launch not started")
+			}
+			for _, line := range tt.output {
+				next, _ := m.Update(cmdOutputMsg(line))
+				m = next.(model)
+			}
+			next, _ := m.Update(cmdDoneMsg(tt.ok))
+			m = next.(model)
+			log := strings.Join(plainLogLines(m.logLines, false), "\n")
+			for _, s := range tt.want {
+				if !strings.Contains(log, s) {
+					t.Errorf("log lacks %q:\n%s", s, log)
+				}
+			}
+			for _, s := range tt.absent {
+				if strings.Contains(log, s) {
+					t.Errorf("log has %q:\n%s", s, log)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateSuggestsTestLaunch(t *testing.T) {
+	if note := menuItems[itemIndex(t, "Create Container")].doneNote; !strings.Contains(note, "Test Launch") {
+		t.Errorf("Create Container's note %q doesn't point at Test Launch", note)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Theme
+//  [theme] accent recolors everything drawn in the accent color; the
+//  progress bar, spinner and cursor follow it unless given a color of
+//  their own.
+//
+//    [theme]
+//    accent = "#ff79c6"
+//    progress = "#3ddc84"   # optional, per element
+// ─────────────────────────────────────────────────────────────────
+
+type themeConfig struct {
+	Accent   string `toml:"accent"`
+	Progress string `toml:"progress"`
+	Spinner  string `toml:"spinner"`
+	Cursor   string `toml:"cursor"`
+}
+
+// themeColors are the resolved colors of the accented elements.
+type themeColors struct {
+	accent, progress, spinner, cursor lipgloss.Color
+}
+
+var (
+	colProgress = colAccent
+	colSpinner  = colAccent
+	colCursor   = colAccent
+)
+
+var hexColor = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// parseColor accepts "#rgb", "#rrggbb" or an ANSI color number 0–255.
+func parseColor(s string) (lipgloss.Color, error) {
+	if hexColor.MatchString(s) {
+		return lipgloss.Color(s), nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(s), nil
+	}
+	return "", fmt.Errorf("%q is not a color (#rrggbb or 0–255)", s)
+}
+
+// compileTheme resolves t over the built-in accent. A bad color is
+// reported and that element keeps what it would have had without it.
+func compileTheme(t themeConfig) (themeColors, error) {
+	var errs []error
+	pick := func(name, value string, fallback lipgloss.Color) lipgloss.Color {
+		if value == "" {
+			return fallback
+		}
+		c, err := parseColor(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("theme.%s: %w", name, err))
+			return fallback
+		}
+		return c
+	}
+	var tc themeColors
+	tc.accent = pick("accent", t.Accent, colAccent)
+	tc.progress = pick("progress", t.Progress, tc.accent)
+	tc.spinner = pick("spinner", t.Spinner, tc.accent)
+	tc.cursor = pick("cursor", t.Cursor, tc.accent)
+	return tc, errors.Join(errs...)
+}
+
+// applyTheme makes tc current, rebuilding the styles that were made
+// from the accent. It runs before the model is created.
+func applyTheme(tc themeColors) {
+	colAccent = tc.accent
+	colProgress, colSpinner, colCursor = tc.progress, tc.spinner, tc.cursor
+	styleTitle = styleTitle.Foreground(colAccent)
+	styleLogInfo = styleLogInfo.Foreground(colAccent)
+	styleMenuSelected = styleMenuSelected.Foreground(colCursor)
+	styleRowProgress = styleRowProgress.Foreground(colAccent)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		s  string
+		ok bool
+	}{
+		{"#ff79c6", true},
+		{"#F7C", true},
+		{"212", true},
+		{"0", true},
+		{"256", false},
+		{"-1", false},
+		{"pink", false},
+		{"#ff79c", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		c, err := parseColor(tt.s)
+		if (err == nil) != tt.ok || (tt.ok && string(c) != tt.s) {
+			t.Errorf("parseColor(%q) = %q, %v", tt.s, c, err)
+		}
+	}
+}
+
+func TestCompileTheme(t *testing.T) {
+	pink, green := lipgloss.Color("#ff79c6"), lipgloss.Color("#3ddc84")
+	tests := []struct {
+		name    string
+		theme   themeConfig
+		want    themeColors
+		errPart string
+	}{
+		{"built-in", themeConfig{}, themeColors{colAccent, colAccent, colAccent, colAccent}, ""},
+		{"accent only", themeConfig{Accent: "#ff79c6"}, themeColors{pink, pink, pink, pink}, ""},
+		{"per element", themeConfig{Accent: "#ff79c6", Progress: "#3ddc84"}, themeColors{pink, green, pink, pink}, ""},
+		{"element without accent", themeConfig{Cursor: "#3ddc84"}, themeColors{colAccent, colAccent, colAccent, green}, ""},
+		{"bad accent", themeConfig{Accent: "pink", Spinner: "#3ddc84"}, themeColors{colAccent, colAccent, green, colAccent}, "theme.accent"},
+		{"bad element", themeConfig{Accent: "#ff79c6", Progress: "999"}, themeColors{pink, pink, pink, pink}, "theme.progress"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compileTheme(tt.theme)
+			if got != tt.want {
+				t.Errorf("compileTheme = %+v, want %+v", got, tt.want)
+			}
+			if tt.errPart == "" && err != nil || tt.errPart != "" && (err == nil || !strings.Contains(err.Error(), tt.errPart)) {
+				t.Errorf("err = %v, want one mentioning %q", err, tt.errPart)
+			}
+		})
+	}
+}
+
+func TestAccentPropagates(t *testing.T) {
+	defer func(accent, progress, spinner, cursor lipgloss.Color, title, info, selected, rowProgress lipgloss.Style) {
+		colAccent, colProgress, colSpinner, colCursor = accent, progress, spinner, cursor
+		styleTitle, styleLogInfo, styleMenuSelected, styleRowProgress = title, info, selected, rowProgress
+	}(colAccent, colProgress, colSpinner, colCursor, styleTitle, styleLogInfo, styleMenuSelected, styleRowProgress)
+
+	tc, err := compileTheme(themeConfig{Accent: "#ff79c6", Progress: "#3ddc84"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyTheme(tc)
+	m := initialModel()
+	tests := []struct {
+		element string
+		got     string
+		want    string
+	}{
+		{"spinner", colorString(m.spinner.Style.GetForeground()), "#ff79c6"},
+		{"progress bar", m.progressBar.FullColor, "#3ddc84"},
+		{"cursor", colorString(styleMenuSelected.GetForeground()), "#ff79c6"},
+		{"title", colorString(styleTitle.GetForeground()), "#ff79c6"},
+		{"log info", colorString(styleLogInfo.GetForeground()), "#ff79c6"},
+		{"row progress", colorString(styleRowProgress.GetForeground()), "#ff79c6"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s color = %q, want %q", tt.element, tt.got, tt.want)
+		}
+	}
+}
+
+func colorString(c lipgloss.TerminalColor) string {
+	if s, ok := c.(lipgloss.Color); ok {
+		return string(s)
+	}
+	return ""
+}
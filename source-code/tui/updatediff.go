@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Update summary ("what changed")
+// ─────────────────────────────────────────────────────────────────
+
+// updateChange is what an update did, as far as can be told.
+type updateChange struct {
+	versionBefore string
+	versionAfter  string
+	packages      int     // from pacman's "Packages (N)" line
+	netSize       float64 // bytes, from "Net Upgrade Size"
+	hasNetSize    bool
+}
+
+var (
+	rePackages = regexp.MustCompile(`^Packages \((\d+)\)`)
+	reNetSize  = regexp.MustCompile(`Net Upgrade Size:\s*(-?\d+(?:\.\d+)?)\s*([KMG]iB|B)`)
+)
+
+func isUpdateItem(item *menuItem) bool {
+	return item != nil && len(item.cmd) > 0 && item.cmd[0] == "update"
+}
+
+// clientManifest records the installed Steam client build.
+func clientManifest() string {
+	return filepath.Join(steamDir(), "package", "steam_client_ubuntu12.manifest")
+}
+
+// readClientVersion returns the Steam client build number, or "" when
+// it can't be determined (not installed yet, or a remote host).
+func readClientVersion() string {
+	if remoteHost != "" {
+		return ""
+	}
+	f, err := os.Open(clientManifest())
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	return parseClientVersion(f)
+}
+
+// parseClientVersion returns the "version" of a client manifest, the
+// installed one or the one Steam's update server offers.
+func parseClientVersion(r io.Reader) string {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && strings.Trim(fields[0], `"`) == "version" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// summarizeUpdate combines the client versions taken around the update
+// with the figures pacman printed.
+func summarizeUpdate(before, after string, output []string) updateChange {
+	c := updateChange{versionBefore: before, versionAfter: after}
+	for _, line := range output {
+		line = strings.TrimSpace(line)
+		if m := rePackages.FindStringSubmatch(line); m != nil {
+			c.packages, _ = strconv.Atoi(m[1])
+		}
+		if m := reNetSize.FindStringSubmatch(line); m != nil {
+			v, err := strconv.ParseFloat(m[1], 64)
+			if err == nil {
+				c.netSize, c.hasNetSize = v*speedUnits[m[2]], true
+			}
+		}
+	}
+	return c
+}
+
+func formatSizeDelta(bytes float64) string {
+	sign := "+"
+	if bytes < 0 {
+		sign = "-"
+		bytes = -bytes
+	}
+	// formatSpeed's units without the "/s".
+	return sign + strings.TrimSuffix(formatSpeed(bytes), "/s")
+}
+
+// lines renders the summary for the log panel.
+func (c updateChange) lines() []string {
+	var out []string
+	switch {
+	case c.versionBefore == "" && c.versionAfter == "":
+		out = append(out, "Steam client: version info not available")
+	case c.versionBefore == c.versionAfter:
+		out = append(out, "Steam client: unchanged ("+c.versionAfter+")")
+	default:
+		out = append(out, "Steam client: "+orUnknown(c.versionBefore)+" → "+orUnknown(c.versionAfter))
+	}
+	if c.packages > 0 {
+		out = append(out, fmt.Sprintf("Packages upgraded: %d", c.packages))
+	} else {
+		out = append(out, "Packages upgraded: none")
+	}
+	if c.hasNetSize {
+		out = append(out, "Size change: "+formatSizeDelta(c.netSize))
+	}
+	return out
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func (m *model) logUpdateSummary() {
+	change := summarizeUpdate(m.versionBeforeUpdate, readClientVersion(), m.runOutput)
+	m.appendLog(styleLogHeader.Render("  What changed"))
+	for _, line := range change.lines() {
+		m.appendLog(styleLogInfo.Render("  → " + line))
+	}
+}
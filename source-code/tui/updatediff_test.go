@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeUpdate(t *testing.T) {
+	pacman := []string{
+		"Packages (3) steam-1.0.0.81-1 mesa-24.1-1 lib32-mesa-24.1-1",
+		"",
+		"Total Download Size:   120.50 MiB",
+		"Net Upgrade Size:       12.25 MiB",
+	}
+	tests := []struct {
+		name          string
+		before, after string
+		output        []string
+		want          []string
+	}{
+		{
+			"upgraded",
+			"1716584667", "1718906999", pacman,
+			[]string{"Steam client: 1716584667 → 1718906999", "Packages upgraded: 3", "Size change: +12.2 MiB"},
+		},
+		{
+			"unchanged, nothing to do",
+			"1718906999", "1718906999", []string{" there is nothing to do"},
+			[]string{"Steam client: unchanged (1718906999)", "Packages upgraded: none"},
+		},
+		{
+			"first install",
+			"", "1718906999", nil,
+			[]string{"Steam client: unknown → 1718906999", "Packages upgraded: none"},
+		},
+		{
+			"no version info",
+			"", "", []string{"Net Upgrade Size:  -2.00 MiB"},
+			[]string{"Steam client: version info not available", "Packages upgraded: none", "Size change: -2.0 MiB"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizeUpdate(tt.before, tt.after, tt.output).lines()
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("lines =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestFormatSizeDelta(t *testing.T) {
+	tests := []struct {
+		bytes float64
+		want  string
+	}{
+		{0, "+0 B"},
+		{2048, "+2.0 KiB"},
+		{-3 << 20, "-3.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := formatSizeDelta(tt.bytes); got != tt.want {
+			t.Errorf("formatSizeDelta(%v) = %q, want %q", tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestReadClientVersion(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if v := readClientVersion(); v != "" {
+		t.Errorf("version without a manifest = %q", v)
+	}
+	manifest := "\"ubuntu12\"\n{\n\t\"version\"\t\t\"1718906999\"\n\t\"bootstrapper\"\t\t\"zip\"\n}\n"
+	if err := os.MkdirAll(filepath.Dir(clientManifest()), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(clientManifest(), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if v := readClientVersion(); v != "1718906999" {
+		t.Errorf("readClientVersion() = %q, want 1718906999", v)
+	}
+}
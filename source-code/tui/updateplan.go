@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Update preview
+//  Update Container asks first, showing what pacman would download:
+//  `update --plan` syncs a scratch copy of the databases and lists the
+//  packages with their sizes, without touching the container. A CLI
+//  without --plan, or a plan that fails, leaves a generic prompt.
+// ─────────────────────────────────────────────────────────────────
+
+// planListed is how many packages the prompt names.
+const planListed = 6
+
+// planPackage is a package the update would install or upgrade.
+type planPackage struct {
+	name string
+	from string // installed version, "" for a new dependency
+	to   string
+	size int64 // download size in bytes; 0 when already cached
+}
+
+type updatePlan struct {
+	packages []planPackage
+}
+
+func (p updatePlan) downloadSize() int64 {
+	var total int64
+	for _, pkg := range p.packages {
+		total += pkg.size
+	}
+	return total
+}
+
+// parseUpdatePlan reads the output of `update --plan`. The plan counts
+// only when it ran to its "plan-end" line.
+func parseUpdatePlan(out string) (updatePlan, error) {
+	var plan updatePlan
+	complete := false
+	for _, line := range strings.Split(stripANSI(out), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "plan-end":
+			complete = true
+		case strings.HasPrefix(line, "plan-error:"):
+			return updatePlan{}, errors.New(strings.TrimSpace(strings.TrimPrefix(line, "plan-error:")))
+		case strings.HasPrefix(line, "plan: "):
+			f := strings.Fields(strings.TrimPrefix(line, "plan: "))
+			if len(f) != 4 {
+				continue
+			}
+			size, err := strconv.ParseInt(f[3], 10, 64)
+			if err != nil {
+				continue
+			}
+			pkg := planPackage{name: f[0], from: f[1], to: f[2], size: size}
+			if pkg.from == "-" {
+				pkg.from = ""
+			}
+			plan.packages = append(plan.packages, pkg)
+		}
+	}
+	if !complete {
+		return updatePlan{}, errors.New("the plan ended early")
+	}
+	return plan, nil
+}
+
+// lines renders the plan for the confirm prompt: the totals, then the
+// largest downloads.
+func (p updatePlan) lines() []string {
+	if len(p.packages) == 0 {
+		return []string{
+			"The packages are up to date; nothing to download.",
+			"distrobox-upgrade still refreshes the container.",
+		}
+	}
+	size := func(n int64) string { return strings.TrimSuffix(formatSpeed(float64(n)), "/s") }
+	noun := "packages"
+	if len(p.packages) == 1 {
+		noun = "package"
+	}
+	out := []string{fmt.Sprintf("%d %s to upgrade, %s to download:", len(p.packages), noun, size(p.downloadSize()))}
+
+	pkgs := append([]planPackage(nil), p.packages...)
+	sort.SliceStable(pkgs, func(i, j int) bool { return pkgs[i].size > pkgs[j].size })
+	for _, pkg := range pkgs[:min(planListed, len(pkgs))] {
+		change := pkg.from + " → " + pkg.to
+		if pkg.from == "" {
+			change = pkg.to + " (new)"
+		}
+		out = append(out, fmt.Sprintf("  %s %s  %s", pkg.name, change, size(pkg.size)))
+	}
+	if more := len(pkgs) - planListed; more > 0 {
+		out = append(out, fmt.Sprintf("  … and %d more", more))
+	}
+	return out
+}
+
+// updateWarning is the prompt when no plan could be made.
+var updateWarning = []string{
+	"Refreshes the container and upgrades every package",
+	"with a newer version; pacman shows the sizes as it goes.",
+}
+
+// cliHasPlan reports whether the CLI's help lists update --plan. An
+// older CLI ignores the flag and would run the update itself.
+func cliHasPlan(help string) bool {
+	return strings.Contains(stripANSI(help), "--plan")
+}
+
+// describeUpdate is the describe hook of Update Container.
+func describeUpdate(string) ([]string, error) {
+	help, _ := auditedOutput("Update Container", hostCommand(commandArgv([]string{"--help"}), nil, false), stdoutOf)
+	if !cliHasPlan(string(help)) {
+		return nil, errors.New("this hackeros-steam can't preview updates")
+	}
+	out, err := auditedOutput("Update Container", hostCommand(commandArgv([]string{"update", "--plan"}), nil, false), combinedTailOf)
+	plan, perr := parseUpdatePlan(out)
+	if perr != nil {
+		return nil, perr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return plan.lines(), nil
+}
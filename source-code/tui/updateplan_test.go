@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// confirmUpdate starts Update Container through its prompt, as if the
+// preview had been read and accepted.
+func confirmUpdate(t *testing.T, m model) model {
+	t.Helper()
+	m.containerStatus = "running"
+	m.cursor = itemIndex(t, "Update Container")
+	m.openPrompt(stateConfirm, menuItems[m.cursor])
+	return press(settle(m), "y")
+}
+
+func TestParseUpdatePlan(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []planPackage
+		err  string
+	}{
+		{"upgrades", "plan: mesa 24.1.1-1 24.1.2-1 9437184\nplan: libdrm - 2.4.121-1 262144\nplan-end\n",
+			[]planPackage{{"mesa", "24.1.1-1", "24.1.2-1", 9437184}, {"libdrm", "", "2.4.121-1", 262144}}, ""},
+		{"nothing to do", "plan-end\n", nil, ""},
+		{"colored and indented", "\x1b[1m  plan: steam 1.0.0.80-1 1.0.0.81-1 0\x1b[0m\nplan-end", []planPackage{{"steam", "1.0.0.80-1", "1.0.0.81-1", 0}}, ""},
+		{"noise between lines", ":: Synchronizing package databases...\nplan: mesa 1 2 10\nplan: broken line\nplan: mesa 1 2 big\nplan-end",
+			[]planPackage{{"mesa", "1", "2", 10}}, ""},
+		{"cut short", "plan: mesa 1 2 10\n", nil, "the plan ended early"},
+		{"reported failure", "plan-error: failed to synchronize all databases\nplan-end", nil, "failed to synchronize all databases"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := parseUpdatePlan(tt.out)
+			if got := ""; err != nil {
+				got = err.Error()
+				if got != tt.err {
+					t.Fatalf("error = %q, want %q", got, tt.err)
+				}
+				return
+			}
+			if tt.err != "" {
+				t.Fatalf("no error, want %q", tt.err)
+			}
+			if len(plan.packages) != len(tt.want) {
+				t.Fatalf("packages = %+v, want %+v", plan.packages, tt.want)
+			}
+			for i, pkg := range plan.packages {
+				if pkg != tt.want[i] {
+					t.Errorf("package %d = %+v, want %+v", i, pkg, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUpdatePlanLines(t *testing.T) {
+	one := updatePlan{packages: []planPackage{{"mesa", "1", "2", 3 << 20}}}
+	if got := strings.Join(one.lines(), "\n"); !strings.HasPrefix(got, "1 package to upgrade, 3.0 MiB to download:") || !strings.Contains(got, "mesa 1 → 2  3.0 MiB") {
+		t.Errorf("one package:\n%s", got)
+	}
+
+	var many updatePlan
+	for i := 0; i < planListed+2; i++ {
+		many.packages = append(many.packages, planPackage{name: string(rune('a' + i)), to: "1", size: int64(i) << 10})
+	}
+	lines := many.lines()
+	if len(lines) != planListed+2 {
+		t.Fatalf("%d lines for %d packages:\n%s", len(lines), len(many.packages), strings.Join(lines, "\n"))
+	}
+	if !strings.Contains(lines[1], "h 1 (new)") {
+		t.Errorf("largest download not listed first: %q", lines[1])
+	}
+	if lines[len(lines)-1] != "  … and 2 more" {
+		t.Errorf("last line = %q", lines[len(lines)-1])
+	}
+
+	if got := (updatePlan{}).lines(); !strings.Contains(got[0], "nothing to download") {
+		t.Errorf("empty plan = %q", got)
+	}
+}
+
+func TestCliHasPlan(t *testing.T) {
+	tests := []struct {
+		help string
+		want bool
+	}{
+		{"  update [--plan]   Upgrade the container", true},
+		{"  update   Upgrade the container\n  --force", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := cliHasPlan(tt.help); got != tt.want {
+			t.Errorf("cliHasPlan(%q) = %v, want %v", tt.help, got, tt.want)
+		}
+	}
+}
+
+// Without a plan the prompt keeps the generic warning and says why.
+func TestUpdatePreviewFallback(t *testing.T) {
+	if _, err := os.Stat(cli); err == nil {
+		t.Skip(cli + " is installed")
+	}
+	_, err := describeUpdate("")
+	if err == nil {
+		t.Fatal("describeUpdate without a CLI succeeded")
+	}
+
+	m := initialModel()
+	m.containerStatus = "running"
+	update := menuItems[itemIndex(t, "Update Container")]
+	m.handleDescribed(describedMsg{item: update, action: menuAction(itemIndex(t, "Update Container")), err: err})
+	warning := strings.Join(m.pendingItem.warning, "\n")
+	if m.state != stateConfirm || !strings.HasPrefix(warning, updateWarning[0]) || !strings.Contains(warning, "can't preview") {
+		t.Errorf("fallback prompt (state %v):\n%s", m.state, warning)
+	}
+	m = press(settle(m), "y")
+	if countLogged(m, "$ hackeros-steam update") != 1 {
+		t.Error("accepting the fallback prompt did not update")
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Verify game files
+//  `hackeros-steam verify [APPID…]` has Steam validate each game (all
+//  installed ones when none is given) and prints one result line per
+//  game; those lines are summed up once it finishes.
+// ─────────────────────────────────────────────────────────────────
+
+var (
+	reVerified     = regexp.MustCompile(`Verified (.+) \((\d+)\): (?:all files OK|(\d+) missing or corrupt files? re-downloaded)`)
+	reVerifyFailed = regexp.MustCompile(`Verifying (.+) \((\d+)\) (?:failed|didn't finish)`)
+)
+
+// verifyResult is the outcome for one game.
+type verifyResult struct {
+	name, appID string
+	repaired    int  // files Steam had to fetch again
+	failed      bool // validation didn't complete
+}
+
+// parseVerifyResults picks the per-game result lines out of the
+// command's output.
+func parseVerifyResults(lines []string) []verifyResult {
+	var results []verifyResult
+	for _, line := range lines {
+		if m := reVerified.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.Atoi(m[3])
+			results = append(results, verifyResult{name: m[1], appID: m[2], repaired: n})
+		} else if m := reVerifyFailed.FindStringSubmatch(line); m != nil {
+			results = append(results, verifyResult{name: m[1], appID: m[2], failed: true})
+		}
+	}
+	return results
+}
+
+// verifyItem verifies games, or every installed game when games is
+// empty.
+func verifyItem(games []game) menuItem {
+	item := menuItem{icon: "✓", label: "Verify Game Files", cmd: []string{"verify"}, requires: reqExists}
+	for _, g := range games {
+		item.cmd = append(item.cmd, g.appID)
+	}
+	if len(games) == 1 {
+		item.label = "Verify " + games[0].name
+	} else if len(games) > 1 {
+		item.label = fmt.Sprintf("Verify %d Games", len(games))
+	}
+	return item
+}
+
+func isVerifyItem(item *menuItem) bool {
+	return item != nil && actionName(*item) == "verify"
+}
+
+// verifyAll is the "Verify Game Files" action.
+func (m *model) verifyAll() tea.Cmd {
+	// Over --remote the library can't be read here; the CLI finds the
+	// games itself.
+	if remoteHost == "" {
+		games, err := loadGames(steamappsDir())
+		if err == nil && len(games) == 0 {
+			m.appendLog(styleLogDim.Render("  No installed games to verify."))
+			return m.showToast("No installed games to verify")
+		}
+	}
+	return m.execCommand(verifyItem(nil))
+}
+
+// logVerifySummary sums up the verify run that just ended.
+func (m *model) logVerifySummary() {
+	results := parseVerifyResults(m.runOutput)
+	if len(results) == 0 {
+		return
+	}
+	var ok, repaired, failed, files int
+	for _, r := range results {
+		switch {
+		case r.failed:
+			failed++
+		case r.repaired > 0:
+			repaired++
+			files += r.repaired
+		default:
+			ok++
+		}
+	}
+	summary := fmt.Sprintf("  Verified %d game(s): %d OK, %d repaired", len(results), ok, repaired)
+	if files > 0 {
+		summary += fmt.Sprintf(" (%d files re-downloaded)", files)
+	}
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d not verified", failed)
+	}
+	style := styleLogSuccess
+	if repaired > 0 || failed > 0 {
+		style = styleLogWarning
+	}
+	m.appendLog(style.Render(summary))
+	for _, r := range results {
+		switch {
+		case r.failed:
+			m.appendLog(styleLogError.Render("    ✖ " + r.name + " — verification didn't complete"))
+		case r.repaired > 0:
+			m.appendLog(styleLogWarning.Render(fmt.Sprintf("    ⚠ %s — %d file(s) re-downloaded", r.name, r.repaired)))
+		}
+	}
+	m.recordStatus(strings.TrimSpace(summary))
+}
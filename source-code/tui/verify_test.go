@@ -0,0 +1,144 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseVerifyResults(t *testing.T) {
+	lines := []string{
+		"══ Verifying Game Files ══",
+		"[1/4] Verifying Dota 2",
+		"  ✔  Verified Dota 2 (570): all files OK",
+		"[2/4] Verifying Portal 2",
+		"  ⚠  Verified Portal 2 (620): 12 missing or corrupt files re-downloaded",
+		"  ⚠  Verified Half-Life (70): 1 missing or corrupt file re-downloaded",
+		"  ✖  Verifying Team Fortress 2 (440) failed: steam exited with 1",
+		"  ✖  Verifying Counter-Strike 2 (730) didn't finish within 2h",
+		"  Verified nothing useful",
+	}
+	want := []verifyResult{
+		{name: "Dota 2", appID: "570"},
+		{name: "Portal 2", appID: "620", repaired: 12},
+		{name: "Half-Life", appID: "70", repaired: 1},
+		{name: "Team Fortress 2", appID: "440", failed: true},
+		{name: "Counter-Strike 2", appID: "730", failed: true},
+	}
+	got := parseVerifyResults(lines)
+	if len(got) != len(want) {
+		t.Fatalf("parseVerifyResults = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestVerifyItem(t *testing.T) {
+	dota := game{appID: "570", name: "Dota 2"}
+	portal := game{appID: "620", name: "Portal 2"}
+	tests := []struct {
+		name  string
+		games []game
+		label string
+		cmd   string
+	}{
+		{"all", nil, "Verify Game Files", "verify"},
+		{"one", []game{dota}, "Verify Dota 2", "verify 570"},
+		{"marked", []game{dota, portal}, "Verify 2 Games", "verify 570 620"},
+	}
+	for _, tt := range tests {
+		item := verifyItem(tt.games)
+		if item.label != tt.label || strings.Join(item.cmd, " ") != tt.cmd || !isVerifyItem(&item) {
+			t.Errorf("%s: verifyItem = %q %q", tt.name, item.label, item.cmd)
+		}
+	}
+	if isVerifyItem(&menuItems[itemIndex(t, "Update Container")]) || isVerifyItem(nil) {
+		t.Error("isVerifyItem matched a non-verify item")
+	}
+}
+
+func TestVerifyFromGamesList(t *testing.T) {
+	tests := []struct {
+		name  string
+		marks []int
+		cmd   string
+	}{
+		{"the game under the cursor", nil, "$ hackeros-steam verify 440"},
+		{"the marked games", []int{0, 1}, "$ hackeros-steam verify 570 620"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := batchModel(t, tt.marks...)
+			m.games.cursor = 2
+			m = press(m, "v")
+			if m.games != nil || !m.busy || countLogged(m, tt.cmd) != 1 {
+				t.Errorf("games open %v, busy %v, log %q", m.games != nil, m.busy, plainLogLines(m.logLines, false))
+			}
+		})
+	}
+}
+
+func TestVerifyAll(t *testing.T) {
+	defer func(saved string) { remoteHost = saved }(remoteHost)
+	remoteHost = ""
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	steamapps := filepath.Join(home, ".local", "share", "Steam", "steamapps")
+	os.MkdirAll(steamapps, 0o755)
+
+	m := initialModel()
+	m.containerStatus = "running"
+	m.verifyAll()
+	if m.busy || countLogged(m, "No installed games to verify.") != 1 {
+		t.Fatalf("empty library: busy=%v, log %q", m.busy, plainLogLines(m.logLines, false))
+	}
+
+	writeManifests(t, steamapps, game{appID: "570", name: "Dota 2", size: 1})
+	m = initialModel()
+	m.containerStatus = "running"
+	m.verifyAll()
+	if !m.busy || countLogged(m, "$ hackeros-steam verify") != 1 {
+		t.Errorf("with a game: busy=%v, log %q", m.busy, plainLogLines(m.logLines, false))
+	}
+}
+
+func TestVerifySummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		output []string
+		want   []string
+	}{
+		{"all good", []string{"Verified Dota 2 (570): all files OK"},
+			[]string{"Verified 1 game(s): 1 OK, 0 repaired"}},
+		{"repairs and a failure", []string{
+			"Verified Dota 2 (570): all files OK",
+			"Verified Portal 2 (620): 3 missing or corrupt files re-downloaded",
+			"Verifying Team Fortress 2 (440) failed: steam exited with 1",
+		}, []string{
+			"Verified 3 game(s): 1 OK, 1 repaired (3 files re-downloaded), 1 not verified",
+			"⚠ Portal 2 — 3 file(s) re-downloaded",
+			"✖ Team Fortress 2 — verification didn't complete",
+		}},
+		{"no result lines", []string{"error: container not running"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			before := len(m.logLines)
+			m.runOutput = tt.output
+			m.logVerifySummary()
+			for _, w := range tt.want {
+				if countLogged(m, w) != 1 {
+					t.Errorf("summary lacks %q: %q", w, plainLogLines(m.logLines, false))
+				}
+			}
+			if tt.want == nil && len(m.logLines) != before {
+				t.Errorf("logged %q", plainLogLines(m.logLines[before:], false))
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Start view (--view name)
+//  Lets a desktop launcher open the TUI straight on a screen other than
+//  the main menu. Each view just drives the normal state machine, so
+//  backing out lands on the menu as usual.
+// ─────────────────────────────────────────────────────────────────
+
+var startView = "menu"
+
+// startViews maps --view names to the setup that opens them.
+var startViews = map[string]func(m *model){
+	"menu":    func(m *model) {},
+	"channel": func(m *model) { m.openChannelMenu() },
+	"history": func(m *model) { m.popup = popupHistory },
+}
+
+// viewNames lists the valid --view names in help order.
+var viewNames = []string{"menu", "channel", "history"}
+
+func validateView(name string) error {
+	if _, ok := startViews[name]; !ok {
+		return fmt.Errorf("unknown --view %q (valid: %s)", name, strings.Join(viewNames, ", "))
+	}
+	return nil
+}
+
+func (m *model) applyStartView() {
+	startViews[startView](m)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStartViews(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defer func(saved string) { startView = saved }(startView)
+	tests := []struct {
+		view  string
+		state viewState
+		popup popup
+	}{
+		{"menu", stateMenu, popupNone},
+		{"channel", stateSubmenu, popupNone},
+		{"history", stateMenu, popupHistory},
+	}
+	for _, tt := range tests {
+		startView = tt.view
+		m := initialModel()
+		if m.state != tt.state || m.popup != tt.popup {
+			t.Errorf("--view %s: state=%v popup=%v, want %v %v", tt.view, m.state, m.popup, tt.state, tt.popup)
+		}
+	}
+}
+
+func TestValidateView(t *testing.T) {
+	for _, name := range viewNames {
+		if err := validateView(name); err != nil {
+			t.Errorf("validateView(%q) = %v", name, err)
+		}
+	}
+	if len(viewNames) != len(startViews) {
+		t.Errorf("viewNames lists %d views, startViews has %d", len(viewNames), len(startViews))
+	}
+	err := validateView("settings")
+	if err == nil || !strings.Contains(err.Error(), strings.Join(viewNames, ", ")) {
+		t.Errorf("validateView(settings) = %v, want an error listing the valid views", err)
+	}
+}
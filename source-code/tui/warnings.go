@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Warnings panel
+//  What a running command prints on stderr — pacman's "warning: …"
+//  lines during an update, say — goes to the log like the rest of its
+//  output and is also listed under the progress bar, so it isn't lost
+//  in the scroll while stdout keeps the bar moving.
+// ─────────────────────────────────────────────────────────────────
+
+const (
+	warningRows       = 3  // latest warnings shown under the progress bar
+	warningsKept      = 50 // older ones only stay in the log
+	warningsMinHeight = 12 // log height below which the panel is left out
+)
+
+// cmdStderrMsg is a line the running command wrote to stderr.
+type cmdStderrMsg string
+
+// stderrWarnings collects the stderr lines of the running action.
+type stderrWarnings struct {
+	lines []string
+	total int
+}
+
+func (w *stderrWarnings) add(line string) {
+	w.total++
+	w.lines = append(w.lines, line)
+	if len(w.lines) > warningsKept {
+		w.lines = w.lines[len(w.lines)-warningsKept:]
+	}
+}
+
+func (w *stderrWarnings) reset() { *w = stderrWarnings{} }
+
+// handleStderr logs a stderr line and, unless blank, lists it in the
+// warnings panel.
+func (m *model) handleStderr(line string) {
+	plain := stripANSI(line)
+	if strings.TrimSpace(plain) == "" {
+		m.captureLine(plain, "")
+		return
+	}
+	m.captureLine(plain, renderOutputLine(line))
+	m.warnings.add(strings.TrimSpace(plain))
+}
+
+// renderWarnings draws the warnings panel w cells wide, or nothing
+// when the running action hasn't written to stderr.
+func (m model) renderWarnings(w int) string {
+	if !m.busy || m.warnings.total == 0 {
+		return ""
+	}
+	style := lipgloss.NewStyle().Background(lipgloss.Color("#0d0f14")).Width(w).Padding(0, 1)
+	head := fmt.Sprintf("⚠ %d warning", m.warnings.total)
+	if m.warnings.total != 1 {
+		head += "s"
+	}
+	rows := []string{style.Foreground(colYellow).Bold(true).Render(head + " on stderr")}
+	shown := m.warnings.lines[max(len(m.warnings.lines)-warningRows, 0):]
+	for _, line := range shown {
+		rows = append(rows, style.Foreground(colSub).Render(truncate("  "+line, max(w-2, 2))))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// Progress is parsed from stdout only; stderr lines become warnings.
+func TestInterleavedProgressAndWarnings(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	script := `echo "Progress: 10%"; echo "warning: a" >&2; echo "Progress: 50%"; ` +
+		`echo "Progress: 77%" >&2; echo "warning: b" >&2; sleep 0.2; exit 3`
+	msgs := runStream([]string{sh, "-c", script})
+
+	var out, stderr []string
+	highest := 0.0
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case cmdOutputMsg:
+			out = append(out, string(msg))
+		case cmdStderrMsg:
+			stderr = append(stderr, string(msg))
+		case progressMsg:
+			highest = max(highest, msg.percent)
+		}
+	}
+	if strings.Join(out, "|") != "Progress: 10%|Progress: 50%" {
+		t.Errorf("stdout = %q", out)
+	}
+	if strings.Join(stderr, "|") != "warning: a|Progress: 77%|warning: b" {
+		t.Errorf("stderr = %q", stderr)
+	}
+	if highest != 0.5 {
+		t.Errorf("progress reached %v, want 0.5 from stdout", highest)
+	}
+
+	m := initialModel()
+	m.width, m.height = 120, 40
+	m.busy, m.state = true, stateRunning
+	m.beginCapture()
+	for _, line := range append(stderr, "   ") {
+		m.handleStderr(line)
+	}
+	if m.warnings.total != 3 || countLogged(m, "warning: b") != 1 {
+		t.Errorf("warnings %+v", m.warnings)
+	}
+	panel := m.renderWarnings(60)
+	for _, s := range []string{"3 warnings on stderr", "warning: a", "warning: b"} {
+		if !strings.Contains(panel, s) {
+			t.Errorf("panel lacks %q:\n%s", s, panel)
+		}
+	}
+}
+
+func TestWarningsPanel(t *testing.T) {
+	tests := []struct {
+		lines int
+		busy  bool
+		head  string
+		shown int
+	}{
+		{0, true, "", 0},
+		{1, true, "1 warning on stderr", 1},
+		{5, true, "5 warnings on stderr", warningRows},
+		{2, false, "", 0},
+	}
+	for _, tt := range tests {
+		m := initialModel()
+		m.busy = tt.busy
+		for i := 0; i < tt.lines; i++ {
+			m.warnings.add(fmt.Sprintf("warning %d", i))
+		}
+		panel := m.renderWarnings(60)
+		if tt.head == "" {
+			if panel != "" {
+				t.Errorf("%d lines, busy=%v: panel drawn", tt.lines, tt.busy)
+			}
+			continue
+		}
+		if !strings.Contains(panel, tt.head) || strings.Count(panel, "\n") != tt.shown {
+			t.Errorf("%d lines: panel\n%s", tt.lines, panel)
+		}
+		if tt.lines > warningRows && !strings.Contains(panel, fmt.Sprintf("warning %d", tt.lines-1)) {
+			t.Error("latest warning not shown")
+		}
+	}
+}
+
+func TestWarningsAreBounded(t *testing.T) {
+	var w stderrWarnings
+	for i := 0; i < warningsKept+20; i++ {
+		w.add(fmt.Sprint(i))
+	}
+	if len(w.lines) != warningsKept || w.total != warningsKept+20 || w.lines[0] != "20" {
+		t.Errorf("kept %d lines from %q, total %d", len(w.lines), w.lines[0], w.total)
+	}
+	w.reset()
+	if w.total != 0 || len(w.lines) != 0 {
+		t.Error("reset kept warnings")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ─────────────────────────────────────────────────────────────────
+//  Base directories
+//  Config, state and logs follow XDG_CONFIG_HOME, XDG_STATE_HOME and
+//  XDG_CACHE_HOME. When a variable is unset the original location,
+//  ~/.hackeros/HackerOS-Steam, is used as before. Files left there by
+//  an older version are moved over on the first run that has the
+//  variable set.
+// ─────────────────────────────────────────────────────────────────
+
+const appDirName = "HackerOS-Steam"
+
+func legacyDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".hackeros", appDirName)
+}
+
+// xdgDir resolves the app directory under the base named by env. The
+// spec says relative values are invalid and must be ignored.
+func xdgDir(env string) string {
+	if base := os.Getenv(env); filepath.IsAbs(base) {
+		return filepath.Join(base, appDirName)
+	}
+	return legacyDir()
+}
+
+func configDir() string { return xdgDir("XDG_CONFIG_HOME") }
+func stateDir() string  { return xdgDir("XDG_STATE_HOME") }
+func cacheDir() string  { return xdgDir("XDG_CACHE_HOME") }
+
+func defaultLogDir() string {
+	return filepath.Join(cacheDir(), "logs")
+}
+
+// tildePath shortens a path under the home directory for display.
+func tildePath(p string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "/" || !strings.HasPrefix(p, home+"/") {
+		return p
+	}
+	return "~" + p[len(home):]
+}
+
+// writeFileAtomic replaces path with data, creating its directory, so
+// a crash never leaves a half-written file behind.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// startupNotes are logged when the TUI opens, e.g. what was migrated.
+var startupNotes []string
+
+// migrateLegacy moves the config file and the logs directory out of the
+// legacy directory into their XDG homes. Nothing is overwritten: a
+// target that already exists is left alone, as is its legacy source.
+func migrateLegacy() ([]string, error) {
+	legacy := legacyDir()
+	moves := []struct{ from, to string }{
+		{filepath.Join(legacy, configFileName), configPath()},
+		{filepath.Join(legacy, "logs"), defaultLogDir()},
+	}
+	var moved []string
+	var errs []error
+	for _, mv := range moves {
+		if mv.from == mv.to {
+			continue
+		}
+		if _, err := os.Lstat(mv.from); err != nil {
+			continue
+		}
+		if _, err := os.Lstat(mv.to); !errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(mv.to), 0o755); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.Rename(mv.from, mv.to); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		moved = append(moved, mv.from+" → "+mv.to)
+	}
+	return moved, errors.Join(errs...)
+}
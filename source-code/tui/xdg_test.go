@@ -0,0 +1,124 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestXDGDirs(t *testing.T) {
+	home := t.TempDir()
+	legacy := filepath.Join(home, ".hackeros", appDirName)
+	tests := []struct {
+		name                 string
+		config, state, cache string
+		wantConfig           string
+		wantState            string
+		wantLogs             string
+	}{
+		{"unset", "", "", "", legacy, legacy, filepath.Join(legacy, "logs")},
+		{"all set", "/xc", "/xs", "/xk",
+			"/xc/" + appDirName, "/xs/" + appDirName, "/xk/" + appDirName + "/logs"},
+		{"config only", "/xc", "", "",
+			"/xc/" + appDirName, legacy, filepath.Join(legacy, "logs")},
+		{"relative ignored", "rel/config", "/xs", "./cache",
+			legacy, "/xs/" + appDirName, filepath.Join(legacy, "logs")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", home)
+			t.Setenv("XDG_CONFIG_HOME", tt.config)
+			t.Setenv("XDG_STATE_HOME", tt.state)
+			t.Setenv("XDG_CACHE_HOME", tt.cache)
+			if got := configDir(); got != tt.wantConfig {
+				t.Errorf("configDir() = %q, want %q", got, tt.wantConfig)
+			}
+			if got := stateDir(); got != tt.wantState {
+				t.Errorf("stateDir() = %q, want %q", got, tt.wantState)
+			}
+			if got := defaultLogDir(); got != tt.wantLogs {
+				t.Errorf("defaultLogDir() = %q, want %q", got, tt.wantLogs)
+			}
+		})
+	}
+}
+
+// legacyFixture fills the legacy directory with a config and a log.
+func legacyFixture(t *testing.T) string {
+	t.Helper()
+	legacy := legacyDir()
+	if err := os.MkdirAll(filepath.Join(legacy, "logs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{configFileName: "safe_mode = true\n", "logs/a.log": "old log\n"} {
+		if err := os.WriteFile(filepath.Join(legacy, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return legacy
+}
+
+func TestMigrateLegacy(t *testing.T) {
+	tests := []struct {
+		name      string
+		xdg       bool
+		existing  bool // the XDG config already exists
+		wantMoves int
+	}{
+		{"no XDG variables", false, false, 0},
+		{"fresh XDG dirs", true, false, 2},
+		{"config already there", true, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			t.Setenv("HOME", filepath.Join(base, "home"))
+			t.Setenv("XDG_CONFIG_HOME", "")
+			t.Setenv("XDG_CACHE_HOME", "")
+			if tt.xdg {
+				t.Setenv("XDG_CONFIG_HOME", filepath.Join(base, "config"))
+				t.Setenv("XDG_CACHE_HOME", filepath.Join(base, "cache"))
+			}
+			legacy := legacyFixture(t)
+			if tt.existing {
+				if err := os.MkdirAll(configDir(), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(configPath(), []byte("layout = \"grid\"\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			moved, err := migrateLegacy()
+			if err != nil {
+				t.Fatalf("migrateLegacy: %v", err)
+			}
+			if len(moved) != tt.wantMoves {
+				t.Fatalf("moved %q, want %d moves", moved, tt.wantMoves)
+			}
+			if !tt.xdg {
+				return
+			}
+			if data, err := os.ReadFile(filepath.Join(defaultLogDir(), "a.log")); err != nil || string(data) != "old log\n" {
+				t.Errorf("log not moved: %q, %v", data, err)
+			}
+			data, err := os.ReadFile(configPath())
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, legacyErr := os.Stat(filepath.Join(legacy, configFileName))
+			if tt.existing {
+				if string(data) != "layout = \"grid\"\n" || legacyErr != nil {
+					t.Errorf("existing config overwritten (%q) or legacy copy lost (%v)", data, legacyErr)
+				}
+			} else if string(data) != "safe_mode = true\n" || legacyErr == nil {
+				t.Errorf("config not moved: %q, legacy still there: %v", data, legacyErr == nil)
+			}
+
+			again, err := migrateLegacy()
+			if err != nil || len(again) != 0 {
+				t.Errorf("second run moved %q (%v)", again, err)
+			}
+		})
+	}
+}